@@ -10,15 +10,26 @@ const (
 	LabelWebhookManagedBy   = "webhook.kyverno.io/managed-by"
 	LabelExcludeReporting   = "reports.kyverno.io/disabled"
 	LabelEnableVAPReporting = "reports.kyverno.io/enabled"
+	LabelPolicySource       = "kyverno.io/policy-source"
 	// Well known annotations
 	AnnotationAutogenControllers       = "pod-policies.kyverno.io/autogen-controllers"
 	AnnotationImageVerify              = "kyverno.io/verify-images"
 	AnnotationImageVerifyScoped        = "kyverno.io/verify-images-scoped"
 	AnnotationImageVerifyOutcomes      = "kyverno.io/image-verification-outcomes"
+	AnnotationPolicyTitle              = "policies.kyverno.io/title"
 	AnnotationPolicyCategory           = "policies.kyverno.io/category"
 	AnnotationPolicyScored             = "policies.kyverno.io/scored"
 	AnnotationPolicySeverity           = "policies.kyverno.io/severity"
+	AnnotationPolicySubject            = "policies.kyverno.io/subject"
+	AnnotationPolicyDescription        = "policies.kyverno.io/description"
+	AnnotationPolicySchedule           = "policies.kyverno.io/schedule"
+	AnnotationPolicyRequireApproval    = "policies.kyverno.io/require-approval"
+	AnnotationPolicyRollout            = "policies.kyverno.io/rollout"
+	AnnotationPolicyPromotion          = "policies.kyverno.io/promotion"
+	AnnotationPolicyPromotionPeriod    = "policies.kyverno.io/promotion-period"
+	AnnotationPolicyPromotionResources = "policies.kyverno.io/promotion-min-resources"
 	AnnotationCleanupPropagationPolicy = "cleanup.kyverno.io/propagation-policy"
+	AnnotationPolicySourceRevision     = "kyverno.io/policy-source-revision"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
@@ -396,6 +396,11 @@ type Mutation struct {
 	// ForEach applies mutation rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 	// +optional
 	ForEachMutation []ForEachMutation `json:"foreach,omitempty"`
+
+	// Schedule is a cron expression, when set the mutateExisting rule is also applied to all existing targets on schedule.
+	// It uses the same format as CleanupPolicy schedules.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
 }
 
 func (m *Mutation) GetPatchStrategicMerge() apiextensions.JSON {
@@ -788,6 +793,11 @@ type Generation struct {
 	// +optional
 	Synchronize bool `json:"synchronize,omitempty"`
 
+	// Schedule is a cron expression, when set the rule is also applied to all existing triggers on schedule.
+	// It uses the same format as CleanupPolicy schedules.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
 	// OrphanDownstreamOnPolicyDelete controls whether generated resources should be deleted when the rule that generated
 	// them is deleted with synchronization enabled.
 	// See https://kyverno.io/docs/writing-policies/generate/.
@@ -8,6 +8,8 @@ import (
 const (
 	// PolicyConditionReady means that the policy is ready
 	PolicyConditionReady = "Ready"
+	// PolicyConditionSlow means that the policy exceeds its latency budget
+	PolicyConditionSlow = "Slow"
 )
 
 const (
@@ -15,6 +17,10 @@ const (
 	PolicyReasonSucceeded = "Succeeded"
 	// PolicyReasonSucceeded is the reason set when the policy is not ready
 	PolicyReasonFailed = "Failed"
+	// PolicyReasonLatencyBudgetExceeded is the reason set when the policy exceeds its latency budget
+	PolicyReasonLatencyBudgetExceeded = "LatencyBudgetExceeded"
+	// PolicyReasonWithinLatencyBudget is the reason set when the policy is within its latency budget
+	PolicyReasonWithinLatencyBudget = "WithinLatencyBudget"
 )
 
 // Deprecated. Policy metrics are now available via the "/metrics" endpoint.
@@ -31,6 +37,9 @@ type PolicyStatus struct {
 	// ValidatingAdmissionPolicy contains status information
 	// +optional
 	ValidatingAdmissionPolicy ValidatingAdmissionPolicyStatus `json:"validatingadmissionpolicy"`
+	// Rollout contains the progression of the staged enforcement rollout
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -68,6 +77,28 @@ func (status *PolicyStatus) IsReady() bool {
 	return condition != nil && condition.Status == metav1.ConditionTrue
 }
 
+// SetSlow sets the slow condition of the policy
+func (status *PolicyStatus) SetSlow(slow bool, message string) {
+	condition := metav1.Condition{
+		Type:    PolicyConditionSlow,
+		Message: message,
+	}
+	if slow {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyReasonLatencyBudgetExceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonWithinLatencyBudget
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsSlow indicates if the policy exceeds its latency budget
+func (status *PolicyStatus) IsSlow() bool {
+	condition := meta.FindStatusCondition(status.Conditions, PolicyConditionSlow)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
 // AutogenStatus contains autogen status information.
 type AutogenStatus struct {
 	// Rules is a list of Rule instances. It contains auto generated rules added for pod controllers
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_Rollout_Validate(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(d))
+		return &t
+	}
+	percentage := func(p int32) *int32 { return &p }
+	testCases := []struct {
+		name    string
+		rollout Rollout
+		fields  []string
+	}{{
+		name: "valid",
+		rollout: Rollout{Stages: []RolloutStage{{
+			Name:              "canary",
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+		}, {
+			Name:       "half",
+			Percentage: percentage(50),
+			StartTime:  at(time.Hour),
+		}, {
+			Name:      "all",
+			StartTime: at(2 * time.Hour),
+		}}},
+	}, {
+		name:    "no stages",
+		rollout: Rollout{},
+		fields:  []string{"rollout.stages"},
+	}, {
+		name: "duplicate and missing names",
+		rollout: Rollout{Stages: []RolloutStage{
+			{Name: "canary"},
+			{Name: "canary"},
+			{},
+		}},
+		fields: []string{"rollout.stages[1].name", "rollout.stages[2].name"},
+	}, {
+		name: "invalid percentage",
+		rollout: Rollout{Stages: []RolloutStage{
+			{Name: "canary", Percentage: percentage(120)},
+		}},
+		fields: []string{"rollout.stages[0].percentage"},
+	}, {
+		name: "invalid selector",
+		rollout: Rollout{Stages: []RolloutStage{{
+			Name: "canary",
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "canary",
+				Operator: metav1.LabelSelectorOpIn,
+			}}},
+		}}},
+		fields: []string{"rollout.stages[0].namespaceSelector.matchExpressions[0].values"},
+	}, {
+		name: "unordered start times",
+		rollout: Rollout{Stages: []RolloutStage{
+			{Name: "canary", StartTime: at(time.Hour)},
+			{Name: "all", StartTime: at(0)},
+		}},
+		fields: []string{"rollout.stages[1].startTime"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.rollout.Validate(field.NewPath("rollout"))
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.DeepEqual(t, fields, tc.fields)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Rollout defines a staged rollout of policy enforcement across namespaces.
+// Stages are cumulative, once a stage has started the namespaces it selects stay enforced.
+// Requests in namespaces not selected by any started stage are audited instead of blocked.
+type Rollout struct {
+	// Stages is the ordered list of enforcement stages.
+	// +kubebuilder:validation:MinItems=1
+	Stages []RolloutStage `json:"stages"`
+}
+
+// RolloutStage selects the namespaces where the policy is enforced from a point in time.
+type RolloutStage struct {
+	// Name is the name of the stage, it must be unique within the rollout.
+	Name string `json:"name"`
+
+	// NamespaceSelector selects the namespaces enforced by this stage.
+	// When not set, all namespaces and cluster-wide resources are selected.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Percentage restricts the stage to a stable subset of the selected namespaces,
+	// chosen by hashing the namespace name. The default is 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// StartTime is the time from which the stage is enforced.
+	// When not set, the stage is enforced as soon as the previous stages are.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// RolloutStatus reports the progression of a staged rollout.
+type RolloutStatus struct {
+	// CurrentStage is the name of the last started stage, empty when no stage has started yet.
+	// +optional
+	CurrentStage string `json:"currentStage,omitempty"`
+
+	// StartedStages is the number of stages that have started.
+	StartedStages int `json:"startedStages"`
+
+	// TotalStages is the number of stages of the rollout.
+	TotalStages int `json:"totalStages"`
+
+	// Completed indicates whether all stages have started.
+	Completed bool `json:"completed"`
+
+	// NextStageTime is the start time of the next stage, if any.
+	// +optional
+	NextStageTime *metav1.Time `json:"nextStageTime,omitempty"`
+}
+
+// GetPercentage returns the percentage of selected namespaces enforced by the stage.
+func (s *RolloutStage) GetPercentage() int32 {
+	if s.Percentage == nil {
+		return 100
+	}
+	return *s.Percentage
+}
+
+// Validate implements programmatic validation of the rollout.
+func (r *Rollout) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(r.Stages) == 0 {
+		errs = append(errs, field.Required(path.Child("stages"), "at least one stage is required"))
+	}
+	names := sets.New[string]()
+	var previous *metav1.Time
+	for i, stage := range r.Stages {
+		stagePath := path.Child("stages").Index(i)
+		if stage.Name == "" {
+			errs = append(errs, field.Required(stagePath.Child("name"), "stage name is required"))
+		} else if names.Has(stage.Name) {
+			errs = append(errs, field.Duplicate(stagePath.Child("name"), stage.Name))
+		}
+		names.Insert(stage.Name)
+		if stage.Percentage != nil && (*stage.Percentage < 0 || *stage.Percentage > 100) {
+			errs = append(errs, field.Invalid(stagePath.Child("percentage"), *stage.Percentage, "the percentage must be between 0 and 100"))
+		}
+		if stage.NamespaceSelector != nil {
+			errs = append(errs, metav1validation.ValidateLabelSelector(stage.NamespaceSelector, metav1validation.LabelSelectorValidationOptions{}, stagePath.Child("namespaceSelector"))...)
+		}
+		if stage.StartTime != nil {
+			if previous != nil && stage.StartTime.Before(previous) {
+				errs = append(errs, field.Invalid(stagePath.Child("startTime"), stage.StartTime, fmt.Sprintf("the stage must not start before the previous stage (%s)", previous.UTC().Format("2006-01-02T15:04:05Z"))))
+			}
+			previous = stage.StartTime
+		}
+	}
+	return errs
+}
@@ -1152,3 +1152,70 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 		assert.Equal(t, len(warnings) != 0, testcase.warning, testcase.name)
 	}
 }
+
+func Test_ValidateSchedule(t *testing.T) {
+	path := field.NewPath("dummy")
+	testcases := []struct {
+		name       string
+		rule       Rule
+		shouldFail bool
+	}{
+		{
+			name: "no-schedule",
+			rule: Rule{
+				Generation: &Generation{},
+			},
+		},
+		{
+			name: "generate-schedule",
+			rule: Rule{
+				Generation: &Generation{Schedule: "0 0 * * 0"},
+			},
+		},
+		{
+			name: "generate-invalid-schedule",
+			rule: Rule{
+				Generation: &Generation{Schedule: "every week"},
+			},
+			shouldFail: true,
+		},
+		{
+			name: "mutate-existing-schedule",
+			rule: Rule{
+				Mutation: &Mutation{Schedule: "@daily", Targets: []TargetResourceSpec{}},
+			},
+		},
+		{
+			name: "mutate-existing-invalid-schedule",
+			rule: Rule{
+				Mutation: &Mutation{Schedule: "* *", Targets: []TargetResourceSpec{}},
+			},
+			shouldFail: true,
+		},
+		{
+			name: "generate-every-schedule",
+			rule: Rule{
+				Generation: &Generation{Schedule: "@every 1h"},
+			},
+			shouldFail: true,
+		},
+		{
+			name: "mutate-existing-every-schedule",
+			rule: Rule{
+				Mutation: &Mutation{Schedule: "@every 1h", Targets: []TargetResourceSpec{}},
+			},
+			shouldFail: true,
+		},
+		{
+			name: "mutate-standard-schedule",
+			rule: Rule{
+				Mutation: &Mutation{Schedule: "@daily"},
+			},
+			shouldFail: true,
+		},
+	}
+	for _, testcase := range testcases {
+		errs := testcase.rule.ValidateSchedule(path)
+		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name, errs)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/aptible/supercronic/cronexpr"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/pss/utils"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
@@ -129,6 +130,17 @@ func (r *Rule) HasMutateExisting() bool {
 	return r.Mutation != nil && r.Mutation.Targets != nil
 }
 
+// GetSchedule returns the cron schedule of a mutateExisting or generate rule, empty if the rule is not scheduled
+func (r *Rule) GetSchedule() string {
+	if r.HasMutateExisting() {
+		return r.Mutation.Schedule
+	}
+	if r.HasGenerate() {
+		return r.Generation.Schedule
+	}
+	return ""
+}
+
 // HasVerifyImages checks for verifyImages rule
 func (r *Rule) HasVerifyImages() bool {
 	for _, verifyImage := range r.VerifyImages {
@@ -453,6 +465,24 @@ func (r *Rule) ValidateGenerate(path *field.Path, namespaced bool, policyNamespa
 	return r.Generation.Validate(path, namespaced, policyNamespace, clusterResources)
 }
 
+// ValidateSchedule checks the schedule is only set on mutateExisting rules and is in proper cron format
+func (r *Rule) ValidateSchedule(path *field.Path) (errs field.ErrorList) {
+	if r.Mutation != nil && r.Mutation.Schedule != "" {
+		schedulePath := path.Child("mutate", "schedule")
+		if !r.HasMutateExisting() {
+			errs = append(errs, field.Forbidden(schedulePath, "schedule is only supported in mutateExisting rules"))
+		} else if _, err := cronexpr.Parse(r.Mutation.Schedule); err != nil {
+			errs = append(errs, field.Invalid(schedulePath, r.Mutation.Schedule, "schedule is not in proper cron format"))
+		}
+	}
+	if r.Generation != nil && r.Generation.Schedule != "" {
+		if _, err := cronexpr.Parse(r.Generation.Schedule); err != nil {
+			errs = append(errs, field.Invalid(path.Child("generate", "schedule"), r.Generation.Schedule, "schedule is not in proper cron format"))
+		}
+	}
+	return errs
+}
+
 // Validate implements programmatic validation
 func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (warnings []string, errs field.ErrorList) {
 	errs = append(errs, r.ValidateRuleType(path)...)
@@ -461,6 +491,7 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	errs = append(errs, r.ExcludeResources.Validate(path.Child("exclude"), namespaced, clusterResources)...)
 	errs = append(errs, r.ValidateMutationRuleTargetNamespace(path, namespaced, policyNamespace)...)
 	errs = append(errs, r.ValidatePSaControlNames(path)...)
+	errs = append(errs, r.ValidateSchedule(path)...)
 	warning, errors := r.ValidateGenerate(path, namespaced, policyNamespace, clusterResources)
 	warnings = append(warnings, warning...)
 	errs = append(errs, errors...)
@@ -117,6 +117,12 @@ type Spec struct {
 	// WebhookConfiguration specifies the custom configuration for Kubernetes admission webhookconfiguration.
 	// +optional
 	WebhookConfiguration *WebhookConfiguration `json:"webhookConfiguration,omitempty"`
+
+	// Rollout enforces the policy in stages across namespaces.
+	// Validate rules with the Enforce failure action only block requests in the namespaces
+	// selected by the started stages, requests in other namespaces are audited.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
 }
 
 func (s *Spec) CustomWebhookMatchConditions() bool {
@@ -167,6 +173,16 @@ func (s *Spec) HasMutateExisting() bool {
 	return false
 }
 
+// HasSchedule checks if any of the mutateExisting or generate rules is scheduled
+func (s *Spec) HasSchedule() bool {
+	for _, rule := range s.Rules {
+		if rule.GetSchedule() != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // HasValidate checks for validate rule types
 func (s *Spec) HasValidate() bool {
 	for _, rule := range s.Rules {
@@ -384,6 +400,9 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if s.WebhookConfiguration != nil && s.WebhookConfiguration.TimeoutSeconds != nil && (*s.WebhookConfiguration.TimeoutSeconds < 1 || *s.WebhookConfiguration.TimeoutSeconds > 30) {
 		errs = append(errs, field.Invalid(path.Child("webhookConfiguration.timeoutSeconds"), s.WebhookConfiguration.TimeoutSeconds, "the timeout value must be between 1 and 30 seconds"))
 	}
+	if s.Rollout != nil {
+		errs = append(errs, s.Rollout.Validate(path.Child("rollout"))...)
+	}
 	warning, errors := s.ValidateRules(path.Child("rules"), namespaced, policyNamespace, clusterResources)
 	warnings = append(warnings, warning...)
 	errs = append(errs, errors...)
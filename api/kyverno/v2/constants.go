@@ -11,4 +11,7 @@ const (
 	// URGeneratePolicyLabel adds the policy name to URs for generate policies
 	URGeneratePolicyLabel          = "generate.kyverno.io/policy-name"
 	URGenerateRetryCountAnnotation = "generate.kyverno.io/retry-count"
+
+	// URApprovedAnnotation approves the changes computed for URs waiting for approval
+	URApprovedAnnotation = "updaterequest.kyverno.io/approved"
 )
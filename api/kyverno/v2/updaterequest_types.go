@@ -37,6 +37,34 @@ type UpdateRequestStatus struct {
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty"`
 
 	RetryCount int `json:"retryCount,omitempty"`
+
+	// ChangeSets are the changes computed by a mutateExisting policy requiring approval.
+	// They are applied once the update request is approved.
+	// +optional
+	ChangeSets []ChangeSet `json:"changeSets,omitempty"`
+}
+
+// ChangeSet is a change computed by a mutateExisting policy for a target resource.
+type ChangeSet struct {
+	// Resource identifies the target resource.
+	Resource kyvernov1.ResourceSpec `json:"resource"`
+
+	// Subresource is the target subresource, if any.
+	// +optional
+	Subresource string `json:"subresource,omitempty"`
+
+	// ResourceVersion is the resource version of the target the patch was computed against.
+	// If the target changed since, the changes must be approved again.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Base is the JSON representation of the target the patch was computed against, without its managed fields.
+	// It is used to review the changes without the modifications made to the target since.
+	// +optional
+	Base string `json:"base,omitempty"`
+
+	// Patch is the RFC 6902 JSON patch to apply to the target resource.
+	Patch string `json:"patch"`
 }
 
 // +genclient
@@ -179,6 +207,9 @@ const (
 
 	// Skip - the Update Request Controller skips to generate the resource.
 	Skip UpdateRequestState = "Skip"
+
+	// WaitingForApproval - the Update Request Controller computed changes that must be approved before being applied.
+	WaitingForApproval UpdateRequestState = "WaitingForApproval"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -191,6 +222,11 @@ type UpdateRequestList struct {
 	Items           []UpdateRequest `json:"items"`
 }
 
+// IsApproved returns true if the changes computed for the update request were approved
+func (ur *UpdateRequest) IsApproved() bool {
+	return ur.GetAnnotations()[URApprovedAnnotation] == "true"
+}
+
 func (s *UpdateRequestSpec) GetRequestType() RequestType {
 	return s.Type
 }
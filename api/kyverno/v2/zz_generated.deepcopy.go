@@ -80,6 +80,23 @@ func (in *AnyAllConditions) DeepCopy() *AnyAllConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChangeSet) DeepCopyInto(out *ChangeSet) {
+	*out = *in
+	out.Resource = in.Resource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeSet.
+func (in *ChangeSet) DeepCopy() *ChangeSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ChangeSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanupPolicy) DeepCopyInto(out *CleanupPolicy) {
 	*out = *in
@@ -735,6 +752,11 @@ func (in *UpdateRequestStatus) DeepCopyInto(out *UpdateRequestStatus) {
 		*out = make([]kyvernov1.ResourceSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.ChangeSets != nil {
+		in, out := &in.ChangeSets, &out.ChangeSets
+		*out = make([]ChangeSet, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,69 @@
+package v2alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PolicySourceConditionReady means that the policysource is synchronized
+	PolicySourceConditionReady = "Ready"
+)
+
+const (
+	// PolicySourceReasonSucceeded is the reason set when the policysource is synchronized
+	PolicySourceReasonSucceeded = "Succeeded"
+	// PolicySourceReasonFailed is the reason set when the policysource failed to synchronize
+	PolicySourceReasonFailed = "Failed"
+)
+
+type PolicySourceStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Revision is the image digest or the commit SHA of the last synchronized source
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Indicates the time when the policysource was last synchronized successfully
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+	// Policies lists the policies applied from the source
+	// +optional
+	Policies []PolicySourceEntry `json:"policies,omitempty"`
+	// Unsupported lists the policies of the source which are not applied,
+	// Kubernetes admission policies and their bindings are not synchronized
+	// +optional
+	Unsupported []PolicySourceEntry `json:"unsupported,omitempty"`
+}
+
+// PolicySourceEntry references a policy applied from a policy source.
+type PolicySourceEntry struct {
+	Kind string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (status *PolicySourceStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    PolicySourceConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicySourceReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicySourceReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+func (status *PolicySourceStatus) UpdateSyncTime() {
+	status.LastSyncTime = metav1.Now()
+}
+
+// IsReady indicates if the policysource is synchronized
+func (status *PolicySourceStatus) IsReady() bool {
+	condition := meta.FindStatusCondition(status.Conditions, PolicySourceConditionReady)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=polsrc,categories=kyverno,scope="Cluster"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="READY",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="REVISION",type="string",JSONPath=".status.revision"
+// +kubebuilder:printcolumn:name="LAST SYNC",type="date",JSONPath=".status.lastSyncTime"
+
+// PolicySource declares an OCI image or a Git repository holding Kyverno policies
+// that are applied to the cluster and kept in sync with the source.
+type PolicySource struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the policy source.
+	Spec PolicySourceSpec `json:"spec"`
+
+	// Status contains the synchronization state.
+	// +optional
+	Status PolicySourceStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicySource) Validate() (errs field.ErrorList) {
+	errs = append(errs, s.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// PolicySourceSpec stores the policy source spec
+// +kubebuilder:oneOf:={required:{oci}}
+// +kubebuilder:oneOf:={required:{git}}
+type PolicySourceSpec struct {
+	// OCI pulls the policies from an image pushed with `kubectl kyverno oci push`.
+	// Mutually exclusive with Git.
+	// +kubebuilder:validation:Optional
+	OCI *OCIPolicySource `json:"oci,omitempty"`
+
+	// Git clones the policies from a Git repository.
+	// Mutually exclusive with OCI.
+	// +kubebuilder:validation:Optional
+	Git *GitPolicySource `json:"git,omitempty"`
+
+	// Interval defines the interval at which the source is polled.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:default=`5m`
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Prune deletes the policies previously applied from this source when they are removed from the source.
+	// +kubebuilder:default=true
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+
+	// Suspend stops the synchronization, applied policies are left untouched.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+func (s *PolicySourceSpec) IsOCI() bool {
+	return s.OCI != nil
+}
+
+func (s *PolicySourceSpec) IsGit() bool {
+	return s.Git != nil
+}
+
+// PruneEnabled returns true if removed policies must be deleted, it defaults to true.
+func (s *PolicySourceSpec) PruneEnabled() bool {
+	return s.Prune == nil || *s.Prune
+}
+
+// Validate implements programmatic validation
+func (s *PolicySourceSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.IsOCI() == s.IsGit() {
+		errs = append(errs, field.Forbidden(path.Child("oci"), "A policy source should either have OCI or Git"))
+	}
+	if s.IsOCI() {
+		errs = append(errs, s.OCI.Validate(path.Child("oci"))...)
+	}
+	if s.IsGit() {
+		errs = append(errs, s.Git.Validate(path.Child("git"))...)
+	}
+	if s.Interval != nil && s.Interval.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), s.Interval.Duration.String(), "The interval must be greater than 0"))
+	}
+	return errs
+}
+
+// OCIPolicySource declares an image holding policies.
+type OCIPolicySource struct {
+	// Image is the reference of the image holding the policies.
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// ImagePullSecrets is a list of secrets used to pull the image.
+	// Secrets must live in the Kyverno namespace.
+	// +optional
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Verify declares how the image signature and its signed policy manifest are verified.
+	// It is required unless InsecureSkipVerify is set.
+	// +optional
+	Verify *OCISignatureVerification `json:"verify,omitempty"`
+
+	// InsecureSkipVerify applies the policies without verifying the image signature.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *OCIPolicySource) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Image == "" {
+		errs = append(errs, field.Required(path.Child("image"), "An OCI policy source requires an image"))
+	}
+	if s.Verify == nil && !s.InsecureSkipVerify {
+		errs = append(errs, field.Required(path.Child("verify"), "An OCI policy source requires signature verification unless insecureSkipVerify is set"))
+	}
+	if s.Verify != nil && s.InsecureSkipVerify {
+		errs = append(errs, field.Forbidden(path.Child("insecureSkipVerify"), "insecureSkipVerify can't be used with verify"))
+	}
+	if s.Verify != nil {
+		errs = append(errs, s.Verify.Validate(path.Child("verify"))...)
+	}
+	return errs
+}
+
+// OCISignatureVerification declares the cosign key or keyless identity the image was signed with.
+type OCISignatureVerification struct {
+	// PublicKey is a PEM encoded public key or a KMS URI.
+	// Mutually exclusive with Keyless.
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// Keyless declares the identity expected in keyless signing certificates.
+	// Mutually exclusive with PublicKey.
+	// +optional
+	Keyless *KeylessIdentity `json:"keyless,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (v *OCISignatureVerification) Validate(path *field.Path) (errs field.ErrorList) {
+	if (v.PublicKey == "") == (v.Keyless == nil) {
+		errs = append(errs, field.Forbidden(path.Child("publicKey"), "Signature verification should either have a public key or a keyless identity"))
+	}
+	if v.Keyless != nil {
+		if v.Keyless.Subject == "" {
+			errs = append(errs, field.Required(path.Child("keyless", "subject"), "A keyless identity requires a subject"))
+		}
+		if v.Keyless.Issuer == "" {
+			errs = append(errs, field.Required(path.Child("keyless", "issuer"), "A keyless identity requires an issuer"))
+		}
+	}
+	return errs
+}
+
+// KeylessIdentity is the identity of a keyless signing certificate.
+type KeylessIdentity struct {
+	// Subject is the identity the certificate was issued to.
+	// +kubebuilder:validation:Required
+	Subject string `json:"subject"`
+
+	// Issuer is the OIDC issuer of the identity.
+	// +kubebuilder:validation:Required
+	Issuer string `json:"issuer"`
+}
+
+// GitPolicySource declares a Git repository holding policies.
+type GitPolicySource struct {
+	// URL is the HTTP(S) URL of the repository.
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// Branch is the branch to clone.
+	// +kubebuilder:default=main
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// Path is the directory holding the policies, it defaults to the root of the repository.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// SecretRef is the name of a secret with the username and password keys used to authenticate.
+	// The secret must live in the Kyverno namespace.
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// Verify declares the OpenPGP keys the head commit must be signed with.
+	// +optional
+	Verify *GitSignatureVerification `json:"verify,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *GitPolicySource) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.URL == "" {
+		errs = append(errs, field.Required(path.Child("url"), "A Git policy source requires a URL"))
+	}
+	if s.Verify != nil && s.Verify.PublicKeys == "" {
+		errs = append(errs, field.Required(path.Child("verify", "publicKeys"), "Commit signature verification requires public keys"))
+	}
+	return errs
+}
+
+// GitSignatureVerification declares the keys commits are signed with.
+type GitSignatureVerification struct {
+	// PublicKeys is an armored OpenPGP key ring.
+	// +kubebuilder:validation:Required
+	PublicKeys string `json:"publicKeys"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicySourceList is a list of policy sources
+type PolicySourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicySource `json:"items"`
+}
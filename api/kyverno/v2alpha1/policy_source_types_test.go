@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPolicySourceSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    PolicySourceSpec
+		wantErr bool
+	}{
+		{
+			name: "valid OCI with key",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{
+					Image:  "ghcr.io/org/policies:v1",
+					Verify: &OCISignatureVerification{PublicKey: "-----BEGIN PUBLIC KEY-----"},
+				},
+			},
+		},
+		{
+			name: "valid OCI keyless",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{
+					Image: "ghcr.io/org/policies:v1",
+					Verify: &OCISignatureVerification{Keyless: &KeylessIdentity{
+						Subject: "https://github.com/org/policies/.github/workflows/release.yaml@refs/heads/main",
+						Issuer:  "https://token.actions.githubusercontent.com",
+					}},
+				},
+			},
+		},
+		{
+			name: "valid OCI without verification",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{Image: "ghcr.io/org/policies:v1", InsecureSkipVerify: true},
+			},
+		},
+		{
+			name: "valid Git",
+			spec: PolicySourceSpec{
+				Git:      &GitPolicySource{URL: "https://github.com/org/policies", Path: "policies"},
+				Interval: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			name:    "no source",
+			spec:    PolicySourceSpec{},
+			wantErr: true,
+		},
+		{
+			name: "both sources",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{Image: "ghcr.io/org/policies:v1", InsecureSkipVerify: true},
+				Git: &GitPolicySource{URL: "https://github.com/org/policies"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "OCI without image",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{InsecureSkipVerify: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "OCI without verification",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{Image: "ghcr.io/org/policies:v1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "OCI with verification and insecure skip verify",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{
+					Image:              "ghcr.io/org/policies:v1",
+					Verify:             &OCISignatureVerification{PublicKey: "-----BEGIN PUBLIC KEY-----"},
+					InsecureSkipVerify: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "OCI with key and keyless",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{
+					Image: "ghcr.io/org/policies:v1",
+					Verify: &OCISignatureVerification{
+						PublicKey: "-----BEGIN PUBLIC KEY-----",
+						Keyless:   &KeylessIdentity{Subject: "subject", Issuer: "issuer"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "OCI keyless without issuer",
+			spec: PolicySourceSpec{
+				OCI: &OCIPolicySource{
+					Image:  "ghcr.io/org/policies:v1",
+					Verify: &OCISignatureVerification{Keyless: &KeylessIdentity{Subject: "subject"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Git without URL",
+			spec: PolicySourceSpec{
+				Git: &GitPolicySource{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Git verification without keys",
+			spec: PolicySourceSpec{
+				Git: &GitPolicySource{URL: "https://github.com/org/policies", Verify: &GitSignatureVerification{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative interval",
+			spec: PolicySourceSpec{
+				Git:      &GitPolicySource{URL: "https://github.com/org/policies"},
+				Interval: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := PolicySource{Spec: tt.spec}
+			errs := source.Validate()
+			if (len(errs) != 0) != tt.wantErr {
+				t.Errorf("Validate() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicySourceSpecPruneEnabled(t *testing.T) {
+	if !(&PolicySourceSpec{}).PruneEnabled() {
+		t.Error("prune should be enabled by default")
+	}
+	if (&PolicySourceSpec{Prune: ptr.To(false)}).PruneEnabled() {
+		t.Error("prune should be disabled")
+	}
+}
+
+func TestPolicySourceStatusSetReady(t *testing.T) {
+	var status PolicySourceStatus
+	status.SetReady(true, "synchronized")
+	if !status.IsReady() {
+		t.Error("status should be ready")
+	}
+	status.SetReady(false, "failed")
+	if status.IsReady() {
+		t.Error("status should not be ready")
+	}
+}
@@ -79,6 +79,12 @@ type Spec struct {
 	// WebhookConfiguration specifies the custom configuration for Kubernetes admission webhookconfiguration.
 	// +optional
 	WebhookConfiguration *kyvernov1.WebhookConfiguration `json:"webhookConfiguration,omitempty"`
+
+	// Rollout enforces the policy in stages across namespaces.
+	// Validate rules with the Enforce failure action only block requests in the namespaces
+	// selected by the started stages, requests in other namespaces are audited.
+	// +optional
+	Rollout *kyvernov1.Rollout `json:"rollout,omitempty"`
 }
 
 func (s *Spec) CustomWebhookMatchConditions() bool {
@@ -340,6 +346,9 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if s.WebhookConfiguration != nil && s.WebhookConfiguration.TimeoutSeconds != nil && (*s.WebhookConfiguration.TimeoutSeconds < 1 || *s.WebhookConfiguration.TimeoutSeconds > 30) {
 		errs = append(errs, field.Invalid(path.Child("webhookConfiguration.timeoutSeconds"), s.WebhookConfiguration.TimeoutSeconds, "the timeout value must be between 1 and 30 seconds"))
 	}
+	if s.Rollout != nil {
+		errs = append(errs, s.Rollout.Validate(path.Child("rollout"))...)
+	}
 	warning, errors := s.ValidateRules(path.Child("rules"), namespaced, policyNamespace, clusterResources)
 	warnings = append(warnings, warning...)
 	errs = append(errs, errors...)
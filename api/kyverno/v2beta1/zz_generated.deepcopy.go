@@ -1031,6 +1031,11 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(v1.WebhookConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(v1.Rollout)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -91,6 +91,9 @@
     {{- $flags = append $flags (print "--exceptionNamespace=" .) -}}
   {{- end -}}
 {{- end -}}
+{{- with .policySources -}}
+  {{- $flags = append $flags (print "--enablePolicySources=" .enabled) -}}
+{{- end -}}
 {{- with .protectManagedResources -}}
   {{- $flags = append $flags (print "--protectManagedResources=" .enabled) -}}
 {{- end -}}
@@ -12,7 +12,9 @@ import (
 	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	"github.com/kyverno/kyverno/pkg/background/drift"
 	"github.com/kyverno/kyverno/pkg/background/gpol"
+	"github.com/kyverno/kyverno/pkg/background/history"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	celcompiler "github.com/kyverno/kyverno/pkg/cel/compiler"
 	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
@@ -76,8 +78,15 @@ func createrLeaderControllers(
 	mpolEngine mpolengine.Engine,
 	mapper meta.RESTMapper,
 	reportsConfig reportutils.ReportingConfiguration,
+	driftMode drift.Mode,
+	historyStore *history.Store,
 ) ([]internal.Controller, error) {
-	watchManager := gpol.NewWatchManager(logging.WithName("WatchManager"), dynamicClient)
+	driftReporter := drift.NewReporter(driftMode, kyvernoClient, eventGenerator)
+	var recorder history.Recorder
+	if historyStore != nil {
+		recorder = historyStore
+	}
+	watchManager := gpol.NewWatchManager(logging.WithName("WatchManager"), dynamicClient, gpol.WithDriftReporter(driftReporter))
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
 		dynamicClient,
@@ -119,14 +128,20 @@ func createrLeaderControllers(
 		mpolEngine,
 		mapper,
 		eventGenerator,
+		driftReporter,
+		recorder,
 		configuration,
 		jp,
 		reportsConfig,
 	)
-	return []internal.Controller{
+	controllers := []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
 		internal.NewController("background-controller", backgroundController, genWorkers),
-	}, err
+	}
+	if historyStore != nil {
+		controllers = append(controllers, internal.NewController("rollback-history", historyStore, 1))
+	}
+	return controllers, err
 }
 
 func main() {
@@ -139,6 +154,9 @@ func main() {
 		maxBackgroundReports            int
 		maxGlobalContextEntries         int
 		controllerRuntimeMetricsAddress string
+		generateDriftMode               string
+		rollbackRetention               time.Duration
+		maxRollbackRecords              int
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
@@ -149,6 +167,9 @@ func main() {
 	flagset.IntVar(&maxBackgroundReports, "maxBackgroundReports", 10000, "Maximum number of ephemeralreports created for the background policies.")
 	flagset.IntVar(&maxGlobalContextEntries, "maxGlobalContextEntries", 0, "Maximum number of entries in the global context store. When the limit is reached, new entries are rejected and retried. A value of 0 means unbounded.")
 	flagset.StringVar(&controllerRuntimeMetricsAddress, "controllerRuntimeMetricsAddress", "", `Bind address for controller-runtime metrics server. It will be defaulted to ":8080" if unspecified. Set this to "0" to disable the metrics server.`)
+	flagset.StringVar(&generateDriftMode, "generateDriftMode", string(drift.ModeRevert), "Action taken when a downstream resource of a synchronized generate rule or policy is modified, either revert (record the drift in an event and revert the change) or report (record the drift in an event and a policy report, the change is not reverted).")
+	flagset.DurationVar(&rollbackRetention, "rollbackRetention", 0, "Retention of the records of the changes made by mutating and generating policies in the background, used to roll back the changes of a policy. A value of 0 disables recording.")
+	flagset.IntVar(&maxRollbackRecords, "maxRollbackRecords", 10000, "Maximum number of rollback records kept, the oldest records are pruned first. A value of 0 means unbounded.")
 	flagset.Func(toggle.AllowHTTPInNamespacedPoliciesFlagName, toggle.AllowHTTPInNamespacedPoliciesDescription, toggle.AllowHTTPInNamespacedPolicies.Parse)
 	flagset.Func(toggle.HTTPBlocklistFlagName, toggle.HTTPBlocklistDescription, toggle.HTTPBlocklist.Parse)
 	flagset.Func(toggle.HTTPAllowlistFlagName, toggle.HTTPAllowlistDescription, toggle.HTTPAllowlist.Parse)
@@ -182,6 +203,11 @@ func main() {
 		fmt.Fprintf(os.Stderr, "invalid HTTP flag configuration: %v\n", err)
 		os.Exit(1)
 	}
+	driftMode, err := drift.ParseMode(generateDriftMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid drift mode: %v\n", err)
+		os.Exit(1)
+	}
 	var wg wait.Group
 	func() {
 		// setup
@@ -220,6 +246,10 @@ func main() {
 			event.Workers,
 		)
 		urGenerator := generator.NewUpdateRequestGenerator(setup.Configuration, setup.MetadataClient)
+		var historyStore *history.Store
+		if rollbackRetention > 0 {
+			historyStore = history.NewStore(setup.KubeClient.CoreV1(), config.KyvernoNamespace(), rollbackRetention, maxRollbackRecords)
+		}
 		gcstore := store.New(maxGlobalContextEntries)
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
@@ -258,6 +288,7 @@ func main() {
 			apicall.NewAPICallConfiguration(maxAPICallResponseLength, apiCallTimeout),
 			polexCache,
 			gcstore,
+			nil,
 		)
 		ephrCounterFunc := func(c breaker.Counter) func(context.Context) bool {
 			return func(context.Context) bool {
@@ -409,6 +440,8 @@ func main() {
 					mpolEngine,
 					restMapper,
 					setup.ReportingConfiguration,
+					driftMode,
+					historyStore,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
@@ -434,6 +467,7 @@ func main() {
 			setup.Logger.Error(err, "failed to initialize leader election")
 			os.Exit(1)
 		}
+		internal.SetupLeaderElectionAdmin(setup.Logger, le)
 		// start non leader controllers
 		eventController.Run(signalCtx, setup.Logger, &wg)
 		gceController.Run(signalCtx, setup.Logger, &wg)
@@ -441,7 +475,7 @@ func main() {
 			polexController.Run(signalCtx, setup.Logger, &wg)
 		}
 		// start leader election
-		le.Run(signalCtx)
+		internal.RunLeaderElection(signalCtx, setup.Logger, le)
 	}()
 	// wait for everything to shut down and exit
 	wg.Wait()
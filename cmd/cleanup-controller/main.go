@@ -93,6 +93,7 @@ func main() {
 		tlsKeyAlgorithm              string
 		maxGlobalContextEntries      int
 		disableCertManagerController bool
+		externalCertificates         bool
 	)
 	flagset := flag.NewFlagSet("cleanup-controller", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -115,6 +116,7 @@ func main() {
 	flagset.StringVar(&tlsKeyAlgorithm, "tlsKeyAlgorithm", "RSA", "Key algorithm for self-signed TLS certificates (RSA, ECDSA, Ed25519)")
 	flagset.IntVar(&maxGlobalContextEntries, "maxGlobalContextEntries", 0, "Maximum number of entries in the global context store. When the limit is reached, new entries are rejected and retried. A value of 0 means unbounded.")
 	flagset.BoolVar(&disableCertManagerController, "disableCertManagerController", false, "Disable the in-process certificate manager controller.")
+	flagset.BoolVar(&externalCertificates, "externalCertificates", false, "Use the TLS secret issued by an external certificate provider instead of self-signed certificates, the secret must contain the issuing CA in ca.crt.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -276,7 +278,21 @@ func main() {
 					keyAlgorithm,
 				)
 				var certController internal.Controller
-				if !disableCertManagerController {
+				if externalCertificates {
+					certController = internal.NewController(
+						certmanager.ExternalControllerName,
+						certmanager.NewExternalController(
+							caSecret,
+							tlsSecret,
+							setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+							caSecretName,
+							tlsSecretName,
+							config.KyvernoNamespace(),
+							config.InClusterServiceName(config.KyvernoServiceName(), config.KyvernoNamespace()),
+						),
+						certmanager.Workers,
+					)
+				} else if !disableCertManagerController {
 					certController = internal.NewController(
 						certmanager.ControllerName,
 						certmanager.NewController(
@@ -435,6 +451,7 @@ func main() {
 			setup.Logger.Error(err, "failed to initialize leader election")
 			os.Exit(1)
 		}
+		internal.SetupLeaderElectionAdmin(setup.Logger, le)
 		// create handlers
 		policyHandlers := policyhandlers.New(setup.KyvernoDynamicClient)
 		resourceHandlers := resourcehandlers.New(checker)
@@ -463,7 +480,7 @@ func main() {
 		eventController.Run(ctx, setup.Logger, &wg)
 		gceController.Run(ctx, setup.Logger, &wg)
 		// start leader election
-		le.Run(ctx)
+		internal.RunLeaderElection(ctx, setup.Logger, le)
 	}()
 	// wait for everything to shut down and exit
 	wg.Wait()
@@ -58,6 +58,10 @@ type Test struct {
 	// Results are the results to be checked in the test
 	Results []TestResult `json:"results,omitempty"`
 
+	// Steps are ordered admission requests evaluated in sequence, each with its own results.
+	// Resources mutated and generated by a step are carried forward to the following steps.
+	Steps []TestStep `json:"steps,omitempty"`
+
 	// Values are the values to be used in the test
 	Values *ValuesSpec `json:"values,omitempty"`
 
@@ -151,7 +155,7 @@ func ValidateAPICallResponses(entries []APICallResponseEntry) error {
 		if err := validateAPICallResponseEntry(i, entries[i]); err != nil {
 			return err
 		}
-		// Detect duplicate lookup keys — last-write-wins in buildHTTPMockIndex would
+		// Detect duplicate lookup keys — last-write-wins in BuildHTTPMockIndex would
 		// silently discard earlier entries, so we surface it as a validation error.
 		resolvedURL := entries[i].ResolvedURL()
 		method := strings.ToUpper(strings.TrimSpace(entries[i].Method))
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import "fmt"
+
+// TestStep declares a single admission request evaluated as part of an ordered sequence.
+// Resources mutated and generated by a step are carried forward to the following steps.
+type TestStep struct {
+	// Name is an optional name used to identify the step in the test output.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Operation is the admission operation of the step.
+	// Possible values are CREATE, UPDATE and DELETE.
+	// +kubebuilder:validation:Enum=CREATE;UPDATE;DELETE
+	Operation string `json:"operation"`
+
+	// Object is a resource configuration file in yaml format containing the resource
+	// submitted by the step (request.object). It is required for CREATE and UPDATE.
+	// For DELETE, it identifies the resource being deleted and is not sent as request.object.
+	// +optional
+	Object string `json:"object,omitempty"`
+
+	// OldObject is a resource configuration file in yaml format containing the prior
+	// state of the resource (request.oldObject). It is only supported for UPDATE and DELETE.
+	// If unset, the state carried forward from previous steps is used.
+	// +optional
+	OldObject string `json:"oldObject,omitempty"`
+
+	// Results are the results to be checked against the responses of the step.
+	// +optional
+	Results []TestResult `json:"results,omitempty"`
+}
+
+// DisplayName returns the name of the step at the given index, suitable for output.
+func (s TestStep) DisplayName(i int) string {
+	if s.Name != "" {
+		return fmt.Sprintf("step %d (%s)", i+1, s.Name)
+	}
+	return fmt.Sprintf("step %d", i+1)
+}
+
+// ValidateTestSteps validates the ordered steps of a test before a test run.
+func ValidateTestSteps(steps []TestStep) error {
+	for i, step := range steps {
+		name := step.DisplayName(i)
+		switch step.Operation {
+		case "CREATE":
+			if step.Object == "" {
+				return fmt.Errorf("steps: %s: object is required for CREATE", name)
+			}
+			if step.OldObject != "" {
+				return fmt.Errorf("steps: %s: oldObject is not supported for CREATE", name)
+			}
+		case "UPDATE":
+			if step.Object == "" {
+				return fmt.Errorf("steps: %s: object is required for UPDATE", name)
+			}
+		case "DELETE":
+			if step.Object == "" && step.OldObject == "" {
+				return fmt.Errorf("steps: %s: either object or oldObject is required for DELETE", name)
+			}
+		case "":
+			return fmt.Errorf("steps: %s: operation is required", name)
+		default:
+			return fmt.Errorf("steps: %s: invalid operation %q, must be one of CREATE, UPDATE, DELETE", name, step.Operation)
+		}
+		for _, res := range step.Results {
+			if res.Operation != "" && res.Operation != step.Operation {
+				return fmt.Errorf("steps: %s: result for policy %s declares operation %s, expected %s or none", name, res.Policy, res.Operation, step.Operation)
+			}
+			if res.IsDeletingPolicy {
+				return fmt.Errorf("steps: %s: result for policy %s: deleting policies are not supported in steps", name, res.Policy)
+			}
+		}
+	}
+	return nil
+}
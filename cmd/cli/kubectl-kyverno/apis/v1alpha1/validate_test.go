@@ -498,3 +498,74 @@ func TestRawExtensionToObject(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateTestSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []TestStep
+		wantErr string
+	}{{
+		name: "valid sequence",
+		steps: []TestStep{
+			{Operation: "CREATE", Object: "pod.yaml"},
+			{Operation: "UPDATE", Object: "pod-v2.yaml"},
+			{Operation: "UPDATE", Object: "pod-v3.yaml", OldObject: "pod-v2.yaml"},
+			{Operation: "DELETE", Object: "pod-v3.yaml"},
+			{Operation: "DELETE", OldObject: "pod.yaml"},
+		},
+	}, {
+		name:    "missing operation",
+		steps:   []TestStep{{Object: "pod.yaml"}},
+		wantErr: "step 1: operation is required",
+	}, {
+		name:    "invalid operation",
+		steps:   []TestStep{{Name: "connect", Operation: "CONNECT", Object: "pod.yaml"}},
+		wantErr: `step 1 (connect): invalid operation "CONNECT"`,
+	}, {
+		name:    "create without object",
+		steps:   []TestStep{{Operation: "CREATE"}},
+		wantErr: "object is required for CREATE",
+	}, {
+		name:    "create with old object",
+		steps:   []TestStep{{Operation: "CREATE", Object: "pod.yaml", OldObject: "old.yaml"}},
+		wantErr: "oldObject is not supported for CREATE",
+	}, {
+		name:    "update without object",
+		steps:   []TestStep{{Operation: "CREATE", Object: "pod.yaml"}, {Operation: "UPDATE", OldObject: "pod.yaml"}},
+		wantErr: "step 2: object is required for UPDATE",
+	}, {
+		name:    "delete without object",
+		steps:   []TestStep{{Operation: "DELETE"}},
+		wantErr: "either object or oldObject is required for DELETE",
+	}, {
+		name: "result with a different operation",
+		steps: []TestStep{{
+			Operation: "CREATE",
+			Object:    "pod.yaml",
+			Results:   []TestResult{{TestResultBase: TestResultBase{Policy: "pol", Operation: "UPDATE"}}},
+		}},
+		wantErr: "declares operation UPDATE, expected CREATE or none",
+	}, {
+		name: "result for a deleting policy",
+		steps: []TestStep{{
+			Operation: "DELETE",
+			Object:    "pod.yaml",
+			Results:   []TestResult{{TestResultBase: TestResultBase{Policy: "pol", IsDeletingPolicy: true}}},
+		}},
+		wantErr: "deleting policies are not supported in steps",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTestSteps(tt.steps)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
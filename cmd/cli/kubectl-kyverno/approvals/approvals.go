@@ -0,0 +1,232 @@
+package approvals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+type Client struct {
+	kyvernoClient versioned.Interface
+	client        dynamic.Interface
+	mapper        meta.RESTMapper
+	namespace     string
+}
+
+// NewClient returns a client managing the update requests waiting for approval in the given namespace.
+func NewClient(kubeConfig, kubeContext, namespace string) (*Client, error) {
+	clientConfig, err := config.CreateClientConfigWithContext(kubeConfig, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	kyvernoClient, err := versioned.NewForConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		kyvernoClient: kyvernoClient,
+		client:        dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		namespace:     namespace,
+	}, nil
+}
+
+// List returns the update requests waiting for approval, oldest first.
+func (c *Client) List(ctx context.Context) ([]kyvernov2.UpdateRequest, error) {
+	list, err := c.kyvernoClient.KyvernoV2().UpdateRequests(c.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var urs []kyvernov2.UpdateRequest
+	for _, ur := range list.Items {
+		if ur.Status.State == kyvernov2.WaitingForApproval {
+			urs = append(urs, ur)
+		}
+	}
+	sort.SliceStable(urs, func(i, j int) bool {
+		return urs[i].CreationTimestamp.Before(&urs[j].CreationTimestamp)
+	})
+	return urs, nil
+}
+
+// Get returns the update request with the given name, it must be waiting for approval.
+func (c *Client) Get(ctx context.Context, name string) (*kyvernov2.UpdateRequest, error) {
+	ur, err := c.kyvernoClient.KyvernoV2().UpdateRequests(c.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if ur.Status.State != kyvernov2.WaitingForApproval {
+		return nil, fmt.Errorf("update request %s is not waiting for approval (state: %s)", name, ur.Status.State)
+	}
+	return ur, nil
+}
+
+// Approve sets the approval annotation on the update request, the background controller then applies its changes.
+func (c *Client) Approve(ctx context.Context, name string) error {
+	ur, err := c.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if ur.IsApproved() {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				kyvernov2.URApprovedAnnotation: "true",
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.kyvernoClient.KyvernoV2().UpdateRequests(c.namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// Diff returns the diff between the targets the changes were computed against and their state once the changes are applied.
+// Targets that changed since are reported, their changes are computed again and must be approved again.
+func (c *Client) Diff(ctx context.Context, ur kyvernov2.UpdateRequest) (string, error) {
+	var out strings.Builder
+	for _, changeSet := range ur.Status.ChangeSets {
+		fmt.Fprintf(&out, "--- %s\n", changeSet.Resource.String())
+		current, err := c.getTarget(ctx, changeSet)
+		if err != nil {
+			return "", err
+		}
+		if current == nil {
+			fmt.Fprintln(&out, "resource not found")
+			continue
+		}
+		base, err := changeSetBase(changeSet)
+		if err != nil {
+			return "", err
+		}
+		// change sets recorded without their base are diffed against the current state of the target
+		if base == nil {
+			base = current
+		} else if changeSet.ResourceVersion != "" && current.GetResourceVersion() != changeSet.ResourceVersion {
+			fmt.Fprintln(&out, "resource changed since the changes were computed, they must be approved again")
+		}
+		diff, err := DiffChangeSet(*base, changeSet)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprint(&out, diff)
+	}
+	return out.String(), nil
+}
+
+// changeSetBase returns the target the change set was computed against, nil if it was not recorded.
+func changeSetBase(changeSet kyvernov2.ChangeSet) (*unstructured.Unstructured, error) {
+	if changeSet.Base == "" {
+		return nil, nil
+	}
+	var base unstructured.Unstructured
+	if err := json.Unmarshal([]byte(changeSet.Base), &base.Object); err != nil {
+		return nil, fmt.Errorf("failed to decode the base of %s: %w", changeSet.Resource.String(), err)
+	}
+	return &base, nil
+}
+
+func (c *Client) getTarget(ctx context.Context, changeSet kyvernov2.ChangeSet) (*unstructured.Unstructured, error) {
+	gvk := schema.FromAPIVersionAndKind(changeSet.Resource.GetAPIVersion(), changeSet.Resource.GetKind())
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	var client dynamic.ResourceInterface = c.client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		client = c.client.Resource(mapping.Resource).Namespace(changeSet.Resource.GetNamespace())
+	}
+	obj, err := client.Get(ctx, changeSet.Resource.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return obj, err
+}
+
+// DiffChangeSet applies the change set patch to the resource and returns a line diff of their yaml representation.
+func DiffChangeSet(resource unstructured.Unstructured, changeSet kyvernov2.ChangeSet) (string, error) {
+	patch, err := jsonpatch.DecodePatch([]byte(changeSet.Patch))
+	if err != nil {
+		return "", err
+	}
+	original, err := json.Marshal(resource.Object)
+	if err != nil {
+		return "", err
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return "", err
+	}
+	before, err := yaml.JSONToYAML(original)
+	if err != nil {
+		return "", err
+	}
+	after, err := yaml.JSONToYAML(patched)
+	if err != nil {
+		return "", err
+	}
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(string(before), string(after))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+	var out strings.Builder
+	for _, diff := range diffs {
+		prefix := " "
+		switch diff.Type {
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		}
+		for _, line := range strings.SplitAfter(diff.Text, "\n") {
+			if line != "" {
+				fmt.Fprint(&out, prefix+line)
+			}
+		}
+	}
+	return out.String(), nil
+}
+
+// Print writes the update requests waiting for approval as a table.
+func Print(out io.Writer, urs []kyvernov2.UpdateRequest) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPOLICY\tCHANGES\tAPPROVED\tAGE")
+	for _, ur := range urs {
+		age := duration.HumanDuration(time.Since(ur.GetCreationTimestamp().Time))
+		fmt.Fprintf(w, "%s\t%s\t%d\t%t\t%s\n", ur.GetName(), ur.Spec.GetPolicyKey(), len(ur.Status.ChangeSets), ur.IsApproved(), age)
+	}
+	return w.Flush()
+}
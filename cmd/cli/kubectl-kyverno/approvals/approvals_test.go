@@ -0,0 +1,107 @@
+package approvals
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestDiffChangeSet(t *testing.T) {
+	resource := unstructured.Unstructured{}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("ConfigMap")
+	resource.SetName("cm")
+	resource.SetLabels(map[string]string{"team": "a"})
+	changeSet := kyvernov2.ChangeSet{
+		Patch: `[{"op":"replace","path":"/metadata/labels/team","value":"b"}]`,
+	}
+	diff, err := DiffChangeSet(resource, changeSet)
+	assert.NoError(t, err)
+	assert.Equal(t, ` apiVersion: v1
+ kind: ConfigMap
+ metadata:
+   labels:
+-    team: a
++    team: b
+   name: cm
+`, diff)
+}
+
+func TestDiff(t *testing.T) {
+	live := &unstructured.Unstructured{}
+	live.SetAPIVersion("v1")
+	live.SetKind("ConfigMap")
+	live.SetNamespace("default")
+	live.SetName("cm")
+	live.SetLabels(map[string]string{"team": "c", "owner": "x"})
+	live.SetResourceVersion("43")
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	client := &Client{
+		client: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live),
+		mapper: mapper,
+	}
+	changeSet := kyvernov2.ChangeSet{
+		Resource:        kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm"},
+		ResourceVersion: "42",
+		Base:            `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"labels":{"team":"a"},"name":"cm","namespace":"default"}}`,
+		Patch:           `[{"op":"replace","path":"/metadata/labels/team","value":"b"}]`,
+	}
+	ur := kyvernov2.UpdateRequest{Status: kyvernov2.UpdateRequestStatus{ChangeSets: []kyvernov2.ChangeSet{changeSet}}}
+	diff, err := client.Diff(context.TODO(), ur)
+	assert.NoError(t, err)
+	assert.Equal(t, `--- v1/ConfigMap/default/cm
+resource changed since the changes were computed, they must be approved again
+ apiVersion: v1
+ kind: ConfigMap
+ metadata:
+   labels:
+-    team: a
++    team: b
+   name: cm
+   namespace: default
+`, diff)
+
+	// change sets recorded without their base are diffed against the target
+	changeSet.Base = ""
+	changeSet.ResourceVersion = "43"
+	ur.Status.ChangeSets = []kyvernov2.ChangeSet{changeSet}
+	diff, err = client.Diff(context.TODO(), ur)
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "-    team: c\n+    team: b\n")
+	assert.NotContains(t, diff, "must be approved again")
+}
+
+func TestDiffChangeSet_InvalidPatch(t *testing.T) {
+	_, err := DiffChangeSet(unstructured.Unstructured{Object: map[string]any{}}, kyvernov2.ChangeSet{Patch: `{}`})
+	assert.Error(t, err)
+}
+
+func TestPrint(t *testing.T) {
+	urs := []kyvernov2.UpdateRequest{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "ur-1",
+			CreationTimestamp: metav1.Now(),
+			Annotations:       map[string]string{kyvernov2.URApprovedAnnotation: "true"},
+		},
+		Spec: kyvernov2.UpdateRequestSpec{Policy: "add-labels"},
+		Status: kyvernov2.UpdateRequestStatus{
+			State:      kyvernov2.WaitingForApproval,
+			ChangeSets: []kyvernov2.ChangeSet{{Resource: kyvernov1.ResourceSpec{Kind: "ConfigMap"}}},
+		},
+	}}
+	var out bytes.Buffer
+	assert.NoError(t, Print(&out, urs))
+	assert.Contains(t, out.String(), "NAME   POLICY       CHANGES   APPROVED   AGE")
+	assert.Contains(t, out.String(), "ur-1   add-labels   1         true")
+}
@@ -0,0 +1,251 @@
+package bench
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sort"
+	"time"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	utils "github.com/kyverno/kyverno/pkg/utils/restmapper"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Policy is a policy benchmarked in isolation, together with its bindings and the loaded exceptions.
+type Policy struct {
+	Name     string
+	policies policy.LoaderResults
+}
+
+// Options configures a benchmark run.
+type Options struct {
+	// Iterations is the number of times each policy is evaluated against each resource.
+	Iterations int
+	// Warmup is the number of unmeasured iterations run before the measured ones.
+	Warmup    int
+	Variables *variables.Variables
+	UserInfo  *kyvernov2.RequestInfo
+}
+
+// Sample holds the measurements of a single policy evaluation.
+type Sample struct {
+	// Duration is the time taken to evaluate the policy on the resource.
+	Duration time.Duration
+	// Context is the time spent loading the rule contexts.
+	Context time.Duration
+	// Rules is the processing time reported by the engine for each rule.
+	Rules map[string]time.Duration
+}
+
+// Result holds the measurements of a policy.
+type Result struct {
+	Policy  string
+	Samples []Sample
+	// Allocs is the number of heap allocations made by all evaluations.
+	Allocs uint64
+	// Bytes is the number of heap bytes allocated by all evaluations.
+	Bytes  uint64
+	Errors int
+}
+
+// Split splits the loaded policies into policies benchmarked in isolation, sorted by name.
+func Split(results *policy.LoaderResults) []Policy {
+	var policies []Policy
+	add := func(name string, set policy.LoaderResults) {
+		set.PolicyExceptions = results.PolicyExceptions
+		set.PolicyCelExceptions = results.PolicyCelExceptions
+		policies = append(policies, Policy{Name: name, policies: set})
+	}
+	for _, pol := range results.Policies {
+		add(policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName()), policy.LoaderResults{Policies: []kyvernov1.PolicyInterface{pol}})
+	}
+	for _, pol := range results.VAPs {
+		var bindings []admissionregistrationv1.ValidatingAdmissionPolicyBinding
+		for _, binding := range results.VAPBindings {
+			if binding.Spec.PolicyName == pol.Name {
+				bindings = append(bindings, binding)
+			}
+		}
+		add(policyName("ValidatingAdmissionPolicy", "", pol.Name), policy.LoaderResults{
+			VAPs:        []admissionregistrationv1.ValidatingAdmissionPolicy{pol},
+			VAPBindings: bindings,
+		})
+	}
+	for _, pol := range results.MAPs {
+		var bindings []admissionregistrationv1beta1.MutatingAdmissionPolicyBinding
+		for _, binding := range results.MAPBindings {
+			if binding.Spec.PolicyName == pol.Name {
+				bindings = append(bindings, binding)
+			}
+		}
+		add(policyName("MutatingAdmissionPolicy", "", pol.Name), policy.LoaderResults{
+			MAPs:        []admissionregistrationv1beta1.MutatingAdmissionPolicy{pol},
+			MAPBindings: bindings,
+		})
+	}
+	for _, pol := range results.ValidatingPolicies {
+		add(policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName()), policy.LoaderResults{ValidatingPolicies: []policiesv1beta1.ValidatingPolicyLike{pol}})
+	}
+	for _, pol := range results.MutatingPolicies {
+		add(policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName()), policy.LoaderResults{MutatingPolicies: []policiesv1beta1.MutatingPolicyLike{pol}})
+	}
+	for _, pol := range results.GeneratingPolicies {
+		add(policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName()), policy.LoaderResults{GeneratingPolicies: []policiesv1beta1.GeneratingPolicyLike{pol}})
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies
+}
+
+// Run evaluates each policy against each resource through the admission engines and measures the evaluations.
+func Run(policies []Policy, resources []*unstructured.Unstructured, options Options) ([]Result, error) {
+	vars := options.Variables
+	if vars == nil {
+		var err error
+		if vars, err = variables.New(io.Discard, nil, "", "", nil); err != nil {
+			return nil, err
+		}
+	}
+	restMapper, err := utils.GetRESTMapper(nil)
+	if err != nil {
+		return nil, err
+	}
+	var s store.Store
+	s.SetLocal(true)
+	var clock contextClock
+	contextLoaderFactory := clock.wrap(store.ContextLoaderFactory(&s, nil))
+	results := make([]Result, 0, len(policies))
+	for _, pol := range policies {
+		compiled := &processor.CompiledPolicies{}
+		newProcessor := func(resource unstructured.Unstructured) *processor.PolicyProcessor {
+			return &processor.PolicyProcessor{
+				Store:                             &s,
+				Policies:                          pol.policies.Policies,
+				ValidatingAdmissionPolicies:       pol.policies.VAPs,
+				ValidatingAdmissionPolicyBindings: pol.policies.VAPBindings,
+				MutatingAdmissionPolicies:         pol.policies.MAPs,
+				MutatingAdmissionPolicyBindings:   pol.policies.MAPBindings,
+				ValidatingPolicies:                pol.policies.ValidatingPolicies,
+				MutatingPolicies:                  pol.policies.MutatingPolicies,
+				GeneratingPolicies:                pol.policies.GeneratingPolicies,
+				Resource:                          resource,
+				PolicyExceptions:                  pol.policies.PolicyExceptions,
+				CELExceptions:                     pol.policies.PolicyCelExceptions,
+				Variables:                         vars,
+				UserInfo:                          options.UserInfo,
+				PolicyReport:                      true,
+				NamespaceSelectorMap:              vars.NamespaceSelectors(),
+				Rc:                                &processor.ResultCounts{},
+				Subresources:                      vars.Subresources(),
+				Out:                               io.Discard,
+				RESTMapper:                        restMapper,
+				ContextLoaderFactory:              contextLoaderFactory,
+				Compiled:                          compiled,
+			}
+		}
+		evaluate := func(resource *unstructured.Unstructured) ([]engineapi.EngineResponse, error) {
+			return newProcessor(*resource).ApplyPoliciesOnResource()
+		}
+		result := Result{Policy: pol.Name}
+		// policies are compiled once, only their evaluation is measured
+		if err := newProcessor(unstructured.Unstructured{}).Compile(); err != nil {
+			result.Errors = options.Iterations * len(resources)
+			results = append(results, result)
+			continue
+		}
+		// warmup evaluations are not measured and their failures are ignored
+		for i := 0; i < options.Warmup; i++ {
+			for _, resource := range resources {
+				_, _ = evaluate(resource)
+			}
+		}
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		for i := 0; i < options.Iterations; i++ {
+			for _, resource := range resources {
+				clock.reset()
+				start := time.Now()
+				responses, err := evaluate(resource)
+				sample := Sample{Duration: time.Since(start), Context: clock.elapsed}
+				// failed evaluations are counted but excluded from the samples
+				if err != nil {
+					result.Errors++
+					continue
+				}
+				for _, response := range responses {
+					for _, rule := range response.PolicyResponse.Rules {
+						if rule.Stats().Time().IsZero() {
+							continue
+						}
+						if sample.Rules == nil {
+							sample.Rules = map[string]time.Duration{}
+						}
+						sample.Rules[rule.Name()] += rule.Stats().ProcessingTime()
+					}
+				}
+				result.Samples = append(result.Samples, sample)
+			}
+		}
+		runtime.ReadMemStats(&after)
+		result.Allocs = after.Mallocs - before.Mallocs
+		result.Bytes = after.TotalAlloc - before.TotalAlloc
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// contextClock accumulates the time spent loading rule contexts.
+type contextClock struct {
+	elapsed time.Duration
+}
+
+func (c *contextClock) reset() {
+	c.elapsed = 0
+}
+
+func (c *contextClock) wrap(factory engineapi.ContextLoaderFactory) engineapi.ContextLoaderFactory {
+	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
+		return timedContextLoader{clock: c, inner: factory(policy, rule)}
+	}
+}
+
+type timedContextLoader struct {
+	clock *contextClock
+	inner engineapi.ContextLoader
+}
+
+func (l timedContextLoader) Load(
+	ctx context.Context,
+	jp jmespath.Interface,
+	client engineapi.RawClient,
+	rclientFactory engineapi.RegistryClientFactory,
+	contextEntries []kyvernov1.ContextEntry,
+	jsonContext enginecontext.Interface,
+) error {
+	start := time.Now()
+	defer func() {
+		l.clock.elapsed += time.Since(start)
+	}()
+	return l.inner.Load(ctx, jp, client, rclientFactory, contextEntries, jsonContext)
+}
+
+func policyName(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,66 @@
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSplit(t *testing.T) {
+	results := &policy.LoaderResults{
+		Policies: []kyvernov1.PolicyInterface{
+			&kyvernov1.ClusterPolicy{TypeMeta: metav1.TypeMeta{Kind: "ClusterPolicy"}, ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+			&kyvernov1.Policy{TypeMeta: metav1.TypeMeta{Kind: "Policy"}, ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team"}},
+		},
+		VAPs: []admissionregistrationv1.ValidatingAdmissionPolicy{{ObjectMeta: metav1.ObjectMeta{Name: "vap"}}},
+		VAPBindings: []admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+			{ObjectMeta: metav1.ObjectMeta{Name: "binding"}, Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{PolicyName: "vap"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{PolicyName: "other"}},
+		},
+		ValidatingPolicies: []policiesv1beta1.ValidatingPolicyLike{
+			&policiesv1beta1.ValidatingPolicy{TypeMeta: metav1.TypeMeta{Kind: "ValidatingPolicy"}, ObjectMeta: metav1.ObjectMeta{Name: "vpol"}},
+		},
+		PolicyCelExceptions: []*policiesv1beta1.PolicyException{{ObjectMeta: metav1.ObjectMeta{Name: "exception"}}},
+	}
+	policies := Split(results)
+	var names []string
+	for _, pol := range policies {
+		names = append(names, pol.Name)
+		assert.Equal(t, results.PolicyCelExceptions, pol.policies.PolicyCelExceptions)
+	}
+	assert.Equal(t, []string{"ClusterPolicy/b", "Policy/team/a", "ValidatingAdmissionPolicy/vap", "ValidatingPolicy/vpol"}, names)
+	assert.Len(t, policies[0].policies.Policies, 1)
+	assert.Len(t, policies[2].policies.VAPs, 1)
+	assert.Equal(t, results.VAPBindings[:1], policies[2].policies.VAPBindings)
+	assert.Len(t, policies[3].policies.ValidatingPolicies, 1)
+}
+
+type sleepingContextLoader struct{}
+
+func (sleepingContextLoader) Load(context.Context, jmespath.Interface, engineapi.RawClient, engineapi.RegistryClientFactory, []kyvernov1.ContextEntry, enginecontext.Interface) error {
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func TestContextClock(t *testing.T) {
+	var clock contextClock
+	factory := clock.wrap(func(kyvernov1.PolicyInterface, kyvernov1.Rule) engineapi.ContextLoader {
+		return sleepingContextLoader{}
+	})
+	loader := factory(nil, kyvernov1.Rule{})
+	assert.NoError(t, loader.Load(context.TODO(), nil, nil, nil, nil, nil))
+	assert.NoError(t, loader.Load(context.TODO(), nil, nil, nil, nil, nil))
+	assert.GreaterOrEqual(t, clock.elapsed, 2*time.Millisecond)
+	clock.reset()
+	assert.Equal(t, time.Duration(0), clock.elapsed)
+}
@@ -0,0 +1,240 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Latency summarizes a set of durations.
+type Latency struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// NewLatency computes the percentiles of the durations.
+func NewLatency(durations ...time.Duration) Latency {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return Latency{
+		P50: Percentile(sorted, 50),
+		P90: Percentile(sorted, 90),
+		P99: Percentile(sorted, 99),
+		Max: Percentile(sorted, 100),
+	}
+}
+
+// Percentile returns the nearest-rank percentile of sorted durations.
+func Percentile(sorted []time.Duration, percentile float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(percentile * float64(len(sorted)) / 100))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// Runs returns the number of evaluations, including failed ones.
+func (r Result) Runs() int {
+	return len(r.Samples) + r.Errors
+}
+
+// Latency returns the latency of the policy evaluations.
+func (r Result) Latency() Latency {
+	durations := make([]time.Duration, 0, len(r.Samples))
+	for _, sample := range r.Samples {
+		durations = append(durations, sample.Duration)
+	}
+	return NewLatency(durations...)
+}
+
+// ContextLatency returns the time spent loading rule contexts in the policy evaluations.
+func (r Result) ContextLatency() Latency {
+	durations := make([]time.Duration, 0, len(r.Samples))
+	for _, sample := range r.Samples {
+		durations = append(durations, sample.Context)
+	}
+	return NewLatency(durations...)
+}
+
+// RuleLatencies returns the latency of the rules reported by the engines, by rule name.
+func (r Result) RuleLatencies() map[string][]time.Duration {
+	rules := map[string][]time.Duration{}
+	for _, sample := range r.Samples {
+		for rule, duration := range sample.Rules {
+			rules[rule] = append(rules[rule], duration)
+		}
+	}
+	return rules
+}
+
+// AllocsPerOp returns the average number of heap allocations per evaluation.
+func (r Result) AllocsPerOp() uint64 {
+	if r.Runs() == 0 {
+		return 0
+	}
+	return r.Allocs / uint64(r.Runs())
+}
+
+// BytesPerOp returns the average number of heap bytes allocated per evaluation.
+func (r Result) BytesPerOp() uint64 {
+	if r.Runs() == 0 {
+		return 0
+	}
+	return r.Bytes / uint64(r.Runs())
+}
+
+// PolicyRow is the benchmark summary of a policy.
+type PolicyRow struct {
+	Policy  string `header:"policy"`
+	Runs    int    `header:"runs"`
+	P50     string `header:"p50"`
+	P90     string `header:"p90"`
+	P99     string `header:"p99"`
+	Max     string `header:"max"`
+	Context string `header:"context p50"`
+	Allocs  uint64 `header:"allocs/op"`
+	Bytes   uint64 `header:"bytes/op"`
+	Errors  int    `header:"errors"`
+}
+
+// RuleRow is the benchmark summary of a rule.
+type RuleRow struct {
+	Policy string `header:"policy"`
+	Rule   string `header:"rule"`
+	Runs   int    `header:"runs"`
+	P50    string `header:"p50"`
+	P90    string `header:"p90"`
+	P99    string `header:"p99"`
+	Max    string `header:"max"`
+}
+
+// ComparisonRow compares the benchmark summaries of two versions of a policy.
+type ComparisonRow struct {
+	Policy          string `header:"policy"`
+	Baseline        string `header:"baseline p50"`
+	Candidate       string `header:"candidate p50"`
+	Delta           string `header:"delta"`
+	BaselineAllocs  string `header:"baseline allocs/op"`
+	CandidateAllocs string `header:"candidate allocs/op"`
+	AllocsDelta     string `header:"allocs delta"`
+}
+
+// PolicyRows summarizes the results by policy.
+func PolicyRows(results ...Result) []PolicyRow {
+	rows := make([]PolicyRow, 0, len(results))
+	for _, result := range results {
+		latency := result.Latency()
+		rows = append(rows, PolicyRow{
+			Policy:  result.Policy,
+			Runs:    result.Runs(),
+			P50:     formatDuration(latency.P50),
+			P90:     formatDuration(latency.P90),
+			P99:     formatDuration(latency.P99),
+			Max:     formatDuration(latency.Max),
+			Context: formatDuration(result.ContextLatency().P50),
+			Allocs:  result.AllocsPerOp(),
+			Bytes:   result.BytesPerOp(),
+			Errors:  result.Errors,
+		})
+	}
+	return rows
+}
+
+// RuleRows summarizes the results by rule, for the rules timed by the engines.
+func RuleRows(results ...Result) []RuleRow {
+	var rows []RuleRow
+	for _, result := range results {
+		rules := result.RuleLatencies()
+		names := make([]string, 0, len(rules))
+		for name := range rules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			latency := NewLatency(rules[name]...)
+			rows = append(rows, RuleRow{
+				Policy: result.Policy,
+				Rule:   name,
+				Runs:   len(rules[name]),
+				P50:    formatDuration(latency.P50),
+				P90:    formatDuration(latency.P90),
+				P99:    formatDuration(latency.P99),
+				Max:    formatDuration(latency.Max),
+			})
+		}
+	}
+	return rows
+}
+
+// Compare compares the results of two versions of the policies, matching policies by name.
+func Compare(baseline, candidate []Result) []ComparisonRow {
+	byName := func(results []Result) map[string]Result {
+		out := make(map[string]Result, len(results))
+		for _, result := range results {
+			out[result.Policy] = result
+		}
+		return out
+	}
+	baselines, candidates := byName(baseline), byName(candidate)
+	names := make([]string, 0, len(baselines)+len(candidates))
+	for name := range baselines {
+		names = append(names, name)
+	}
+	for name := range candidates {
+		if _, ok := baselines[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	rows := make([]ComparisonRow, 0, len(names))
+	for _, name := range names {
+		row := ComparisonRow{Policy: name, Baseline: "-", Candidate: "-", Delta: "-", BaselineAllocs: "-", CandidateAllocs: "-", AllocsDelta: "-"}
+		before, hasBefore := baselines[name]
+		after, hasAfter := candidates[name]
+		if hasBefore {
+			row.Baseline = formatDuration(before.Latency().P50)
+			row.BaselineAllocs = fmt.Sprint(before.AllocsPerOp())
+		}
+		if hasAfter {
+			row.Candidate = formatDuration(after.Latency().P50)
+			row.CandidateAllocs = fmt.Sprint(after.AllocsPerOp())
+		}
+		if hasBefore && hasAfter {
+			row.Delta = formatDelta(float64(before.Latency().P50), float64(after.Latency().P50))
+			row.AllocsDelta = formatDelta(float64(before.AllocsPerOp()), float64(after.AllocsPerOp()))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.Round(10 * time.Nanosecond).String()
+	}
+}
+
+func formatDelta(before, after float64) string {
+	if before == 0 {
+		if after == 0 {
+			return "+0.0%"
+		}
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", (after-before)/before*100)
+}
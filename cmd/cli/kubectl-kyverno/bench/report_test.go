@@ -0,0 +1,103 @@
+package bench
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLatency(t *testing.T) {
+	var durations []time.Duration
+	for i := 100; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, Latency{
+		P50: 50 * time.Millisecond,
+		P90: 90 * time.Millisecond,
+		P99: 99 * time.Millisecond,
+		Max: 100 * time.Millisecond,
+	}, NewLatency(durations...))
+	assert.Equal(t, 100*time.Millisecond, durations[0])
+	assert.Equal(t, Latency{}, NewLatency())
+	assert.Equal(t, Latency{P50: 3, P90: 3, P99: 3, Max: 3}, NewLatency(3))
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4}
+	assert.Equal(t, time.Duration(1), Percentile(sorted, 0))
+	assert.Equal(t, time.Duration(2), Percentile(sorted, 50))
+	assert.Equal(t, time.Duration(3), Percentile(sorted, 51))
+	assert.Equal(t, time.Duration(4), Percentile(sorted, 100))
+	assert.Equal(t, time.Duration(0), Percentile(nil, 50))
+}
+
+func TestResult(t *testing.T) {
+	result := Result{
+		Policy: "ClusterPolicy/require-labels",
+		Samples: []Sample{
+			{Duration: 3 * time.Millisecond, Context: time.Millisecond, Rules: map[string]time.Duration{"a": 2 * time.Millisecond, "b": time.Millisecond}},
+			{Duration: time.Millisecond, Rules: map[string]time.Duration{"a": time.Millisecond}},
+		},
+		Allocs: 300,
+		Bytes:  3000,
+		Errors: 1,
+	}
+	assert.Equal(t, 3, result.Runs())
+	assert.Equal(t, uint64(100), result.AllocsPerOp())
+	assert.Equal(t, uint64(1000), result.BytesPerOp())
+	assert.Equal(t, time.Millisecond, result.Latency().P50)
+	assert.Equal(t, 3*time.Millisecond, result.Latency().Max)
+	assert.Equal(t, time.Millisecond, result.ContextLatency().Max)
+	assert.Equal(t, map[string][]time.Duration{
+		"a": {2 * time.Millisecond, time.Millisecond},
+		"b": {time.Millisecond},
+	}, result.RuleLatencies())
+	assert.Equal(t, uint64(0), Result{}.AllocsPerOp())
+
+	assert.Equal(t, []PolicyRow{{
+		Policy:  "ClusterPolicy/require-labels",
+		Runs:    3,
+		P50:     "1ms",
+		P90:     "3ms",
+		P99:     "3ms",
+		Max:     "3ms",
+		Context: "0s",
+		Allocs:  100,
+		Bytes:   1000,
+		Errors:  1,
+	}}, PolicyRows(result))
+	assert.Equal(t, []RuleRow{
+		{Policy: "ClusterPolicy/require-labels", Rule: "a", Runs: 2, P50: "1ms", P90: "2ms", P99: "2ms", Max: "2ms"},
+		{Policy: "ClusterPolicy/require-labels", Rule: "b", Runs: 1, P50: "1ms", P90: "1ms", P99: "1ms", Max: "1ms"},
+	}, RuleRows(result))
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		{Policy: "ClusterPolicy/a", Samples: []Sample{{Duration: 2 * time.Millisecond}}, Allocs: 100},
+		{Policy: "ClusterPolicy/b", Samples: []Sample{{Duration: time.Millisecond}}},
+	}
+	candidate := []Result{
+		{Policy: "ClusterPolicy/a", Samples: []Sample{{Duration: 3 * time.Millisecond}}, Allocs: 50},
+		{Policy: "ValidatingPolicy/c", Samples: []Sample{{Duration: time.Millisecond}}},
+	}
+	assert.Equal(t, []ComparisonRow{
+		{Policy: "ClusterPolicy/a", Baseline: "2ms", Candidate: "3ms", Delta: "+50.0%", BaselineAllocs: "100", CandidateAllocs: "50", AllocsDelta: "-50.0%"},
+		{Policy: "ClusterPolicy/b", Baseline: "1ms", Candidate: "-", Delta: "-", BaselineAllocs: "0", CandidateAllocs: "-", AllocsDelta: "-"},
+		{Policy: "ValidatingPolicy/c", Baseline: "-", Candidate: "1ms", Delta: "-", BaselineAllocs: "-", CandidateAllocs: "0", AllocsDelta: "-"},
+	}, Compare(baseline, candidate))
+}
+
+func Test_formatDuration(t *testing.T) {
+	assert.Equal(t, "1.235s", formatDuration(1234567891*time.Nanosecond))
+	assert.Equal(t, "1.235ms", formatDuration(1234567*time.Nanosecond))
+	assert.Equal(t, "12.35µs", formatDuration(12345*time.Nanosecond))
+	assert.Equal(t, "0s", formatDuration(0))
+}
+
+func Test_formatDelta(t *testing.T) {
+	assert.Equal(t, "+0.0%", formatDelta(0, 0))
+	assert.Equal(t, "n/a", formatDelta(0, 1))
+	assert.Equal(t, "-25.0%", formatDelta(4, 3))
+}
@@ -0,0 +1,56 @@
+package celenv
+
+import (
+	"fmt"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/kyverno/kyverno/pkg/utils/restmapper"
+)
+
+// SetupContext sets up the libraries context with the API call responses and global context entries
+// mocked in a test file, and the resources and images of a context file. Both files are optional.
+func SetupContext(testFile, contextFile string) error {
+	var globalContextEntries map[string]any
+	var httpMockIndex map[string]any
+	if testFile != "" {
+		testCases := test.LoadTest(nil, testFile)
+		if len(testCases) == 0 {
+			return fmt.Errorf("no test found in %s", testFile)
+		}
+		testCase := testCases[0]
+		if testCase.Err != nil {
+			return fmt.Errorf("failed to load test %s: %w", testFile, testCase.Err)
+		}
+		if err := v1alpha1.ValidateAPICallResponses(testCase.Test.APICallResponses); err != nil {
+			return err
+		}
+		index, err := store.BuildHTTPMockIndex(testCase.Test.APICallResponses)
+		if err != nil {
+			return err
+		}
+		httpMockIndex = index
+		entries, err := store.ResolveGCEResourceFiles(testCase.Fs, testCase.Dir(), testCase.Test.GlobalContextEntries)
+		if err != nil {
+			return fmt.Errorf("failed to load globalContextEntries resource files: %w", err)
+		}
+		if len(entries) > 0 {
+			globalContextEntries = make(map[string]any, len(entries))
+			for _, entry := range entries {
+				data, err := store.ResolveGlobalContextMockData(entry)
+				if err != nil {
+					return err
+				}
+				globalContextEntries[entry.Name] = data
+			}
+		}
+	}
+	restMapper, err := restmapper.GetRESTMapper(nil)
+	if err != nil {
+		return err
+	}
+	_, err = processor.NewContextProvider(nil, restMapper, nil, contextFile, false, true, globalContextEntries, httpMockIndex)
+	return err
+}
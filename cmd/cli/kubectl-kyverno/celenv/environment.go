@@ -0,0 +1,172 @@
+package celenv
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	gpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/gpol/compiler"
+	mpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
+	vpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apiserver/pkg/cel/lazy"
+)
+
+// PolicyType selects the policy compiler an environment is built from.
+type PolicyType string
+
+const (
+	ValidatingPolicy PolicyType = "validating"
+	MutatingPolicy   PolicyType = "mutating"
+	GeneratingPolicy PolicyType = "generating"
+)
+
+// PolicyTypes lists the supported policy types.
+var PolicyTypes = []PolicyType{ValidatingPolicy, MutatingPolicy, GeneratingPolicy}
+
+// Inputs are the keys of the input data expressions are evaluated against.
+var Inputs = []string{
+	compiler.ObjectKey,
+	compiler.OldObjectKey,
+	compiler.RequestKey,
+	compiler.NamespaceObjectKey,
+}
+
+// Environment evaluates standalone CEL expressions with the same libraries and declarations as the policy compilers.
+type Environment struct {
+	env       *cel.Env
+	provider  *compiler.VariablesProvider
+	inputs    map[string]any
+	variables map[string]ref.Val
+}
+
+// NewEnvironment returns an environment for the given policy type and namespace. The libraries context must be set up
+// before the environment is created, see SetupContext.
+func NewEnvironment(policyType PolicyType, namespace string) (*Environment, error) {
+	var env *cel.Env
+	var provider *compiler.VariablesProvider
+	var err error
+	switch policyType {
+	case ValidatingPolicy, "":
+		env, provider, err = vpolcompiler.NewEnv(namespace)
+	case MutatingPolicy:
+		env, provider, err = mpolcompiler.NewEnv(namespace)
+	case GeneratingPolicy:
+		env, provider, err = gpolcompiler.NewEnv(namespace)
+	default:
+		return nil, fmt.Errorf("unsupported policy type %q, must be one of %v", policyType, PolicyTypes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s policy environment: %w", policyType, err)
+	}
+	return &Environment{
+		env:       env,
+		provider:  provider,
+		inputs:    map[string]any{},
+		variables: map[string]ref.Val{},
+	}, nil
+}
+
+// SetInput sets the value of an input (object, oldObject, request or namespaceObject).
+func (e *Environment) SetInput(name string, value any) error {
+	if !slices.Contains(Inputs, name) {
+		return fmt.Errorf("unknown input %q, must be one of %s", name, strings.Join(Inputs, ", "))
+	}
+	e.inputs[name] = value
+	return nil
+}
+
+// SetVariable declares a variable, it can be referenced as variables.<name> in expressions.
+func (e *Environment) SetVariable(name string, value any) {
+	e.setVariable(name, e.env.CELTypeAdapter().NativeToValue(value))
+}
+
+func (e *Environment) setVariable(name string, value ref.Val) {
+	if _, exists := e.variables[name]; !exists {
+		e.provider.RegisterField(name, cel.DynType)
+	}
+	e.variables[name] = value
+}
+
+// Variables returns the declared variable names, sorted.
+func (e *Environment) Variables() []string {
+	names := make([]string, 0, len(e.variables))
+	for name := range e.variables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Check parses and type checks an expression and returns its output type.
+func (e *Environment) Check(expression string) (*cel.Type, error) {
+	ast, issues := e.env.Compile(expression)
+	if err := issues.Err(); err != nil {
+		return nil, err
+	}
+	return ast.OutputType(), nil
+}
+
+// Eval evaluates an expression and returns its result converted to plain Go values.
+func (e *Environment) Eval(ctx context.Context, expression string) (any, error) {
+	out, err := e.eval(ctx, expression)
+	if err != nil {
+		return nil, err
+	}
+	return toNative(out), nil
+}
+
+// Let evaluates an expression and declares its result as a variable.
+func (e *Environment) Let(ctx context.Context, name string, expression string) error {
+	out, err := e.eval(ctx, expression)
+	if err != nil {
+		return err
+	}
+	e.setVariable(name, out)
+	return nil
+}
+
+func (e *Environment) eval(ctx context.Context, expression string) (ref.Val, error) {
+	ast, issues := e.env.Compile(expression)
+	if err := issues.Err(); err != nil {
+		return nil, err
+	}
+	program, err := e.env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := program.ContextEval(ctx, e.activation())
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (e *Environment) activation() map[string]any {
+	data := make(map[string]any, len(Inputs)+1)
+	for _, name := range Inputs {
+		data[name] = e.inputs[name]
+	}
+	variables := lazy.NewMapValue(compiler.VariablesType)
+	for name, value := range e.variables {
+		variables.Append(name, func(*lazy.MapValue) ref.Val {
+			return value
+		})
+	}
+	data[compiler.VariablesKey] = variables
+	return data
+}
+
+func toNative(value ref.Val) any {
+	if native, err := value.ConvertToNative(reflect.TypeFor[*structpb.Value]()); err == nil {
+		if value, ok := native.(*structpb.Value); ok {
+			return value.AsInterface()
+		}
+	}
+	return value.Value()
+}
@@ -0,0 +1,114 @@
+package celenv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// Options are the command line options shared by the cel commands to build an environment.
+type Options struct {
+	PolicyType      string
+	Namespace       string
+	Object          string
+	OldObject       string
+	Request         string
+	NamespaceObject string
+	Variables       string
+	TestFile        string
+	ContextFile     string
+}
+
+// AddEnvFlags registers the flags configuring the environment.
+func (o *Options) AddEnvFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.PolicyType, "policy-type", string(ValidatingPolicy), "Policy type whose compiler environment is used (validating, mutating or generating)")
+	flags.StringVarP(&o.Namespace, "namespace", "n", "", "Namespace of the policy, used to scope the resource library")
+	flags.StringVar(&o.Variables, "variables", "", "Read variables from a JSON or YAML file mapping variable names to values")
+	flags.StringVar(&o.TestFile, "test", "", "Read API call responses and global context entries mocks from a kyverno-test.yaml file")
+	flags.StringVar(&o.ContextFile, "context", "", "Read resources and images mocks from a context file")
+}
+
+// AddInputFlags registers the flags loading the input data.
+func (o *Options) AddInputFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.Object, "object", "", "Read object from a JSON or YAML file")
+	flags.StringVar(&o.OldObject, "old-object", "", "Read oldObject from a JSON or YAML file")
+	flags.StringVar(&o.Request, "request", "", "Read request from a JSON or YAML file")
+	flags.StringVar(&o.NamespaceObject, "namespace-object", "", "Read namespaceObject from a JSON or YAML file")
+}
+
+// Environment sets up the libraries context and returns an environment loaded with the configured files.
+func (o *Options) Environment() (*Environment, error) {
+	if err := SetupContext(o.TestFile, o.ContextFile); err != nil {
+		return nil, err
+	}
+	env, err := NewEnvironment(PolicyType(o.PolicyType), o.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	inputs := map[string]string{
+		compiler.ObjectKey:          o.Object,
+		compiler.OldObjectKey:       o.OldObject,
+		compiler.RequestKey:         o.Request,
+		compiler.NamespaceObjectKey: o.NamespaceObject,
+	}
+	for _, name := range Inputs {
+		if inputs[name] == "" {
+			continue
+		}
+		if err := env.LoadInput(name, inputs[name]); err != nil {
+			return nil, err
+		}
+	}
+	if o.Variables != "" {
+		if err := env.LoadVariables(o.Variables); err != nil {
+			return nil, err
+		}
+	}
+	return env, nil
+}
+
+// LoadInput sets an input from a JSON or YAML file.
+func (e *Environment) LoadInput(name, path string) error {
+	data, err := loadFile(path)
+	if err != nil {
+		return err
+	}
+	return e.SetInput(name, data)
+}
+
+// LoadVariables declares the variables of a JSON or YAML file mapping variable names to values.
+func (e *Environment) LoadVariables(path string) error {
+	data, err := loadFile(path)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+	variables, ok := data.(map[string]any)
+	if !ok {
+		return fmt.Errorf("variables file %s must map variable names to values", path)
+	}
+	for name, value := range variables {
+		e.SetVariable(name, value)
+	}
+	return nil
+}
+
+func loadFile(path string) (any, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	var data any
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse file %s: %w", path, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,24 @@
+package celenv
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FormatResult formats an evaluation result as JSON, strings are left unquoted when requested.
+func FormatResult(result any, unquoted bool, compact bool) (string, error) {
+	if converted, isString := result.(string); unquoted && isString {
+		return converted, nil
+	}
+	var toJSON []byte
+	var err error
+	if compact {
+		toJSON, err = json.Marshal(result)
+	} else {
+		toJSON, err = json.MarshalIndent(result, "", "  ")
+	}
+	if err != nil {
+		return "", fmt.Errorf("error marshalling result to JSON: %w", err)
+	}
+	return string(toJSON), nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/payload"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/render"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
@@ -68,6 +70,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
 )
 
 type SkippedInvalidPolicies struct {
@@ -120,6 +123,20 @@ type ApplyCommandConfig struct {
 	ContinueOnError           bool
 	ShowPerformance           bool
 	CrdPaths                  []string
+	HelmCharts                []string
+	HelmValues                []string
+	HelmReleaseName           string
+	HelmNamespace             string
+	HelmKubeVersion           string
+	Kustomizations            []string
+	Impersonate               string
+	ImpersonateGroups         []string
+	// restConfig is the configuration used to connect to the cluster.
+	restConfig *rest.Config
+	// namespaces restricts the cluster scan to the namespaces accessible to the impersonated identity.
+	namespaces []string
+	// sources maps rendered resources to the template or file they were rendered from.
+	sources map[string]string
 	// Cloner is an optional function for cloning git repositories.
 	// If nil, defaults to gitutils.Clone. Tests can inject a fake
 	// to avoid real network calls while still exercising the git-URL
@@ -162,13 +179,15 @@ func Command() *cobra.Command {
 			} else if applyCommandConfig.GenerateExceptions {
 				printExceptions(out, responses, applyCommandConfig.AuditWarn, applyCommandConfig.OutputFormat, applyCommandConfig.GeneratedExceptionTTL)
 			} else if table {
-				printTable(out, detailedResults, applyCommandConfig.AuditWarn, responses...)
+				printTable(out, detailedResults, applyCommandConfig.AuditWarn, applyCommandConfig.sources, responses...)
 			} else {
 				for _, response := range responses {
 					var failedRules []engineapi.RuleResponse
 					resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
 					if resPath == "//" {
 						resPath = "JSON payload"
+					} else if source, ok := applyCommandConfig.sources[render.Key(&response.Resource)]; ok {
+						resPath = fmt.Sprintf("%s (%s)", resPath, source)
 					}
 					for _, rule := range response.PolicyResponse.Rules {
 						if rule.Status() == engineapi.RuleStatusFail {
@@ -207,6 +226,9 @@ func Command() *cobra.Command {
 					}
 				}
 				printViolations(out, rc)
+				if applyCommandConfig.Cluster && applyCommandConfig.impersonating() {
+					printNamespaceSummary(out, applyCommandConfig.AuditWarn, responses...)
+				}
 			}
 			return exit(out, rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed)
 		},
@@ -217,6 +239,12 @@ func Command() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.EnvoyPayloadPaths, "envoy-payload", "", []string{}, "Path to Envoy check request payload files (JSON)")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resources", "", []string{}, "Path to resource files")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.HelmCharts, "helm-chart", nil, "Path to Helm chart directories or archives rendered as resources, dependencies must be present in the charts directory and lookup returns empty results")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.HelmValues, "helm-values", nil, "Path to values files used to render Helm charts, use <chart>=<file> to scope a values file to one of the charts")
+	cmd.Flags().StringVar(&applyCommandConfig.HelmReleaseName, "helm-release-name", "", "Release name used to render Helm charts (defaults to the chart name)")
+	cmd.Flags().StringVar(&applyCommandConfig.HelmNamespace, "helm-namespace", "default", "Release namespace used to render Helm charts")
+	cmd.Flags().StringVar(&applyCommandConfig.HelmKubeVersion, "helm-kube-version", "", "Kubernetes version exposed to Helm charts as .Capabilities.KubeVersion (defaults to the latest version known to the CLI)")
+	cmd.Flags().StringSliceVarP(&applyCommandConfig.Kustomizations, "kustomize", "k", nil, "Path to kustomization directories rendered as resources")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.TargetResourcePaths, "target-resource", "", []string{}, "Path to individual files containing target resources files for policies that have mutate existing")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.TargetResourcePaths, "target-resources", "", []string{}, "Path to a directory containing target resources files for policies that have mutate existing")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
@@ -233,6 +261,8 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&applyCommandConfig.RegistryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().StringVar(&applyCommandConfig.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
 	cmd.Flags().StringVar(&applyCommandConfig.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVar(&applyCommandConfig.Impersonate, "as", "", "Username to impersonate when scanning the cluster, restricts the scan to the namespaces the identity can list")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.ImpersonateGroups, "as-group", nil, "Groups to impersonate when scanning the cluster, restricts the scan to the namespaces the identity can list")
 	cmd.Flags().StringVarP(&applyCommandConfig.GitBranch, "git-branch", "b", "", "test git repository branch")
 	cmd.Flags().StringVar(&applyCommandConfig.GitUsername, "username", "", "Username for connecting to git repository")
 	cmd.Flags().StringVar(&applyCommandConfig.GitPassword, "password", "", "Password for connecting to git repository")
@@ -348,11 +378,22 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err != nil {
 		return nil, nil, skippedInvalidPolicies, nil, err
 	}
+	if c.Cluster && c.impersonating() {
+		if err := c.restrictNamespaces(context.Background(), out, genericPolicies, dClient); err != nil {
+			return nil, nil, skippedInvalidPolicies, nil, err
+		}
+	}
 
 	resources, jsonPayloads, err := c.loadResources(out, c.ResourcePaths, genericPolicies, dClient)
 	if err != nil {
 		return nil, nil, skippedInvalidPolicies, nil, err
 	}
+	rendered, err := c.renderResources()
+	if err != nil {
+		return nil, nil, skippedInvalidPolicies, nil, err
+	}
+	resources = append(resources, test.ProcessResources(render.Unstructured(rendered...))...)
+	c.sources = render.Sources(rendered...)
 
 	// Separate GlobalContextEntry resources from regular resources
 	var gctxEntries []*kyvernov2.GlobalContextEntry
@@ -1053,9 +1094,18 @@ func (c *ApplyCommandConfig) loadResources(out io.Writer, paths []string, polici
 		ContinueOnError: c.ContinueOnError,
 		Timeout:         5 * time.Minute,
 	}
-	resources, err := common.GetResourceAccordingToResourcePath(out, nil, paths, c.Cluster, policies, dClient, c.Namespace, c.PolicyReport, c.ClusterWideResources, "", resourceOptions, c.ShowPerformance)
-	if err != nil {
-		return resources, nil, fmt.Errorf("failed to load resources (%w)", err)
+	namespaces := []string{c.Namespace}
+	if dClient != nil && c.namespaces != nil {
+		namespaces = c.namespaces
+	}
+	var resources []*unstructured.Unstructured
+	for _, namespace := range namespaces {
+		resourceOptions.Namespace = namespace
+		loaded, err := common.GetResourceAccordingToResourcePath(out, nil, paths, c.Cluster, policies, dClient, namespace, c.PolicyReport, c.ClusterWideResources, "", resourceOptions, c.ShowPerformance)
+		if err != nil {
+			return append(resources, loaded...), nil, fmt.Errorf("failed to load resources (%w)", err)
+		}
+		resources = append(resources, loaded...)
 	}
 	resources = test.ProcessResources(resources)
 	var jsonPayloads []*unstructured.Unstructured
@@ -1072,6 +1122,48 @@ func (c *ApplyCommandConfig) loadResources(out io.Writer, paths []string, polici
 	return resources, jsonPayloads, nil
 }
 
+// helmValuesFiles returns the values files used to render a chart, values files are scoped to a chart
+// with <chart>=<file> where chart is the path passed to --helm-chart, unscoped values files are only accepted
+// when a single chart is rendered.
+func helmValuesFiles(chart string, charts, values []string) []string {
+	var files []string
+	for _, value := range values {
+		if scope, file, ok := strings.Cut(value, "="); ok && slices.Contains(charts, scope) {
+			if scope == chart {
+				files = append(files, file)
+			}
+		} else {
+			files = append(files, value)
+		}
+	}
+	return files
+}
+
+// renderResources renders the Helm charts and kustomizations passed on the command line.
+func (c *ApplyCommandConfig) renderResources() ([]render.Resource, error) {
+	var resources []render.Resource
+	for _, chart := range c.HelmCharts {
+		rendered, err := render.Helm(chart, render.HelmOptions{
+			ReleaseName: c.HelmReleaseName,
+			Namespace:   c.HelmNamespace,
+			ValuesFiles: helmValuesFiles(chart, c.HelmCharts, c.HelmValues),
+			KubeVersion: c.HelmKubeVersion,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render Helm chart (%w)", err)
+		}
+		resources = append(resources, rendered...)
+	}
+	for _, kustomization := range c.Kustomizations {
+		rendered, err := render.Kustomize(kustomization)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render kustomization (%w)", err)
+		}
+		resources = append(resources, rendered...)
+	}
+	return resources, nil
+}
+
 func (c *ApplyCommandConfig) loadPolicies() (
 	[]kyvernov1.PolicyInterface,
 	[]*kyvernov2.PolicyException,
@@ -1211,6 +1303,11 @@ func (c *ApplyCommandConfig) initStoreAndClusterClient(store *store.Store, targe
 		if err != nil {
 			return nil, err
 		}
+		restConfig.Impersonate = rest.ImpersonationConfig{
+			UserName: c.Impersonate,
+			Groups:   c.ImpersonateGroups,
+		}
+		c.restConfig = restConfig
 		kubeClient, err := kubernetes.NewForConfig(restConfig)
 		if err != nil {
 			return nil, err
@@ -1276,10 +1373,27 @@ func (c *ApplyCommandConfig) checkArguments() error {
 	if hasStdinPath(c.PolicyPaths) && hasStdinPath(c.ResourcePaths) {
 		return fmt.Errorf("a stdin pipe can be used for either policies or resources, not both")
 	}
-	if len(c.ResourcePaths) != 0 && len(c.JSONPaths) != 0 {
+	hasResources := len(c.ResourcePaths) != 0 || len(c.HelmCharts) != 0 || len(c.Kustomizations) != 0
+	if hasResources && len(c.JSONPaths) != 0 {
 		return fmt.Errorf("both resource and json files can not be used together, use one or the other")
 	}
-	if len(c.ResourcePaths) == 0 && len(c.JSONPaths) == 0 && len(c.HTTPPayloadPaths) == 0 && len(c.EnvoyPayloadPaths) == 0 && !c.Cluster {
+	if c.impersonating() && !c.Cluster {
+		return fmt.Errorf("as and as-group require the cluster flag")
+	}
+	if c.impersonating() && c.ClusterWideResources {
+		return fmt.Errorf("cluster-wide-resources can not be used with as or as-group, impersonated scans are restricted to namespaces")
+	}
+	if len(c.HelmValues) != 0 && len(c.HelmCharts) == 0 {
+		return fmt.Errorf("helm-values requires a helm-chart")
+	}
+	if len(c.HelmCharts) > 1 {
+		for _, value := range c.HelmValues {
+			if scope, _, ok := strings.Cut(value, "="); !ok || !slices.Contains(c.HelmCharts, scope) {
+				return fmt.Errorf("helm-values %s must be scoped to a chart with <chart>=<file> when several helm charts are rendered", value)
+			}
+		}
+	}
+	if !hasResources && len(c.JSONPaths) == 0 && len(c.HTTPPayloadPaths) == 0 && len(c.EnvoyPayloadPaths) == 0 && !c.Cluster {
 		return fmt.Errorf("resource file(s) or cluster required")
 	}
 	normalized := make([]string, 0, len(c.CrdPaths))
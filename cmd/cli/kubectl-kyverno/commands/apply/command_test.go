@@ -1395,6 +1395,70 @@ func TestCommandWithJsonAndResource(t *testing.T) {
 	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
 }
 
+func TestCommandWithHelmValuesWithoutChart(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"--helm-values", "values.yaml", "policy"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "helm-values requires a helm-chart")
+
+	cmd = Command()
+	cmd.SetArgs([]string{"--helm-chart", "web", "--helm-chart", "db", "--helm-values", "web=web.yaml", "--helm-values", "values.yaml", "policy"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "helm-values values.yaml must be scoped to a chart with <chart>=<file> when several helm charts are rendered")
+}
+
+func Test_helmValuesFiles(t *testing.T) {
+	charts := []string{"web", "db"}
+	values := []string{"web=web.yaml", "db=db.yaml", "web=web-prod.yaml"}
+	assert.Equal(t, []string{"web.yaml", "web-prod.yaml"}, helmValuesFiles("web", charts, values))
+	assert.Equal(t, []string{"db.yaml"}, helmValuesFiles("db", charts, values))
+	assert.Equal(t, []string{"values.yaml", "a=b.yaml"}, helmValuesFiles("web", []string{"web"}, []string{"values.yaml", "a=b.yaml"}))
+}
+
+func TestCommandWithImpersonationWithoutCluster(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"--as", "alice", "--resource", "resource.yaml", "policy"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "as and as-group require the cluster flag")
+
+	cmd = Command()
+	cmd.SetArgs([]string{"--as-group", "team-a", "--cluster", "--cluster-wide-resources", "policy"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "cluster-wide-resources can not be used with as or as-group, impersonated scans are restricted to namespaces")
+}
+
+func TestCommandWithHelmChart(t *testing.T) {
+	chart := filepath.Join("..", "..", "..", "..", "..", "test", "cli", "apply", "helm", "chart")
+	policy := "../../../../../test/best_practices/disallow_latest_tag.yaml"
+
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{policy, "--helm-chart", chart, "--helm-release-name", "frontend"})
+	assert.NoError(t, cmd.Execute())
+
+	cmd = Command()
+	b = bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{policy, "--helm-chart", chart, "--helm-values", "../../../../../test/cli/apply/helm/values-latest.yaml", "--helm-namespace", "web"})
+	assert.Error(t, cmd.Execute())
+	source := filepath.Join(chart, "templates", "pod.yaml")
+	assert.Contains(t, b.String(), "policy disallow-latest-tag -> resource web/Pod/web ("+source+") failed:")
+}
+
+func TestCommandWithKustomize(t *testing.T) {
+	base := filepath.Join("..", "..", "..", "..", "..", "test", "cli", "apply", "kustomize", "base")
+	overlay := filepath.Join("..", "..", "..", "..", "..", "test", "cli", "apply", "kustomize", "overlay")
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../../../../test/best_practices/disallow_latest_tag.yaml", "--kustomize", overlay})
+	assert.Error(t, cmd.Execute())
+	assert.Contains(t, b.String(), "policy disallow-latest-tag -> resource prod/Pod/web ("+filepath.Join(base, "pod.yaml")+") failed:")
+}
+
 func TestCommandWarnExitCode(t *testing.T) {
 	warnExitCode := 3
 
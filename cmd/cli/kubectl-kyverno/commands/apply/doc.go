@@ -19,6 +19,10 @@ var examples = [][]string{
 		"# Apply on a cluster",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster",
 	},
+	{
+		"# Apply on the namespaces a tenant can access, as the tenant",
+		"kyverno apply /path/to/policy.yaml --cluster --as jane --as-group team-a",
+	},
 	{
 		"# Apply policies from a gitSourceURL on a cluster",
 		"kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster",
@@ -31,4 +35,16 @@ var examples = [][]string{
 		"# Apply multiple policy with variable on multiple resource",
 		"kyverno apply /path/to/policy1.yaml /path/to/policy2.yaml --resource /path/to/resource1.yaml --resource /path/to/resource2.yaml -f /path/to/value.yaml",
 	},
+	{
+		"# Apply on a Helm chart rendered with values files",
+		"kyverno apply /path/to/policy.yaml --helm-chart /path/to/chart --helm-values /path/to/values.yaml --helm-release-name my-release",
+	},
+	{
+		"# Apply on several Helm charts rendered with their own values files",
+		"kyverno apply /path/to/policy.yaml --helm-chart /path/to/web --helm-chart /path/to/db --helm-values /path/to/web=/path/to/web-values.yaml --helm-values /path/to/db=/path/to/db-values.yaml",
+	},
+	{
+		"# Apply on a Kustomize overlay",
+		"kyverno apply /path/to/policy.yaml --kustomize /path/to/overlay",
+	},
 }
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -194,3 +196,56 @@ func wildcardTagOrDigest(images []string) []string {
 func printViolations(out io.Writer, rc *processor.ResultCounts) {
 	fmt.Fprintf(out, "\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n", rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
 }
+
+type namespaceSummary struct {
+	Namespace string `header:"namespace"`
+	Pass      int    `header:"pass"`
+	Fail      int    `header:"fail"`
+	Warn      int    `header:"warn"`
+	Error     int    `header:"error"`
+	Skip      int    `header:"skip"`
+}
+
+// namespaceSummaries counts rule results per resource namespace, cluster-scoped resources are counted under "-".
+func namespaceSummaries(auditWarn bool, engineResponses ...engineapi.EngineResponse) []namespaceSummary {
+	var summaries []namespaceSummary
+	index := map[string]int{}
+	for _, response := range engineResponses {
+		namespace := response.Resource.GetNamespace()
+		if namespace == "" {
+			namespace = "-"
+		}
+		i, ok := index[namespace]
+		if !ok {
+			i = len(summaries)
+			index[namespace] = i
+			summaries = append(summaries, namespaceSummary{Namespace: namespace})
+		}
+		for _, rule := range response.PolicyResponse.Rules {
+			switch rule.Status() {
+			case engineapi.RuleStatusPass:
+				summaries[i].Pass++
+			case engineapi.RuleStatusFail:
+				if auditWarn && response.GetValidationFailureAction().Audit() {
+					summaries[i].Warn++
+				} else {
+					summaries[i].Fail++
+				}
+			case engineapi.RuleStatusWarn:
+				summaries[i].Warn++
+			case engineapi.RuleStatusError:
+				summaries[i].Error++
+			case engineapi.RuleStatusSkip:
+				summaries[i].Skip++
+			}
+		}
+	}
+	slices.SortFunc(summaries, func(a, b namespaceSummary) int { return strings.Compare(a.Namespace, b.Namespace) })
+	return summaries
+}
+
+func printNamespaceSummary(out io.Writer, auditWarn bool, engineResponses ...engineapi.EngineResponse) {
+	fmt.Fprintln(out, "\nSummary by namespace:")
+	printer := table.NewTablePrinter(out)
+	printer.Print(namespaceSummaries(auditWarn, engineResponses...))
+}
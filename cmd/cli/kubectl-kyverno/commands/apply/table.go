@@ -6,10 +6,11 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy/annotations"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/render"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 )
 
-func printTable(out io.Writer, compact, auditWarn bool, engineResponses ...engineapi.EngineResponse) {
+func printTable(out io.Writer, compact, auditWarn bool, sources map[string]string, engineResponses ...engineapi.EngineResponse) {
 	var resultsTable table.Table
 	id := 1
 	for _, engineResponse := range engineResponses {
@@ -20,6 +21,7 @@ func printTable(out io.Writer, compact, auditWarn bool, engineResponses ...engin
 		resourceKind := engineResponse.Resource.GetKind()
 		resourceNamespace := engineResponse.Resource.GetNamespace()
 		resourceName := engineResponse.Resource.GetName()
+		source := sources[render.Key(&engineResponse.Resource)]
 
 		for _, ruleResponse := range engineResponse.PolicyResponse.Rules {
 			var row table.Row
@@ -30,6 +32,9 @@ func printTable(out io.Writer, compact, auditWarn bool, engineResponses ...engin
 				row.Rule = color.Rule(ruleResponse.Name())
 			}
 			row.Resource = color.Resource(resourceKind, resourceNamespace, resourceName)
+			if source != "" {
+				row.Resource += " (" + source + ")"
+			}
 			if ruleResponse.Status() == engineapi.RuleStatusPass {
 				row.Result = color.ResultPass()
 			} else if ruleResponse.Status() == engineapi.RuleStatusFail {
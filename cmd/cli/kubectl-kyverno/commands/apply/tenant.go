@@ -0,0 +1,88 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/auth"
+	"github.com/kyverno/kyverno/pkg/auth/checker"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// impersonating returns true when the cluster is scanned with the permissions of another identity.
+func (c *ApplyCommandConfig) impersonating() bool {
+	return c.Impersonate != "" || len(c.ImpersonateGroups) != 0
+}
+
+// identity describes the impersonated identity.
+func (c *ApplyCommandConfig) identity() string {
+	var parts []string
+	if c.Impersonate != "" {
+		parts = append(parts, "user "+c.Impersonate)
+	}
+	if len(c.ImpersonateGroups) != 0 {
+		parts = append(parts, "groups "+strings.Join(c.ImpersonateGroups, ", "))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// restrictNamespaces restricts the scan to the namespaces where the impersonated identity
+// can list at least one of the kinds matched by the policies.
+func (c *ApplyCommandConfig) restrictNamespaces(ctx context.Context, out io.Writer, policies []engineapi.GenericPolicy, dClient dclient.Interface) error {
+	candidates := []string{c.Namespace}
+	if c.Namespace == "" {
+		// the impersonated identity is usually not allowed to list namespaces, use the caller identity instead
+		config := rest.CopyConfig(c.restConfig)
+		config.Impersonate = rest.ImpersonationConfig{}
+		kubeClient, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return err
+		}
+		namespaces, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list namespaces (%w)", err)
+		}
+		candidates = candidates[:0]
+		for _, namespace := range namespaces.Items {
+			candidates = append(candidates, namespace.Name)
+		}
+	}
+	fetcher := &common.ResourceFetcher{Out: out, Policies: policies, Client: dClient}
+	authChecker := checker.NewSelfChecker(dClient.GetKubeClient().AuthorizationV1().SelfSubjectAccessReviews())
+	namespaces, err := accessibleNamespaces(ctx, dClient.Discovery(), authChecker, candidates, fetcher.Kinds())
+	if err != nil {
+		return err
+	}
+	c.namespaces = namespaces
+	if !c.PolicyReport && !c.GenerateExceptions {
+		fmt.Fprintf(out, "\nScanning %d namespace(s) as %s: %s\n", len(namespaces), c.identity(), strings.Join(namespaces, ", "))
+	}
+	return nil
+}
+
+// accessibleNamespaces returns the namespaces where the checker allows listing at least one of the kinds.
+func accessibleNamespaces(ctx context.Context, discovery auth.Discovery, authChecker checker.AuthChecker, namespaces []string, kinds []schema.GroupVersionKind) ([]string, error) {
+	allowed := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		for _, kind := range kinds {
+			canI := auth.NewCanIWithChecker(discovery, authChecker, kind.GroupVersion().String()+"/"+kind.Kind, namespace, "", "list", "")
+			ok, _, err := canI.RunAccessCheck(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				allowed = append(allowed, namespace)
+				break
+			}
+		}
+	}
+	return allowed, nil
+}
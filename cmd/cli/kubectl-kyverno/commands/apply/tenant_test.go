@@ -0,0 +1,98 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auth/checker"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type namespaceChecker struct {
+	allowed map[string][]string
+	err     error
+}
+
+func (c namespaceChecker) Check(_ context.Context, _, _, resource, _, namespace, _, verb string) (*checker.AuthResult, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	for _, allowed := range c.allowed[namespace] {
+		if allowed == resource && verb == "list" {
+			return &checker.AuthResult{Allowed: true}, nil
+		}
+	}
+	return &checker.AuthResult{}, nil
+}
+
+func TestAccessibleNamespaces(t *testing.T) {
+	discovery := dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{
+		{Version: "v1", Resource: "pods"},
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+	})
+	kinds := []schema.GroupVersionKind{
+		{Version: "v1", Kind: "Pod"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+	}
+	authChecker := namespaceChecker{allowed: map[string][]string{
+		"team-a": {"pods"},
+		"team-b": {"deployments"},
+		"team-c": {"secrets"},
+	}}
+	namespaces, err := accessibleNamespaces(context.TODO(), discovery, authChecker, []string{"team-a", "team-b", "team-c", "kube-system"}, kinds)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-b"}, namespaces)
+
+	namespaces, err = accessibleNamespaces(context.TODO(), discovery, authChecker, []string{"team-a"}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, namespaces)
+
+	_, err = accessibleNamespaces(context.TODO(), discovery, namespaceChecker{err: errors.New("forbidden")}, []string{"team-a"}, kinds)
+	assert.Error(t, err)
+}
+
+func TestIdentity(t *testing.T) {
+	assert.Equal(t, "user alice", (&ApplyCommandConfig{Impersonate: "alice"}).identity())
+	assert.Equal(t, "user alice and groups dev, ops", (&ApplyCommandConfig{Impersonate: "alice", ImpersonateGroups: []string{"dev", "ops"}}).identity())
+	assert.False(t, (&ApplyCommandConfig{}).impersonating())
+	assert.True(t, (&ApplyCommandConfig{ImpersonateGroups: []string{"dev"}}).impersonating())
+}
+
+func TestNamespaceSummaries(t *testing.T) {
+	audit := kyvernov1.Audit
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-labels"},
+		Spec: kyvernov1.Spec{Rules: []kyvernov1.Rule{{
+			Name:       "check",
+			Validation: &kyvernov1.Validation{FailureAction: &audit},
+		}}},
+	})
+	response := func(namespace string, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+		resource := unstructured.Unstructured{}
+		resource.SetNamespace(namespace)
+		return engineapi.NewEngineResponse(resource, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+	}
+	responses := []engineapi.EngineResponse{
+		response("team-b", *engineapi.RuleFail("check", engineapi.Validation, "", nil)),
+		response("team-a", *engineapi.RulePass("check", engineapi.Validation, "", nil), *engineapi.RuleSkip("check", engineapi.Validation, "", nil)),
+		response("", *engineapi.RulePass("check", engineapi.Validation, "", nil)),
+		response("team-a", *engineapi.RuleFail("check", engineapi.Validation, "", nil)),
+	}
+	assert.Equal(t, []namespaceSummary{
+		{Namespace: "-", Pass: 1},
+		{Namespace: "team-a", Pass: 1, Fail: 1, Skip: 1},
+		{Namespace: "team-b", Fail: 1},
+	}, namespaceSummaries(false, responses...))
+	assert.Equal(t, []namespaceSummary{
+		{Namespace: "-", Pass: 1},
+		{Namespace: "team-a", Pass: 1, Warn: 1, Skip: 1},
+		{Namespace: "team-b", Warn: 1},
+	}, namespaceSummaries(true, responses...))
+}
@@ -0,0 +1,46 @@
+package approve
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/approvals"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	KubeConfig string
+	Context    string
+	Namespace  string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "approve <name>...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := approvals.NewClient(options.KubeConfig, options.Context, options.Namespace)
+			if err != nil {
+				return err
+			}
+			for _, name := range args {
+				if err := client.Approve(ctx, name); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "update request %s approved\n", name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "kyverno", "Namespace where Kyverno is installed")
+	return cmd
+}
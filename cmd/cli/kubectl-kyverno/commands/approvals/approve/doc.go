@@ -0,0 +1,20 @@
+package approve
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#approvals`
+
+var description = []string{
+	`Approves the changes of update requests waiting for approval.`,
+	``,
+	`The background controller applies the changes to the current state of the target resources once approved.`,
+}
+
+var examples = [][]string{
+	{
+		`# Approve the changes of an update request`,
+		`kyverno approvals approve ur-xxxxx`,
+	},
+	{
+		`# Approve the changes of several update requests`,
+		`kyverno approvals approve ur-xxxxx ur-yyyyy`,
+	},
+}
@@ -0,0 +1,29 @@
+package approvals
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/approvals/approve"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/approvals/diff"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/approvals/list"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "approvals",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		approve.Command(),
+		diff.Command(),
+		list.Command(),
+	)
+	return cmd
+}
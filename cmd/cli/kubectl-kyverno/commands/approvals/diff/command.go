@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/approvals"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	KubeConfig string
+	Context    string
+	Namespace  string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "diff <name>",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := approvals.NewClient(options.KubeConfig, options.Context, options.Namespace)
+			if err != nil {
+				return err
+			}
+			ur, err := client.Get(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			diff, err := client.Diff(ctx, *ur)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), diff)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "kyverno", "Namespace where Kyverno is installed")
+	return cmd
+}
@@ -0,0 +1,17 @@
+package diff
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#approvals`
+
+var description = []string{
+	`Shows the changes of an update request waiting for approval.`,
+	``,
+	`The changes are applied to the state of the target resources they were computed against and shown as a diff.`,
+	`Targets that changed since are reported, their changes are computed again and must be approved again.`,
+}
+
+var examples = [][]string{
+	{
+		`# Show the changes of an update request`,
+		`kyverno approvals diff ur-xxxxx`,
+	},
+}
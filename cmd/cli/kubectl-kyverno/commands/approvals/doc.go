@@ -0,0 +1,25 @@
+package approvals
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#approvals`
+
+var description = []string{
+	`Manages the changes of mutateExisting policies waiting for approval.`,
+	``,
+	`Mutating policies annotated with policies.kyverno.io/require-approval: "true" don't patch existing resources directly,`,
+	`the computed changes are stored in update requests and applied by the background controller once approved.`,
+}
+
+var examples = [][]string{
+	{
+		`# List the changes waiting for approval`,
+		`kyverno approvals list`,
+	},
+	{
+		`# Show the changes of an update request`,
+		`kyverno approvals diff ur-xxxxx`,
+	},
+	{
+		`# Approve the changes of an update request`,
+		`kyverno approvals approve ur-xxxxx`,
+	},
+}
@@ -0,0 +1,43 @@
+package list
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/approvals"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	KubeConfig string
+	Context    string
+	Namespace  string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			client, err := approvals.NewClient(options.KubeConfig, options.Context, options.Namespace)
+			if err != nil {
+				return err
+			}
+			urs, err := client.List(ctx)
+			if err != nil {
+				return err
+			}
+			return approvals.Print(cmd.OutOrStdout(), urs)
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "kyverno", "Namespace where Kyverno is installed")
+	return cmd
+}
@@ -0,0 +1,18 @@
+package list
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#approvals`
+
+var description = []string{
+	`Lists the update requests with changes waiting for approval.`,
+}
+
+var examples = [][]string{
+	{
+		`# List the changes waiting for approval`,
+		`kyverno approvals list`,
+	},
+	{
+		`# List the changes waiting for approval when Kyverno is installed in another namespace`,
+		`kyverno approvals list -n kyverno-system`,
+	},
+}
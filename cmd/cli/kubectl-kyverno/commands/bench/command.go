@@ -0,0 +1,135 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/bench"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/clients/dclient/loader"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type options struct {
+	ResourcePaths []string
+	ComparePaths  []string
+	Iterations    int
+	Warmup        int
+	ValuesFile    string
+	Variables     []string
+	UserInfoPath  string
+	Rules         bool
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "bench [dir]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.ResourcePaths, "resource", "r", nil, "Path to resource files evaluated by the policies")
+	cmd.Flags().StringSliceVar(&options.ComparePaths, "compare", nil, "Path to another version of the policies to compare with")
+	cmd.Flags().IntVarP(&options.Iterations, "iterations", "n", 100, "Number of times each policy is evaluated against each resource")
+	cmd.Flags().IntVar(&options.Warmup, "warmup", 1, "Number of unmeasured iterations run before measuring")
+	cmd.Flags().StringVarP(&options.ValuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().StringSliceVarP(&options.Variables, "set", "s", nil, "Variables that are required")
+	cmd.Flags().StringVarP(&options.UserInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
+	cmd.Flags().BoolVar(&options.Rules, "rules", false, "Display the latency of each rule")
+	return cmd
+}
+
+func (o options) validate(paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("policy paths are required")
+	}
+	if len(o.ResourcePaths) == 0 {
+		return errors.New("resource paths are required")
+	}
+	if o.Iterations < 1 {
+		return errors.New("iterations must be at least 1")
+	}
+	if o.Warmup < 0 {
+		return errors.New("warmup must not be negative")
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, paths ...string) error {
+	resources, err := common.GetResourceAccordingToResourcePath(out, nil, o.ResourcePaths, false, nil, nil, "", false, false, "", loader.ResourceOptions{}, false)
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	if len(resources) == 0 {
+		return errors.New("no resources found")
+	}
+	vars, err := variables.New(out, nil, "", o.ValuesFile, nil, o.Variables...)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	var userInfo *kyvernov2.RequestInfo
+	if o.UserInfoPath != "" {
+		info, err := userinfo.Load(nil, o.UserInfoPath, "")
+		if err != nil {
+			return fmt.Errorf("failed to load request info (%w)", err)
+		}
+		userInfo = &info.RequestInfo
+	}
+	benchOptions := bench.Options{
+		Iterations: o.Iterations,
+		Warmup:     o.Warmup,
+		Variables:  vars,
+		UserInfo:   userInfo,
+	}
+	baseline, err := o.run(paths, resources, benchOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Evaluated %d policies against %d resources, %d iterations\n", len(baseline), len(resources), o.Iterations)
+	printer := table.NewTablePrinter(out)
+	printer.Print(bench.PolicyRows(baseline...))
+	if o.Rules {
+		if rows := bench.RuleRows(baseline...); len(rows) != 0 {
+			fmt.Fprintln(out)
+			printer.Print(rows)
+		}
+	}
+	if len(o.ComparePaths) == 0 {
+		return nil
+	}
+	candidate, err := o.run(o.ComparePaths, resources, benchOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nComparison with %v\n", o.ComparePaths)
+	printer.Print(bench.Compare(baseline, candidate))
+	return nil
+}
+
+func (o options) run(paths []string, resources []*unstructured.Unstructured, benchOptions bench.Options) ([]bench.Result, error) {
+	results, err := policy.Load(nil, "", paths...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load policies (%w)", err)
+	}
+	policies := bench.Split(results)
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("no policies found in %v", paths)
+	}
+	return bench.Run(policies, resources, benchOptions)
+}
@@ -0,0 +1,107 @@
+package bench
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const policyYaml = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  background: false
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      failureAction: Audit
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+const resourceYaml = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  namespace: default
+  labels:
+    team: web
+spec:
+  containers:
+  - name: nginx
+    image: nginx:latest
+`
+
+func TestCommandWithoutArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "policy paths are required")
+}
+
+func TestCommandWithoutResources(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"policy.yaml"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "resource paths are required")
+}
+
+func TestCommandWithInvalidIterations(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"policy.yaml", "--resource", "resource.yaml", "-n", "0"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "iterations must be at least 1")
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommand(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policy.yaml")
+	assert.NoError(t, os.WriteFile(policyPath, []byte(policyYaml), 0o600))
+	candidatePath := filepath.Join(dir, "candidate.yaml")
+	assert.NoError(t, os.WriteFile(candidatePath, []byte(strings.ReplaceAll(policyYaml, `"?*"`, `"web | api"`)), 0o600))
+	resourcePath := filepath.Join(dir, "resource.yaml")
+	assert.NoError(t, os.WriteFile(resourcePath, []byte(resourceYaml), 0o600))
+
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{policyPath, "--resource", resourcePath, "-n", "5", "--rules", "--compare", candidatePath})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out := b.String()
+	assert.Contains(t, out, "Evaluated 1 policies against 1 resources, 5 iterations")
+	assert.Contains(t, out, "ClusterPolicy/require-labels")
+	assert.Contains(t, out, "check-team")
+	assert.Contains(t, out, "Comparison with")
+}
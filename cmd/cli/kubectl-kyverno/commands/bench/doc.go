@@ -0,0 +1,28 @@
+package bench
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#bench`
+
+var description = []string{
+	`Measures the cost of evaluating policies.`,
+	``,
+	`The bench command evaluates each policy in isolation against each resource, the given number of times, through the engines used in admission.`,
+	`It reports latency percentiles, the time spent loading rule contexts and heap allocations per evaluation.`,
+	`Policy latency covers the whole evaluation, including the compilation of CEL policies, rule latency is the processing time reported by the engines.`,
+	``,
+	`With --compare, another version of the policies is measured and compared with the first one, policies are matched by kind and name.`,
+}
+
+var examples = [][]string{
+	{
+		`# Benchmark policies against resources`,
+		`kyverno bench /path/to/policies --resource /path/to/resources`,
+	},
+	{
+		`# Benchmark policies with 1000 iterations and display the latency of each rule`,
+		`kyverno bench /path/to/policies --resource /path/to/resources -n 1000 --rules`,
+	},
+	{
+		`# Compare two versions of a policy`,
+		`kyverno bench policy-v1.yaml --compare policy-v2.yaml --resource /path/to/resources`,
+	},
+}
@@ -0,0 +1,60 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/celenv"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options celenv.Options
+	var files []string
+	cmd := &cobra.Command{
+		Use:          "check [-e expression-file|expression]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expressions := make([]string, 0, len(args)+len(files))
+			expressions = append(expressions, args...)
+			for _, file := range files {
+				data, err := os.ReadFile(filepath.Clean(file))
+				if err != nil {
+					return fmt.Errorf("failed to read file %s: %w", file, err)
+				}
+				expressions = append(expressions, string(data))
+			}
+			if len(expressions) == 0 {
+				return errors.New("at least one expression is required")
+			}
+			env, err := options.Environment()
+			if err != nil {
+				return err
+			}
+			failed := 0
+			for _, expression := range expressions {
+				fmt.Fprintln(cmd.OutOrStdout(), "#", expression)
+				outputType, err := env.Check(expression)
+				if err != nil {
+					failed++
+					fmt.Fprintln(cmd.OutOrStdout(), err)
+					continue
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), outputType)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d expressions failed to compile", failed, len(expressions))
+			}
+			return nil
+		},
+	}
+	options.AddEnvFlags(cmd)
+	cmd.Flags().StringSliceVarP(&files, "expression", "e", nil, "Read CEL expression from the specified file")
+	return cmd
+}
@@ -0,0 +1,81 @@
+package check
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandWithoutExpression(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: at least one expression is required`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandCheck(t *testing.T) {
+	dir := t.TempDir()
+	expression := filepath.Join(dir, "expression.cel")
+	require.NoError(t, os.WriteFile(expression, []byte("size(object.spec.containers) > 0"), 0o600))
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"-e", expression, "'foo'.startsWith('f')"})
+	require.NoError(t, cmd.Execute())
+	expected := `
+# size(object.spec.containers) > 0
+bool
+# 'foo'.startsWith('f')
+bool`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(b.String()))
+}
+
+func TestCommandCheckError(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"1 + 'foo'", "true"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 of 2 expressions failed to compile")
+	assert.Contains(t, b.String(), "no matching overload")
+}
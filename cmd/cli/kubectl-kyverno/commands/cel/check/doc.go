@@ -0,0 +1,25 @@
+package check
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/cel/`
+
+var description = []string{
+	`Parses and type checks CEL expressions.`,
+	``,
+	`Expressions are compiled in the environment of the selected policy type and their output type is printed.`,
+	`Variables loaded from a file are declared with a dynamic type.`,
+}
+
+var examples = [][]string{
+	{
+		"# Check expression",
+		"kyverno cel check 'object.spec.containers.all(c, has(c.resources.limits))'",
+	},
+	{
+		"# Check expressions from files",
+		"kyverno cel check -e expression-1.cel -e expression-2.cel",
+	},
+	{
+		"# Check expression in the generating policies environment",
+		"kyverno cel check --policy-type generating 'generator.Apply(\"default\", [object])'",
+	},
+}
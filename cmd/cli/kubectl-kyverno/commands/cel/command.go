@@ -0,0 +1,29 @@
+package cel
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/cel/check"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/cel/eval"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/cel/repl"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "cel",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		check.Command(),
+		eval.Command(),
+		repl.Command(),
+	)
+	return cmd
+}
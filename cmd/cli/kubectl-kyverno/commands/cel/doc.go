@@ -0,0 +1,22 @@
+package cel
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/cel/`
+
+var description = []string{
+	`Provides a command-line interface to CEL, with the same environment and Kyverno libraries as CEL policies.`,
+}
+
+var examples = [][]string{
+	{
+		"# Evaluate expression",
+		"kyverno cel eval --object pod.yaml 'object.spec.containers.all(c, has(c.resources.limits))'",
+	},
+	{
+		"# Type check expression",
+		"kyverno cel check 'object.metadata.labels.orValue({})'",
+	},
+	{
+		"# Start an interactive session",
+		"kyverno cel repl --object pod.yaml",
+	},
+}
@@ -0,0 +1,70 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/celenv"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options celenv.Options
+	var compact, unquoted bool
+	var files []string
+	cmd := &cobra.Command{
+		Use:          "eval [-e expression-file|expression]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expressions, err := loadExpressions(args, files)
+			if err != nil {
+				return err
+			}
+			if len(expressions) == 0 {
+				return errors.New("at least one expression is required")
+			}
+			env, err := options.Environment()
+			if err != nil {
+				return err
+			}
+			for _, expression := range expressions {
+				result, err := env.Eval(cmd.Context(), expression)
+				if err != nil {
+					return fmt.Errorf("failed to evaluate expression: %s, error: %w", expression, err)
+				}
+				output, err := celenv.FormatResult(result, unquoted, compact)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "#", expression)
+				fmt.Fprintln(cmd.OutOrStdout(), output)
+			}
+			return nil
+		},
+	}
+	options.AddEnvFlags(cmd)
+	options.AddInputFlags(cmd)
+	cmd.Flags().BoolVarP(&compact, "compact", "c", false, "Produce compact JSON output that omits non essential whitespace")
+	cmd.Flags().BoolVarP(&unquoted, "unquoted", "u", false, "If the final result is a string, it will be printed without quotes")
+	cmd.Flags().StringSliceVarP(&files, "expression", "e", nil, "Read CEL expression from the specified file")
+	return cmd
+}
+
+func loadExpressions(args []string, files []string) ([]string, error) {
+	expressions := make([]string, 0, len(args)+len(files))
+	expressions = append(expressions, args...)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+		expressions = append(expressions, string(data))
+	}
+	return expressions, nil
+}
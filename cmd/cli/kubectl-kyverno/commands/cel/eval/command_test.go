@@ -0,0 +1,79 @@
+package eval
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandWithoutExpression(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: at least one expression is required`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandEval(t *testing.T) {
+	dir := t.TempDir()
+	object := filepath.Join(dir, "object.yaml")
+	require.NoError(t, os.WriteFile(object, []byte("metadata:\n  name: nginx\n  labels:\n    app: web\n"), 0o600))
+	variables := filepath.Join(dir, "variables.yaml")
+	require.NoError(t, os.WriteFile(variables, []byte("allowed:\n- web\n- api\n"), 0o600))
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--object", object, "--variables", variables, "-u", "object.metadata.name", "object.metadata.labels.app in variables.allowed"})
+	require.NoError(t, cmd.Execute())
+	expected := `
+# object.metadata.name
+nginx
+# object.metadata.labels.app in variables.allowed
+true`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(b.String()))
+}
+
+func TestCommandEvalError(t *testing.T) {
+	cmd := Command()
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"object.metadata.name +"})
+	assert.Error(t, cmd.Execute())
+}
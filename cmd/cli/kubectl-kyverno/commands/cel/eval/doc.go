@@ -0,0 +1,34 @@
+package eval
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/cel/`
+
+var description = []string{
+	`Evaluates CEL expressions.`,
+	``,
+	`Expressions are evaluated in the environment of the selected policy type, with the Kyverno libraries available.`,
+	`The object, oldObject, request and namespaceObject inputs and the variables are loaded from files.`,
+	`HTTP calls and global context entries can be mocked with the apiCallResponses and globalContextEntries of a test file.`,
+}
+
+var examples = [][]string{
+	{
+		"# Evaluate expression",
+		"kyverno cel eval --object pod.yaml 'object.metadata.name'",
+	},
+	{
+		"# Evaluate expressions from files",
+		"kyverno cel eval --object pod.yaml -e expression-1.cel -e expression-2.cel",
+	},
+	{
+		"# Evaluate expression with variables",
+		"kyverno cel eval --object pod.yaml --variables variables.yaml 'variables.allowed.exists(r, object.spec.containers[0].image.startsWith(r))'",
+	},
+	{
+		"# Evaluate expression with mocked HTTP calls and global context entries",
+		"kyverno cel eval --test kyverno-test.yaml 'http.Get(\"https://example.com/allowed\").body'",
+	},
+	{
+		"# Evaluate expression in the mutating policies environment",
+		"kyverno cel eval --policy-type mutating --object pod.yaml 'Object{metadata: Object.metadata{labels: {\"foo\": \"bar\"}}}'",
+	},
+}
@@ -0,0 +1,127 @@
+package repl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/celenv"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/spf13/cobra"
+)
+
+const prompt = "cel> "
+
+var help = `Commands:
+  :help                        show this help
+  :quit, :exit                 leave the session
+  :let <name> = <expression>   evaluate an expression and declare its result as variables.<name>
+  :load <input> <file>         load object, oldObject, request, namespaceObject or variables from a file
+  :type <expression>           print the output type of an expression
+  :vars                        list declared variables
+  <expression>                 evaluate an expression, end a line with \ to continue on the next one`
+
+func Command() *cobra.Command {
+	var options celenv.Options
+	cmd := &cobra.Command{
+		Use:          "repl",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			env, err := options.Environment()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Kyverno CEL REPL, type :help for help.")
+			return run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), env)
+		},
+	}
+	options.AddEnvFlags(cmd)
+	options.AddInputFlags(cmd)
+	return cmd
+}
+
+func run(ctx context.Context, in io.Reader, out io.Writer, env *celenv.Environment) error {
+	scanner := bufio.NewScanner(in)
+	var buffer strings.Builder
+	fmt.Fprint(out, prompt)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasSuffix(line, `\`) {
+			buffer.WriteString(strings.TrimSuffix(line, `\`))
+			buffer.WriteString("\n")
+			continue
+		}
+		buffer.WriteString(line)
+		input := strings.TrimSpace(buffer.String())
+		buffer.Reset()
+		if input == ":quit" || input == ":exit" {
+			return nil
+		}
+		if input != "" {
+			if err := execute(ctx, out, env, input); err != nil {
+				fmt.Fprintln(out, "error:", err)
+			}
+		}
+		fmt.Fprint(out, prompt)
+	}
+	fmt.Fprintln(out)
+	return scanner.Err()
+}
+
+func execute(ctx context.Context, out io.Writer, env *celenv.Environment, input string) error {
+	if !strings.HasPrefix(input, ":") {
+		result, err := env.Eval(ctx, input)
+		if err != nil {
+			return err
+		}
+		output, err := celenv.FormatResult(result, false, false)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
+		return nil
+	}
+	command, args, _ := strings.Cut(input, " ")
+	args = strings.TrimSpace(args)
+	switch command {
+	case ":help":
+		fmt.Fprintln(out, help)
+	case ":vars":
+		for _, name := range env.Variables() {
+			fmt.Fprintln(out, name)
+		}
+	case ":type":
+		outputType, err := env.Check(args)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, outputType)
+	case ":let":
+		name, expression, found := strings.Cut(args, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return fmt.Errorf("usage: :let <name> = <expression>")
+		}
+		return env.Let(ctx, name, expression)
+	case ":load":
+		input, file, found := strings.Cut(args, " ")
+		file = strings.TrimSpace(file)
+		if !found || file == "" {
+			return fmt.Errorf("usage: :load <input> <file>")
+		}
+		if input == compiler.VariablesKey {
+			return env.LoadVariables(file)
+		}
+		return env.LoadInput(input, file)
+	default:
+		return fmt.Errorf("unknown command %s, type :help for help", command)
+	}
+	return nil
+}
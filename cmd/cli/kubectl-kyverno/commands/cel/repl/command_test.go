@@ -0,0 +1,65 @@
+package repl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/celenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"foo"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	object := filepath.Join(dir, "object.yaml")
+	require.NoError(t, os.WriteFile(object, []byte("metadata:\n  name: nginx\n"), 0o600))
+	env, err := celenv.NewEnvironment(celenv.ValidatingPolicy, "")
+	require.NoError(t, err)
+	in := strings.NewReader(strings.Join([]string{
+		":load object " + object,
+		"object.metadata.name",
+		":let size = size(object.metadata.name)",
+		":vars",
+		"variables.size + \\",
+		"  1",
+		":type variables.size",
+		"unknown(",
+		":quit",
+		"'not evaluated'",
+	}, "\n"))
+	out := bytes.NewBufferString("")
+	require.NoError(t, run(context.TODO(), in, out, env))
+	output := out.String()
+	assert.Contains(t, output, `"nginx"`)
+	assert.Contains(t, output, "size\n")
+	assert.Contains(t, output, "6\n")
+	assert.Contains(t, output, "dyn\n")
+	assert.Contains(t, output, "error:")
+	assert.NotContains(t, output, "not evaluated")
+}
@@ -0,0 +1,21 @@
+package repl
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/cel/`
+
+var description = []string{
+	`Starts an interactive session to evaluate CEL expressions.`,
+	``,
+	`Expressions are evaluated in the environment of the selected policy type, with the Kyverno libraries available.`,
+	`Inputs and variables can be loaded from files with flags or during the session, type :help for the list of commands.`,
+}
+
+var examples = [][]string{
+	{
+		"# Start a session",
+		"kyverno cel repl",
+	},
+	{
+		"# Start a session with an object and mocks",
+		"kyverno cel repl --object pod.yaml --test kyverno-test.yaml",
+	},
+}
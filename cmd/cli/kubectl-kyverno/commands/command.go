@@ -3,13 +3,20 @@ package commands
 import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/approvals"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bench"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/cel"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/completion"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/docs"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/fix"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lineage"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lint"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/migrate"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/replay"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/rollback"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/version"
 	"github.com/spf13/cobra"
@@ -27,11 +34,18 @@ func RootCommand(experimental bool) *cobra.Command {
 	}
 	cmd.AddCommand(
 		apply.Command(),
+		approvals.Command(),
+		bench.Command(),
+		cel.Command(),
 		completion.Command(),
 		create.Command(),
 		docs.Command(cmd),
 		jp.Command(),
+		lineage.Command(),
+		lint.Command(),
 		migrate.Command(),
+		replay.Command(),
+		rollback.Command(),
 		test.Command(),
 		version.Command(),
 	)
@@ -12,7 +12,7 @@ import (
 func TestRootCommand(t *testing.T) {
 	cmd := RootCommand(false)
 	assert.NotNil(t, cmd)
-	assert.Len(t, cmd.Commands(), 8)
+	assert.Len(t, cmd.Commands(), 15)
 	err := cmd.Execute()
 	assert.NoError(t, err)
 }
@@ -20,7 +20,7 @@ func TestRootCommand(t *testing.T) {
 func TestRootCommandExperimental(t *testing.T) {
 	cmd := RootCommand(true)
 	assert.NotNil(t, cmd)
-	assert.Len(t, cmd.Commands(), 10)
+	assert.Len(t, cmd.Commands(), 17)
 	err := cmd.Execute()
 	assert.NoError(t, err)
 }
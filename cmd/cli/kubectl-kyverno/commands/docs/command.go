@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/docs/policies"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +29,7 @@ func Command(root *cobra.Command) *cobra.Command {
 	cmd.Flags().BoolVar(&options.autogenTag, "autogenTag", true, "Determines if the generated docs should contain a timestamp")
 	cmd.Flags().BoolVar(&options.noDate, "noDate", false, "Determines if the generated docs should contain a date")
 	cmd.Flags().BoolVar(&options.mdLinks, "markdownLinks", false, "Determines if the generated docs should contain links to markdown files")
+	cmd.AddCommand(policies.Command())
 	if err := cmd.MarkFlagDirname("output"); err != nil {
 		log.Println("WARNING", err)
 	}
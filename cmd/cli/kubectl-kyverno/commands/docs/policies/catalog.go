@@ -0,0 +1,271 @@
+package policies
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const uncategorized = "Uncategorized"
+
+type Catalog struct {
+	Title      string
+	Categories []Category
+}
+
+type Category struct {
+	Name     string
+	Policies []Policy
+}
+
+type Policy struct {
+	Kind        string
+	Name        string
+	Namespace   string
+	Title       string
+	Severity    string
+	Subject     string
+	Description string
+	Match       []string
+	Exclude     []string
+	Rules       []Rule
+	Tests       []TestResult
+	categories  []string
+}
+
+type Rule struct {
+	Name    string
+	Type    string
+	Message string
+}
+
+type TestResult struct {
+	Rule     string
+	Resource string
+	Result   string
+	Reason   string
+	Failed   bool
+}
+
+// Anchor returns an identifier used to link to the policy.
+func (p Policy) Anchor() string {
+	if p.Namespace == "" {
+		return strings.ToLower(p.Kind + "-" + p.Name)
+	}
+	return strings.ToLower(p.Kind + "-" + p.Namespace + "-" + p.Name)
+}
+
+// Passed returns the number of passed test results.
+func (p Policy) Passed() int {
+	return len(p.Tests) - p.Failed()
+}
+
+// Failed returns the number of failed test results.
+func (p Policy) Failed() int {
+	count := 0
+	for _, test := range p.Tests {
+		if test.Failed {
+			count++
+		}
+	}
+	return count
+}
+
+func (p Policy) key() string {
+	if p.Namespace == "" {
+		return p.Name
+	}
+	return p.Namespace + "/" + p.Name
+}
+
+func buildCatalog(title string, results *policy.LoaderResults, rows []table.Row) Catalog {
+	var policies []Policy
+	for _, pol := range results.Policies {
+		policies = append(policies, fromKyvernoPolicy(pol))
+	}
+	for _, pol := range results.ValidatingPolicies {
+		spec := pol.GetValidatingPolicySpec()
+		policies = append(policies, fromCELPolicy(pol, pol.GetKind(), spec.MatchConstraints, validations("validate", spec.Validations)))
+	}
+	for _, pol := range results.ImageValidatingPolicies {
+		spec := pol.GetSpec()
+		policies = append(policies, fromCELPolicy(pol, pol.GetKind(), spec.MatchConstraints, validations("verifyImages", spec.Validations)))
+	}
+	for _, pol := range results.MutatingPolicies {
+		spec := pol.GetSpec()
+		var rules []Rule
+		for i, mutation := range spec.Mutations {
+			rules = append(rules, Rule{Name: celRuleName(i), Type: "mutate", Message: string(mutation.PatchType)})
+		}
+		policies = append(policies, fromCELPolicy(pol, pol.GetKind(), spec.MatchConstraints, rules))
+	}
+	for _, pol := range results.GeneratingPolicies {
+		spec := pol.GetSpec()
+		var rules []Rule
+		for i, generation := range spec.Generation {
+			rules = append(rules, Rule{Name: celRuleName(i), Type: "generate", Message: generation.Expression})
+		}
+		policies = append(policies, fromCELPolicy(pol, pol.GetKind(), spec.MatchConstraints, rules))
+	}
+	for _, pol := range results.DeletingPolicies {
+		spec := pol.GetDeletingPolicySpec()
+		var rules []Rule
+		for _, condition := range spec.Conditions {
+			rules = append(rules, Rule{Name: condition.Name, Type: "delete", Message: condition.Expression})
+		}
+		policies = append(policies, fromCELPolicy(pol, pol.GetKind(), spec.MatchConstraints, rules))
+	}
+	tests := map[string][]TestResult{}
+	for _, row := range rows {
+		tests[row.Policy] = append(tests[row.Policy], TestResult{
+			Rule:     row.Rule,
+			Resource: row.Resource,
+			Result:   row.Result,
+			Reason:   row.Reason,
+			Failed:   row.IsFailure,
+		})
+	}
+	categories := map[string][]Policy{}
+	for _, pol := range policies {
+		pol.Tests = tests[pol.key()]
+		for _, category := range pol.categories {
+			categories[category] = append(categories[category], pol)
+		}
+	}
+	catalog := Catalog{Title: title}
+	for name, policies := range categories {
+		slices.SortFunc(policies, func(a, b Policy) int {
+			if c := strings.Compare(a.Title, b.Title); c != 0 {
+				return c
+			}
+			return strings.Compare(a.key(), b.key())
+		})
+		catalog.Categories = append(catalog.Categories, Category{Name: name, Policies: policies})
+	}
+	slices.SortFunc(catalog.Categories, func(a, b Category) int {
+		// uncategorized policies come last
+		if (a.Name == uncategorized) != (b.Name == uncategorized) {
+			if a.Name == uncategorized {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return catalog
+}
+
+func fromKyvernoPolicy(pol kyvernov1.PolicyInterface) Policy {
+	out := fromMetadata(pol, pol.GetKind())
+	match, exclude := sets.New[string](), sets.New[string]()
+	for _, rule := range pol.GetSpec().Rules {
+		match.Insert(rule.MatchResources.GetKinds()...)
+		if rule.ExcludeResources != nil {
+			exclude.Insert(rule.ExcludeResources.GetKinds()...)
+		}
+		out.Rules = append(out.Rules, Rule{Name: rule.Name, Type: ruleType(rule), Message: ruleMessage(rule)})
+	}
+	out.Match = sets.List(match)
+	out.Exclude = sets.List(exclude)
+	return out
+}
+
+func fromCELPolicy(pol metav1.Object, kind string, constraints *admissionregistrationv1.MatchResources, rules []Rule) Policy {
+	out := fromMetadata(pol, kind)
+	if constraints != nil {
+		out.Match = resources(constraints.ResourceRules)
+		out.Exclude = resources(constraints.ExcludeResourceRules)
+	}
+	out.Rules = rules
+	return out
+}
+
+func fromMetadata(pol metav1.Object, kind string) Policy {
+	annotations := pol.GetAnnotations()
+	out := Policy{
+		Kind:        kind,
+		Name:        pol.GetName(),
+		Namespace:   pol.GetNamespace(),
+		Title:       annotations[kyverno.AnnotationPolicyTitle],
+		Severity:    annotations[kyverno.AnnotationPolicySeverity],
+		Subject:     annotations[kyverno.AnnotationPolicySubject],
+		Description: strings.TrimSpace(annotations[kyverno.AnnotationPolicyDescription]),
+	}
+	if out.Title == "" {
+		out.Title = out.Name
+	}
+	for _, category := range strings.Split(annotations[kyverno.AnnotationPolicyCategory], ",") {
+		if category := strings.TrimSpace(category); category != "" && !slices.Contains(out.categories, category) {
+			out.categories = append(out.categories, category)
+		}
+	}
+	if len(out.categories) == 0 {
+		out.categories = []string{uncategorized}
+	}
+	return out
+}
+
+func resources(rules []admissionregistrationv1.NamedRuleWithOperations) []string {
+	out := sets.New[string]()
+	for _, rule := range rules {
+		for _, resource := range rule.Resources {
+			for _, group := range rule.APIGroups {
+				if group == "" {
+					out.Insert(resource)
+				} else {
+					out.Insert(group + "/" + resource)
+				}
+			}
+		}
+	}
+	return sets.List(out)
+}
+
+func validations(ruleType string, validations []admissionregistrationv1.Validation) []Rule {
+	var rules []Rule
+	for i, validation := range validations {
+		message := validation.Message
+		if message == "" {
+			message = validation.MessageExpression
+		}
+		if message == "" {
+			message = validation.Expression
+		}
+		rules = append(rules, Rule{Name: celRuleName(i), Type: ruleType, Message: message})
+	}
+	return rules
+}
+
+func celRuleName(index int) string {
+	return fmt.Sprintf("#%d", index+1)
+}
+
+func ruleType(rule kyvernov1.Rule) string {
+	switch {
+	case rule.HasValidate():
+		return "validate"
+	case rule.HasMutate():
+		return "mutate"
+	case rule.HasGenerate():
+		return "generate"
+	case rule.HasVerifyImages():
+		return "verifyImages"
+	default:
+		return ""
+	}
+}
+
+func ruleMessage(rule kyvernov1.Rule) string {
+	if rule.Validation != nil {
+		return rule.Validation.Message
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package policies
+
+import (
+	"testing"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_buildCatalog(t *testing.T) {
+	results := &policy.LoaderResults{
+		Policies: []kyvernov1.PolicyInterface{
+			&kyvernov1.ClusterPolicy{
+				TypeMeta: metav1.TypeMeta{Kind: "ClusterPolicy"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "require-labels",
+					Annotations: map[string]string{
+						"policies.kyverno.io/title":       "Require Labels",
+						"policies.kyverno.io/category":    "Best Practices, Security",
+						"policies.kyverno.io/severity":    "medium",
+						"policies.kyverno.io/description": "  Labels are required.\n",
+					},
+				},
+				Spec: kyvernov1.Spec{Rules: []kyvernov1.Rule{{
+					Name: "check-team",
+					MatchResources: kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod", "Deployment"}},
+					}}},
+					ExcludeResources: &kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Job"}}},
+					Validation:       &kyvernov1.Validation{Message: "label team is required"},
+				}}},
+			},
+			&kyvernov1.Policy{
+				TypeMeta:   metav1.TypeMeta{Kind: "Policy"},
+				ObjectMeta: metav1.ObjectMeta{Name: "team-policy", Namespace: "team-a"},
+			},
+		},
+		ValidatingPolicies: []policiesv1beta1.ValidatingPolicyLike{
+			&policiesv1beta1.ValidatingPolicy{
+				TypeMeta: metav1.TypeMeta{Kind: "ValidatingPolicy"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "disallow-host-path",
+					Annotations: map[string]string{"policies.kyverno.io/category": "Security"},
+				},
+				Spec: policiesv1beta1.ValidatingPolicySpec{
+					MatchConstraints: &admissionregistrationv1.MatchResources{ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{Rule: admissionregistrationv1.Rule{
+							APIGroups: []string{"", "apps"},
+							Resources: []string{"pods", "deployments"},
+						}},
+					}}},
+					Validations: []admissionregistrationv1.Validation{
+						{Expression: "!has(object.spec.volumes)", Message: "hostPath volumes are forbidden"},
+						{Expression: "true"},
+					},
+				},
+			},
+		},
+	}
+	rows := []table.Row{
+		{RowCompact: table.RowCompact{Policy: "require-labels", Rule: "check-team", Resource: "v1/Pod/default/good", Result: "Pass"}},
+		{RowCompact: table.RowCompact{Policy: "require-labels", Rule: "check-team", Resource: "v1/Pod/default/bad", Result: "Fail", IsFailure: true}},
+		{RowCompact: table.RowCompact{Policy: "team-a/team-policy", Result: "Pass"}},
+	}
+	catalog := buildCatalog("Catalog", results, rows)
+	assert.Equal(t, "Catalog", catalog.Title)
+	var names []string
+	for _, category := range catalog.Categories {
+		names = append(names, category.Name)
+	}
+	assert.Equal(t, []string{"Best Practices", "Security", "Uncategorized"}, names)
+
+	security := catalog.Categories[1].Policies
+	assert.Len(t, security, 2)
+	assert.Equal(t, "Require Labels", security[0].Title)
+	assert.Equal(t, "disallow-host-path", security[1].Title, "the title defaults to the name")
+
+	requireLabels := security[0]
+	assert.Equal(t, "medium", requireLabels.Severity)
+	assert.Equal(t, "Labels are required.", requireLabels.Description)
+	assert.Equal(t, []string{"Deployment", "Pod"}, requireLabels.Match)
+	assert.Equal(t, []string{"Job"}, requireLabels.Exclude)
+	assert.Equal(t, []Rule{{Name: "check-team", Type: "validate", Message: "label team is required"}}, requireLabels.Rules)
+	assert.Len(t, requireLabels.Tests, 2)
+	assert.Equal(t, 1, requireLabels.Passed())
+	assert.Equal(t, 1, requireLabels.Failed())
+
+	hostPath := security[1]
+	assert.Equal(t, []string{"apps/deployments", "apps/pods", "deployments", "pods"}, hostPath.Match)
+	assert.Equal(t, []Rule{
+		{Name: "#1", Type: "validate", Message: "hostPath volumes are forbidden"},
+		{Name: "#2", Type: "validate", Message: "true"},
+	}, hostPath.Rules)
+
+	teamPolicy := catalog.Categories[2].Policies[0]
+	assert.Equal(t, "team-a", teamPolicy.Namespace)
+	assert.Equal(t, "policy-team-a-team-policy", teamPolicy.Anchor())
+	assert.Len(t, teamPolicy.Tests, 1)
+}
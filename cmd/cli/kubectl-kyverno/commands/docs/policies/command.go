@@ -0,0 +1,38 @@
+package policies
+
+import (
+	"log"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "policies [dir]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Output file (defaults to stdout)")
+	cmd.Flags().StringVar(&options.format, "format", formatMarkdown, "Output format (markdown, html)")
+	cmd.Flags().StringVar(&options.title, "title", "Policy Catalog", "Catalog title")
+	cmd.Flags().StringSliceVar(&options.tests, "tests", nil, "Directories containing test files whose results are linked to the policies")
+	cmd.Flags().StringVarP(&options.fileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
+	cmd.Flags().BoolVar(&options.registryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
+	if err := cmd.MarkFlagFilename("output"); err != nil {
+		log.Println("WARNING", err)
+	}
+	if err := cmd.MarkFlagDirname("tests"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
@@ -0,0 +1,55 @@
+package policies
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandWithoutArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"../../../../../../test/cli/test/simple/policy.yaml", "--format", "pdf"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../../../../../test/cli/test/simple/policy.yaml", "--tests", "../../../../../../test/cli/test/simple"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out := b.String()
+	assert.True(t, strings.HasPrefix(out, "# Policy Catalog\n"))
+	assert.Contains(t, out, "## Best Practices\n")
+	assert.Contains(t, out, "## Sample\n")
+	assert.Contains(t, out, "- **Name:** `disallow-latest-tag`\n")
+	assert.Contains(t, out, "**Tests:** 4 passed, 0 failed\n")
+}
+
+func TestCommandWithOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "catalog.html")
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"../../../../../../test/cli/test/simple/policy.yaml", "--format", "html", "-o", output})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "<!DOCTYPE html>"))
+}
@@ -0,0 +1,25 @@
+package policies
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#docs`
+
+var description = []string{
+	`Generates a policy catalog.`,
+	``,
+	`The policies command renders a Markdown or HTML catalog of policies grouped by category.`,
+	``,
+	`Each policy is documented from its policies.kyverno.io/title, category, severity, subject and description annotations,`,
+	`the resources it matches and its rules or validations.`,
+	``,
+	`When test directories are provided, the kyverno-test.yaml files they contain are run and their results are linked to the policies.`,
+}
+
+var examples = [][]string{
+	{
+		`# Generate a Markdown catalog`,
+		`kyverno docs policies /path/to/policies`,
+	},
+	{
+		`# Generate an HTML catalog including test results`,
+		`kyverno docs policies /path/to/policies --tests /path/to/tests --format html -o catalog.html`,
+	},
+}
@@ -0,0 +1,74 @@
+package policies
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	testcommand "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+)
+
+type options struct {
+	output         string
+	format         string
+	title          string
+	tests          []string
+	fileName       string
+	registryAccess bool
+}
+
+func (o options) validate(paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("at least one policy path is required")
+	}
+	if o.format != formatMarkdown && o.format != formatHTML {
+		return fmt.Errorf("invalid format %s, expected (%s, %s)", o.format, formatMarkdown, formatHTML)
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, paths ...string) error {
+	results, err := policy.Load(nil, "", paths...)
+	if err != nil {
+		return fmt.Errorf("unable to load policies (%w)", err)
+	}
+	rows, err := o.testResults()
+	if err != nil {
+		return err
+	}
+	catalog := buildCatalog(o.title, results, rows)
+	if o.output != "" {
+		file, err := os.Create(o.output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	return render(out, o.format, catalog)
+}
+
+func (o options) testResults() ([]table.Row, error) {
+	var rows []table.Row
+	for _, dir := range o.tests {
+		tests, err := test.LoadTests(dir, o.fileName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load tests from %s (%w)", dir, err)
+		}
+		for _, testCase := range tests {
+			if testCase.Err != nil {
+				return nil, fmt.Errorf("unable to load test %s (%w)", testCase.Path, testCase.Err)
+			}
+			results, err := testcommand.Results(testCase, o.registryAccess)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run test %s (%w)", testCase.Path, err)
+			}
+			rows = append(rows, results...)
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,62 @@
+package policies
+
+import (
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	formatMarkdown = "markdown"
+	formatHTML     = "html"
+)
+
+//go:embed templates/catalog.md
+var markdownTemplate string
+
+//go:embed templates/catalog.html
+var htmlTemplate string
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+var funcs = map[string]any{
+	"anchor": anchor,
+	"cell":   cell,
+	"join": func(values []string) string {
+		return strings.Join(values, ", ")
+	},
+}
+
+func render(out io.Writer, format string, catalog Catalog) error {
+	switch format {
+	case formatMarkdown:
+		tmpl, err := texttemplate.New("catalog").Funcs(funcs).Parse(markdownTemplate)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(out, catalog)
+	case formatHTML:
+		tmpl, err := htmltemplate.New("catalog").Funcs(funcs).Parse(htmlTemplate)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(out, catalog)
+	default:
+		return fmt.Errorf("invalid format %s, expected (%s, %s)", format, formatMarkdown, formatHTML)
+	}
+}
+
+// anchor converts a category name to an identifier used to link to it.
+func anchor(name string) string {
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// cell escapes a value so that it fits in a single Markdown table cell.
+func cell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,77 @@
+package policies
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var catalog = Catalog{
+	Title: "Catalog",
+	Categories: []Category{{
+		Name: "Best Practices",
+		Policies: []Policy{{
+			Kind:        "ClusterPolicy",
+			Name:        "require-labels",
+			Title:       "Require <Labels>",
+			Severity:    "medium",
+			Description: "Labels are required.",
+			Match:       []string{"Deployment", "Pod"},
+			Rules:       []Rule{{Name: "check-team", Type: "validate", Message: "label team | owner\nis required"}},
+			Tests: []TestResult{
+				{Rule: "check-team", Resource: "v1/Pod/default/good", Result: "Pass"},
+				{Rule: "check-team", Resource: "v1/Pod/default/bad", Result: "Fail", Failed: true},
+			},
+		}},
+	}},
+}
+
+func Test_renderMarkdown(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, render(&out, formatMarkdown, catalog))
+	expected := "# Catalog\n" +
+		"\n" +
+		"- [Best Practices](#best-practices) (1)\n" +
+		"\n" +
+		"## Best Practices\n" +
+		"\n" +
+		"### <a id=\"clusterpolicy-require-labels\"></a>Require <Labels>\n" +
+		"\n" +
+		"- **Kind:** ClusterPolicy\n" +
+		"- **Name:** `require-labels`\n" +
+		"- **Severity:** medium\n" +
+		"- **Matches:** Deployment, Pod\n" +
+		"\n" +
+		"Labels are required.\n" +
+		"\n" +
+		"| Rule | Type | Message |\n" +
+		"|------|------|---------|\n" +
+		"| check-team | validate | label team \\| owner is required |\n" +
+		"\n" +
+		"**Tests:** 1 passed, 1 failed\n" +
+		"\n" +
+		"| Rule | Resource | Result | Reason |\n" +
+		"|------|----------|--------|--------|\n" +
+		"| check-team | v1/Pod/default/good | Pass |  |\n" +
+		"| check-team | v1/Pod/default/bad | Fail |  |\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_renderHTML(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, render(&out, formatHTML, catalog))
+	html := out.String()
+	assert.Contains(t, html, `<h2 id="best-practices">Best Practices</h2>`)
+	assert.Contains(t, html, `<h3 id="clusterpolicy-require-labels">Require &lt;Labels&gt;</h3>`)
+	assert.Contains(t, html, `<p><strong>Tests:</strong> 1 passed, 1 failed</p>`)
+	assert.Contains(t, html, `<tr class="fail"><td>check-team</td><td>v1/Pod/default/bad</td><td>Fail</td><td></td></tr>`)
+}
+
+func Test_renderInvalidFormat(t *testing.T) {
+	assert.Error(t, render(&bytes.Buffer{}, "pdf", catalog))
+}
+
+func Test_anchor(t *testing.T) {
+	assert.Equal(t, "pod-security-standards-baseline", anchor("Pod Security Standards (Baseline)"))
+}
@@ -0,0 +1,29 @@
+package lineage
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lineage/orphans"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lineage/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lineage/trigger"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "lineage",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		orphans.Command(),
+		resource.Command(),
+		trigger.Command(),
+	)
+	return cmd
+}
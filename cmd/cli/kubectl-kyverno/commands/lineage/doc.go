@@ -0,0 +1,25 @@
+package lineage
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#lineage`
+
+var description = []string{
+	`Shows the lineage of resources generated by policies.`,
+	``,
+	`The lineage is built from the labels set on generated resources and tells which policy, rule and trigger generated a resource,`,
+	`which resources were generated for a trigger, and which generated resources are orphaned or drifted from their clone source.`,
+}
+
+var examples = [][]string{
+	{
+		`# Show what generated a resource`,
+		`kyverno lineage resource configmap my-config -n default`,
+	},
+	{
+		`# Show the resources generated for a trigger`,
+		`kyverno lineage trigger namespace team-a`,
+	},
+	{
+		`# Show orphaned and drifted generated resources`,
+		`kyverno lineage orphans`,
+	},
+}
@@ -0,0 +1,47 @@
+package orphans
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lineage"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	KubeConfig string
+	Context    string
+	Output     string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "orphans",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			client, err := lineage.NewClusterClient(options.KubeConfig, options.Context)
+			if err != nil {
+				return err
+			}
+			objs, err := client.ListDownstreams(ctx, nil)
+			if err != nil {
+				return err
+			}
+			downstreams, err := lineage.EvaluateAll(ctx, client, objs, true)
+			if err != nil {
+				return err
+			}
+			return lineage.Print(cmd.OutOrStdout(), options.Output, downstreams)
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "Output format (json, yaml)")
+	return cmd
+}
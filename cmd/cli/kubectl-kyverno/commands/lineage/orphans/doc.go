@@ -0,0 +1,21 @@
+package orphans
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#lineage`
+
+var description = []string{
+	`Shows the generated resources that are orphaned or drifted.`,
+	``,
+	`A generated resource is orphaned when its trigger or clone source does not exist anymore,`,
+	`and drifted when its content differs from its clone source.`,
+}
+
+var examples = [][]string{
+	{
+		`# Show orphaned and drifted generated resources`,
+		`kyverno lineage orphans`,
+	},
+	{
+		`# Show orphaned and drifted generated resources in json format`,
+		`kyverno lineage orphans -o json`,
+	},
+}
@@ -0,0 +1,53 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lineage"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	KubeConfig string
+	Context    string
+	Namespace  string
+	Output     string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "resource <type> <name>",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := lineage.NewClusterClient(options.KubeConfig, options.Context)
+			if err != nil {
+				return err
+			}
+			obj, err := client.Get(ctx, args[0], options.Namespace, args[1])
+			if err != nil {
+				return err
+			}
+			downstream, ok := lineage.NewDownstream(*obj)
+			if !ok {
+				return fmt.Errorf("%s %s was not generated by a policy", obj.GetKind(), obj.GetName())
+			}
+			if err := lineage.Evaluate(ctx, client, &downstream, *obj); err != nil {
+				return err
+			}
+			return lineage.Print(cmd.OutOrStdout(), options.Output, []lineage.Downstream{downstream})
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Namespace of the resource")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "Output format (json, yaml)")
+	return cmd
+}
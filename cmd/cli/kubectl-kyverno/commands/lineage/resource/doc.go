@@ -0,0 +1,18 @@
+package resource
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#lineage`
+
+var description = []string{
+	`Shows the policy, rule and trigger that generated a resource.`,
+}
+
+var examples = [][]string{
+	{
+		`# Show what generated a config map`,
+		`kyverno lineage resource configmap my-config -n default`,
+	},
+	{
+		`# Show what generated a network policy in json format`,
+		`kyverno lineage resource networkpolicies.networking.k8s.io default-deny -n team-a -o json`,
+	},
+}
@@ -0,0 +1,53 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lineage"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	KubeConfig string
+	Context    string
+	Namespace  string
+	Output     string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "trigger <type> <name>",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := lineage.NewClusterClient(options.KubeConfig, options.Context)
+			if err != nil {
+				return err
+			}
+			trigger, err := client.Get(ctx, args[0], options.Namespace, args[1])
+			if err != nil {
+				return err
+			}
+			objs, err := client.ListDownstreams(ctx, trigger)
+			if err != nil {
+				return err
+			}
+			downstreams, err := lineage.EvaluateAll(ctx, client, objs, false)
+			if err != nil {
+				return err
+			}
+			return lineage.Print(cmd.OutOrStdout(), options.Output, downstreams)
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Namespace of the trigger")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "Output format (json, yaml)")
+	return cmd
+}
@@ -0,0 +1,20 @@
+package trigger
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#lineage`
+
+var description = []string{
+	`Shows the resources generated for a trigger.`,
+	``,
+	`Generated resources of synchronized rules are deleted when their trigger is deleted.`,
+}
+
+var examples = [][]string{
+	{
+		`# Show the resources generated for a namespace`,
+		`kyverno lineage trigger namespace team-a`,
+	},
+	{
+		`# Show the resources generated for a deployment in yaml format`,
+		`kyverno lineage trigger deployment nginx -n default -o yaml`,
+	},
+}
@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lint"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type options struct {
+	KubeConfig string
+	Context    string
+	Cluster    bool
+	Ignore     []string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "lint [dir]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.execute(context.Background(), cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().BoolVar(&options.Cluster, "cluster", false, "Lint the policies installed in the cluster")
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringSliceVar(&options.Ignore, "ignore", nil, "Checks to ignore")
+	return cmd
+}
+
+func (o options) validate(paths []string) error {
+	if len(paths) == 0 && !o.Cluster {
+		return errors.New("policy paths or --cluster are required")
+	}
+	if unknown := sets.List(sets.New(o.Ignore...).Difference(sets.New(lint.Checks...))); len(unknown) != 0 {
+		return fmt.Errorf("unknown checks %v, expected %v", unknown, lint.Checks)
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, paths ...string) error {
+	results, err := o.load(ctx, paths...)
+	if err != nil {
+		return err
+	}
+	findings := lint.Lint(results, o.Ignore...)
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "No issues found")
+		return nil
+	}
+	printer := table.NewTablePrinter(out)
+	printer.Print(findings)
+	return fmt.Errorf("%d issues found", len(findings))
+}
+
+func (o options) load(ctx context.Context, paths ...string) (*policy.LoaderResults, error) {
+	if !o.Cluster {
+		results, err := policy.Load(nil, "", paths...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load policies (%w)", err)
+		}
+		return results, nil
+	}
+	restConfig, err := config.CreateClientConfigWithContext(o.KubeConfig, o.Context)
+	if err != nil {
+		return nil, err
+	}
+	client, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	results, err := lint.FromCluster(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list policies (%w)", err)
+	}
+	if len(paths) != 0 {
+		files, err := policy.Load(nil, "", paths...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load policies (%w)", err)
+		}
+		results.Policies = append(results.Policies, files.Policies...)
+		results.ValidatingPolicies = append(results.ValidatingPolicies, files.ValidatingPolicies...)
+		results.ImageValidatingPolicies = append(results.ImageValidatingPolicies, files.ImageValidatingPolicies...)
+		results.MutatingPolicies = append(results.MutatingPolicies, files.MutatingPolicies...)
+		results.GeneratingPolicies = append(results.GeneratingPolicies, files.GeneratingPolicies...)
+	}
+	return results, nil
+}
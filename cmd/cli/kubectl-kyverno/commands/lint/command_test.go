@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const policyYaml = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  background: true
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    preconditions:
+      all:
+      - key: "{{ request.object.kind }}"
+        operator: Equals
+        value: Deployment
+    validate:
+      failureAction: Audit
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+func TestCommandWithoutArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithUnknownCheck(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"policy.yaml", "--ignore", "foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(policyYaml), 0o600))
+
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{path})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 issues found")
+	assert.Contains(t, b.String(), "unreachable-rule")
+
+	cmd = Command()
+	b = bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{path, "--ignore", "unreachable-rule"})
+	err = cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "No issues found\n", b.String())
+}
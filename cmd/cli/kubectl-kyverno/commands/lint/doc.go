@@ -0,0 +1,29 @@
+package lint
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#lint`
+
+var description = []string{
+	`Lints policies for best-practice and performance issues.`,
+	``,
+	`The lint command reports the following issues in policy files and, with --cluster, in the policies installed in the cluster:`,
+	`  - wildcard-kind: the policy matches all kinds or resources`,
+	`  - foreach-api-call: an API call is made for each element of a foreach without a jmesPath filter`,
+	`  - cel-resource-list: a CEL expression lists resources on every admission request`,
+	`  - background-audit: background or the failure action is not set explicitly`,
+	`  - duplicate-rule: a rule is identical to another rule, a CEL policy to another policy of the same kind,`,
+	`    or a CEL validation or mutation to another one in the same policy`,
+	`  - unreachable-rule: the rule preconditions contradict its match block`,
+	``,
+	`The command fails when issues are found.`,
+}
+
+var examples = [][]string{
+	{
+		`# Lint policy files`,
+		`kyverno lint /path/to/policies`,
+	},
+	{
+		`# Lint the policies installed in the cluster, ignoring some checks`,
+		`kyverno lint --cluster --ignore background-audit,duplicate-rule`,
+	},
+}
@@ -2,11 +2,12 @@ package internal
 
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/policy/bundle"
 )
 
 const (
 	PolicyConfigMediaType = "application/vnd.cncf.kyverno.config.v1+json"
-	PolicyLayerMediaType  = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
+	PolicyLayerMediaType  = bundle.PolicyLayerMediaType
 	AnnotationKind        = "io.kyverno.image.kind"
 	AnnotationName        = "io.kyverno.image.name"
 	AnnotationApiVersion  = "io.kyverno.image.apiVersion"
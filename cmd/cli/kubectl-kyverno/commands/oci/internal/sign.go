@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/kyverno/kyverno/pkg/policy/bundle"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/attest"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/generate"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/sign"
+	"github.com/sigstore/cosign/v3/cmd/cosign/cli/signcommon"
+)
+
+// SignOptions configure how a policy bundle is signed with cosign.
+type SignOptions struct {
+	// Key is the path to a private key file, a KMS URI or a Kubernetes secret.
+	Key string
+	// Keyless signs with a short-lived certificate issued by Fulcio.
+	Keyless bool
+	// IdentityToken is the OIDC identity token used for keyless signing.
+	IdentityToken string
+	// SkipConfirmation skips the transparency log upload confirmation prompt.
+	SkipConfirmation bool
+}
+
+// Enabled returns true when the bundle must be signed.
+func (o SignOptions) Enabled() bool {
+	return o.Key != "" || o.Keyless
+}
+
+// Validate checks the options are consistent.
+func (o SignOptions) Validate() error {
+	if o.Key != "" && o.Keyless {
+		return errors.New("key and keyless are mutually exclusive")
+	}
+	if o.IdentityToken != "" && !o.Keyless {
+		return errors.New("identity token requires keyless signing")
+	}
+	return nil
+}
+
+// Sign signs the image and attaches the policy manifest as a signed attestation.
+func (o SignOptions) Sign(ctx context.Context, digest name.Digest, manifest bundle.Manifest, keychain authn.Keychain) error {
+	ko := options.KeyOpts{
+		KeyRef:           o.Key,
+		PassFunc:         generate.GetPass,
+		FulcioURL:        options.DefaultFulcioURL,
+		RekorURL:         options.DefaultRekorURL,
+		OIDCIssuer:       options.DefaultOIDCIssuerURL,
+		OIDCClientID:     "sigstore",
+		IDToken:          o.IdentityToken,
+		SkipConfirmation: o.SkipConfirmation,
+		NewBundleFormat:  true,
+	}
+	if err := signcommon.LoadTrustedMaterialAndSigningConfig(ctx, &ko, true, "", "", "", "", "", "", true, true, "", o.Key, false, "", "", "", "", "", ""); err != nil {
+		return fmt.Errorf("loading signing config: %w", err)
+	}
+	registry := options.RegistryOptions{Keychain: keychain}
+	signOpts := options.SignOptions{
+		Key:              o.Key,
+		Upload:           true,
+		TlogUpload:       true,
+		SkipConfirmation: o.SkipConfirmation,
+		NewBundleFormat:  true,
+		UseSigningConfig: true,
+		Registry:         registry,
+	}
+	if err := sign.SignCmd(ctx, &options.RootOptions{Timeout: options.DefaultTimeout}, ko, signOpts, []string{digest.String()}); err != nil {
+		return fmt.Errorf("signing image: %w", err)
+	}
+	predicate, err := os.CreateTemp("", "kyverno-policy-manifest-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(predicate.Name())
+	if err := json.NewEncoder(predicate).Encode(manifest); err != nil {
+		predicate.Close()
+		return fmt.Errorf("writing policy manifest: %w", err)
+	}
+	if err := predicate.Close(); err != nil {
+		return err
+	}
+	attestCmd := attest.AttestCommand{
+		KeyOpts:         ko,
+		RegistryOptions: registry,
+		PredicatePath:   predicate.Name(),
+		PredicateType:   bundle.ManifestPredicateType,
+		Timeout:         options.DefaultTimeout,
+		TlogUpload:      true,
+		RekorEntryType:  "dsse",
+	}
+	if err := attestCmd.Exec(ctx, digest.String()); err != nil {
+		return fmt.Errorf("attesting policy manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     SignOptions
+		wantEnabled bool
+		wantErr     bool
+	}{{
+		name: "disabled",
+	}, {
+		name:        "key",
+		options:     SignOptions{Key: "cosign.key"},
+		wantEnabled: true,
+	}, {
+		name:        "keyless",
+		options:     SignOptions{Keyless: true, IdentityToken: "token"},
+		wantEnabled: true,
+	}, {
+		name:        "key and keyless",
+		options:     SignOptions{Key: "cosign.key", Keyless: true},
+		wantEnabled: true,
+		wantErr:     true,
+	}, {
+		name:    "identity token without keyless",
+		options: SignOptions{IdentityToken: "token"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEnabled, tt.options.Enabled())
+			err := tt.options.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -26,6 +26,10 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringVar(&options.verify.Key, "key", "", "path to the public key file or KMS URI used to verify the image signature")
+	cmd.Flags().StringVar(&options.verify.CertificateIdentity, "certificate-identity", "", "identity expected in the keyless signing certificate")
+	cmd.Flags().StringVar(&options.verify.CertificateOIDCIssuer, "certificate-oidc-issuer", "", "OIDC issuer expected in the keyless signing certificate")
+	cmd.Flags().BoolVar(&options.insecureSkipVerify, "insecure-skip-verify", false, "pull policies without verifying the image signature")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
@@ -4,11 +4,23 @@ var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#pulling`
 
 var description = []string{
 	`Pulls policie(s) that are included in an OCI image from OCI registry and saves them to a local directory.`,
+	``,
+	`The image signature and its signed policy manifest are verified before any policy is saved,`,
+	`either with a public key or with the identity of a keyless signing certificate.`,
+	`Verification can only be skipped explicitly with --insecure-skip-verify.`,
 }
 
 var examples = [][]string{
 	{
-		`# Pull policy from an OCI image and save it to the specific directory`,
-		`kyverno oci pull . -i <imgref>`,
+		`# Pull policy from an OCI image signed with a cosign key and save it to the specific directory`,
+		`kyverno oci pull . -i <imgref> --key cosign.pub`,
+	},
+	{
+		`# Pull policy from an OCI image signed with a keyless certificate`,
+		`kyverno oci pull . -i <imgref> --certificate-identity <identity> --certificate-oidc-issuer <issuer>`,
+	},
+	{
+		`# Pull policy from an unsigned OCI image`,
+		`kyverno oci pull . -i <imgref> --insecure-skip-verify`,
 	},
 }
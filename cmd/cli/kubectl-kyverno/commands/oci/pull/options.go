@@ -13,12 +13,15 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/internal"
+	"github.com/kyverno/kyverno/pkg/policy/bundle"
 	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
 )
 
 type options struct {
-	imageRef string
+	imageRef           string
+	verify             bundle.VerifyOptions
+	insecureSkipVerify bool
 }
 
 func (o options) validate(dir string) error {
@@ -28,7 +31,16 @@ func (o options) validate(dir string) error {
 	if dir == "" {
 		return errors.New("dir is required")
 	}
-	return nil
+	if o.insecureSkipVerify {
+		if o.verify.Enabled() {
+			return errors.New("insecure-skip-verify can't be used with a key or certificate identity")
+		}
+		return nil
+	}
+	if !o.verify.Enabled() {
+		return errors.New("signature verification is required, provide a key or a certificate identity and OIDC issuer (or insecure-skip-verify to pull unsigned policies)")
+	}
+	return o.verify.Validate()
 }
 
 func (o options) execute(ctx context.Context, dir string, keychain authn.Keychain) error {
@@ -66,6 +78,22 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err != nil {
 		return fmt.Errorf("getting image: %v", err)
 	}
+	if !o.insecureSkipVerify {
+		// verify the exact image that was downloaded, the tag could be moved in between
+		digestRef := ref.Context().Digest(rmt.Digest.String())
+		fmt.Fprintf(os.Stderr, "Verifying signatures [%s]...\n", digestRef.Name())
+		attestor, err := o.verify.Attestor()
+		if err != nil {
+			return err
+		}
+		manifest, err := bundle.Verify(ctx, digestRef.String(), attestor, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+		if err != nil {
+			return err
+		}
+		if err := manifest.Verify(img, internal.PolicyLayerMediaType); err != nil {
+			return fmt.Errorf("verifying policy manifest: %v", err)
+		}
+	}
 	l, err := img.Layers()
 	if err != nil {
 		return fmt.Errorf("getting image layers: %v", err)
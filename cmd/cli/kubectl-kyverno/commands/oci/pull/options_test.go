@@ -0,0 +1,44 @@
+package pull
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/policy/bundle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options options
+		wantErr bool
+	}{{
+		name:    "no verification",
+		options: options{imageRef: "ghcr.io/kyverno/policies:latest"},
+		wantErr: true,
+	}, {
+		name:    "key",
+		options: options{imageRef: "ghcr.io/kyverno/policies:latest", verify: bundle.VerifyOptions{Key: "cosign.pub"}},
+	}, {
+		name:    "incomplete keyless",
+		options: options{imageRef: "ghcr.io/kyverno/policies:latest", verify: bundle.VerifyOptions{CertificateIdentity: "me@example.com"}},
+		wantErr: true,
+	}, {
+		name:    "insecure skip verify",
+		options: options{imageRef: "ghcr.io/kyverno/policies:latest", insecureSkipVerify: true},
+	}, {
+		name:    "insecure skip verify with key",
+		options: options{imageRef: "ghcr.io/kyverno/policies:latest", insecureSkipVerify: true, verify: bundle.VerifyOptions{Key: "cosign.pub"}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.validate("policies")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -26,6 +26,10 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringVar(&options.sign.Key, "key", "", "path to the private key file, KMS URI or Kubernetes secret used to sign the image")
+	cmd.Flags().BoolVar(&options.sign.Keyless, "keyless", false, "sign the image with a short-lived certificate issued by Fulcio")
+	cmd.Flags().StringVar(&options.sign.IdentityToken, "identity-token", "", "OIDC identity token used for keyless signing")
+	cmd.Flags().BoolVarP(&options.sign.SkipConfirmation, "yes", "y", false, "skip confirmation prompts when signing")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
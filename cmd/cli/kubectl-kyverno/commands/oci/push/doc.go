@@ -4,6 +4,9 @@ var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#pushing`
 
 var description = []string{
 	`Push policie(s) that are included in an OCI image to OCI registry.`,
+	``,
+	`When a key or keyless signing is configured, the image is signed with cosign and a manifest listing`,
+	`the name and layer digest of every policy is attached to the image as a signed in-toto attestation.`,
 }
 
 var examples = [][]string{
@@ -15,4 +18,12 @@ var examples = [][]string{
 		`# Push multiple policies to an OCI image from a given directory that includes policies`,
 		`kyverno oci push . -i <imgref>`,
 	},
+	{
+		`# Push and sign policies with a cosign private key`,
+		`kyverno oci push . -i <imgref> --key cosign.key`,
+	},
+	{
+		`# Push and sign policies with a short-lived certificate (keyless)`,
+		`kyverno oci push . -i <imgref> --keyless`,
+	},
 }
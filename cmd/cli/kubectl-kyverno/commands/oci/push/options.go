@@ -16,12 +16,14 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/internal"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/policy/bundle"
 	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
 )
 
 type options struct {
 	imageRef string
+	sign     internal.SignOptions
 }
 
 func (o options) validate(policy string) error {
@@ -31,7 +33,7 @@ func (o options) validate(policy string) error {
 	if policy == "" {
 		return errors.New("policy is required")
 	}
-	return nil
+	return o.sign.Validate()
 }
 
 func (o options) execute(ctx context.Context, dir string, keychain authn.Keychain) error {
@@ -51,6 +53,7 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err != nil {
 		return fmt.Errorf("parsing image reference: %v", err)
 	}
+	var manifest bundle.Manifest
 	for _, policy := range results.Policies {
 		if policy.IsNamespaced() {
 			fmt.Fprintf(os.Stderr, "Adding policy [%s]\n", policy.GetName())
@@ -62,18 +65,35 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 			return fmt.Errorf("converting policy to yaml: %v", err)
 		}
 		policyLayer := static.NewLayer(policyBytes, internal.PolicyLayerMediaType)
+		annotations := internal.Annotations(policy)
 		img, err = mutate.Append(img, mutate.Addendum{
 			Layer:       policyLayer,
-			Annotations: internal.Annotations(policy),
+			Annotations: annotations,
 		})
 		if err != nil {
 			return fmt.Errorf("mutating image: %v", err)
 		}
+		digest, err := policyLayer.Digest()
+		if err != nil {
+			return fmt.Errorf("computing layer digest: %v", err)
+		}
+		manifest.Add(annotations[internal.AnnotationApiVersion], annotations[internal.AnnotationKind], policy.GetName(), digest)
 	}
 	fmt.Fprintf(os.Stderr, "Uploading [%s]...\n", ref.Name())
 	if err = remote.Write(ref, img, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)); err != nil {
 		return fmt.Errorf("writing image: %v", err)
 	}
+	if o.sign.Enabled() {
+		digest, err := img.Digest()
+		if err != nil {
+			return fmt.Errorf("computing image digest: %v", err)
+		}
+		digestRef := ref.Context().Digest(digest.String())
+		fmt.Fprintf(os.Stderr, "Signing [%s]...\n", digestRef.Name())
+		if err := o.sign.Sign(ctx, digestRef, manifest, keychain); err != nil {
+			return err
+		}
+	}
 	fmt.Fprintf(os.Stderr, "Done.")
 	return nil
 }
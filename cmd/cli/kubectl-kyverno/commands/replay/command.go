@@ -0,0 +1,104 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/replay"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	RequestPaths []string
+	ValuesFile   string
+	Detailed     bool
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "replay [dir]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.execute(context.Background(), cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.RequestPaths, "request", "r", nil, "Path to captured admission requests, files or directories")
+	cmd.Flags().StringVarP(&options.ValuesFile, "values-file", "f", "", "File containing the labels of the namespaces")
+	cmd.Flags().BoolVar(&options.Detailed, "detailed", false, "Display how replayed decisions differ from captured ones")
+	return cmd
+}
+
+func (o options) validate(paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("policy paths are required")
+	}
+	if len(o.RequestPaths) == 0 {
+		return errors.New("request paths are required")
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, paths ...string) error {
+	captures, err := replay.Load(o.RequestPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to load requests (%w)", err)
+	}
+	if len(captures) == 0 {
+		return errors.New("no requests found")
+	}
+	policies, err := policy.Load(nil, "", paths...)
+	if err != nil {
+		return fmt.Errorf("unable to load policies (%w)", err)
+	}
+	vars, err := variables.New(out, nil, "", o.ValuesFile, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	replayer, err := replay.New(ctx, policies, captures, vars.NamespaceSelectors())
+	if err != nil {
+		return fmt.Errorf("failed to create replayer (%w)", err)
+	}
+	results := make([]replay.Result, 0, len(captures))
+	mismatches := 0
+	for _, capture := range captures {
+		response, err := replayer.Replay(ctx, capture)
+		result := replay.Result{Capture: capture, Replayed: response, Error: err}
+		if len(result.Differences()) != 0 {
+			mismatches++
+		}
+		results = append(results, result)
+	}
+	fmt.Fprintf(out, "Replayed %d requests\n", len(results))
+	table.NewTablePrinter(out).Print(replay.Rows(results...))
+	if o.Detailed {
+		for _, result := range results {
+			differences := result.Differences()
+			if len(differences) == 0 {
+				continue
+			}
+			fmt.Fprintf(out, "\n%s\n", result.Capture.Path)
+			for _, difference := range differences {
+				fmt.Fprintf(out, "  %s\n", difference)
+			}
+		}
+	}
+	if mismatches != 0 {
+		return fmt.Errorf("%d decisions differ", mismatches)
+	}
+	return nil
+}
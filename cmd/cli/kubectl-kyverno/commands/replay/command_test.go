@@ -0,0 +1,107 @@
+package replay
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const policyYaml = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  background: false
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      failureAction: Enforce
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+const requestJson = `{
+  "apiVersion": "admission.k8s.io/v1",
+  "kind": "AdmissionReview",
+  "request": {
+    "uid": "42",
+    "kind": {"group": "", "version": "v1", "kind": "Pod"},
+    "resource": {"group": "", "version": "v1", "resource": "pods"},
+    "name": "web",
+    "namespace": "default",
+    "operation": "CREATE",
+    "userInfo": {"username": "alice"},
+    "object": {"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "web", "namespace": "default"}, "spec": {"containers": [{"name": "nginx", "image": "nginx"}]}},
+    "dryRun": false
+  },
+  "response": {"uid": "42", "allowed": true},
+  "webhook": "VALIDATE",
+  "failurePolicy": "fail",
+  "groupVersionKind": {"group": "", "version": "v1", "kind": "Pod"},
+  "requestTime": "2026-01-01T00:00:00Z"
+}`
+
+func TestCommandWithoutArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "policy paths are required")
+}
+
+func TestCommandWithoutRequests(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"policy.yaml"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "request paths are required")
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommand(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policy.yaml")
+	assert.NoError(t, os.WriteFile(policyPath, []byte(policyYaml), 0o600))
+	requests := filepath.Join(dir, "requests")
+	assert.NoError(t, os.MkdirAll(requests, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(requests, "1-42-validate.json"), []byte(requestJson), 0o600))
+
+	// the request was allowed before the policy was enforced
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{policyPath, "--request", requests, "--detailed"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 decisions differ")
+	out := b.String()
+	assert.Contains(t, out, "Replayed 1 requests")
+	assert.Contains(t, out, "pods/default/web")
+	assert.Contains(t, out, "allowed: true != false")
+	assert.Contains(t, out, "label team is required")
+}
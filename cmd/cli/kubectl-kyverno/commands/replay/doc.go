@@ -0,0 +1,35 @@
+package replay
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#replay`
+
+var description = []string{
+	`Replays captured admission requests against policies.`,
+	``,
+	`Admission requests are captured by the admission controller when started with --captureAdmissionRequests=<dir>,`,
+	`each capture is an AdmissionReview holding the request, the response and what Kyverno resolved to evaluate the request.`,
+	`The directory must be writable, with the Helm chart set admissionController.captureAdmissionRequests.enabled to mount an emptyDir volume`,
+	`and pass the flags. At most --captureAdmissionRequestsLimit captures (1000 by default) are written,`,
+	`captures already in the directory count towards the limit. Captures are written in the background and dropped when writes lag behind.`,
+	``,
+	`Only the webhooks of ClusterPolicy and Policy (MUTATE and VALIDATE) and of ValidatingPolicy (VPOL and NVPOL) capture requests`,
+	`and can be replayed, requests served by MutatingPolicy, ImageValidatingPolicy and GeneratingPolicy webhooks are not captured`,
+	`and the command fails when such policies are given.`,
+	``,
+	`The replay command re-evaluates each request offline with the webhook handler that served it,`,
+	`using the captured userInfo, roles, oldObject, dryRun and request time, and compares the decision with the captured one.`,
+	`The command fails when a replayed decision differs from the captured one.`,
+	``,
+	`Namespace labels are not captured and can be provided with a values file.`,
+	`Context entries calling the API server or registries and Secret data, which is redacted when captured, are not available offline.`,
+}
+
+var examples = [][]string{
+	{
+		`# Replay captured requests against policies`,
+		`kyverno replay /path/to/policies --request /path/to/captures`,
+	},
+	{
+		`# Replay captured requests with namespace labels and display the differences`,
+		`kyverno replay /path/to/policies --request /path/to/captures --values-file values.yaml --detailed`,
+	},
+}
@@ -0,0 +1,136 @@
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/pkg/background/history"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+)
+
+type options struct {
+	KubeConfig       string
+	Context          string
+	Namespace        string
+	KyvernoNamespace string
+	PolicyVersion    int64
+	DryRun           bool
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "rollback <kind> <name>",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, namespaced, err := parseKind(args[0])
+			if err != nil {
+				return err
+			}
+			namespace := ""
+			if namespaced {
+				if options.Namespace == "" {
+					return fmt.Errorf("a namespace is required for %s", kind)
+				}
+				namespace = options.Namespace
+			}
+			return options.execute(context.Background(), cmd.OutOrStdout(), kind, namespace, args[1])
+		},
+	}
+	cmd.Flags().StringVar(&options.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Namespace of the policy, for namespaced policies")
+	cmd.Flags().StringVar(&options.KyvernoNamespace, "kyverno-namespace", "kyverno", "Namespace where Kyverno is installed")
+	cmd.Flags().Int64Var(&options.PolicyVersion, "policy-version", 0, "Only roll back the changes made by this version (generation) of the policy, all versions by default")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "Show the changes that would be rolled back without rolling them back")
+	return cmd
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, kind, namespace, name string) error {
+	restConfig, err := config.CreateClientConfigWithContext(o.KubeConfig, o.Context)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	metadataClient, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	client, err := dclient.NewClient(ctx, dynamicClient, kubeClient, 15*time.Minute, false, metadataClient)
+	if err != nil {
+		return err
+	}
+	store := history.NewStore(kubeClient.CoreV1(), o.KyvernoNamespace, 0, 0)
+	records, err := store.List(ctx, kind, namespace, name, o.PolicyVersion)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		fmt.Fprintf(out, "No changes recorded for %s %s\n", kind, name)
+		return nil
+	}
+	done, rollbackErr := history.Rollback(ctx, client, records, o.DryRun)
+	if !o.DryRun {
+		for _, record := range done {
+			if err := store.Delete(ctx, record); err != nil {
+				return err
+			}
+		}
+	}
+	if err := printRecords(out, done); err != nil {
+		return err
+	}
+	return rollbackErr
+}
+
+func parseKind(kind string) (string, bool, error) {
+	switch strings.ToLower(kind) {
+	case "mutatingpolicy", "mutatingpolicies", "mpol":
+		return "MutatingPolicy", false, nil
+	case "namespacedmutatingpolicy", "namespacedmutatingpolicies", "nmpol":
+		return "NamespacedMutatingPolicy", true, nil
+	case "generatingpolicy", "generatingpolicies", "gpol":
+		return "GeneratingPolicy", false, nil
+	case "namespacedgeneratingpolicy", "namespacedgeneratingpolicies", "ngpol":
+		return "NamespacedGeneratingPolicy", true, nil
+	default:
+		return "", false, fmt.Errorf("unsupported policy kind %q, must be a mutating or generating policy", kind)
+	}
+}
+
+func printRecords(out io.Writer, records []history.Record) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "OPERATION\tVERSION\tAPIVERSION\tKIND\tNAMESPACE\tNAME")
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			record.Operation,
+			record.Policy.Version,
+			record.Resource.APIVersion,
+			record.Resource.Kind,
+			record.Resource.Namespace,
+			record.Resource.Name,
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,77 @@
+package rollback
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/history"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandWithInvalidArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"mutatingpolicy"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandWithInvalidKind(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"clusterpolicy", "add-labels"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithoutNamespace(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"ngpol", "generate-netpol"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "a namespace is required for NamespacedGeneratingPolicy")
+}
+
+func TestPrintRecords(t *testing.T) {
+	b := bytes.NewBufferString("")
+	err := printRecords(b, []history.Record{{
+		Policy:    history.PolicyRef{Kind: "MutatingPolicy", Name: "add-labels", Version: 3},
+		Operation: history.OperationMutate,
+		Resource:  kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "team-a", Name: "config"},
+	}})
+	assert.NoError(t, err)
+	expected := `
+OPERATION   VERSION   APIVERSION   KIND        NAMESPACE   NAME
+mutate      3         v1           ConfigMap   team-a      config`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(b.String()))
+}
@@ -0,0 +1,26 @@
+package rollback
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#rollback`
+
+var description = []string{
+	`Rolls back the changes made in the background by a mutating or generating policy.`,
+	``,
+	`The background controller records the prior state of the resources it mutates and the resources it generates when started with --rollbackRetention.`,
+	`Mutated resources are patched back to their prior state. Generated resources still owned by the policy are restored to their prior state, or deleted when the policy created them.`,
+	`Delete or update the policy first, otherwise the next background scan applies the changes again.`,
+}
+
+var examples = [][]string{
+	{
+		`# Show the changes made by a mutating policy`,
+		`kyverno rollback mutatingpolicy add-labels --dry-run`,
+	},
+	{
+		`# Roll back the changes made by a given version (generation) of a mutating policy`,
+		`kyverno rollback mutatingpolicy add-labels --policy-version 3`,
+	},
+	{
+		`# Roll back the resources generated by a namespaced generating policy`,
+		`kyverno rollback namespacedgeneratingpolicy generate-netpol -n team-a`,
+	},
+}
@@ -141,16 +141,26 @@ func testCommandExecute(
 			}
 
 			// filter results
-			var filteredResults []v1alpha1.TestResult
-			for _, res := range test.Test.Results {
-				if filter.Apply(res) {
-					if len(resourceFilters) > 0 {
-						res.Resources = resourceFilters
+			filterResults := func(results []v1alpha1.TestResult) []v1alpha1.TestResult {
+				var filteredResults []v1alpha1.TestResult
+				for _, res := range results {
+					if filter.Apply(res) {
+						if len(resourceFilters) > 0 {
+							res.Resources = resourceFilters
+						}
+						filteredResults = append(filteredResults, res)
 					}
-					filteredResults = append(filteredResults, res)
 				}
+				return filteredResults
 			}
-			if len(filteredResults) == 0 {
+			filteredResults := filterResults(test.Test.Results)
+			filteredStepResults := make([][]v1alpha1.TestResult, len(test.Test.Steps))
+			stepResultsCount := 0
+			for i, step := range test.Test.Steps {
+				filteredStepResults[i] = filterResults(step.Results)
+				stepResultsCount += len(filteredStepResults[i])
+			}
+			if len(filteredResults) == 0 && stepResultsCount == 0 {
 				continue
 			}
 			resourcePath := filepath.Dir(test.Path)
@@ -158,21 +168,37 @@ func testCommandExecute(
 			if err != nil {
 				return fmt.Errorf("failed to run test (%w)", err)
 			}
-			fmt.Fprintln(out, "  Checking results ...")
-			var resultsTable table.Table
-			if err := printTestResult(filteredResults, responses, rc, &resultsTable, test.Fs, resourcePath, removeColor); err != nil {
-				return fmt.Errorf("failed to print test result (%w)", err)
+			printResults := func(resultsTable table.Table) {
+				fullTable.AddFailed(resultsTable.RawRows...)
+				if !failOnly {
+					if len(outputFormat) > 0 {
+						printOutputFormats(out, outputFormat, resultsTable, detailedResults)
+					} else {
+						printer := table.NewTablePrinter(out)
+						fmt.Fprintln(out)
+						printer.Print(resultsTable.Rows(detailedResults))
+						fmt.Fprintln(out)
+					}
+				}
+			}
+			if len(filteredResults) > 0 {
+				fmt.Fprintln(out, "  Checking results ...")
+				var resultsTable table.Table
+				if err := printTestResult(filteredResults, responses, rc, &resultsTable, test.Fs, resourcePath, removeColor); err != nil {
+					return fmt.Errorf("failed to print test result (%w)", err)
+				}
+				printResults(resultsTable)
 			}
-			fullTable.AddFailed(resultsTable.RawRows...)
-			if !failOnly {
-				if len(outputFormat) > 0 {
-					printOutputFormats(out, outputFormat, resultsTable, detailedResults)
-				} else {
-					printer := table.NewTablePrinter(out)
-					fmt.Fprintln(out)
-					printer.Print(resultsTable.Rows(detailedResults))
-					fmt.Fprintln(out)
+			for i, step := range test.Test.Steps {
+				if len(filteredStepResults[i]) == 0 {
+					continue
+				}
+				fmt.Fprintln(out, "  Checking results of", step.DisplayName(i), "...")
+				var resultsTable table.Table
+				if err := printTestResult(filteredStepResults[i], responses.stepResponse(i, step.Operation), rc, &resultsTable, test.Fs, resourcePath, removeColor); err != nil {
+					return fmt.Errorf("failed to print test result of %s (%w)", step.DisplayName(i), err)
 				}
+				printResults(resultsTable)
 			}
 		}
 	}
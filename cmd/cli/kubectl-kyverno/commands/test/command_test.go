@@ -1116,3 +1116,60 @@ func Test_InvalidResultOperation(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid operation")
 }
+
+func Test_Steps(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "Failed to get working directory")
+	rootDir := filepath.Join(wd, "..", "..", "..", "..", "..")
+	testDir := filepath.Join(rootDir, "test", "cli", "test-steps", "carry-forward")
+
+	testFile := filepath.Join(testDir, "kyverno-test.yaml")
+	testCases := test.LoadTest(nil, testFile)
+	require.Len(t, testCases, 1, "Expected exactly one test case in %s", testFile)
+	testCase := testCases[0]
+
+	testResponse, err := runTest(io.Discard, testCase, false)
+	require.NoError(t, err, "Failed to run test")
+	require.Len(t, testResponse.Steps, len(testCase.Test.Steps))
+
+	resourceKey := "v1,Pod,test-ns,test-pod"
+	status := func(step int, policy string) engineapi.RuleStatus {
+		for _, response := range testResponse.Steps[step][resourceKey] {
+			if response.Policy().GetName() == policy && len(response.PolicyResponse.Rules) > 0 {
+				return response.PolicyResponse.Rules[0].Status()
+			}
+		}
+		return ""
+	}
+
+	assert.Equal(t, engineapi.RuleStatusPass, status(0, "add-env-label"), "CREATE step is mutated")
+	assert.Equal(t, engineapi.RuleStatusFail, status(1, "preserve-env-label"), "UPDATE step sees the mutated resource as oldObject")
+	assert.Equal(t, engineapi.RuleStatusPass, status(1, "immutable-team-label"))
+	assert.Equal(t, engineapi.RuleStatusPass, status(2, "preserve-env-label"), "explicit oldObject takes precedence")
+	assert.Equal(t, engineapi.RuleStatusFail, status(2, "immutable-team-label"))
+	assert.Equal(t, engineapi.RuleStatusFail, status(3, "deny-dev-deletion"), "denied updates do not change the carried state")
+
+	rows, err := Results(testCase, false)
+	require.NoError(t, err)
+	require.Len(t, rows, 6)
+	for _, row := range rows {
+		assert.False(t, row.IsFailure, "unexpected failure for %s: %s", row.Policy, row.Message)
+	}
+}
+
+func Test_StepsUpdateWithoutPriorState(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err, "Failed to get working directory")
+	rootDir := filepath.Join(wd, "..", "..", "..", "..", "..")
+	testDir := filepath.Join(rootDir, "test", "cli", "test-steps", "carry-forward")
+
+	testFile := filepath.Join(testDir, "kyverno-test.yaml")
+	testCases := test.LoadTest(nil, testFile)
+	require.Len(t, testCases, 1)
+	testCase := testCases[0]
+	testCase.Test.Steps = testCase.Test.Steps[1:]
+
+	_, err = runTest(io.Discard, testCase, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "step 1 (drop-env-label): no prior state for resource Pod/test-ns/test-pod")
+}
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+)
+
+// Results runs a test case without printing anything and returns one row per checked result.
+// Row fields are not colored.
+func Results(testCase test.TestCase, registryAccess bool) ([]table.Row, error) {
+	responses, err := runTest(io.Discard, testCase, registryAccess)
+	if err != nil {
+		return nil, err
+	}
+	var resultsTable table.Table
+	if err := printTestResult(testCase.Test.Results, responses, &resultCounts{}, &resultsTable, testCase.Fs, filepath.Dir(testCase.Path), true); err != nil {
+		return nil, err
+	}
+	for i, step := range testCase.Test.Steps {
+		if err := printTestResult(step.Results, responses.stepResponse(i, step.Operation), &resultCounts{}, &resultsTable, testCase.Fs, filepath.Dir(testCase.Path), true); err != nil {
+			return nil, err
+		}
+	}
+	rows := resultsTable.RawRows
+	for i := range rows {
+		rows[i].Policy = StripANSI(rows[i].Policy)
+		rows[i].Rule = StripANSI(rows[i].Rule)
+		rows[i].Resource = StripANSI(rows[i].Resource)
+		rows[i].Result = StripANSI(rows[i].Result)
+		rows[i].Reason = StripANSI(rows[i].Reason)
+	}
+	return rows, nil
+}
@@ -0,0 +1,158 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/cli/loader"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// stepState holds the resources carried forward between test steps, keyed by resource key.
+type stepState map[string]*unstructured.Unstructured
+
+// loadStepResource loads the single resource declared in a step object or oldObject file.
+func loadStepResource(out io.Writer, fs billy.Filesystem, testDir string, isGit bool, file string, policies []engineapi.GenericPolicy) (*unstructured.Unstructured, error) {
+	fullPaths := path.GetFullPaths([]string{file}, testDir, isGit)
+	resources, err := common.GetResourceAccordingToResourcePath(out, fs, fullPaths, false, policies, nil, "", false, false, testDir, loader.ResourceOptions{}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resource %s (%w)", file, err)
+	}
+	resources = ProcessResources(resources)
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("resource file %s must contain exactly one resource, found %d", file, len(resources))
+	}
+	return resources[0], nil
+}
+
+// resolveStepRequest computes the resource to evaluate for a step and, for UPDATE, its prior
+// state. An explicit old object takes precedence over the state carried forward from previous
+// steps. For DELETE, the returned resource is the state being deleted.
+func (s stepState) resolveStepRequest(step v1alpha1.TestStep, object, oldObject *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	switch step.Operation {
+	case "UPDATE":
+		if oldObject != nil {
+			return object, oldObject, nil
+		}
+		if current, ok := s[generateResourceKey(object)]; ok {
+			return object, current, nil
+		}
+		return nil, nil, fmt.Errorf("no prior state for resource %s, set oldObject or create it in a previous step", resourceDisplayName(object))
+	case "DELETE":
+		if oldObject != nil {
+			return oldObject, nil, nil
+		}
+		if current, ok := s[generateResourceKey(object)]; ok {
+			return current, nil, nil
+		}
+		return object, nil, nil
+	default:
+		return object, nil, nil
+	}
+}
+
+// carryForward records the outcome of a step: the mutated resource replaces the submitted one
+// (or is removed on DELETE), and generated and patched target resources are added. Changes are
+// mirrored to the fake cluster so later steps observe them. A denied request changes nothing.
+func (s stepState) carryForward(client dclient.Interface, operation string, resource *unstructured.Unstructured, responses []engineapi.EngineResponse) {
+	if isDenied(responses) {
+		return
+	}
+	if operation == "DELETE" {
+		delete(s, generateResourceKey(resource))
+		if client != nil {
+			if err := client.DeleteResource(context.TODO(), resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), false, metav1.DeleteOptions{}); err != nil {
+				log.Log.V(3).Info("failed to delete resource from the fake cluster", "resource", resourceDisplayName(resource), "error", err)
+			}
+		}
+	} else {
+		final := resource.DeepCopy()
+		for _, response := range responses {
+			for _, rule := range response.PolicyResponse.Rules {
+				if rule.RuleType() == engineapi.Mutation && rule.Status() == engineapi.RuleStatusPass {
+					final = response.PatchedResource.DeepCopy()
+					break
+				}
+			}
+		}
+		s.put(client, final)
+	}
+	for _, response := range responses {
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status() != engineapi.RuleStatusPass {
+				continue
+			}
+			for _, generated := range rule.GeneratedResources() {
+				s.put(client, generated.DeepCopy())
+			}
+			if target, _, _ := rule.PatchedTarget(); target != nil {
+				s.put(client, target.DeepCopy())
+			}
+		}
+	}
+}
+
+// stepResponse returns the responses of the step at the given index, shaped so that the
+// step results can be checked with printTestResult.
+func (r *TestResponse) stepResponse(i int, operation string) *TestResponse {
+	trigger := r.Steps[i]
+	return &TestResponse{
+		Trigger:            trigger,
+		TriggerByOperation: map[string]map[string][]engineapi.EngineResponse{operation: trigger},
+		SkippedPolicies:    r.SkippedPolicies,
+	}
+}
+
+// isDenied reports whether the API server would have rejected the request: a Kyverno policy
+// rule failed with an Enforce action, or a validating policy with the Deny action failed.
+func isDenied(responses []engineapi.EngineResponse) bool {
+	for _, response := range responses {
+		if response.Policy() == nil || !response.IsFailed() {
+			continue
+		}
+		if response.HasEnforcedFailure() {
+			return true
+		}
+		if vpol := response.Policy().AsValidatingPolicyLike(); vpol != nil && slices.Contains(vpol.GetSpec().ValidationActions(), admissionregistrationv1.Deny) {
+			return true
+		}
+	}
+	return false
+}
+
+// put stores a resource in the state and creates or updates it in the fake cluster.
+func (s stepState) put(client dclient.Interface, resource *unstructured.Unstructured) {
+	s[generateResourceKey(resource)] = resource
+	if client == nil {
+		return
+	}
+	obj := resource.DeepCopy()
+	if _, err := client.GetResource(context.TODO(), obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName()); err == nil {
+		_, err = client.UpdateResource(context.TODO(), obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj, false)
+		if err != nil {
+			log.Log.V(3).Info("failed to update resource in the fake cluster", "resource", resourceDisplayName(obj), "error", err)
+		}
+		return
+	}
+	if _, err := client.CreateResource(context.TODO(), obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj, false); err != nil {
+		log.Log.V(3).Info("failed to create resource in the fake cluster", "resource", resourceDisplayName(obj), "error", err)
+	}
+}
+
+func resourceDisplayName(resource *unstructured.Unstructured) string {
+	if resource.GetNamespace() == "" {
+		return resource.GetKind() + "/" + resource.GetName()
+	}
+	return resource.GetKind() + "/" + resource.GetNamespace() + "/" + resource.GetName()
+}
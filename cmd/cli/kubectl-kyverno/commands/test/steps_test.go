@@ -0,0 +1,132 @@
+package test
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newStepPod(labels map[string]string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "test-pod",
+			"namespace": "default",
+		},
+	}}
+	pod.SetLabels(labels)
+	return pod
+}
+
+func newStepResponse(resource *unstructured.Unstructured, action kyvernov1.ValidationFailureAction, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+	policy := &kyvernov1.ClusterPolicy{}
+	policy.SetName("test-policy")
+	policy.Spec.Rules = []kyvernov1.Rule{{Name: "test-rule"}}
+	policy.Spec.ValidationFailureAction = action
+	return engineapi.NewEngineResponse(*resource, engineapi.NewKyvernoPolicy(policy), nil).
+		WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+}
+
+func TestStepStateResolveStepRequest(t *testing.T) {
+	current := newStepPod(map[string]string{"env": "dev"})
+	object := newStepPod(map[string]string{"env": "prod"})
+	state := stepState{generateResourceKey(current): current}
+
+	t.Run("CREATE", func(t *testing.T) {
+		resource, oldResource, err := state.resolveStepRequest(v1alpha1.TestStep{Operation: "CREATE"}, object, nil)
+		require.NoError(t, err)
+		assert.Equal(t, object, resource)
+		assert.Nil(t, oldResource)
+	})
+	t.Run("UPDATE uses the carried state", func(t *testing.T) {
+		resource, oldResource, err := state.resolveStepRequest(v1alpha1.TestStep{Operation: "UPDATE"}, object, nil)
+		require.NoError(t, err)
+		assert.Equal(t, object, resource)
+		assert.Equal(t, current, oldResource)
+	})
+	t.Run("UPDATE prefers the explicit old object", func(t *testing.T) {
+		oldObject := newStepPod(map[string]string{"env": "test"})
+		_, oldResource, err := state.resolveStepRequest(v1alpha1.TestStep{Operation: "UPDATE"}, object, oldObject)
+		require.NoError(t, err)
+		assert.Equal(t, oldObject, oldResource)
+	})
+	t.Run("UPDATE without prior state", func(t *testing.T) {
+		_, _, err := stepState{}.resolveStepRequest(v1alpha1.TestStep{Operation: "UPDATE"}, object, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no prior state for resource Pod/default/test-pod")
+	})
+	t.Run("DELETE uses the carried state", func(t *testing.T) {
+		resource, oldResource, err := state.resolveStepRequest(v1alpha1.TestStep{Operation: "DELETE"}, object, nil)
+		require.NoError(t, err)
+		assert.Equal(t, current, resource)
+		assert.Nil(t, oldResource)
+	})
+	t.Run("DELETE falls back to the object", func(t *testing.T) {
+		resource, _, err := stepState{}.resolveStepRequest(v1alpha1.TestStep{Operation: "DELETE"}, object, nil)
+		require.NoError(t, err)
+		assert.Equal(t, object, resource)
+	})
+}
+
+func TestStepStateCarryForward(t *testing.T) {
+	resource := newStepPod(nil)
+	key := generateResourceKey(resource)
+
+	t.Run("mutated resource replaces the submitted one", func(t *testing.T) {
+		state := stepState{}
+		mutated := newStepPod(map[string]string{"env": "dev"})
+		response := newStepResponse(resource, kyvernov1.Audit, *engineapi.RulePass("test-rule", engineapi.Mutation, "mutated", nil)).
+			WithPatchedResource(*mutated)
+		state.carryForward(nil, "CREATE", resource, []engineapi.EngineResponse{response})
+		require.Contains(t, state, key)
+		assert.Equal(t, map[string]string{"env": "dev"}, state[key].GetLabels())
+	})
+	t.Run("generated resources are added", func(t *testing.T) {
+		state := stepState{}
+		generated := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "generated", "namespace": "default"},
+		}}
+		rule := engineapi.RulePass("test-rule", engineapi.Generation, "generated", nil).
+			WithGeneratedResources([]*unstructured.Unstructured{generated})
+		state.carryForward(nil, "CREATE", resource, []engineapi.EngineResponse{newStepResponse(resource, kyvernov1.Audit, *rule)})
+		assert.Contains(t, state, key)
+		assert.Contains(t, state, generateResourceKey(generated))
+	})
+	t.Run("DELETE removes the resource", func(t *testing.T) {
+		state := stepState{key: resource}
+		state.carryForward(nil, "DELETE", resource, nil)
+		assert.NotContains(t, state, key)
+	})
+	t.Run("denied request changes nothing", func(t *testing.T) {
+		state := stepState{}
+		response := newStepResponse(resource, kyvernov1.Enforce, *engineapi.RuleFail("test-rule", engineapi.Validation, "denied", nil))
+		state.carryForward(nil, "CREATE", resource, []engineapi.EngineResponse{response})
+		assert.Empty(t, state)
+	})
+	t.Run("audited failure is carried forward", func(t *testing.T) {
+		state := stepState{}
+		response := newStepResponse(resource, kyvernov1.Audit, *engineapi.RuleFail("test-rule", engineapi.Validation, "audited", nil))
+		state.carryForward(nil, "CREATE", resource, []engineapi.EngineResponse{response})
+		assert.Contains(t, state, key)
+	})
+}
+
+func TestStepResponse(t *testing.T) {
+	trigger := map[string][]engineapi.EngineResponse{"v1,Pod,default,test-pod": nil}
+	responses := &TestResponse{
+		Steps:           []map[string][]engineapi.EngineResponse{trigger},
+		SkippedPolicies: map[string]string{"invalid": "reason"},
+	}
+	step := responses.stepResponse(0, "UPDATE")
+	assert.Equal(t, trigger, step.Trigger)
+	assert.Equal(t, trigger, step.TriggerByOperation["UPDATE"])
+	assert.Equal(t, responses.SkippedPolicies, step.SkippedPolicies)
+}
@@ -68,6 +68,9 @@ type TestResponse struct {
 	TriggerByOperation map[string]map[string][]engineapi.EngineResponse
 	Target             map[string][]engineapi.EngineResponse
 	SkippedPolicies    map[string]string
+	// Steps holds the responses of the ordered test steps, in declaration order,
+	// keyed by resource key.
+	Steps []map[string][]engineapi.EngineResponse
 }
 
 func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestResponse, error) {
@@ -317,6 +320,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 		testCase.Test.GlobalContextEntries = resolved
 	}
 	resolveGlobalContextMock := store.ResolveGlobalContextMockData
+	buildHTTPMockIndex := store.BuildHTTPMockIndex
 	var store store.Store
 	store.SetLocal(true)
 	store.SetRegistryAccess(registryAccess)
@@ -359,10 +363,14 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	}
 
 	// TODO document the code below
+	allResults := testCase.Test.Results
+	for _, step := range testCase.Test.Steps {
+		allResults = append(allResults, step.Results...)
+	}
 	ruleToCloneSourceResource := map[string]string{}
 	for _, policy := range results.Policies {
 		for _, rule := range autogen.Default.ComputeRules(policy, "") {
-			for _, res := range testCase.Test.Results {
+			for _, res := range allResults {
 				if isRulelessPolicyKind(policy.GetKind()) {
 					continue
 				}
@@ -453,7 +461,10 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 		}
 		explicitOperations.Insert(res.Operation)
 	}
-	evalResource := func(resource *unstructured.Unstructured, operation string, defaultRun bool) ([]engineapi.EngineResponse, error) {
+	if err := v1alpha1.ValidateTestSteps(testCase.Test.Steps); err != nil {
+		return nil, err
+	}
+	evalResource := func(resource *unstructured.Unstructured, oldResource *unstructured.Unstructured, operation string, defaultRun bool) ([]engineapi.EngineResponse, error) {
 		// the policy processor is for multiple policies at once
 		pp := processor.PolicyProcessor{
 			Store:                             &store,
@@ -467,6 +478,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 			MutatingAdmissionPolicyBindings:   results.MAPBindings,
 			TargetResources:                   targetResources,
 			Resource:                          *resource,
+			OldResource:                       oldResource,
 			Operation:                         operation,
 			PolicyExceptions:                  polexLoader.Exceptions,
 			CELExceptions:                     polexLoader.CELExceptions,
@@ -511,6 +523,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 				restMapper,
 				gceMap,
 				operation,
+				oldResource,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("failed to apply policies on resource %v (%w)", resource.GetName(), err)
@@ -544,7 +557,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	for _, resource := range uniques {
 		resourceKey := generateResourceKey(resource)
 		// default run, honoring the values file operation when set
-		ers, err := evalResource(resource, "", true)
+		ers, err := evalResource(resource, nil, "", true)
 		if err != nil {
 			return nil, err
 		}
@@ -552,7 +565,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 		testResponse.Trigger[resourceKey] = ers
 		// one additional run per distinct operation explicitly declared on results
 		for _, operation := range sets.List(explicitOperations) {
-			ers, err := evalResource(resource, operation, false)
+			ers, err := evalResource(resource, nil, operation, false)
 			if err != nil {
 				return nil, err
 			}
@@ -563,6 +576,35 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 			testResponse.TriggerByOperation[operation][resourceKey] = ers
 		}
 	}
+	// ordered steps, each evaluated against the state carried forward from the previous ones
+	state := stepState{}
+	for i, step := range testCase.Test.Steps {
+		var object, oldObject *unstructured.Unstructured
+		if step.Object != "" {
+			object, err = loadStepResource(out, testCase.Fs, testDir, isGit, step.Object, genericPolicies)
+			if err != nil {
+				return nil, fmt.Errorf("error: %s: %w", step.DisplayName(i), err)
+			}
+		}
+		if step.OldObject != "" {
+			oldObject, err = loadStepResource(out, testCase.Fs, testDir, isGit, step.OldObject, genericPolicies)
+			if err != nil {
+				return nil, fmt.Errorf("error: %s: %w", step.DisplayName(i), err)
+			}
+		}
+		resource, oldResource, err := state.resolveStepRequest(step, object, oldObject)
+		if err != nil {
+			return nil, fmt.Errorf("error: %s: %w", step.DisplayName(i), err)
+		}
+		ers, err := evalResource(resource, oldResource, step.Operation, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", step.DisplayName(i), err)
+		}
+		testResponse.Steps = append(testResponse.Steps, map[string][]engineapi.EngineResponse{
+			generateResourceKey(resource): ers,
+		})
+		state.carryForward(dClient, step.Operation, resource, ers)
+	}
 
 	for _, jp := range jsonPayloads {
 		processor := processor.PolicyProcessor{
@@ -618,6 +660,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 				restMapper,
 				gceMap,
 				"",
+				nil,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("failed to apply validating policies on JSON payload %s (%w)", jp.name, err)
@@ -701,6 +744,7 @@ func applyImageValidatingPolicies(
 	restMapper meta.RESTMapper,
 	gceMap map[string]interface{},
 	operation string,
+	oldResource *unstructured.Unstructured,
 ) ([]engineapi.EngineResponse, error) {
 	provider, err := ivpolengine.NewProvider(ivps, celExceptions)
 	if err != nil {
@@ -758,7 +802,7 @@ func applyImageValidatingPolicies(
 		if userInfo != nil {
 			user = userInfo.AdmissionUserInfo
 		}
-		op, object, oldObject := processor.AdmissionRequestShape(operation, resource)
+		op, object, oldObject := processor.AdmissionRequestShapeWithOld(operation, resource, oldResource)
 		request := celengine.Request(
 			contextProvider,
 			resource.GroupVersionKind(),
@@ -970,35 +1014,3 @@ func ProcessResources(resources []*unstructured.Unstructured) []*unstructured.Un
 	}
 	return resources
 }
-
-func buildHTTPMockIndex(mocks []v1alpha1.APICallResponseEntry) (map[string]interface{}, error) {
-	if len(mocks) == 0 {
-		return nil, nil
-	}
-	index := make(map[string]interface{}, len(mocks))
-	for _, m := range mocks {
-		body, err := v1alpha1.RawExtensionToObject(m.Response.Body)
-		if err != nil {
-			return nil, fmt.Errorf("apiCallResponses %q: invalid body: %w", m.ResolvedURL(), err)
-		}
-		wrapped := wrapHTTPResponse(body, m.Response.StatusCode)
-		url := m.ResolvedURL()
-		method := strings.ToUpper(strings.TrimSpace(m.Method))
-		key := url
-		if method != "" {
-			key = method + ":" + url
-		}
-		index[key] = wrapped
-	}
-	return index, nil
-}
-
-func wrapHTTPResponse(body interface{}, statusCode int) interface{} {
-	if statusCode == 0 {
-		statusCode = 200
-	}
-	return map[string]interface{}{
-		"body":       body,
-		"statusCode": statusCode,
-	}
-}
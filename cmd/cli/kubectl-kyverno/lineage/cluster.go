@@ -0,0 +1,155 @@
+package lineage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/config"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+type ClusterClient struct {
+	discovery discovery.DiscoveryInterface
+	client    dynamic.Interface
+	mapper    meta.RESTMapper
+}
+
+// NewClusterClient returns a client looking up generated resources, triggers and clone sources in the cluster.
+func NewClusterClient(kubeConfig, kubeContext string) (*ClusterClient, error) {
+	clientConfig, err := config.CreateClientConfigWithContext(kubeConfig, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterClient{
+		discovery: discoveryClient,
+		client:    dynamicClient,
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+func (c *ClusterClient) resourceInterface(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.client.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return c.client.Resource(mapping.Resource), nil
+}
+
+// Get returns the resource of the given type, the type can be a resource or a kind, singular or plural.
+func (c *ClusterClient) Get(ctx context.Context, resourceType, namespace, name string) (*unstructured.Unstructured, error) {
+	gvr, err := c.mapper.ResourceFor(schema.ParseGroupResource(strings.ToLower(resourceType)).WithVersion(""))
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := c.mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.resourceInterface(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return client.Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c *ClusterClient) GetResource(ctx context.Context, resource Resource) (*unstructured.Unstructured, error) {
+	client, err := c.resourceInterface(resource.GroupVersionKind(), resource.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Get(ctx, resource.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return obj, err
+}
+
+func (c *ClusterClient) GetTrigger(ctx context.Context, downstream Downstream) (*unstructured.Unstructured, error) {
+	trigger := downstream.Trigger
+	client, err := c.resourceInterface(trigger.GroupVersionKind(), trigger.Namespace)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// policies using the kyverno.io/v1 api don't record the trigger name
+	if trigger.Name != "" {
+		obj, err := client.Get(ctx, trigger.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if trigger.UID != "" && obj.GetUID() != trigger.UID {
+			return nil, nil
+		}
+		return obj, nil
+	}
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if list.Items[i].GetUID() == trigger.UID {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ListDownstreams returns the resources generated by policies in all listable resource types,
+// optionally restricted to the ones generated for the given trigger.
+func (c *ClusterClient) ListDownstreams(ctx context.Context, trigger *unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	selector := common.GeneratePolicyLabel
+	if trigger != nil {
+		selector = fmt.Sprintf("%s=%s", common.GenerateTriggerUIDLabel, trigger.GetUID())
+	}
+	resources, err := c.discovery.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+	var downstreams []unstructured.Unstructured
+	for _, list := range resources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			items, err := c.client.Resource(gv.WithResource(resource.Name)).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				if errors.IsForbidden(err) || errors.IsNotFound(err) || errors.IsMethodNotSupported(err) {
+					continue
+				}
+				return nil, err
+			}
+			downstreams = append(downstreams, items.Items...)
+		}
+	}
+	return downstreams, nil
+}
@@ -0,0 +1,186 @@
+package lineage
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/background/common"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type Status string
+
+const (
+	// StatusSynced means the trigger exists and the downstream matches its clone source
+	StatusSynced Status = "Synced"
+	// StatusOrphaned means the trigger of the downstream does not exist anymore
+	StatusOrphaned Status = "Orphaned"
+	// StatusDrifted means the downstream content differs from its clone source
+	StatusDrifted Status = "Drifted"
+)
+
+type Resource struct {
+	APIVersion string    `json:"apiVersion,omitempty"`
+	Kind       string    `json:"kind,omitempty"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	UID        types.UID `json:"uid,omitempty"`
+}
+
+func (r Resource) String() string {
+	name := r.Name
+	if name == "" {
+		name = string(r.UID)
+	}
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Kind, name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, name)
+}
+
+func (r Resource) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(r.APIVersion, r.Kind)
+}
+
+// Downstream describes a resource generated by a policy and the trigger it was generated for.
+type Downstream struct {
+	Resource Resource  `json:"resource"`
+	Policy   string    `json:"policy"`
+	Rule     string    `json:"rule,omitempty"`
+	Trigger  Resource  `json:"trigger"`
+	Source   *Resource `json:"source,omitempty"`
+	Status   Status    `json:"status,omitempty"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// Cluster gives access to the triggers and clone sources of downstream resources.
+type Cluster interface {
+	// GetTrigger returns the trigger of the downstream, or nil if it does not exist
+	GetTrigger(ctx context.Context, downstream Downstream) (*unstructured.Unstructured, error)
+	// GetResource returns the given resource, or nil if it does not exist
+	GetResource(ctx context.Context, resource Resource) (*unstructured.Unstructured, error)
+}
+
+// NewDownstream builds the lineage of a resource from its generate labels,
+// it returns false if the resource was not generated by a policy.
+func NewDownstream(obj unstructured.Unstructured) (Downstream, bool) {
+	labels := obj.GetLabels()
+	policy, ok := labels[common.GeneratePolicyLabel]
+	if !ok {
+		return Downstream{}, false
+	}
+	if ns := labels[common.GeneratePolicyNamespaceLabel]; ns != "" {
+		policy = ns + "/" + policy
+	}
+	downstream := Downstream{
+		Resource: Resource{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+		},
+		Policy: policy,
+		Rule:   labels[common.GenerateRuleLabel],
+		Trigger: Resource{
+			APIVersion: schema.GroupVersion{Group: labels[common.GenerateTriggerGroupLabel], Version: labels[common.GenerateTriggerVersionLabel]}.String(),
+			Kind:       labels[common.GenerateTriggerKindLabel],
+			Namespace:  labels[common.GenerateTriggerNSLabel],
+			Name:       labels[common.GenerateTriggerNameLabel],
+			UID:        types.UID(labels[common.GenerateTriggerUIDLabel]),
+		},
+	}
+	if name := labels[common.GenerateSourceNameLabel]; name != "" {
+		downstream.Source = &Resource{
+			APIVersion: schema.GroupVersion{Group: labels[common.GenerateSourceGroupLabel], Version: labels[common.GenerateSourceVersionLabel]}.String(),
+			Kind:       labels[common.GenerateSourceKindLabel],
+			Namespace:  labels[common.GenerateSourceNSLabel],
+			Name:       name,
+			UID:        types.UID(labels[common.GenerateSourceUIDLabel]),
+		}
+	}
+	return downstream, true
+}
+
+// Evaluate sets the status of the downstream by looking up its trigger and clone source.
+func Evaluate(ctx context.Context, cluster Cluster, downstream *Downstream, obj unstructured.Unstructured) error {
+	downstream.Status = StatusSynced
+	downstream.Message = ""
+	if downstream.Trigger.Kind != "" {
+		trigger, err := cluster.GetTrigger(ctx, *downstream)
+		if err != nil {
+			return err
+		}
+		if trigger == nil {
+			downstream.Status = StatusOrphaned
+			downstream.Message = fmt.Sprintf("trigger %s not found", downstream.Trigger)
+			return nil
+		}
+		downstream.Trigger.Name = trigger.GetName()
+	}
+	if downstream.Source != nil {
+		source, err := cluster.GetResource(ctx, *downstream.Source)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			downstream.Status = StatusOrphaned
+			downstream.Message = fmt.Sprintf("clone source %s not found", downstream.Source)
+			return nil
+		}
+		if fields := Drift(*source, obj); len(fields) != 0 {
+			downstream.Status = StatusDrifted
+			downstream.Message = fmt.Sprintf("fields differ from clone source %s: %s", downstream.Source, strings.Join(fields, ", "))
+		}
+	}
+	return nil
+}
+
+// Drift returns the top level fields whose content differs between the clone source and the downstream,
+// metadata and status are ignored.
+func Drift(source, downstream unstructured.Unstructured) []string {
+	var fields []string
+	keys := map[string]struct{}{}
+	for key := range source.Object {
+		keys[key] = struct{}{}
+	}
+	for key := range downstream.Object {
+		keys[key] = struct{}{}
+	}
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		switch key {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		if !datautils.DeepEqual(source.Object[key], downstream.Object[key]) {
+			fields = append(fields, key)
+		}
+	}
+	return fields
+}
+
+// EvaluateAll builds and evaluates the lineage of the given resources, resources not generated by a policy are ignored.
+// When unhealthyOnly is true, synced resources are omitted.
+func EvaluateAll(ctx context.Context, cluster Cluster, objs []unstructured.Unstructured, unhealthyOnly bool) ([]Downstream, error) {
+	var downstreams []Downstream
+	for _, obj := range objs {
+		downstream, ok := NewDownstream(obj)
+		if !ok {
+			continue
+		}
+		if err := Evaluate(ctx, cluster, &downstream, obj); err != nil {
+			return nil, err
+		}
+		if unhealthyOnly && downstream.Status == StatusSynced {
+			continue
+		}
+		downstreams = append(downstreams, downstream)
+	}
+	return downstreams, nil
+}
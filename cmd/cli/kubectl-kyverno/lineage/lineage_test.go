@@ -0,0 +1,156 @@
+package lineage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeCluster struct {
+	triggers  map[types.UID]*unstructured.Unstructured
+	resources map[string]*unstructured.Unstructured
+}
+
+func (c fakeCluster) GetTrigger(_ context.Context, downstream Downstream) (*unstructured.Unstructured, error) {
+	return c.triggers[downstream.Trigger.UID], nil
+}
+
+func (c fakeCluster) GetResource(_ context.Context, resource Resource) (*unstructured.Unstructured, error) {
+	return c.resources[resource.String()], nil
+}
+
+func configMap(namespace, name string, labels map[string]any, data map[string]any) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"namespace": namespace,
+			"name":      name,
+			"uid":       namespace + "-" + name,
+			"labels":    labels,
+		},
+		"data": data,
+	}}
+}
+
+func generateLabels(clone bool) map[string]any {
+	labels := map[string]any{
+		"app.kubernetes.io/managed-by":        "kyverno",
+		"generate.kyverno.io/policy-name":     "sync-config",
+		"generate.kyverno.io/rule-name":       "clone",
+		"generate.kyverno.io/trigger-kind":    "Namespace",
+		"generate.kyverno.io/trigger-group":   "",
+		"generate.kyverno.io/trigger-version": "v1",
+		"generate.kyverno.io/trigger-uid":     "ns-uid",
+	}
+	if clone {
+		labels["generate.kyverno.io/source-name"] = "source"
+		labels["generate.kyverno.io/source-namespace"] = "default"
+		labels["generate.kyverno.io/source-kind"] = "ConfigMap"
+		labels["generate.kyverno.io/source-version"] = "v1"
+		labels["generate.kyverno.io/source-group"] = ""
+	}
+	return labels
+}
+
+func TestNewDownstream(t *testing.T) {
+	_, ok := NewDownstream(configMap("default", "test", nil, nil))
+	assert.False(t, ok)
+	downstream, ok := NewDownstream(configMap("team-a", "config", generateLabels(true), nil))
+	assert.True(t, ok)
+	assert.Equal(t, Downstream{
+		Resource: Resource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "team-a", Name: "config", UID: "team-a-config"},
+		Policy:   "sync-config",
+		Rule:     "clone",
+		Trigger:  Resource{APIVersion: "v1", Kind: "Namespace", UID: "ns-uid"},
+		Source:   &Resource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "source"},
+	}, downstream)
+	assert.Equal(t, "ConfigMap/team-a/config", downstream.Resource.String())
+	assert.Equal(t, "Namespace/ns-uid", downstream.Trigger.String())
+}
+
+func TestEvaluate(t *testing.T) {
+	namespace := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata":   map[string]any{"name": "team-a", "uid": "ns-uid"},
+	}}
+	source := configMap("default", "source", nil, map[string]any{"key": "value"})
+	cluster := fakeCluster{
+		triggers:  map[types.UID]*unstructured.Unstructured{"ns-uid": namespace},
+		resources: map[string]*unstructured.Unstructured{"ConfigMap/default/source": &source},
+	}
+	tests := []struct {
+		name    string
+		cluster fakeCluster
+		obj     unstructured.Unstructured
+		status  Status
+		message string
+	}{{
+		name:    "synced",
+		cluster: cluster,
+		obj:     configMap("team-a", "config", generateLabels(true), map[string]any{"key": "value"}),
+		status:  StatusSynced,
+	}, {
+		name:    "drifted",
+		cluster: cluster,
+		obj:     configMap("team-a", "config", generateLabels(true), map[string]any{"key": "changed"}),
+		status:  StatusDrifted,
+		message: "fields differ from clone source ConfigMap/default/source: data",
+	}, {
+		name:    "orphaned trigger",
+		cluster: fakeCluster{resources: cluster.resources},
+		obj:     configMap("team-a", "config", generateLabels(false), nil),
+		status:  StatusOrphaned,
+		message: "trigger Namespace/ns-uid not found",
+	}, {
+		name:    "orphaned source",
+		cluster: fakeCluster{triggers: cluster.triggers},
+		obj:     configMap("team-a", "config", generateLabels(true), nil),
+		status:  StatusOrphaned,
+		message: "clone source ConfigMap/default/source not found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			downstream, ok := NewDownstream(tt.obj)
+			assert.True(t, ok)
+			assert.NoError(t, Evaluate(context.TODO(), tt.cluster, &downstream, tt.obj))
+			assert.Equal(t, tt.status, downstream.Status)
+			assert.Equal(t, tt.message, downstream.Message)
+			if tt.status != StatusOrphaned || tt.name == "orphaned source" {
+				assert.Equal(t, "team-a", downstream.Trigger.Name)
+			}
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	source := configMap("default", "source", map[string]any{"a": "b"}, map[string]any{"key": "value"})
+	assert.Empty(t, Drift(source, configMap("team-a", "config", nil, map[string]any{"key": "value"})))
+	downstream := configMap("team-a", "config", nil, map[string]any{"key": "value"})
+	downstream.Object["binaryData"] = map[string]any{"key": "dmFsdWU="}
+	assert.Equal(t, []string{"binaryData"}, Drift(source, downstream))
+}
+
+func TestPrint(t *testing.T) {
+	downstreams := []Downstream{{
+		Resource: Resource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "team-a", Name: "config"},
+		Policy:   "sync-config",
+		Rule:     "clone",
+		Trigger:  Resource{APIVersion: "v1", Kind: "Namespace", Name: "team-a"},
+		Status:   StatusSynced,
+	}}
+	var out bytes.Buffer
+	assert.NoError(t, Print(&out, "", downstreams))
+	assert.Equal(t, `RESOURCE                  POLICY        RULE    TRIGGER            STATUS   MESSAGE
+ConfigMap/team-a/config   sync-config   clone   Namespace/team-a   Synced   
+`, out.String())
+	out.Reset()
+	assert.NoError(t, Print(&out, "yaml", downstreams))
+	assert.Contains(t, out.String(), "status: Synced")
+	assert.Error(t, Print(&out, "xml", downstreams))
+}
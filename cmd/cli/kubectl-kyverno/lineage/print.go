@@ -0,0 +1,38 @@
+package lineage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Print writes the downstreams as a table, or in the given output format (json, yaml).
+func Print(out io.Writer, output string, downstreams []Downstream) error {
+	switch output {
+	case "":
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "RESOURCE\tPOLICY\tRULE\tTRIGGER\tSTATUS\tMESSAGE")
+		for _, downstream := range downstreams {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", downstream.Resource, downstream.Policy, downstream.Rule, downstream.Trigger, downstream.Status, downstream.Message)
+		}
+		return w.Flush()
+	case "json":
+		data, err := json.MarshalIndent(downstreams, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+	case "yaml":
+		data, err := yaml.Marshal(downstreams)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+	default:
+		return fmt.Errorf("invalid output format: %s (supported formats: json, yaml)", output)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+var resourceListCall = regexp.MustCompile(`\bresource\.List\s*\(`)
+
+type expression struct {
+	path  string
+	value string
+}
+
+func wildcardResources(name string, constraints *admissionregistrationv1.MatchResources) []Finding {
+	if constraints == nil {
+		return nil
+	}
+	for _, rule := range constraints.ResourceRules {
+		if slices.Contains(rule.Resources, "*") || slices.Contains(rule.Resources, "*/*") {
+			return []Finding{{
+				Check:   CheckWildcardKind,
+				Policy:  name,
+				Message: "the policy matches all resources, restrict matchConstraints to the resources it applies to",
+			}}
+		}
+	}
+	return nil
+}
+
+func resourceList(name string, expressions []expression) []Finding {
+	var findings []Finding
+	for _, expression := range expressions {
+		if resourceListCall.MatchString(expression.value) {
+			findings = append(findings, Finding{
+				Check:   CheckCELResourceList,
+				Policy:  name,
+				Rule:    expression.path,
+				Message: "the expression lists resources on every admission request, consider a global context entry",
+			})
+		}
+	}
+	return findings
+}
+
+func celBackgroundAudit(name string, hasValidationActions, hasBackground bool) []Finding {
+	var findings []Finding
+	if !hasBackground {
+		findings = append(findings, Finding{
+			Check:   CheckBackgroundAudit,
+			Policy:  name,
+			Message: "evaluation.background.enabled is not set, existing resources are scanned by default",
+		})
+	}
+	if !hasValidationActions {
+		findings = append(findings, Finding{
+			Check:   CheckBackgroundAudit,
+			Policy:  name,
+			Message: "validationActions is not set, violations are denied by default",
+		})
+	}
+	return findings
+}
+
+// celPolicy is the part of a CEL policy compared to find duplicates.
+type celPolicy struct {
+	kind      string
+	namespace string
+	name      string
+	spec      any
+}
+
+func celPolicies(results *policy.LoaderResults) []celPolicy {
+	var policies []celPolicy
+	for _, pol := range results.ValidatingPolicies {
+		policies = append(policies, celPolicy{pol.GetKind(), pol.GetNamespace(), pol.GetName(), pol.GetValidatingPolicySpec()})
+	}
+	for _, pol := range results.ImageValidatingPolicies {
+		policies = append(policies, celPolicy{pol.GetKind(), pol.GetNamespace(), pol.GetName(), pol.GetSpec()})
+	}
+	for _, pol := range results.MutatingPolicies {
+		policies = append(policies, celPolicy{pol.GetKind(), pol.GetNamespace(), pol.GetName(), pol.GetSpec()})
+	}
+	for _, pol := range results.GeneratingPolicies {
+		policies = append(policies, celPolicy{pol.GetKind(), pol.GetNamespace(), pol.GetName(), pol.GetSpec()})
+	}
+	for _, pol := range results.DeletingPolicies {
+		policies = append(policies, celPolicy{pol.GetKind(), pol.GetNamespace(), pol.GetName(), pol.GetDeletingPolicySpec()})
+	}
+	return policies
+}
+
+// duplicatePolicies flags CEL policies identical, name aside, to a policy of the same kind seen before in the same scope.
+// CEL policies have no rules, the policy plays the role of a rule.
+func duplicatePolicies(policies []celPolicy) []Finding {
+	var findings []Finding
+	seen := map[string]string{}
+	for _, pol := range policies {
+		data, err := json.Marshal(pol.spec)
+		if err != nil {
+			continue
+		}
+		name := policyName(pol.kind, pol.namespace, pol.name)
+		key := pol.kind + "/" + pol.namespace + "/" + string(data)
+		if first, ok := seen[key]; ok {
+			findings = append(findings, Finding{
+				Check:   CheckDuplicateRule,
+				Policy:  name,
+				Message: fmt.Sprintf("the policy is identical to policy %s", first),
+			})
+		} else {
+			seen[key] = name
+		}
+	}
+	return findings
+}
+
+// duplicateEntries flags the entries of a CEL policy field identical to an entry seen before in the same field.
+func duplicateEntries[T any](name, field string, entries []T) []Finding {
+	var findings []Finding
+	seen := map[string]int{}
+	for i, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		if first, ok := seen[string(data)]; ok {
+			findings = append(findings, Finding{
+				Check:   CheckDuplicateRule,
+				Policy:  name,
+				Rule:    fmt.Sprintf("%s[%d]", field, i),
+				Message: fmt.Sprintf("the entry is identical to %s[%d]", field, first),
+			})
+		} else {
+			seen[string(data)] = i
+		}
+	}
+	return findings
+}
+
+func validatingExpressions(
+	variables []admissionregistrationv1.Variable,
+	matchConditions []admissionregistrationv1.MatchCondition,
+	validations []admissionregistrationv1.Validation,
+	auditAnnotations []admissionregistrationv1.AuditAnnotation,
+) []expression {
+	expressions := commonExpressions(variables, matchConditions)
+	for i, validation := range validations {
+		expressions = append(expressions,
+			expression{path: fmt.Sprintf("validations[%d].expression", i), value: validation.Expression},
+			expression{path: fmt.Sprintf("validations[%d].messageExpression", i), value: validation.MessageExpression},
+		)
+	}
+	for i, annotation := range auditAnnotations {
+		expressions = append(expressions, expression{path: fmt.Sprintf("auditAnnotations[%d].valueExpression", i), value: annotation.ValueExpression})
+	}
+	return expressions
+}
+
+func mutatingExpressions(spec *policiesv1beta1.MutatingPolicySpec) []expression {
+	expressions := commonExpressions(spec.Variables, spec.MatchConditions)
+	for i, mutation := range spec.Mutations {
+		if mutation.ApplyConfiguration != nil {
+			expressions = append(expressions, expression{path: fmt.Sprintf("mutations[%d].applyConfiguration.expression", i), value: mutation.ApplyConfiguration.Expression})
+		}
+		if mutation.JSONPatch != nil {
+			expressions = append(expressions, expression{path: fmt.Sprintf("mutations[%d].jsonPatch.expression", i), value: mutation.JSONPatch.Expression})
+		}
+	}
+	return expressions
+}
+
+func commonExpressions(variables []admissionregistrationv1.Variable, matchConditions []admissionregistrationv1.MatchCondition) []expression {
+	var expressions []expression
+	for _, variable := range variables {
+		expressions = append(expressions, expression{path: "variables." + variable.Name, value: variable.Expression})
+	}
+	for _, condition := range matchConditions {
+		expressions = append(expressions, expression{path: "matchConditions." + condition.Name, value: condition.Expression})
+	}
+	return expressions
+}
@@ -0,0 +1,100 @@
+package lint
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FromCluster lists the Kyverno and CEL policies installed in the cluster.
+func FromCluster(ctx context.Context, client versioned.Interface) (*policy.LoaderResults, error) {
+	var results policy.LoaderResults
+	cpols, err := client.KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range cpols.Items {
+		results.Policies = append(results.Policies, &cpols.Items[i])
+	}
+	pols, err := client.KyvernoV1().Policies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pols.Items {
+		results.Policies = append(results.Policies, &pols.Items[i])
+	}
+	v1beta1 := client.PoliciesV1beta1()
+	vpols, err := v1beta1.ValidatingPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range vpols.Items {
+		results.ValidatingPolicies = append(results.ValidatingPolicies, &vpols.Items[i])
+	}
+	nvpols, err := v1beta1.NamespacedValidatingPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range nvpols.Items {
+		results.ValidatingPolicies = append(results.ValidatingPolicies, &nvpols.Items[i])
+	}
+	ivpols, err := v1beta1.ImageValidatingPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range ivpols.Items {
+		results.ImageValidatingPolicies = append(results.ImageValidatingPolicies, &ivpols.Items[i])
+	}
+	nivpols, err := v1beta1.NamespacedImageValidatingPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range nivpols.Items {
+		results.ImageValidatingPolicies = append(results.ImageValidatingPolicies, &nivpols.Items[i])
+	}
+	mpols, err := v1beta1.MutatingPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range mpols.Items {
+		results.MutatingPolicies = append(results.MutatingPolicies, &mpols.Items[i])
+	}
+	nmpols, err := v1beta1.NamespacedMutatingPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range nmpols.Items {
+		results.MutatingPolicies = append(results.MutatingPolicies, &nmpols.Items[i])
+	}
+	gpols, err := v1beta1.GeneratingPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range gpols.Items {
+		results.GeneratingPolicies = append(results.GeneratingPolicies, &gpols.Items[i])
+	}
+	ngpols, err := v1beta1.NamespacedGeneratingPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range ngpols.Items {
+		results.GeneratingPolicies = append(results.GeneratingPolicies, &ngpols.Items[i])
+	}
+	dpols, err := v1beta1.DeletingPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range dpols.Items {
+		results.DeletingPolicies = append(results.DeletingPolicies, &dpols.Items[i])
+	}
+	ndpols, err := v1beta1.NamespacedDeletingPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range ndpols.Items {
+		results.DeletingPolicies = append(results.DeletingPolicies, &ndpols.Items[i])
+	}
+	return &results, nil
+}
@@ -0,0 +1,241 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func lintKyvernoPolicy(pol kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	name := policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName())
+	spec := pol.GetSpec()
+	if spec.Background == nil {
+		findings = append(findings, Finding{
+			Check:   CheckBackgroundAudit,
+			Policy:  name,
+			Message: "background is not set, existing resources are scanned by default",
+		})
+	}
+	for _, rule := range spec.Rules {
+		kinds := matchKinds(rule.MatchResources)
+		if kinds == nil {
+			findings = append(findings, Finding{
+				Check:   CheckWildcardKind,
+				Policy:  name,
+				Rule:    rule.Name,
+				Message: "the rule matches all kinds, restrict it to the kinds it applies to",
+			})
+		}
+		if rule.HasValidate() && rule.Validation.FailureAction == nil && spec.ValidationFailureAction == "" {
+			findings = append(findings, Finding{
+				Check:   CheckBackgroundAudit,
+				Policy:  name,
+				Rule:    rule.Name,
+				Message: "failureAction is not set, violations are audited by default",
+			})
+		}
+		for _, entry := range foreachAPICalls(rule) {
+			findings = append(findings, Finding{
+				Check:   CheckForeachAPICall,
+				Policy:  name,
+				Rule:    rule.Name,
+				Message: fmt.Sprintf("context entry %s makes an API call for each element without a jmesPath filter", entry),
+			})
+		}
+		if unreachable(rule.GetAnyAllConditions(), kinds, matchOperations(rule.MatchResources)) {
+			findings = append(findings, Finding{
+				Check:   CheckUnreachableRule,
+				Policy:  name,
+				Rule:    rule.Name,
+				Message: "the rule preconditions contradict its match block, the rule never applies",
+			})
+		}
+	}
+	return findings
+}
+
+// duplicateRules flags rules identical, name aside, to a rule seen before in the same scope.
+func duplicateRules(policies []kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	seen := map[string]string{}
+	for _, pol := range policies {
+		name := policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName())
+		for _, rule := range pol.GetSpec().Rules {
+			ruleName := rule.Name
+			rule.Name = ""
+			data, err := json.Marshal(rule)
+			if err != nil {
+				continue
+			}
+			key := pol.GetNamespace() + "/" + string(data)
+			if first, ok := seen[key]; ok {
+				findings = append(findings, Finding{
+					Check:   CheckDuplicateRule,
+					Policy:  name,
+					Rule:    ruleName,
+					Message: fmt.Sprintf("the rule is identical to rule %s", first),
+				})
+			} else {
+				seen[key] = name + "/" + ruleName
+			}
+		}
+	}
+	return findings
+}
+
+// matchKinds returns the kinds matched by a rule, nil means all kinds.
+func matchKinds(match kyvernov1.MatchResources) sets.Set[string] {
+	kinds := sets.New[string]()
+	for _, kind := range match.GetKinds() {
+		_, _, kind, _ = kubeutils.ParseKindSelector(kind)
+		if kind == "*" {
+			return nil
+		}
+		kinds.Insert(kind)
+	}
+	if kinds.Len() == 0 {
+		return nil
+	}
+	return kinds
+}
+
+// matchOperations returns the operations matched by a rule, nil means all operations.
+func matchOperations(match kyvernov1.MatchResources) sets.Set[string] {
+	descriptions := []kyvernov1.ResourceDescription{match.ResourceDescription}
+	for _, filter := range match.Any {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	for _, filter := range match.All {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	operations := sets.New[string]()
+	for _, description := range descriptions {
+		if description.IsEmpty() {
+			continue
+		}
+		if len(description.Operations) == 0 {
+			return nil
+		}
+		operations.Insert(description.GetOperations()...)
+	}
+	if operations.Len() == 0 {
+		return nil
+	}
+	return operations
+}
+
+// foreachAPICalls returns the names of the context entries making API calls
+// without a JMESPath filter inside validate or mutate foreach declarations.
+func foreachAPICalls(rule kyvernov1.Rule) []string {
+	var entries []string
+	check := func(context []kyvernov1.ContextEntry) {
+		for _, entry := range context {
+			if entry.APICall != nil && entry.APICall.JMESPath == "" {
+				entries = append(entries, entry.Name)
+			}
+		}
+	}
+	var validations func([]kyvernov1.ForEachValidation)
+	validations = func(foreach []kyvernov1.ForEachValidation) {
+		for _, item := range foreach {
+			check(item.Context)
+			validations(item.GetForEachValidation())
+		}
+	}
+	var mutations func([]kyvernov1.ForEachMutation)
+	mutations = func(foreach []kyvernov1.ForEachMutation) {
+		for _, item := range foreach {
+			check(item.Context)
+			mutations(item.GetForEachMutation())
+		}
+	}
+	if rule.Validation != nil {
+		validations(rule.Validation.ForEachValidation)
+	}
+	if rule.Mutation != nil {
+		mutations(rule.Mutation.ForEachMutation)
+	}
+	return entries
+}
+
+// unreachable returns true when the preconditions can't be met by the matched kinds and operations.
+func unreachable(conditions any, kinds, operations sets.Set[string]) bool {
+	switch conditions := conditions.(type) {
+	case []kyvernov1.Condition:
+		for _, condition := range conditions {
+			if contradicts(condition, kinds, operations) {
+				return true
+			}
+		}
+	case kyvernov1.AnyAllConditions:
+		for _, condition := range conditions.AllConditions {
+			if contradicts(condition, kinds, operations) {
+				return true
+			}
+		}
+		if len(conditions.AnyConditions) == 0 {
+			return false
+		}
+		for _, condition := range conditions.AnyConditions {
+			if !contradicts(condition, kinds, operations) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// contradicts returns true when a condition on the request kind or operation
+// can't be met by the matched kinds or operations.
+func contradicts(condition kyvernov1.Condition, kinds, operations sets.Set[string]) bool {
+	key, ok := condition.GetKey().(string)
+	if !ok {
+		return false
+	}
+	var matched sets.Set[string]
+	switch strings.Join(strings.Fields(strings.Trim(strings.TrimSpace(key), "{}")), "") {
+	case "request.object.kind", "request.kind.kind":
+		matched = kinds
+	case "request.operation":
+		matched = operations
+	}
+	if matched == nil {
+		return false
+	}
+	var values []string
+	switch value := condition.GetValue().(type) {
+	case string:
+		values = []string{value}
+	case []any:
+		for _, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return false
+			}
+			values = append(values, s)
+		}
+	default:
+		return false
+	}
+	for _, value := range values {
+		// variables and wildcards can't be compared statically
+		if strings.Contains(value, "{{") || strings.ContainsAny(value, "*?") {
+			return false
+		}
+	}
+	switch condition.Operator {
+	case "Equal", "Equals", "In", "AnyIn", "AllIn":
+		// the request value must be one of the values
+		return !matched.HasAny(values...)
+	case "NotEqual", "NotEquals", "NotIn", "AnyNotIn", "AllNotIn":
+		// the request value must not be one of the values
+		return sets.New(values...).IsSuperset(matched)
+	}
+	return false
+}
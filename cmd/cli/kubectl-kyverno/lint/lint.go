@@ -0,0 +1,101 @@
+package lint
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// CheckWildcardKind flags policies matching all kinds or resources.
+	CheckWildcardKind = "wildcard-kind"
+	// CheckForeachAPICall flags API calls made for each element of a foreach without a JMESPath filter.
+	CheckForeachAPICall = "foreach-api-call"
+	// CheckCELResourceList flags CEL expressions listing resources in admission.
+	CheckCELResourceList = "cel-resource-list"
+	// CheckBackgroundAudit flags policies relying on the default background and failure action settings.
+	CheckBackgroundAudit = "background-audit"
+	// CheckDuplicateRule flags rules identical to another rule, CEL policies identical to another policy
+	// and CEL validations or mutations identical to another one in the same policy.
+	CheckDuplicateRule = "duplicate-rule"
+	// CheckUnreachableRule flags rules whose preconditions contradict their match block.
+	CheckUnreachableRule = "unreachable-rule"
+)
+
+// Checks lists all the checks.
+var Checks = []string{
+	CheckWildcardKind,
+	CheckForeachAPICall,
+	CheckCELResourceList,
+	CheckBackgroundAudit,
+	CheckDuplicateRule,
+	CheckUnreachableRule,
+}
+
+// Finding is a best-practice or performance issue found in a policy.
+type Finding struct {
+	Check   string `header:"check"`
+	Policy  string `header:"policy"`
+	Rule    string `header:"rule"`
+	Message string `header:"message"`
+}
+
+// Lint runs all checks except the ignored ones on the loaded policies.
+func Lint(results *policy.LoaderResults, ignored ...string) []Finding {
+	var findings []Finding
+	for _, pol := range results.Policies {
+		findings = append(findings, lintKyvernoPolicy(pol)...)
+	}
+	findings = append(findings, duplicateRules(results.Policies)...)
+	for _, pol := range results.ValidatingPolicies {
+		spec := pol.GetValidatingPolicySpec()
+		name := policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName())
+		findings = append(findings, wildcardResources(name, spec.MatchConstraints)...)
+		if spec.AdmissionEnabled() {
+			findings = append(findings, resourceList(name, validatingExpressions(spec.Variables, spec.MatchConditions, spec.Validations, spec.AuditAnnotations))...)
+		}
+		findings = append(findings, celBackgroundAudit(name, len(spec.ValidationAction) != 0, spec.EvaluationConfiguration != nil && spec.EvaluationConfiguration.Background != nil && spec.EvaluationConfiguration.Background.Enabled != nil)...)
+		findings = append(findings, duplicateEntries(name, "validations", spec.Validations)...)
+	}
+	for _, pol := range results.ImageValidatingPolicies {
+		spec := pol.GetSpec()
+		name := policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName())
+		findings = append(findings, wildcardResources(name, spec.MatchConstraints)...)
+		if spec.AdmissionEnabled() {
+			findings = append(findings, resourceList(name, validatingExpressions(spec.Variables, spec.MatchConditions, spec.Validations, spec.AuditAnnotations))...)
+		}
+		findings = append(findings, celBackgroundAudit(name, len(spec.ValidationAction) != 0, spec.EvaluationConfiguration != nil && spec.EvaluationConfiguration.Background != nil && spec.EvaluationConfiguration.Background.Enabled != nil)...)
+		findings = append(findings, duplicateEntries(name, "validations", spec.Validations)...)
+	}
+	for _, pol := range results.MutatingPolicies {
+		spec := pol.GetSpec()
+		name := policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName())
+		findings = append(findings, wildcardResources(name, spec.MatchConstraints)...)
+		if spec.AdmissionEnabled() {
+			findings = append(findings, resourceList(name, mutatingExpressions(spec))...)
+		}
+		findings = append(findings, duplicateEntries(name, "mutations", spec.Mutations)...)
+	}
+	for _, pol := range results.GeneratingPolicies {
+		spec := pol.GetSpec()
+		findings = append(findings, wildcardResources(policyName(pol.GetKind(), pol.GetNamespace(), pol.GetName()), spec.MatchConstraints)...)
+	}
+	findings = append(findings, duplicatePolicies(celPolicies(results))...)
+	if len(ignored) == 0 {
+		return findings
+	}
+	skip := sets.New(ignored...)
+	var out []Finding
+	for _, finding := range findings {
+		if !skip.Has(finding.Check) {
+			out = append(out, finding)
+		}
+	}
+	return out
+}
+
+func policyName(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,235 @@
+package lint
+
+import (
+	"testing"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+)
+
+func clusterPolicy(name string, rules ...kyvernov1.Rule) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       kyvernov1.Spec{Background: ptr.To(true), Rules: rules},
+	}
+}
+
+func match(kinds ...string) kyvernov1.MatchResources {
+	return kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{{
+		ResourceDescription: kyvernov1.ResourceDescription{Kinds: kinds},
+	}}}
+}
+
+func checks(findings []Finding) []string {
+	var out []string
+	for _, finding := range findings {
+		out = append(out, finding.Check)
+	}
+	return out
+}
+
+func TestLint(t *testing.T) {
+	audit := kyvernov1.Audit
+	results := &policy.LoaderResults{
+		Policies: []kyvernov1.PolicyInterface{
+			clusterPolicy("good", kyvernov1.Rule{
+				Name:           "check",
+				MatchResources: match("Pod"),
+				Validation:     &kyvernov1.Validation{FailureAction: &audit, Message: "ok"},
+			}),
+			clusterPolicy("wildcard", kyvernov1.Rule{
+				Name:           "all",
+				MatchResources: match("*"),
+				Mutation:       &kyvernov1.Mutation{Targets: []kyvernov1.TargetResourceSpec{{}}},
+			}),
+			&kyvernov1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+				Spec: kyvernov1.Spec{Rules: []kyvernov1.Rule{{
+					Name:           "check",
+					MatchResources: match("Deployment"),
+					Validation:     &kyvernov1.Validation{Message: "defaults"},
+				}}},
+			},
+			clusterPolicy("duplicate", kyvernov1.Rule{
+				Name:           "check-again",
+				MatchResources: match("Pod"),
+				Validation:     &kyvernov1.Validation{FailureAction: &audit, Message: "ok"},
+			}),
+		},
+		ValidatingPolicies: []policiesv1beta1.ValidatingPolicyLike{
+			&policiesv1beta1.ValidatingPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "list"},
+				Spec: policiesv1beta1.ValidatingPolicySpec{
+					MatchConstraints: &admissionregistrationv1.MatchResources{ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{Rule: admissionregistrationv1.Rule{
+							APIGroups: []string{"*"},
+							Resources: []string{"*"},
+						}},
+					}}},
+					Variables:        []admissionregistrationv1.Variable{{Name: "cms", Expression: `resource.List("v1", "configmaps", "")`}},
+					Validations:      []admissionregistrationv1.Validation{{Expression: "size(variables.cms.items) < 10"}},
+					ValidationAction: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit},
+				},
+			},
+		},
+	}
+	findings := Lint(results)
+	assert.Equal(t, []Finding{
+		{Check: CheckWildcardKind, Policy: "ClusterPolicy/wildcard", Rule: "all", Message: "the rule matches all kinds, restrict it to the kinds it applies to"},
+		{Check: CheckBackgroundAudit, Policy: "ClusterPolicy/defaults", Message: "background is not set, existing resources are scanned by default"},
+		{Check: CheckBackgroundAudit, Policy: "ClusterPolicy/defaults", Rule: "check", Message: "failureAction is not set, violations are audited by default"},
+		{Check: CheckDuplicateRule, Policy: "ClusterPolicy/duplicate", Rule: "check-again", Message: "the rule is identical to rule ClusterPolicy/good/check"},
+		{Check: CheckWildcardKind, Policy: "ValidatingPolicy/list", Message: "the policy matches all resources, restrict matchConstraints to the resources it applies to"},
+		{Check: CheckCELResourceList, Policy: "ValidatingPolicy/list", Rule: "variables.cms", Message: "the expression lists resources on every admission request, consider a global context entry"},
+		{Check: CheckBackgroundAudit, Policy: "ValidatingPolicy/list", Message: "evaluation.background.enabled is not set, existing resources are scanned by default"},
+	}, findings)
+
+	findings = Lint(results, CheckBackgroundAudit, CheckWildcardKind)
+	assert.Equal(t, []string{CheckDuplicateRule, CheckCELResourceList}, checks(findings))
+}
+
+func Test_foreachAPICalls(t *testing.T) {
+	rule := kyvernov1.Rule{
+		Validation: &kyvernov1.Validation{ForEachValidation: []kyvernov1.ForEachValidation{{
+			List: "request.object.spec.containers",
+			Context: []kyvernov1.ContextEntry{
+				{Name: "filtered", APICall: &kyvernov1.ContextAPICall{JMESPath: "items[].metadata.name"}},
+				{Name: "unfiltered", APICall: &kyvernov1.ContextAPICall{}},
+				{Name: "variable", Variable: &kyvernov1.Variable{JMESPath: "foo"}},
+			},
+		}}},
+	}
+	assert.Equal(t, []string{"unfiltered"}, foreachAPICalls(rule))
+	assert.Empty(t, foreachAPICalls(kyvernov1.Rule{Context: []kyvernov1.ContextEntry{{Name: "top-level", APICall: &kyvernov1.ContextAPICall{}}}}))
+}
+
+func Test_unreachable(t *testing.T) {
+	condition := func(key string, operator kyvernov1.ConditionOperator, value any) kyvernov1.Condition {
+		var c kyvernov1.Condition
+		c.SetKey(key)
+		c.Operator = operator
+		c.SetValue(value)
+		return c
+	}
+	pods := sets.New("Pod")
+	create := sets.New("CREATE")
+	tests := []struct {
+		name       string
+		conditions any
+		kinds      sets.Set[string]
+		operations sets.Set[string]
+		want       bool
+	}{{
+		name:       "kind not matched",
+		conditions: kyvernov1.AnyAllConditions{AllConditions: []kyvernov1.Condition{condition("{{ request.object.kind }}", "Equals", "Deployment")}},
+		kinds:      pods,
+		want:       true,
+	}, {
+		name:       "kind matched",
+		conditions: kyvernov1.AnyAllConditions{AllConditions: []kyvernov1.Condition{condition("{{request.object.kind}}", "Equals", "Pod")}},
+		kinds:      pods,
+	}, {
+		name:       "all kinds matched",
+		conditions: kyvernov1.AnyAllConditions{AllConditions: []kyvernov1.Condition{condition("{{ request.object.kind }}", "Equals", "Deployment")}},
+	}, {
+		name:       "operation not matched",
+		conditions: []kyvernov1.Condition{condition("{{ request.operation }}", "AnyIn", []any{"DELETE", "UPDATE"})},
+		kinds:      pods,
+		operations: create,
+		want:       true,
+	}, {
+		name:       "operation excluded",
+		conditions: []kyvernov1.Condition{condition("{{ request.operation }}", "NotEquals", "CREATE")},
+		operations: create,
+		want:       true,
+	}, {
+		name: "one any condition possible",
+		conditions: kyvernov1.AnyAllConditions{AnyConditions: []kyvernov1.Condition{
+			condition("{{ request.operation }}", "Equals", "DELETE"),
+			condition("{{ request.object.kind }}", "Equals", "Pod"),
+		}},
+		kinds:      pods,
+		operations: create,
+	}, {
+		name: "no any condition possible",
+		conditions: kyvernov1.AnyAllConditions{AnyConditions: []kyvernov1.Condition{
+			condition("{{ request.operation }}", "Equals", "DELETE"),
+			condition("{{ request.object.kind }}", "Equals", "Deployment"),
+		}},
+		kinds:      pods,
+		operations: create,
+		want:       true,
+	}, {
+		name:       "wildcard value",
+		conditions: []kyvernov1.Condition{condition("{{ request.object.kind }}", "Equals", "Dep*")},
+		kinds:      pods,
+	}, {
+		name:       "other key",
+		conditions: []kyvernov1.Condition{condition("{{ request.object.metadata.name }}", "Equals", "foo")},
+		kinds:      pods,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unreachable(tt.conditions, tt.kinds, tt.operations))
+		})
+	}
+}
+
+func Test_matchKindsAndOperations(t *testing.T) {
+	assert.Nil(t, matchKinds(match("*")))
+	assert.Nil(t, matchKinds(kyvernov1.MatchResources{}))
+	assert.Equal(t, sets.New("Pod", "Deployment"), matchKinds(match("v1/Pod", "apps/v1/Deployment")))
+
+	assert.Nil(t, matchOperations(match("Pod")))
+	withOperations := kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{{
+		ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyvernov1.AdmissionOperation{"CREATE"}},
+	}}}
+	assert.Equal(t, sets.New("CREATE"), matchOperations(withOperations))
+}
+
+func Test_duplicatePolicies(t *testing.T) {
+	spec := policiesv1beta1.ValidatingPolicySpec{
+		Validations: []admissionregistrationv1.Validation{{Expression: "has(object.metadata.labels)"}},
+	}
+	other := policiesv1beta1.ValidatingPolicySpec{
+		Validations: []admissionregistrationv1.Validation{{Expression: "has(object.metadata.annotations)"}},
+	}
+	vpol := func(name, namespace string, spec policiesv1beta1.ValidatingPolicySpec) policiesv1beta1.ValidatingPolicyLike {
+		if namespace == "" {
+			return &policiesv1beta1.ValidatingPolicy{TypeMeta: metav1.TypeMeta{Kind: "ValidatingPolicy"}, ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+		}
+		return &policiesv1beta1.NamespacedValidatingPolicy{TypeMeta: metav1.TypeMeta{Kind: "NamespacedValidatingPolicy"}, ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+	}
+	results := &policy.LoaderResults{
+		ValidatingPolicies: []policiesv1beta1.ValidatingPolicyLike{
+			vpol("labels", "", spec),
+			vpol("annotations", "", other),
+			vpol("labels-again", "", spec),
+			vpol("labels", "team-a", spec),
+			vpol("labels", "team-b", spec),
+			vpol("labels-again", "team-a", spec),
+		},
+	}
+	assert.Equal(t, []Finding{
+		{Check: CheckDuplicateRule, Policy: "ValidatingPolicy/labels-again", Message: "the policy is identical to policy ValidatingPolicy/labels"},
+		{Check: CheckDuplicateRule, Policy: "NamespacedValidatingPolicy/team-a/labels-again", Message: "the policy is identical to policy NamespacedValidatingPolicy/team-a/labels"},
+	}, duplicatePolicies(celPolicies(results)))
+}
+
+func Test_duplicateEntries(t *testing.T) {
+	validations := []admissionregistrationv1.Validation{
+		{Expression: "has(object.metadata.labels)"},
+		{Expression: "has(object.metadata.labels)", Message: "labels are required"},
+		{Expression: "has(object.metadata.labels)"},
+	}
+	assert.Equal(t, []Finding{
+		{Check: CheckDuplicateRule, Policy: "ValidatingPolicy/labels", Rule: "validations[2]", Message: "the entry is identical to validations[0]"},
+	}, duplicateEntries("ValidatingPolicy/labels", "validations", validations))
+	assert.Empty(t, duplicateEntries("ValidatingPolicy/labels", "validations", validations[:2]))
+}
@@ -0,0 +1,157 @@
+package processor
+
+import (
+	"fmt"
+
+	policieskyvernoio "github.com/kyverno/api/api/policies.kyverno.io"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/cel/libs"
+	gpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/gpol/compiler"
+	gpolengine "github.com/kyverno/kyverno/pkg/cel/policies/gpol/engine"
+	mpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
+	mpolengine "github.com/kyverno/kyverno/pkg/cel/policies/mpol/engine"
+	vpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
+	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	utils "github.com/kyverno/kyverno/pkg/utils/restmapper"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// CompiledPolicies holds the providers compiled from the CEL policies of a PolicyProcessor.
+// It must only be shared between processors configured with the same policies and exceptions.
+type CompiledPolicies struct {
+	mpolContext      libs.Context
+	mpolProvider     mpolengine.Provider
+	vpolProvider     vpolengine.Provider
+	vpolJSONProvider vpolengine.Provider
+	gpolPolicies     []gpolengine.Policy
+}
+
+// Compile compiles the CEL policies of the processor into Compiled, so that subsequent calls to
+// ApplyPoliciesOnResource evaluate them without compiling them again.
+func (p *PolicyProcessor) Compile() error {
+	if p.Compiled == nil {
+		p.Compiled = &CompiledPolicies{}
+	}
+	restMapper := p.RESTMapper
+	if restMapper == nil {
+		var err error
+		restMapper, err = utils.GetRESTMapper(p.Client)
+		if err != nil {
+			return err
+		}
+	}
+	if len(p.MutatingPolicies) != 0 {
+		if _, _, err := p.mutatingPolicyProvider(restMapper); err != nil {
+			return err
+		}
+	}
+	if len(p.ValidatingPolicies) != 0 {
+		if _, err := p.validatingPolicyProvider(false); err != nil {
+			return err
+		}
+		if _, err := p.validatingPolicyProvider(true); err != nil {
+			return err
+		}
+	}
+	if len(p.GeneratingPolicies) != 0 {
+		// initialize the context provider before compiling to make it globally available
+		if _, err := NewContextProvider(p.Client, restMapper, p.ContextFs, p.ContextPath, true, !p.Cluster, p.GlobalContextEntries, p.Store.GetHTTPMockIndex()); err != nil {
+			return err
+		}
+		if _, err := p.generatingPolicies(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mutatingPolicyProvider returns the provider of the mutating policies together with the context it was built with.
+func (p *PolicyProcessor) mutatingPolicyProvider(restMapper meta.RESTMapper) (libs.Context, mpolengine.Provider, error) {
+	if p.Compiled != nil && p.Compiled.mpolProvider != nil {
+		return p.Compiled.mpolContext, p.Compiled.mpolProvider, nil
+	}
+	contextProvider, err := NewContextProvider(p.Client, restMapper, p.ContextFs, p.ContextPath, true, !p.Cluster, p.GlobalContextEntries, p.Store.GetHTTPMockIndex())
+	if err != nil {
+		return nil, nil, err
+	}
+	provider, err := mpolengine.NewProvider(mpolcompiler.NewCompiler(), p.MutatingPolicies, p.CELExceptions, contextProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.Compiled != nil {
+		p.Compiled.mpolContext, p.Compiled.mpolProvider = contextProvider, provider
+	}
+	return contextProvider, provider, nil
+}
+
+// splitValidatingPolicies separates validating policies by evaluation mode to route them correctly.
+// JSON-mode policies evaluate against raw JSON and must not go through the
+// Kubernetes admission path (which requires GVK/GVR and admission attributes).
+// Kubernetes-mode policies (the default) require admission attributes and must
+// not be sent through the JSON path (which would cause a nil pointer dereference).
+func (p *PolicyProcessor) splitValidatingPolicies() ([]policiesv1beta1.ValidatingPolicyLike, []policiesv1beta1.ValidatingPolicyLike) {
+	jsonPolicies := make([]policiesv1beta1.ValidatingPolicyLike, 0)
+	k8sPolicies := make([]policiesv1beta1.ValidatingPolicyLike, 0)
+	for i := range p.ValidatingPolicies {
+		pol := p.ValidatingPolicies[i]
+		if pol.GetValidatingPolicySpec().EvaluationMode() == policieskyvernoio.EvaluationModeJSON {
+			jsonPolicies = append(jsonPolicies, pol)
+		} else {
+			k8sPolicies = append(k8sPolicies, pol)
+		}
+	}
+	return jsonPolicies, k8sPolicies
+}
+
+// validatingPolicyProvider returns the provider of the JSON-mode or Kubernetes-mode validating policies.
+func (p *PolicyProcessor) validatingPolicyProvider(json bool) (vpolengine.Provider, error) {
+	cached := func() *vpolengine.Provider {
+		if p.Compiled == nil {
+			return nil
+		}
+		if json {
+			return &p.Compiled.vpolJSONProvider
+		}
+		return &p.Compiled.vpolProvider
+	}()
+	if cached != nil && *cached != nil {
+		return *cached, nil
+	}
+	jsonPolicies, k8sPolicies := p.splitValidatingPolicies()
+	policies := k8sPolicies
+	if json {
+		policies = jsonPolicies
+	}
+	provider, err := vpolengine.NewProvider(vpolcompiler.NewCompiler(), policies, p.CELExceptions)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		*cached = provider
+	}
+	return provider, nil
+}
+
+// generatingPolicies returns the compiled generating policies.
+func (p *PolicyProcessor) generatingPolicies() ([]gpolengine.Policy, error) {
+	if p.Compiled != nil && p.Compiled.gpolPolicies != nil {
+		return p.Compiled.gpolPolicies, nil
+	}
+	compiler := gpolcompiler.NewCompiler()
+	compiledPolicies := make([]gpolengine.Policy, 0, len(p.GeneratingPolicies))
+	for _, pol := range p.GeneratingPolicies {
+		compiled, errs := compiler.Compile(pol, p.CELExceptions)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("failed to compile policy %s (%w)", pol.GetName(), errs.ToAggregate())
+		}
+		compiledPolicies = append(compiledPolicies, gpolengine.Policy{
+			Policy:         engineapi.NewGeneratingPolicyFromLike(pol).AsGeneratingPolicy(),
+			CompiledPolicy: compiled,
+		})
+	}
+	if p.Compiled != nil {
+		p.Compiled.gpolPolicies = compiledPolicies
+	}
+	return compiledPolicies, nil
+}
@@ -51,6 +51,17 @@ func AdmissionRequestShape(operation string, resource *unstructured.Unstructured
 	}
 }
 
+// AdmissionRequestShapeWithOld is AdmissionRequestShape with an explicit prior
+// state: for UPDATE, oldObject is set to oldResource instead of the resource
+// itself. A nil oldResource behaves exactly like AdmissionRequestShape.
+func AdmissionRequestShapeWithOld(operation string, resource, oldResource *unstructured.Unstructured) (admissionv1.Operation, runtime.Object, runtime.Object) {
+	op, object, oldObject := AdmissionRequestShape(operation, resource)
+	if op == admissionv1.Update && oldResource != nil {
+		oldObject = oldResource.DeepCopy()
+	}
+	return op, object, oldObject
+}
+
 // resolveOperation returns the effective operation for the processor: the
 // explicitly configured operation takes precedence, then the `request.operation`
 // global value from the values file, then the default (CREATE). CONNECT from the
@@ -66,3 +77,9 @@ func (p *PolicyProcessor) resolveOperation() string {
 	}
 	return ""
 }
+
+// admissionRequestShape returns the admission request shape for the effective
+// operation of the processor, honoring the configured old resource.
+func (p *PolicyProcessor) admissionRequestShape(resource *unstructured.Unstructured) (admissionv1.Operation, runtime.Object, runtime.Object) {
+	return AdmissionRequestShapeWithOld(p.resolveOperation(), resource, p.OldResource)
+}
@@ -116,3 +116,37 @@ func TestAdmissionRequestShapeUpdateDeepCopy(t *testing.T) {
 	object.(*unstructured.Unstructured).SetLabels(map[string]string{"mutated": "true"})
 	assert.Empty(t, oldObject.(*unstructured.Unstructured).GetLabels())
 }
+
+func TestAdmissionRequestShapeWithOld(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "test-pod", "labels": map[string]interface{}{"version": "2"}},
+	}}
+	oldResource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "test-pod", "labels": map[string]interface{}{"version": "1"}},
+	}}
+	t.Run("UPDATE uses the old resource", func(t *testing.T) {
+		op, object, oldObject := AdmissionRequestShapeWithOld("UPDATE", resource, oldResource)
+		assert.Equal(t, admissionv1.Update, op)
+		assert.Equal(t, resource, object)
+		assert.Equal(t, oldResource.Object, oldObject.(*unstructured.Unstructured).Object)
+		assert.NotSame(t, oldResource, oldObject)
+	})
+	t.Run("UPDATE without old resource", func(t *testing.T) {
+		_, _, oldObject := AdmissionRequestShapeWithOld("UPDATE", resource, nil)
+		assert.Equal(t, resource.Object, oldObject.(*unstructured.Unstructured).Object)
+	})
+	t.Run("CREATE ignores the old resource", func(t *testing.T) {
+		op, _, oldObject := AdmissionRequestShapeWithOld("CREATE", resource, oldResource)
+		assert.Equal(t, admissionv1.Create, op)
+		assert.Nil(t, oldObject)
+	})
+	t.Run("processor honors OldResource", func(t *testing.T) {
+		p := &PolicyProcessor{Operation: "UPDATE", OldResource: oldResource}
+		_, _, oldObject := p.admissionRequestShape(resource)
+		assert.Equal(t, oldResource.Object, oldObject.(*unstructured.Unstructured).Object)
+	})
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/go-git/go-billy/v5"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
-	policieskyvernoio "github.com/kyverno/api/api/policies.kyverno.io"
 	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
@@ -28,11 +27,9 @@ import (
 	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
 	"github.com/kyverno/kyverno/pkg/cel/matching"
-	gpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/gpol/compiler"
 	gpolengine "github.com/kyverno/kyverno/pkg/cel/policies/gpol/engine"
 	mpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
 	mpolengine "github.com/kyverno/kyverno/pkg/cel/policies/mpol/engine"
-	vpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
 	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
@@ -83,7 +80,10 @@ type PolicyProcessor struct {
 	// Operation is the admission operation to simulate (CREATE, UPDATE or DELETE).
 	// When empty, the `request.operation` global value from the values file is
 	// honored, defaulting to CREATE.
-	Operation          string
+	Operation string
+	// OldResource is the prior state of the resource (request.oldObject) for
+	// UPDATE operations. When nil, the resource itself is used.
+	OldResource        *unstructured.Unstructured
 	PolicyExceptions   []*kyvernov2.PolicyException
 	CELExceptions      []*policiesv1beta1.PolicyException
 	MutateLogPath      string
@@ -110,6 +110,10 @@ type PolicyProcessor struct {
 	NamespaceCache            map[string]*unstructured.Unstructured
 	ConfigMapResolver         engineapi.ConfigmapResolver
 	RESTMapper                meta.RESTMapper
+	// ContextLoaderFactory overrides the context loader factory built from the store when set.
+	ContextLoaderFactory engineapi.ContextLoaderFactory
+	// Compiled caches the compiled CEL policies across calls when set, see Compile.
+	Compiled *CompiledPolicies
 }
 
 func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse, error) {
@@ -131,6 +135,10 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 	if rclient == nil {
 		rclient = registryclient.New(nil, "", "", "", false)
 	}
+	contextLoaderFactory := p.ContextLoaderFactory
+	if contextLoaderFactory == nil {
+		contextLoaderFactory = store.ContextLoaderFactory(p.Store, p.ConfigMapResolver)
+	}
 	isCluster := false
 	if len(p.CrdPaths) > 0 {
 		if err := p.loadCrds(); err != nil {
@@ -143,7 +151,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		client,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(p.Store, p.ConfigMapResolver),
+		contextLoaderFactory,
 		exceptions.New(policyExceptionLister),
 		&isCluster,
 	)
@@ -309,13 +317,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 	}
 	// MutatingPolicies
 	if len(p.MutatingPolicies) != 0 {
-		compiler := mpolcompiler.NewCompiler()
-		contextProvider, err := NewContextProvider(p.Client, restMapper, p.ContextFs, p.ContextPath, true, !p.Cluster, p.GlobalContextEntries, p.Store.GetHTTPMockIndex())
-		if err != nil {
-			return nil, err
-		}
-
-		provider, err := mpolengine.NewProvider(compiler, p.MutatingPolicies, p.CELExceptions, contextProvider)
+		contextProvider, provider, err := p.mutatingPolicyProvider(restMapper)
 		if err != nil {
 			return nil, err
 		}
@@ -348,7 +350,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 				user = p.UserInfo.AdmissionUserInfo
 			}
 			// create engine request
-			operation, object, oldObject := AdmissionRequestShape(p.resolveOperation(), &resource)
+			operation, object, oldObject := p.admissionRequestShape(&resource)
 			request := celengine.Request(
 				contextProvider,
 				gvk,
@@ -499,22 +501,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 	// validating policies
 	if len(p.ValidatingPolicies) != 0 {
 		ctx := context.TODO()
-		compiler := vpolcompiler.NewCompiler()
-		// Separate policies by evaluation mode to route them correctly.
-		// JSON-mode policies evaluate against raw JSON and must not go through the
-		// Kubernetes admission path (which requires GVK/GVR and admission attributes).
-		// Kubernetes-mode policies (the default) require admission attributes and must
-		// not be sent through the JSON path (which would cause a nil pointer dereference).
-		jsonPolicies := make([]policiesv1beta1.ValidatingPolicyLike, 0)
-		k8sPolicies := make([]policiesv1beta1.ValidatingPolicyLike, 0)
-		for i := range p.ValidatingPolicies {
-			pol := p.ValidatingPolicies[i]
-			if pol.GetValidatingPolicySpec().EvaluationMode() == policieskyvernoio.EvaluationModeJSON {
-				jsonPolicies = append(jsonPolicies, pol)
-			} else {
-				k8sPolicies = append(k8sPolicies, pol)
-			}
-		}
+		jsonPolicies, k8sPolicies := p.splitValidatingPolicies()
 		contextProvider, err := NewContextProvider(p.Client, restMapper, p.ContextFs, p.ContextPath, true, !p.Cluster, p.GlobalContextEntries, p.Store.GetHTTPMockIndex())
 		if err != nil {
 			return nil, err
@@ -522,7 +509,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		if resource.Object != nil {
 			// Evaluate Kubernetes-mode policies via the admission path
 			if len(k8sPolicies) > 0 {
-				provider, err := vpolengine.NewProvider(compiler, k8sPolicies, p.CELExceptions)
+				provider, err := p.validatingPolicyProvider(false)
 				if err != nil {
 					return nil, err
 				}
@@ -554,7 +541,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 					user = p.UserInfo.AdmissionUserInfo
 				}
 				// create engine request
-				operation, object, oldObject := AdmissionRequestShape(p.resolveOperation(), &resource)
+				operation, object, oldObject := p.admissionRequestShape(&resource)
 				request := celengine.Request(
 					contextProvider,
 					gvk,
@@ -590,7 +577,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 			}
 			// Also evaluate JSON-mode policies against the K8s resource as raw JSON
 			if len(jsonPolicies) > 0 {
-				provider, err := vpolengine.NewProvider(compiler, jsonPolicies, p.CELExceptions)
+				provider, err := p.validatingPolicyProvider(true)
 				if err != nil {
 					return nil, err
 				}
@@ -622,7 +609,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 					"skippedPolicies", len(k8sPolicies))
 			}
 			if len(jsonPolicies) > 0 {
-				provider, err := vpolengine.NewProvider(compiler, jsonPolicies, p.CELExceptions)
+				provider, err := p.validatingPolicyProvider(true)
 				if err != nil {
 					return nil, err
 				}
@@ -654,17 +641,9 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 			return nil, err
 		}
 
-		compiler := gpolcompiler.NewCompiler()
-		compiledPolicies := make([]gpolengine.Policy, 0, len(p.GeneratingPolicies))
-		for _, pol := range p.GeneratingPolicies {
-			compiled, errs := compiler.Compile(pol, p.CELExceptions)
-			if len(errs) > 0 {
-				return nil, fmt.Errorf("failed to compile policy %s (%w)", pol.GetName(), errs.ToAggregate())
-			}
-			compiledPolicies = append(compiledPolicies, gpolengine.Policy{
-				Policy:         engineapi.NewGeneratingPolicyFromLike(pol).AsGeneratingPolicy(),
-				CompiledPolicy: compiled,
-			})
+		compiledPolicies, err := p.generatingPolicies()
+		if err != nil {
+			return nil, err
 		}
 		if resource.Object != nil {
 			engine := gpolengine.NewEngine(p.Variables.Namespace, matching.NewMatcher())
@@ -694,7 +673,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 				user = p.UserInfo.AdmissionUserInfo
 			}
 			// create engine request
-			operation, object, oldObject := AdmissionRequestShape(p.resolveOperation(), &resource)
+			operation, object, oldObject := p.admissionRequestShape(&resource)
 			request := celengine.Request(
 				contextProvider,
 				gvk,
@@ -829,9 +808,12 @@ func (p *PolicyProcessor) makePolicyContext(
 		return nil, fmt.Errorf("failed to create policy context (%w)", err)
 	}
 	if operation == kyvernov1.Update {
-		resource := resource.DeepCopy()
-		policyContext = policyContext.WithOldResource(*resource)
-		if err := policyContext.JSONContext().AddOldResource(resource.Object); err != nil {
+		oldResource := resource.DeepCopy()
+		if p.OldResource != nil {
+			oldResource = p.OldResource.DeepCopy()
+		}
+		policyContext = policyContext.WithOldResource(*oldResource)
+		if err := policyContext.JSONContext().AddOldResource(oldResource.Object); err != nil {
 			return nil, fmt.Errorf("failed to update old resource in json context (%w)", err)
 		}
 	}
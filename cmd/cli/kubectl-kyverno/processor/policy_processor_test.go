@@ -296,3 +296,34 @@ func Test_resolveResource_generatingPolicyFallsThrough(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, "deployments", got)
 }
+
+func Test_validatingPolicyProvider_compiledOnce(t *testing.T) {
+	valid := &policiesv1beta1.ValidatingPolicy{}
+	valid.SetName("valid")
+	valid.Spec.Validations = []admissionregistrationv1.Validation{{Expression: "true"}}
+	invalid := &policiesv1beta1.ValidatingPolicy{}
+	invalid.SetName("invalid")
+	invalid.Spec.Validations = []admissionregistrationv1.Validation{{Expression: "object.("}}
+
+	compiled := &CompiledPolicies{}
+	p := &PolicyProcessor{
+		ValidatingPolicies: []policiesv1beta1.ValidatingPolicyLike{valid},
+		Compiled:           compiled,
+	}
+	_, err := p.validatingPolicyProvider(false)
+	assert.NilError(t, err)
+	assert.Assert(t, compiled.vpolProvider != nil)
+
+	// the cached provider is reused, the policies are not compiled again
+	p = &PolicyProcessor{
+		ValidatingPolicies: []policiesv1beta1.ValidatingPolicyLike{invalid},
+		Compiled:           compiled,
+	}
+	_, err = p.validatingPolicyProvider(false)
+	assert.NilError(t, err)
+
+	// without a cache the policies are compiled on each call
+	p.Compiled = nil
+	_, err = p.validatingPolicyProvider(false)
+	assert.ErrorContains(t, err, "failed to compile policy invalid")
+}
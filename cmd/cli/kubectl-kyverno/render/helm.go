@@ -0,0 +1,688 @@
+package render
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/gobwas/glob"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	yamlutils "github.com/kyverno/kyverno/ext/yaml"
+	"github.com/pelletier/go-toml/v2"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+	goyaml "sigs.k8s.io/yaml/goyaml.v3"
+)
+
+const (
+	// defaultKubeVersion is the Kubernetes version exposed as .Capabilities.KubeVersion unless HelmOptions.KubeVersion is set
+	defaultKubeVersion = "v1.36.0"
+	maxIncludeDepth    = 1000
+	templatesDir       = "templates"
+	chartsDir          = "charts"
+	chartFileName      = "Chart.yaml"
+	valuesFileName     = "values.yaml"
+	notesFileName      = "NOTES.txt"
+	partialFilePrefix  = "_"
+	libraryChartType   = "library"
+)
+
+// HelmOptions configures how a chart is rendered.
+type HelmOptions struct {
+	// ReleaseName is the name of the release, defaults to the chart name.
+	ReleaseName string
+	// Namespace is the namespace of the release, defaults to "default".
+	Namespace string
+	// ValuesFiles are merged over the chart values, later files take precedence.
+	ValuesFiles []string
+	// KubeVersion is exposed to templates as .Capabilities.KubeVersion, defaults to defaultKubeVersion.
+	KubeVersion string
+}
+
+// ChartMetadata holds the fields of Chart.yaml exposed to templates as .Chart.
+type ChartMetadata struct {
+	APIVersion   string            `json:"apiVersion,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	AppVersion   string            `json:"appVersion,omitempty"`
+	KubeVersion  string            `json:"kubeVersion,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Type         string            `json:"type,omitempty"`
+	Keywords     []string          `json:"keywords,omitempty"`
+	Home         string            `json:"home,omitempty"`
+	Icon         string            `json:"icon,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Dependencies []ChartDependency `json:"dependencies,omitempty"`
+}
+
+// ChartDependency is a dependency declared in Chart.yaml.
+type ChartDependency struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version,omitempty"`
+	Alias     string   `json:"alias,omitempty"`
+	Condition string   `json:"condition,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+type chart struct {
+	metadata ChartMetadata
+	values   map[string]any
+	files    map[string][]byte
+	charts   []*chart
+}
+
+type renderedTemplate struct {
+	// name is the template name, e.g. mychart/charts/subchart/templates/deployment.yaml
+	name string
+	// prefix is the chart the template belongs to, e.g. mychart/charts/subchart/
+	prefix  string
+	chart   *chart
+	values  map[string]any
+	content string
+}
+
+// Helm renders a chart directory or archive without the helm binary. Each resource is
+// attributed to the template it was rendered from, e.g. mychart/templates/deployment.yaml.
+//
+// The rendering follows `helm template` for the common cases but it is not the Helm engine,
+// the known differences are:
+//   - dependencies must be present as unpacked or archived subcharts in the charts directory,
+//     they are not downloaded and their version constraints are not checked
+//   - import-values and values.schema.json are ignored
+//   - lookup always returns an empty result, like `helm template` without cluster access
+//   - .Capabilities.APIVersions lists the APIs known to the CLI and .Capabilities.KubeVersion
+//     is HelmOptions.KubeVersion, they are not discovered from a cluster
+//   - hooks are rendered like any other template
+//   - toToml and fromToml rely on a different TOML library, the output format can differ
+func Helm(chartPath string, options HelmOptions) ([]Resource, error) {
+	root, err := loadChart(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s (%w)", chartPath, err)
+	}
+	values := root.values
+	for _, file := range options.ValuesFiles {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file %s (%w)", file, err)
+		}
+		var override map[string]any
+		if err := yaml.Unmarshal(content, &override); err != nil {
+			return nil, fmt.Errorf("failed to parse values file %s (%w)", file, err)
+		}
+		values = mergeValues(values, override)
+	}
+	if options.ReleaseName == "" {
+		options.ReleaseName = root.metadata.Name
+	}
+	if options.Namespace == "" {
+		options.Namespace = "default"
+	}
+	if options.KubeVersion == "" {
+		options.KubeVersion = defaultKubeVersion
+	}
+	kubeVersion, err := version.ParseGeneric(options.KubeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kube version %s (%w)", options.KubeVersion, err)
+	}
+	options.KubeVersion = "v" + kubeVersion.String()
+	rendered, err := renderChart(root, values, options)
+	if err != nil {
+		return nil, err
+	}
+	var resources []Resource
+	for _, tpl := range rendered {
+		documents, err := yamlutils.SplitDocuments([]byte(tpl.content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s (%w)", tpl.name, err)
+		}
+		source := filepath.Join(chartPath, filepath.FromSlash(strings.TrimPrefix(tpl.name, root.metadata.Name+"/")))
+		for _, document := range documents {
+			var object map[string]any
+			if err := yaml.Unmarshal(document, &object); err != nil {
+				return nil, fmt.Errorf("failed to parse template %s (%w)", tpl.name, err)
+			}
+			if len(object) == 0 {
+				continue
+			}
+			unstructured, err := resource.YamlToUnstructured(document)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse template %s (%w)", tpl.name, err)
+			}
+			if !hasNamespace(object) && unstructured.GetNamespace() != "" {
+				unstructured.SetNamespace(options.Namespace)
+			}
+			resources = append(resources, Resource{Resource: unstructured, Source: source})
+		}
+	}
+	return resources, nil
+}
+
+func hasNamespace(object map[string]any) bool {
+	metadata, _ := object["metadata"].(map[string]any)
+	namespace, _ := metadata["namespace"].(string)
+	return namespace != ""
+}
+
+func loadChart(chartPath string) (*chart, error) {
+	info, err := os.Stat(chartPath)
+	if err != nil {
+		return nil, err
+	}
+	var files map[string][]byte
+	if info.IsDir() {
+		files, err = readDir(chartPath)
+	} else {
+		files, err = readArchive(chartPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newChart(files)
+}
+
+func newChart(files map[string][]byte) (*chart, error) {
+	content, ok := files[chartFileName]
+	if !ok {
+		return nil, errors.New("Chart.yaml not found")
+	}
+	c := &chart{files: map[string][]byte{}, values: map[string]any{}}
+	if err := yaml.Unmarshal(content, &c.metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse Chart.yaml (%w)", err)
+	}
+	if c.metadata.Name == "" {
+		return nil, errors.New("chart name is required in Chart.yaml")
+	}
+	if content, ok := files[valuesFileName]; ok {
+		if err := yaml.Unmarshal(content, &c.values); err != nil {
+			return nil, fmt.Errorf("failed to parse values.yaml (%w)", err)
+		}
+		if c.values == nil {
+			c.values = map[string]any{}
+		}
+	}
+	subcharts := map[string]map[string][]byte{}
+	for name, content := range files {
+		rest, ok := strings.CutPrefix(name, chartsDir+"/")
+		if !ok {
+			c.files[name] = content
+			continue
+		}
+		subchart, file, ok := strings.Cut(rest, "/")
+		if !ok {
+			if strings.HasSuffix(subchart, ".tgz") {
+				archive, err := untar(bytes.NewReader(content))
+				if err != nil {
+					return nil, fmt.Errorf("failed to read subchart %s (%w)", subchart, err)
+				}
+				subcharts[subchart] = archive
+			}
+			continue
+		}
+		if subcharts[subchart] == nil {
+			subcharts[subchart] = map[string][]byte{}
+		}
+		subcharts[subchart][file] = content
+	}
+	names := make([]string, 0, len(subcharts))
+	for name := range subcharts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		subchart, err := newChart(subcharts[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load subchart %s (%w)", name, err)
+		}
+		c.charts = append(c.charts, subchart)
+	}
+	for _, dependency := range c.metadata.Dependencies {
+		if !slices.ContainsFunc(c.charts, func(subchart *chart) bool { return subchart.metadata.Name == dependency.Name }) {
+			return nil, fmt.Errorf("dependency %s of chart %s is missing in the charts directory, run helm dependency build", dependency.Name, c.metadata.Name)
+		}
+	}
+	return c, nil
+}
+
+func readDir(root string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(file) // #nosec G304
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = content
+		return nil
+	})
+	return files, err
+}
+
+func readArchive(file string) (map[string][]byte, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return untar(f)
+}
+
+// untar reads a gzipped chart archive, dropping the chart directory at the root of the archive.
+func untar(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	files := map[string][]byte{}
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		_, name, ok := strings.Cut(path.Clean(filepath.ToSlash(header.Name)), "/")
+		if !ok {
+			continue
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = content
+	}
+}
+
+// mergeValues deep merges override into base, override takes precedence.
+func mergeValues(base, override map[string]any) map[string]any {
+	out := make(map[string]any, len(base))
+	for key, value := range base {
+		out[key] = value
+	}
+	for key, value := range override {
+		if value == nil {
+			delete(out, key)
+			continue
+		}
+		if overrideMap, ok := value.(map[string]any); ok {
+			if baseMap, ok := out[key].(map[string]any); ok {
+				out[key] = mergeValues(baseMap, overrideMap)
+				continue
+			}
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// collect returns the templates of a chart and its enabled subcharts along with the values they are rendered with,
+// tags are the tags defined in the values of the root chart.
+func collect(c *chart, values, tags map[string]any, prefix string) []renderedTemplate {
+	var templates []renderedTemplate
+	for name := range c.files {
+		if strings.HasPrefix(name, templatesDir+"/") {
+			templates = append(templates, renderedTemplate{name: prefix + name, prefix: prefix, chart: c, values: values})
+		}
+	}
+	global, _ := values["global"].(map[string]any)
+	for _, subchart := range c.charts {
+		name, condition, dependencyTags := subchart.metadata.Name, "", []string(nil)
+		for _, dependency := range c.metadata.Dependencies {
+			if dependency.Name == subchart.metadata.Name {
+				if dependency.Alias != "" {
+					name = dependency.Alias
+				}
+				condition, dependencyTags = dependency.Condition, dependency.Tags
+				break
+			}
+		}
+		if !enabled(values, tags, condition, dependencyTags) {
+			continue
+		}
+		subvalues, _ := values[name].(map[string]any)
+		subvalues = mergeValues(subchart.values, subvalues)
+		if global != nil {
+			subglobal, _ := subvalues["global"].(map[string]any)
+			subvalues["global"] = mergeValues(subglobal, global)
+		}
+		templates = append(templates, collect(subchart, subvalues, tags, prefix+chartsDir+"/"+name+"/")...)
+	}
+	return templates
+}
+
+// enabled evaluates a dependency condition and tags, the first condition path resolving to a boolean wins.
+// Without a resolved condition, the dependency is disabled when none of its tags is true and at least one is false.
+func enabled(values, tags map[string]any, condition string, dependencyTags []string) bool {
+	for _, path := range strings.Split(condition, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		var current any = values
+		for _, key := range strings.Split(path, ".") {
+			m, _ := current.(map[string]any)
+			current = m[key]
+		}
+		if enabled, ok := current.(bool); ok {
+			return enabled
+		}
+	}
+	hasTrue, hasFalse := false, false
+	for _, tag := range dependencyTags {
+		if enabled, ok := tags[tag].(bool); ok {
+			hasTrue = hasTrue || enabled
+			hasFalse = hasFalse || !enabled
+		}
+	}
+	return hasTrue || !hasFalse
+}
+
+func renderChart(root *chart, values map[string]any, options HelmOptions) ([]renderedTemplate, error) {
+	tags, _ := values["tags"].(map[string]any)
+	templates := collect(root, values, tags, root.metadata.Name+"/")
+	slices.SortFunc(templates, func(a, b renderedTemplate) int { return strings.Compare(a.name, b.name) })
+	t := template.New("gotpl").Option("missingkey=zero")
+	t.Funcs(funcMap(t))
+	for _, tpl := range templates {
+		content := tpl.chart.files[strings.TrimPrefix(tpl.name, tpl.prefix)]
+		if _, err := t.New(tpl.name).Parse(string(content)); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s (%w)", tpl.name, err)
+		}
+	}
+	capabilities := newCapabilities(options.KubeVersion)
+	release := map[string]any{
+		"Name":      options.ReleaseName,
+		"Namespace": options.Namespace,
+		"Service":   "Helm",
+		"IsInstall": true,
+		"IsUpgrade": false,
+		"Revision":  1,
+	}
+	var rendered []renderedTemplate
+	for _, tpl := range templates {
+		base := path.Base(tpl.name)
+		if strings.HasPrefix(base, partialFilePrefix) || base == notesFileName || tpl.chart.metadata.Type == libraryChartType {
+			continue
+		}
+		data := map[string]any{
+			"Values":       tpl.values,
+			"Release":      release,
+			"Chart":        tpl.chart.metadata,
+			"Capabilities": capabilities,
+			"Files":        newFiles(tpl.chart.files),
+			"Template": map[string]any{
+				"Name":     tpl.name,
+				"BasePath": tpl.prefix + templatesDir,
+			},
+		}
+		var buf strings.Builder
+		if err := t.ExecuteTemplate(&buf, tpl.name, data); err != nil {
+			return nil, fmt.Errorf("failed to render template %s (%w)", tpl.name, err)
+		}
+		tpl.content = strings.ReplaceAll(buf.String(), "<no value>", "")
+		rendered = append(rendered, tpl)
+	}
+	return rendered, nil
+}
+
+func funcMap(t *template.Template) template.FuncMap {
+	funcs := sprig.HermeticTxtFuncMap()
+	includes := map[string]int{}
+	funcs["include"] = func(name string, data any) (string, error) {
+		if includes[name] > maxIncludeDepth {
+			return "", fmt.Errorf("rendering template has a nested reference name: %s", name)
+		}
+		includes[name]++
+		defer func() { includes[name]-- }()
+		var buf strings.Builder
+		if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	funcs["tpl"] = func(text string, data any) (string, error) {
+		clone, err := t.Clone()
+		if err != nil {
+			return "", err
+		}
+		tpl, err := clone.New("tpl").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		var buf strings.Builder
+		if err := tpl.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return strings.ReplaceAll(buf.String(), "<no value>", ""), nil
+	}
+	funcs["required"] = func(message string, value any) (any, error) {
+		if value == nil {
+			return nil, errors.New(message)
+		}
+		if s, ok := value.(string); ok && s == "" {
+			return nil, errors.New(message)
+		}
+		return value, nil
+	}
+	funcs["lookup"] = func(string, string, string, string) (map[string]any, error) {
+		return map[string]any{}, nil
+	}
+	funcs["toYaml"] = func(value any) string {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(string(data), "\n")
+	}
+	funcs["mustToYaml"] = func(value any) (string, error) {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(string(data), "\n"), nil
+	}
+	funcs["toYamlPretty"] = func(value any) string {
+		var buf bytes.Buffer
+		encoder := goyaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+	funcs["toToml"] = func(value any) string {
+		data, err := toml.Marshal(value)
+		if err != nil {
+			return err.Error()
+		}
+		return string(data)
+	}
+	funcs["fromToml"] = func(text string) map[string]any {
+		m := map[string]any{}
+		if err := toml.Unmarshal([]byte(text), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	funcs["fromYaml"] = func(text string) map[string]any {
+		m := map[string]any{}
+		if err := yaml.Unmarshal([]byte(text), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	funcs["fromYamlArray"] = func(text string) []any {
+		var a []any
+		if err := yaml.Unmarshal([]byte(text), &a); err != nil {
+			a = []any{err.Error()}
+		}
+		return a
+	}
+	funcs["toJson"] = func(value any) string {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+	funcs["mustToJson"] = func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	funcs["fromJson"] = func(text string) map[string]any {
+		m := map[string]any{}
+		if err := json.Unmarshal([]byte(text), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	funcs["fromJsonArray"] = func(text string) []any {
+		var a []any
+		if err := json.Unmarshal([]byte(text), &a); err != nil {
+			a = []any{err.Error()}
+		}
+		return a
+	}
+	return funcs
+}
+
+// files is exposed to templates as .Files.
+type files map[string][]byte
+
+func newFiles(all map[string][]byte) files {
+	f := files{}
+	for name, content := range all {
+		if name == chartFileName || name == valuesFileName || strings.HasPrefix(name, templatesDir+"/") {
+			continue
+		}
+		f[name] = content
+	}
+	return f
+}
+
+func (f files) Get(name string) string {
+	return string(f[name])
+}
+
+func (f files) GetBytes(name string) []byte {
+	return f[name]
+}
+
+// Glob returns the files matching a glob pattern, an invalid pattern matches no file.
+func (f files) Glob(pattern string) files {
+	matched := files{}
+	g, err := glob.Compile(pattern, '/')
+	if err != nil {
+		return matched
+	}
+	for name, content := range f {
+		if g.Match(name) {
+			matched[name] = content
+		}
+	}
+	return matched
+}
+
+// AsConfig returns the files as the data of a config map, keyed by their base name.
+func (f files) AsConfig() string {
+	if len(f) == 0 {
+		return ""
+	}
+	m := make(map[string]string, len(f))
+	for name, content := range f {
+		m[path.Base(name)] = string(content)
+	}
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// AsSecrets returns the files as the base64 encoded data of a secret, keyed by their base name.
+func (f files) AsSecrets() string {
+	if len(f) == 0 {
+		return ""
+	}
+	m := make(map[string]string, len(f))
+	for name, content := range f {
+		m[path.Base(name)] = base64.StdEncoding.EncodeToString(content)
+	}
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+func (f files) Lines(name string) []string {
+	content := strings.TrimSuffix(string(f[name]), "\n")
+	if content == "" {
+		return []string{}
+	}
+	return strings.Split(content, "\n")
+}
+
+// versionSet is exposed to templates as .Capabilities.APIVersions.
+type versionSet []string
+
+func (v versionSet) Has(version string) bool {
+	return slices.Contains(v, version)
+}
+
+func newCapabilities(kubeVersion string) map[string]any {
+	var versions versionSet
+	if groups, err := data.APIGroupResources(); err == nil {
+		for _, group := range groups {
+			for version, resources := range group.VersionedResources {
+				groupVersion := version
+				if group.Group.Name != "" {
+					groupVersion = group.Group.Name + "/" + version
+				}
+				versions = append(versions, groupVersion)
+				for _, resource := range resources {
+					versions = append(versions, groupVersion+"/"+resource.Kind)
+				}
+			}
+		}
+	}
+	major, minor, _ := strings.Cut(strings.TrimPrefix(kubeVersion, "v"), ".")
+	minor, _, _ = strings.Cut(minor, ".")
+	return map[string]any{
+		"APIVersions": versions,
+		"KubeVersion": map[string]any{
+			"Version":    kubeVersion,
+			"GitVersion": kubeVersion,
+			"Major":      major,
+			"Minor":      minor,
+		},
+	}
+}
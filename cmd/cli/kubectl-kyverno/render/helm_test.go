@@ -0,0 +1,218 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func TestHelm(t *testing.T) {
+	chart := filepath.Join(t.TempDir(), "app")
+	writeFiles(t, chart, map[string]string{
+		"Chart.yaml": `apiVersion: v2
+name: app
+version: 1.2.3
+dependencies:
+- name: cache
+  condition: cache.enabled
+- name: db
+  alias: database
+  condition: database.enabled
+`,
+		"values.yaml": `image:
+  repository: nginx
+  tag: latest
+replicas: 1
+global:
+  team: platform
+cache:
+  enabled: true
+database:
+  enabled: false
+`,
+		"templates/_helpers.tpl": `{{- define "app.labels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}`,
+		"templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  labels:
+    {{- include "app.labels" . | nindent 4 }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: app
+        image: {{ printf "%s:%s" .Values.image.repository .Values.image.tag | quote }}
+`,
+		"templates/service.yaml": `{{- if .Values.service }}
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Release.Name }}
+{{- end }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-config
+  namespace: kube-system
+data:
+  kube: {{ .Capabilities.KubeVersion.Version }}
+  config: {{ tpl .Values.image.repository . }}
+`,
+		"templates/NOTES.txt":          "not a resource",
+		"charts/cache/Chart.yaml":      "name: cache\nversion: 0.1.0\n",
+		"charts/cache/values.yaml":     "size: 1\n",
+		"charts/cache/templates/x.yml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cache\ndata:\n  size: {{ .Values.size | quote }}\n  team: {{ .Values.global.team }}\n",
+		"charts/db/Chart.yaml":         "name: db\nversion: 0.1.0\n",
+		"charts/db/templates/x.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: db\n",
+	})
+	values := filepath.Join(t.TempDir(), "values.yaml")
+	writeFiles(t, filepath.Dir(values), map[string]string{
+		"values.yaml": "image:\n  tag: \"1.27\"\nreplicas: 3\ncache:\n  size: 2\n",
+	})
+
+	resources, err := Helm(chart, HelmOptions{ReleaseName: "web", Namespace: "apps", ValuesFiles: []string{values}})
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	assert.Equal(t, filepath.Join(chart, "charts", "cache", "templates", "x.yml"), resources[0].Source)
+	assert.Equal(t, "apps", resources[0].Resource.GetNamespace())
+	data, _, _ := unstructured.NestedStringMap(resources[0].Resource.Object, "data")
+	assert.Equal(t, map[string]string{"size": "2", "team": "platform"}, data)
+
+	assert.Equal(t, filepath.Join(chart, "templates", "deployment.yaml"), resources[1].Source)
+	deployment := resources[1].Resource
+	assert.Equal(t, "web", deployment.GetName())
+	assert.Equal(t, "apps", deployment.GetNamespace())
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "app", "app.kubernetes.io/instance": "web"}, deployment.GetLabels())
+	assert.Equal(t, int64(3), deployment.Object["spec"].(map[string]any)["replicas"])
+
+	assert.Equal(t, filepath.Join(chart, "templates", "service.yaml"), resources[2].Source)
+	assert.Equal(t, "web-config", resources[2].Resource.GetName())
+	assert.Equal(t, "kube-system", resources[2].Resource.GetNamespace())
+	data, _, _ = unstructured.NestedStringMap(resources[2].Resource.Object, "data")
+	assert.Equal(t, map[string]string{"kube": defaultKubeVersion, "config": "nginx"}, data)
+}
+
+func TestHelmFunctions(t *testing.T) {
+	chart := t.TempDir()
+	writeFiles(t, chart, map[string]string{
+		"Chart.yaml": `name: app
+dependencies:
+- name: metrics
+  tags: [monitoring]
+- name: tracing
+  tags: [monitoring, tracing]
+- name: lib
+`,
+		"values.yaml":  "tags:\n  monitoring: false\n  tracing: true\nconfig:\n  port: 80\n",
+		"files/a.conf": "a",
+		"files/b.conf": "b",
+		"files/c.txt":  "c",
+		"templates/config.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  {{- (.Files.Glob "files/*.conf").AsConfig | nindent 2 }}
+  kube: {{ .Capabilities.KubeVersion.Version }}
+  toml: {{ toToml .Values.config | quote }}
+  yaml: {{ toYamlPretty .Values.config | quote }}
+  port: {{ (fromToml "port = 80").port | quote }}
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+data:
+  {{- (.Files.Glob "files/c.*").AsSecrets | nindent 2 }}
+`,
+		"charts/metrics/Chart.yaml":       "name: metrics\n",
+		"charts/metrics/templates/x.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: metrics\n",
+		"charts/tracing/Chart.yaml":       "name: tracing\n",
+		"charts/tracing/templates/x.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: tracing\n",
+		"charts/lib/Chart.yaml":           "name: lib\ntype: library\n",
+		"charts/lib/templates/_lib.tpl":   "{{ define \"lib.name\" }}lib{{ end }}",
+		"charts/lib/templates/x.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: lib\n",
+	})
+
+	resources, err := Helm(chart, HelmOptions{KubeVersion: "1.31"})
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	assert.Equal(t, "tracing", resources[0].Resource.GetName())
+
+	data, _, _ := unstructured.NestedStringMap(resources[1].Resource.Object, "data")
+	assert.Equal(t, map[string]string{
+		"a.conf": "a",
+		"b.conf": "b",
+		"kube":   "v1.31",
+		"toml":   "port = 80.0\n",
+		"yaml":   "port: 80",
+		"port":   "80",
+	}, data)
+
+	data, _, _ = unstructured.NestedStringMap(resources[2].Resource.Object, "data")
+	assert.Equal(t, map[string]string{"c.txt": "Yw=="}, data)
+
+	_, err = Helm(chart, HelmOptions{KubeVersion: "latest"})
+	assert.ErrorContains(t, err, "invalid kube version latest")
+}
+
+func TestHelmErrors(t *testing.T) {
+	_, err := Helm(filepath.Join(t.TempDir(), "missing"), HelmOptions{})
+	assert.Error(t, err)
+
+	chart := t.TempDir()
+	writeFiles(t, chart, map[string]string{
+		"Chart.yaml":           "name: app\n",
+		"templates/secret.yml": "value: {{ required \"password is required\" .Values.password }}\n",
+	})
+	_, err = Helm(chart, HelmOptions{})
+	assert.ErrorContains(t, err, "password is required")
+
+	chart = t.TempDir()
+	writeFiles(t, chart, map[string]string{
+		"Chart.yaml": "name: app\ndependencies:\n- name: db\n",
+	})
+	_, err = Helm(chart, HelmOptions{})
+	assert.ErrorContains(t, err, "dependency db of chart app is missing in the charts directory")
+}
+
+func Test_mergeValues(t *testing.T) {
+	base := map[string]any{"a": map[string]any{"b": 1, "c": 2}, "d": 3, "e": 4}
+	override := map[string]any{"a": map[string]any{"b": 5}, "d": map[string]any{"f": 6}, "e": nil}
+	assert.Equal(t, map[string]any{"a": map[string]any{"b": 5, "c": 2}, "d": map[string]any{"f": 6}}, mergeValues(base, override))
+	assert.Equal(t, map[string]any{"a": map[string]any{"b": 1, "c": 2}, "d": 3, "e": 4}, base)
+}
+
+func Test_enabled(t *testing.T) {
+	values := map[string]any{"a": map[string]any{"enabled": false}, "b": map[string]any{"enabled": true}}
+	tags := map[string]any{"on": true, "off": false}
+	assert.True(t, enabled(values, tags, "", nil))
+	assert.True(t, enabled(values, tags, "c.enabled", nil))
+	assert.False(t, enabled(values, tags, "a.enabled", nil))
+	assert.True(t, enabled(values, tags, "c.enabled, b.enabled,a.enabled", nil))
+	assert.False(t, enabled(values, tags, "", []string{"off"}))
+	assert.True(t, enabled(values, tags, "", []string{"off", "on"}))
+	assert.True(t, enabled(values, tags, "", []string{"unknown"}))
+	assert.True(t, enabled(values, tags, "b.enabled", []string{"off"}))
+}
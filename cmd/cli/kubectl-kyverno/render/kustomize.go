@@ -0,0 +1,90 @@
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// originFileSystem enables origin annotations in the root kustomization so that
+// rendered resources can be attributed to the files they come from.
+type originFileSystem struct {
+	filesys.FileSystem
+	kustomizations []string
+}
+
+func (fs originFileSystem) ReadFile(path string) ([]byte, error) {
+	content, err := fs.FileSystem.ReadFile(path)
+	if err != nil || !slices.Contains(fs.kustomizations, filepath.Clean(path)) {
+		return content, err
+	}
+	var kustomization map[string]any
+	if err := yaml.Unmarshal(content, &kustomization); err != nil {
+		return content, nil
+	}
+	if kustomization == nil {
+		kustomization = map[string]any{}
+	}
+	metadata, _ := kustomization["buildMetadata"].([]any)
+	if slices.Contains(metadata, any(types.OriginAnnotations)) {
+		return content, nil
+	}
+	kustomization["buildMetadata"] = append(metadata, types.OriginAnnotations)
+	return yaml.Marshal(kustomization)
+}
+
+// Kustomize builds a kustomization directory the same way `kustomize build` does.
+// Each resource is attributed to the file it was loaded from, or to the kustomization
+// declaring the generator that created it.
+func Kustomize(path string) ([]Resource, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	fs := originFileSystem{FileSystem: filesys.MakeFsOnDisk()}
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		fs.kustomizations = append(fs.kustomizations, filepath.Join(root, name))
+	}
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fs, root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %s (%w)", path, err)
+	}
+	resources := make([]Resource, 0, resMap.Size())
+	for _, res := range resMap.Resources() {
+		source := path
+		origin, err := res.GetOrigin()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read origin of %s (%w)", res.CurId(), err)
+		}
+		if origin != nil {
+			switch {
+			case origin.Repo != "":
+				source = origin.Repo + "//" + origin.Path
+			case origin.Path != "":
+				source = filepath.Join(path, origin.Path)
+			case origin.ConfiguredIn != "":
+				source = filepath.Join(path, origin.ConfiguredIn)
+			}
+			if err := res.SetOrigin(nil); err != nil {
+				return nil, err
+			}
+		}
+		document, err := res.AsYAML()
+		if err != nil {
+			return nil, err
+		}
+		unstructured, err := resource.YamlToUnstructured(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s (%w)", res.CurId(), err)
+		}
+		resources = append(resources, Resource{Resource: unstructured, Source: source})
+	}
+	return resources, nil
+}
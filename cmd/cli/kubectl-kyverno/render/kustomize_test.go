@@ -0,0 +1,54 @@
+package render
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKustomize(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"base/kustomization.yaml": "resources:\n- deployment.yaml\n",
+		"base/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx
+`,
+		"overlay/kustomization.yaml": `namespace: prod
+resources:
+- ../base
+configMapGenerator:
+- name: settings
+  literals:
+  - mode=prod
+  options:
+    disableNameSuffixHash: true
+`,
+	})
+	overlay := filepath.Join(root, "overlay")
+	resources, err := Kustomize(overlay)
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	sources := map[string]string{}
+	for _, resource := range resources {
+		assert.Equal(t, "prod", resource.Resource.GetNamespace())
+		assert.Empty(t, resource.Resource.GetAnnotations())
+		sources[resource.Resource.GetKind()] = resource.Source
+	}
+	assert.Equal(t, map[string]string{
+		"Deployment": filepath.Join(root, "base", "deployment.yaml"),
+		"ConfigMap":  filepath.Join(overlay, "kustomization.yaml"),
+	}, sources)
+
+	_, err = Kustomize(filepath.Join(root, "missing"))
+	assert.Error(t, err)
+}
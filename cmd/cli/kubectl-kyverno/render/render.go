@@ -0,0 +1,46 @@
+package render
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Resource is a rendered resource along with the file it was rendered from.
+type Resource struct {
+	Resource *unstructured.Unstructured
+	// Source is the template or the kustomize resource file the resource was rendered from.
+	Source string
+}
+
+// Unstructured returns the rendered resources.
+func Unstructured(resources ...Resource) []*unstructured.Unstructured {
+	out := make([]*unstructured.Unstructured, 0, len(resources))
+	for _, resource := range resources {
+		out = append(out, resource.Resource)
+	}
+	return out
+}
+
+// Sources indexes the source of the rendered resources by resource key. Resources rendered
+// with the same key from several sources can't be told apart, all their sources are listed.
+func Sources(resources ...Resource) map[string]string {
+	all := map[string][]string{}
+	for _, resource := range resources {
+		key := Key(resource.Resource)
+		if !slices.Contains(all[key], resource.Source) {
+			all[key] = append(all[key], resource.Source)
+		}
+	}
+	sources := make(map[string]string, len(all))
+	for key, list := range all {
+		sources[key] = strings.Join(list, ", ")
+	}
+	return sources
+}
+
+// Key identifies a resource by api version, kind, namespace and name.
+func Key(resource *unstructured.Unstructured) string {
+	return resource.GetAPIVersion() + "/" + resource.GetKind() + "/" + resource.GetNamespace() + "/" + resource.GetName()
+}
@@ -0,0 +1,31 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSources(t *testing.T) {
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace("default")
+	pod.SetName("web")
+	namespace := &unstructured.Unstructured{}
+	namespace.SetAPIVersion("v1")
+	namespace.SetKind("Namespace")
+	namespace.SetName("apps")
+	resources := []Resource{{Resource: pod, Source: "templates/pod.yaml"}, {Resource: namespace, Source: "templates/namespace.yaml"}}
+	assert.Equal(t, []*unstructured.Unstructured{pod, namespace}, Unstructured(resources...))
+	assert.Equal(t, map[string]string{
+		"v1/Pod/default/web": "templates/pod.yaml",
+		"v1/Namespace//apps": "templates/namespace.yaml",
+	}, Sources(resources...))
+	assert.Equal(t, "v1/Pod/default/web", Key(pod))
+
+	// identical resources rendered from several charts keep all their sources
+	resources = append(resources, Resource{Resource: pod.DeepCopy(), Source: "other/templates/pod.yaml"})
+	assert.Equal(t, "templates/pod.yaml, other/templates/pod.yaml", Sources(resources...)[Key(pod)])
+}
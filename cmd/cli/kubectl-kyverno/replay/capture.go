@@ -0,0 +1,68 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+)
+
+// Capture is an admission request captured by the admission controller.
+type Capture struct {
+	// Path is the file the capture was loaded from.
+	Path   string
+	Review handlers.CapturedAdmissionReview
+}
+
+// Load loads the captures from the given files and directories, directories are walked for json files.
+func Load(paths ...string) ([]Capture, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var found []string
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && filepath.Ext(file) == ".json" {
+				found = append(found, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	captures := make([]Capture, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		capture := Capture{Path: file}
+		if err := json.Unmarshal(data, &capture.Review); err != nil {
+			return nil, fmt.Errorf("failed to decode capture %s (%w)", file, err)
+		}
+		if capture.Review.Request == nil {
+			return nil, fmt.Errorf("capture %s has no request", file)
+		}
+		if capture.Review.Response == nil {
+			return nil, fmt.Errorf("capture %s has no response", file)
+		}
+		captures = append(captures, capture)
+	}
+	return captures, nil
+}
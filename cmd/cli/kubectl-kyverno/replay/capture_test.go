@@ -0,0 +1,69 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+const review = `{
+  "apiVersion": "admission.k8s.io/v1",
+  "kind": "AdmissionReview",
+  "request": {
+    "uid": "42",
+    "kind": {"group": "", "version": "v1", "kind": "Pod"},
+    "resource": {"group": "", "version": "v1", "resource": "pods"},
+    "name": "nginx",
+    "namespace": "default",
+    "operation": "UPDATE",
+    "userInfo": {"username": "alice"},
+    "object": {"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "nginx", "namespace": "default"}},
+    "oldObject": {"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "nginx", "namespace": "default"}},
+    "dryRun": true
+  },
+  "response": {"uid": "42", "allowed": true},
+  "webhook": "VALIDATE",
+  "failurePolicy": "fail",
+  "roles": ["default:developer"],
+  "groupVersionKind": {"group": "", "version": "v1", "kind": "Pod"},
+  "requestTime": "2026-01-01T00:00:00Z"
+}`
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "2-request.json"), []byte(review), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1-request.json"), []byte(review), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+
+	captures, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, captures, 2)
+	assert.Equal(t, filepath.Join(dir, "1-request.json"), captures[0].Path)
+	assert.Equal(t, filepath.Join(dir, "nested", "2-request.json"), captures[1].Path)
+	capture := captures[0].Review
+	assert.Equal(t, "VALIDATE", capture.Webhook)
+	assert.Equal(t, "fail", capture.FailurePolicy)
+	assert.Equal(t, []string{"default:developer"}, capture.Roles)
+	assert.Equal(t, admissionv1.Update, capture.Request.Operation)
+	assert.Equal(t, "alice", capture.Request.UserInfo.Username)
+	assert.NotEmpty(t, capture.Request.OldObject.Raw)
+	assert.True(t, *capture.Request.DryRun)
+	assert.True(t, capture.Response.Allowed)
+	assert.Equal(t, 2026, capture.RequestTime.Year())
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "request.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"apiVersion": "admission.k8s.io/v1", "kind": "AdmissionReview"}`), 0o600))
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "has no request")
+
+	_, err = Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,271 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	vpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
+	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
+	fakekyvernov1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/exceptions"
+	imageverifycache "github.com/kyverno/kyverno/pkg/image/verification/cache"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	utils "github.com/kyverno/kyverno/pkg/utils/restmapper"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/vpol"
+	"github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
+	"github.com/kyverno/sdk/extensions/registryclient"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+type webhookHandler = func(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) handlers.AdmissionResponse
+
+// Webhooks lists the webhooks whose captured requests can be replayed, they are the only webhooks capturing requests.
+// Requests served by MutatingPolicy, ImageValidatingPolicy and GeneratingPolicy webhooks are not captured.
+var Webhooks = []string{"MUTATE", "VALIDATE", "VPOL", "NVPOL"}
+
+// Replayer re-evaluates captured admission requests through the admission controller webhook handlers.
+type Replayer struct {
+	webhooks map[string]webhookHandler
+}
+
+// checkPolicies rejects the policies served by webhooks that do not capture their requests,
+// replaying without them would silently report different decisions.
+func checkPolicies(policies *policy.LoaderResults) error {
+	var unsupported []metav1.Object
+	for _, pol := range policies.MutatingPolicies {
+		unsupported = append(unsupported, pol)
+	}
+	for _, pol := range policies.ImageValidatingPolicies {
+		unsupported = append(unsupported, pol)
+	}
+	for _, pol := range policies.GeneratingPolicies {
+		unsupported = append(unsupported, pol)
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(unsupported))
+	for _, pol := range unsupported {
+		names = append(names, pol.GetName())
+	}
+	return fmt.Errorf("policies %s can not be replayed, requests served by MutatingPolicy, ImageValidatingPolicy and GeneratingPolicy webhooks are not captured", strings.Join(names, ", "))
+}
+
+// New creates a replayer evaluating the captures against the given policies.
+// Namespaces referenced by the captures exist with the given labels, or without labels.
+func New(ctx context.Context, policies *policy.LoaderResults, captures []Capture, namespaceLabels map[string]map[string]string) (*Replayer, error) {
+	if err := checkPolicies(policies); err != nil {
+		return nil, err
+	}
+	names := sets.New[string]()
+	for _, capture := range captures {
+		if namespace := capture.Review.Request.Namespace; namespace != "" {
+			names.Insert(namespace)
+		}
+	}
+	for name := range namespaceLabels {
+		names.Insert(name)
+	}
+	namespaces := make([]runtime.Object, 0, names.Len())
+	for _, name := range sets.List(names) {
+		namespaces = append(namespaces, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: namespaceLabels[name]}})
+	}
+	kubeClient := kubefake.NewSimpleClientset(namespaces...)
+	kubeInformer := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	nsLister := kubeInformer.Core().V1().Namespaces().Lister()
+	kyvernoPolicies := make([]kyvernov1.PolicyInterface, 0, len(policies.Policies))
+	objects := make([]runtime.Object, 0, len(policies.Policies)+len(policies.PolicyExceptions))
+	for _, pol := range policies.Policies {
+		if pol.IsNamespaced() && pol.GetNamespace() == "" {
+			pol = pol.CreateDeepCopy()
+			pol.SetNamespace("default")
+		}
+		kyvernoPolicies = append(kyvernoPolicies, pol)
+		objects = append(objects, pol.(runtime.Object))
+	}
+	for _, exception := range policies.PolicyExceptions {
+		objects = append(objects, exception)
+	}
+	kyvernoClient := fakekyvernov1.NewSimpleClientset(objects...)
+	kyvernoInformer := kyvernoinformers.NewSharedInformerFactory(kyvernoClient, 0)
+	cpolInformer := kyvernoInformer.Kyverno().V1().ClusterPolicies()
+	polInformer := kyvernoInformer.Kyverno().V1().Policies()
+	peLister := kyvernoInformer.Kyverno().V2().PolicyExceptions().Lister()
+	urLister := kyvernoInformer.Kyverno().V2().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace())
+	kubeInformer.Start(ctx.Done())
+	kyvernoInformer.Start(ctx.Done())
+	kubeInformer.WaitForCacheSync(ctx.Done())
+	kyvernoInformer.WaitForCacheSync(ctx.Done())
+	finder := newResourceFinder(captures)
+	pCache := policycache.NewCache()
+	for _, pol := range kyvernoPolicies {
+		key, err := cache.MetaNamespaceKeyFunc(pol)
+		if err != nil {
+			return nil, err
+		}
+		if err := pCache.Set(key, pol, finder); err != nil {
+			return nil, err
+		}
+	}
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	client := dclient.NewEmptyFakeClient()
+	var s store.Store
+	s.SetLocal(true)
+	isCluster := false
+	eng := engine.NewEngine(
+		cfg,
+		jp,
+		adapters.Client(client),
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.New(nil, "", "", "", false)), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		store.ContextLoaderFactory(&s, nil),
+		exceptions.New(peLister),
+		&isCluster,
+	)
+	resourceHandlers := webhooksresource.NewHandlers(
+		eng,
+		client,
+		kyvernoClient,
+		cfg,
+		metrics.NewFakeMetricsConfig(),
+		pCache,
+		nsLister,
+		urLister,
+		cpolInformer,
+		polInformer,
+		updaterequest.NewFake(),
+		event.NewFake(),
+		false,
+		"",
+		"",
+		jp,
+		1,
+		1,
+	)
+	restMapper, err := utils.GetRESTMapper(nil)
+	if err != nil {
+		return nil, err
+	}
+	contextProvider, err := processor.NewContextProvider(nil, restMapper, nil, "", false, true, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	vpolProvider, err := vpolengine.NewProvider(vpolcompiler.NewCompiler(), policies.ValidatingPolicies, policies.PolicyCelExceptions)
+	if err != nil {
+		return nil, err
+	}
+	vpolEngine := vpolengine.NewEngine(vpolProvider, celengine.NewNamespaceResolver(logr.Discard(), nsLister, kubeClient), matching.NewMatcher())
+	vpolHandlers := vpol.New(vpolEngine, contextProvider, kyvernoClient, false, event.NewFake(), nsLister)
+	return &Replayer{
+		webhooks: map[string]webhookHandler{
+			"MUTATE":   resourceHandlers.Mutate,
+			"VALIDATE": resourceHandlers.Validate,
+			"VPOL":     vpolHandlers.ValidateClustered,
+			"NVPOL":    vpolHandlers.ValidateNamespaced,
+		},
+	}, nil
+}
+
+// Replay re-evaluates a captured admission request with the webhook handler that served it.
+func (r *Replayer) Replay(ctx context.Context, capture Capture) (handlers.AdmissionResponse, error) {
+	review := capture.Review
+	handler, ok := r.webhooks[review.Webhook]
+	if !ok {
+		return handlers.AdmissionResponse{}, fmt.Errorf("webhook %q can not be replayed, only %s webhooks can be replayed", review.Webhook, strings.Join(Webhooks, ", "))
+	}
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: *review.Request.DeepCopy(),
+		Roles:            review.Roles,
+		ClusterRoles:     review.ClusterRoles,
+		GroupVersionKind: schema.GroupVersionKind(review.GroupVersionKind),
+		URLParams:        review.URLParams,
+	}
+	// CEL policy webhooks read the evaluated policies from the route parameters
+	params := httprouter.Params{{Key: "policies", Value: "/" + strings.Join(review.Policies, "/")}}
+	ctx = context.WithValue(ctx, httprouter.ParamsKey, params)
+	startTime := review.RequestTime.Time
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+	return handler(ctx, logr.Discard(), request, review.FailurePolicy, startTime), nil
+}
+
+// resourceFinder resolves policy kind selectors against the resources of the captured requests,
+// it replaces API discovery when building the policy cache.
+type resourceFinder []dclient.TopLevelApiDescription
+
+func newResourceFinder(captures []Capture) resourceFinder {
+	apis := sets.New[dclient.TopLevelApiDescription]()
+	for _, capture := range captures {
+		request := capture.Review.Request
+		api := dclient.TopLevelApiDescription{
+			GroupVersion: schema.GroupVersion{Group: request.Resource.Group, Version: request.Resource.Version},
+			Kind:         capture.Review.GroupVersionKind.Kind,
+			Resource:     request.Resource.Resource,
+		}
+		if api.Kind == "" {
+			api.Kind = request.Kind.Kind
+		}
+		apis.Insert(api)
+		if request.SubResource != "" {
+			apis.Insert(api.WithSubResource(request.SubResource))
+		}
+	}
+	return apis.UnsortedList()
+}
+
+func (f resourceFinder) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	resources := map[dclient.TopLevelApiDescription]metav1.APIResource{}
+	for _, api := range f {
+		if !wildcard.Match(group, api.Group) || !wildcard.Match(version, api.Version) || !wildcard.Match(kind, api.Kind) {
+			continue
+		}
+		switch {
+		case kind == "*" && subresource == "*":
+		case subresource == "":
+			if api.SubResource != "" {
+				continue
+			}
+		default:
+			if api.SubResource == "" || !wildcard.Match(subresource, api.SubResource) {
+				continue
+			}
+		}
+		name := api.Resource
+		if api.SubResource != "" {
+			name += "/" + api.SubResource
+		}
+		resources[api] = metav1.APIResource{Name: name, Kind: api.Kind, Group: api.Group, Version: api.Version}
+	}
+	return resources, nil
+}
@@ -0,0 +1,58 @@
+package replay
+
+import (
+	"testing"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourceFinder(t *testing.T) {
+	request := func(group, version, resource, subresource, kind string) Capture {
+		return Capture{Review: handlers.CapturedAdmissionReview{
+			AdmissionReview: admissionv1.AdmissionReview{Request: &admissionv1.AdmissionRequest{
+				Resource:    metav1.GroupVersionResource{Group: group, Version: version, Resource: resource},
+				SubResource: subresource,
+			}},
+			GroupVersionKind: metav1.GroupVersionKind{Group: group, Version: version, Kind: kind},
+		}}
+	}
+	finder := newResourceFinder([]Capture{
+		request("", "v1", "pods", "", "Pod"),
+		request("apps", "v1", "deployments", "scale", "Deployment"),
+	})
+	pods := dclient.TopLevelApiDescription{GroupVersion: schema.GroupVersion{Version: "v1"}, Kind: "Pod", Resource: "pods"}
+	deployments := dclient.TopLevelApiDescription{GroupVersion: schema.GroupVersion{Group: "apps", Version: "v1"}, Kind: "Deployment", Resource: "deployments"}
+	scale := deployments.WithSubResource("scale")
+	keys := func(group, version, kind, subresource string) []dclient.TopLevelApiDescription {
+		resources, err := finder.FindResources(group, version, kind, subresource)
+		assert.NoError(t, err)
+		var out []dclient.TopLevelApiDescription
+		for api := range resources {
+			out = append(out, api)
+		}
+		return out
+	}
+	assert.ElementsMatch(t, []dclient.TopLevelApiDescription{pods}, keys("*", "*", "Pod", ""))
+	assert.ElementsMatch(t, []dclient.TopLevelApiDescription{pods, deployments}, keys("*", "*", "*", ""))
+	assert.ElementsMatch(t, []dclient.TopLevelApiDescription{deployments}, keys("apps", "v1", "Deployment", ""))
+	assert.ElementsMatch(t, []dclient.TopLevelApiDescription{scale}, keys("*", "*", "Deployment", "scale"))
+	assert.ElementsMatch(t, []dclient.TopLevelApiDescription{pods, deployments, scale}, keys("*", "*", "*", "*"))
+	assert.Empty(t, keys("*", "*", "Service", ""))
+	assert.Empty(t, keys("*", "*", "Pod", "status"))
+}
+
+func TestCheckPolicies(t *testing.T) {
+	assert.NoError(t, checkPolicies(&policy.LoaderResults{}))
+	err := checkPolicies(&policy.LoaderResults{
+		MutatingPolicies:        []policiesv1beta1.MutatingPolicyLike{&policiesv1beta1.MutatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "add-labels"}}},
+		ImageValidatingPolicies: []policiesv1beta1.ImageValidatingPolicyLike{&policiesv1beta1.ImageValidatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify-images"}}},
+	})
+	assert.EqualError(t, err, "policies add-labels, verify-images can not be replayed, requests served by MutatingPolicy, ImageValidatingPolicy and GeneratingPolicy webhooks are not captured")
+}
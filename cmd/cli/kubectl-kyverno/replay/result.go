@@ -0,0 +1,121 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+)
+
+// Result holds the captured and replayed decisions of an admission request.
+type Result struct {
+	Capture  Capture
+	Replayed handlers.AdmissionResponse
+	// Error is set when the request could not be replayed.
+	Error error
+}
+
+// Differences returns how the replayed decision differs from the captured one.
+func (r Result) Differences() []string {
+	if r.Error != nil {
+		return []string{r.Error.Error()}
+	}
+	captured := r.Capture.Review.Response
+	var differences []string
+	if captured.Allowed != r.Replayed.Allowed {
+		differences = append(differences, fmt.Sprintf("allowed: %t != %t", captured.Allowed, r.Replayed.Allowed))
+	}
+	if message(captured) != message(&r.Replayed) {
+		differences = append(differences, fmt.Sprintf("message: %q != %q", message(captured), message(&r.Replayed)))
+	}
+	if !samePatch(captured.Patch, r.Replayed.Patch) {
+		differences = append(differences, fmt.Sprintf("patch: %s != %s", captured.Patch, r.Replayed.Patch))
+	}
+	if !reflect.DeepEqual(nonEmpty(captured.Warnings), nonEmpty(r.Replayed.Warnings)) {
+		differences = append(differences, fmt.Sprintf("warnings: %q != %q", captured.Warnings, r.Replayed.Warnings))
+	}
+	return differences
+}
+
+// Row is the replay summary of an admission request.
+type Row struct {
+	Request   string `header:"request"`
+	Webhook   string `header:"webhook"`
+	Operation string `header:"operation"`
+	Resource  string `header:"resource"`
+	Captured  string `header:"captured"`
+	Replayed  string `header:"replayed"`
+	Match     bool   `header:"match"`
+}
+
+// Rows summarizes the results.
+func Rows(results ...Result) []Row {
+	rows := make([]Row, 0, len(results))
+	for _, result := range results {
+		request := result.Capture.Review.Request
+		resource := request.Resource.Resource
+		if request.SubResource != "" {
+			resource += "/" + request.SubResource
+		}
+		if request.Namespace != "" {
+			resource += "/" + request.Namespace
+		}
+		resource += "/" + request.Name
+		replayed := "error"
+		if result.Error == nil {
+			replayed = decision(&result.Replayed)
+		}
+		rows = append(rows, Row{
+			Request:   filepath.Base(result.Capture.Path),
+			Webhook:   result.Capture.Review.Webhook,
+			Operation: string(request.Operation),
+			Resource:  resource,
+			Captured:  decision(result.Capture.Review.Response),
+			Replayed:  replayed,
+			Match:     len(result.Differences()) == 0,
+		})
+	}
+	return rows
+}
+
+func decision(response *handlers.AdmissionResponse) string {
+	switch {
+	case !response.Allowed:
+		return "denied"
+	case len(response.Patch) != 0:
+		return "mutated"
+	default:
+		return "allowed"
+	}
+}
+
+func message(response *handlers.AdmissionResponse) string {
+	if response.Result == nil {
+		return ""
+	}
+	return strings.TrimSpace(response.Result.Message)
+}
+
+func samePatch(left, right []byte) bool {
+	if len(left) == 0 || len(right) == 0 {
+		return len(left) == len(right)
+	}
+	var l, r interface{}
+	if err := json.Unmarshal(left, &l); err != nil {
+		return string(left) == string(right)
+	}
+	if err := json.Unmarshal(right, &r); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+func nonEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,115 @@
+package replay
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func capture(response handlers.AdmissionResponse) Capture {
+	return Capture{
+		Path: "/captures/1-42-validate.json",
+		Review: handlers.CapturedAdmissionReview{
+			AdmissionReview: admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UID:       "42",
+					Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+					Name:      "nginx",
+					Namespace: "default",
+					Operation: admissionv1.Create,
+				},
+				Response: &response,
+			},
+			Webhook: "VALIDATE",
+		},
+	}
+}
+
+func TestDifferences(t *testing.T) {
+	denied := handlers.AdmissionResponse{Result: &metav1.Status{Message: "label app is required"}}
+	tests := []struct {
+		name     string
+		captured handlers.AdmissionResponse
+		replayed handlers.AdmissionResponse
+		err      error
+		want     []string
+	}{{
+		name:     "same denial",
+		captured: denied,
+		replayed: handlers.AdmissionResponse{Result: &metav1.Status{Message: "label app is required\n"}},
+	}, {
+		name:     "allowed instead of denied",
+		captured: denied,
+		replayed: handlers.AdmissionResponse{Allowed: true},
+		want:     []string{"allowed: false != true", `message: "label app is required" != ""`},
+	}, {
+		name:     "equivalent patches",
+		captured: handlers.AdmissionResponse{Allowed: true, Patch: []byte(`[{"op":"add","path":"/metadata/labels","value":{"app":"nginx"}}]`)},
+		replayed: handlers.AdmissionResponse{Allowed: true, Patch: []byte(`[{"path":"/metadata/labels","op":"add","value":{"app":"nginx"}}]`)},
+	}, {
+		name:     "missing patch",
+		captured: handlers.AdmissionResponse{Allowed: true, Patch: []byte(`[]`)},
+		replayed: handlers.AdmissionResponse{Allowed: true},
+		want:     []string{"patch: [] != "},
+	}, {
+		name:     "warnings",
+		captured: handlers.AdmissionResponse{Allowed: true, Warnings: []string{}},
+		replayed: handlers.AdmissionResponse{Allowed: true, Warnings: []string{"policy warn: failed"}},
+		want:     []string{`warnings: [] != ["policy warn: failed"]`},
+	}, {
+		name:     "replay error",
+		captured: denied,
+		err:      errors.New(`webhook "GENERATE" can not be replayed`),
+		want:     []string{`webhook "GENERATE" can not be replayed`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Result{Capture: capture(tt.captured), Replayed: tt.replayed, Error: tt.err}
+			assert.Equal(t, tt.want, result.Differences())
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	rows := Rows(
+		Result{
+			Capture:  capture(handlers.AdmissionResponse{Result: &metav1.Status{Message: "denied"}}),
+			Replayed: handlers.AdmissionResponse{Result: &metav1.Status{Message: "denied"}},
+		},
+		Result{
+			Capture:  capture(handlers.AdmissionResponse{Allowed: true}),
+			Replayed: handlers.AdmissionResponse{Allowed: true, Patch: []byte(`[{"op":"remove","path":"/spec"}]`)},
+		},
+		Result{
+			Capture: capture(handlers.AdmissionResponse{Allowed: true}),
+			Error:   errors.New("boom"),
+		},
+	)
+	assert.Equal(t, []Row{{
+		Request:   "1-42-validate.json",
+		Webhook:   "VALIDATE",
+		Operation: "CREATE",
+		Resource:  "pods/default/nginx",
+		Captured:  "denied",
+		Replayed:  "denied",
+		Match:     true,
+	}, {
+		Request:   "1-42-validate.json",
+		Webhook:   "VALIDATE",
+		Operation: "CREATE",
+		Resource:  "pods/default/nginx",
+		Captured:  "allowed",
+		Replayed:  "mutated",
+	}, {
+		Request:   "1-42-validate.json",
+		Webhook:   "VALIDATE",
+		Operation: "CREATE",
+		Resource:  "pods/default/nginx",
+		Captured:  "allowed",
+		Replayed:  "error",
+	}}, rows)
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestBuildHTTPMockIndex(t *testing.T) {
+	index, err := BuildHTTPMockIndex(nil)
+	require.NoError(t, err)
+	assert.Nil(t, index)
+	index, err = BuildHTTPMockIndex([]v1alpha1.APICallResponseEntry{{
+		URL: "https://example.com/allowed",
+		Response: v1alpha1.APICallResponse{
+			Body: runtime.RawExtension{Raw: []byte(`{"allowed":true}`)},
+		},
+	}, {
+		URL:    "https://example.com/check",
+		Method: "post",
+		Response: v1alpha1.APICallResponse{
+			StatusCode: 403,
+			Body:       runtime.RawExtension{Raw: []byte(`"denied"`)},
+		},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"https://example.com/allowed": map[string]interface{}{
+			"body":       map[string]interface{}{"allowed": true},
+			"statusCode": 200,
+		},
+		"POST:https://example.com/check": map[string]interface{}{
+			"body":       "denied",
+			"statusCode": 403,
+		},
+	}, index)
+}
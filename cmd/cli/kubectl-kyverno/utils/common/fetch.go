@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -176,6 +177,23 @@ func (rf *ResourceFetcher) getFromCluster() ([]*unstructured.Unstructured, error
 	return resources, nil
 }
 
+// Kinds returns the kinds of the resources matched by the policies, subresources excluded.
+func (rf *ResourceFetcher) Kinds() []schema.GroupVersionKind {
+	info := &resourceTypeInfo{
+		gvkMap:         make(map[schema.GroupVersionKind]bool),
+		subresourceMap: make(map[schema.GroupVersionKind]v1alpha1.Subresource),
+	}
+	rf.extractResourcesFromPolicies(info)
+	kinds := make([]schema.GroupVersionKind, 0, len(info.gvkMap))
+	for gvk := range info.gvkMap {
+		kinds = append(kinds, gvk)
+	}
+	slices.SortFunc(kinds, func(a, b schema.GroupVersionKind) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return kinds
+}
+
 func (rf *ResourceFetcher) extractResourcesFromPolicies(info *resourceTypeInfo) {
 	for _, policy := range rf.Policies {
 		if kpol := policy.AsKyvernoPolicy(); kpol != nil {
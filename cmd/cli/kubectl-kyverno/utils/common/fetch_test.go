@@ -306,3 +306,27 @@ func TestGetFromCluster_HyphenatedResource(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceFetcher_Kinds(t *testing.T) {
+	dClient, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{})
+	if err != nil {
+		t.Fatalf("failed to create fake client: %v", err)
+	}
+	dClient.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{
+		{Group: "", Version: "v1", Resource: "pods"},
+	}))
+	rf := &ResourceFetcher{
+		Client: dClient,
+		Policies: []engineapi.GenericPolicy{
+			engineapi.NewMutatingPolicy(&policiesv1beta1.MutatingPolicy{
+				Spec: policiesv1beta1.MutatingPolicySpec{
+					MatchConstraints: makeMatchResources("", "pods"),
+				},
+			}),
+		},
+	}
+	kinds := rf.Kinds()
+	if len(kinds) != 1 || kinds[0] != (schema.GroupVersionKind{Version: "v1", Kind: "Pod"}) {
+		t.Errorf("expected v1/Pod, got %v", kinds)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/latency"
 	imageverifycache "github.com/kyverno/kyverno/pkg/image/verification/cache"
 	"github.com/kyverno/sdk/extensions/registryclient"
 	"k8s.io/client-go/kubernetes"
@@ -38,6 +39,7 @@ func NewEngine(
 	apiCallConfig apicall.APICallConfiguration,
 	exceptionsSelector engineapi.PolicyExceptionSelector,
 	gctxStore loaders.Store,
+	latencyTracker latency.Tracker,
 ) engineapi.Engine {
 	configMapResolver := NewConfigMapResolver(ctx, logger, kubeClient, resyncPeriod)
 	logger = logger.WithName("engine")
@@ -48,9 +50,15 @@ func NewEngine(
 		adapters.Client(client),
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.MustRegistryClient()), secretLister),
 		ivCache,
-		factories.DefaultContextLoaderFactory(configMapResolver, factories.WithAPICallConfig(apiCallConfig), factories.WithGlobalContextStore(gctxStore)),
+		factories.DefaultContextLoaderFactory(
+			configMapResolver,
+			factories.WithAPICallConfig(apiCallConfig),
+			factories.WithGlobalContextStore(gctxStore),
+			factories.WithLatencyTracker(latencyTracker),
+		),
 		exceptionsSelector,
 		nil,
+		engine.WithLatencyTracker(latencyTracker),
 	)
 }
 
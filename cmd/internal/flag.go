@@ -8,6 +8,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/toggle"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/sigstore/sigstore/pkg/tuf"
 )
 
@@ -25,6 +26,9 @@ var (
 	tracingAddress string
 	tracingPort    string
 	tracingCreds   string
+	tracingProto   string
+	tracingHeaders string
+	tracingRatio   float64
 	// metrics
 	otel                 string
 	otelCollector        string
@@ -60,7 +64,8 @@ var (
 	allowInsecureRegistry     bool
 	registryCredentialHelpers string
 	// leader election
-	leaderElectionRetryPeriod time.Duration
+	leaderElectionRetryPeriod  time.Duration
+	leaderElectionAdminAddress string
 	// cleanupServer port and host for listening address
 	cleanupServerHost string
 	cleanupServerPort int
@@ -103,9 +108,12 @@ func initProfilingFlags() {
 
 func initTracingFlags() {
 	flag.BoolVar(&tracingEnabled, "enableTracing", false, "Set this flag to 'true', to enable tracing.")
-	flag.StringVar(&tracingPort, "tracingPort", "4317", "Tracing receiver port, defaults to '4317'.")
+	flag.StringVar(&tracingPort, "tracingPort", "", "Tracing receiver port, defaults to '4317' for the 'grpc' protocol and '4318' for the 'http' protocol.")
 	flag.StringVar(&tracingAddress, "tracingAddress", "", "Tracing receiver address, defaults to ''.")
 	flag.StringVar(&tracingCreds, "tracingCreds", "", "Set this flag to the CA secret containing the certificate which is used by our Opentelemetry Tracing Client. If empty string is set, means an insecure connection will be used")
+	flag.StringVar(&tracingProto, "tracingProtocol", tracing.ProtocolGRPC, "Tracing receiver protocol, supported values are 'grpc' and 'http', defaults to 'grpc'.")
+	flag.StringVar(&tracingHeaders, "tracingHeaders", "", "Comma separated list of key=value headers sent to the tracing receiver, typically used for authentication. Prefer the OTEL_EXPORTER_OTLP_TRACES_HEADERS environment variable for secret values.")
+	flag.Float64Var(&tracingRatio, "tracingSamplingRatio", 1, "Ratio of root spans being sampled, between 0 and 1. Child spans follow the sampling decision of their parent.")
 }
 
 func initMetricsFlags() {
@@ -170,6 +178,7 @@ func initImageVerifyCacheFlags() {
 
 func initLeaderElectionFlags() {
 	flag.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flag.StringVar(&leaderElectionAdminAddress, "leaderElectionAdminAddress", "", "Address of the leader election admin server, serving the leader election status and allowing to release the leadership (e.g. 127.0.0.1:6061). The admin server is disabled when empty.")
 }
 
 func initCleanupFlags() {
@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/leaderelection"
+)
+
+func SetupLeaderElectionAdmin(logger logr.Logger, le leaderelection.Interface) {
+	if leaderElectionAdminAddress == "" {
+		return
+	}
+	leaderelection.StartAdmin(logger.WithName("leader-election-admin"), leaderElectionAdminAddress, le)
+}
+
+// RunLeaderElection runs the leader election until the context is done. The leader controllers and
+// the informers they registered handlers on can't be reused for another term, when the leadership
+// is lost or released the process exits so that it restarts and takes part in the election again.
+func RunLeaderElection(ctx context.Context, logger logr.Logger, le leaderelection.Interface) {
+	le.Run(ctx)
+	if ctx.Err() == nil {
+		logger.Info("leadership ended, exiting to take part in the election again")
+		os.Exit(0)
+	}
+}
@@ -2,7 +2,9 @@ package internal
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/tracing"
@@ -10,18 +12,43 @@ import (
 )
 
 func SetupTracing(logger logr.Logger, name string, kubeClient kubernetes.Interface) context.CancelFunc {
-	logger = logger.WithName("tracing").WithValues("enabled", tracingEnabled, "name", name, "address", tracingAddress, "port", tracingPort, "creds", tracingCreds)
+	logger = logger.WithName("tracing").WithValues("enabled", tracingEnabled, "name", name, "address", tracingAddress, "port", tracingPort, "creds", tracingCreds, "protocol", tracingProto, "samplingRatio", tracingRatio)
 	if tracingEnabled {
 		logger.V(2).Info("setup tracing...")
+		headers, err := parseTracingHeaders(tracingHeaders)
+		checkError(logger, err, "failed to parse tracing headers")
+		port := tracingPort
+		if port == "" {
+			port = tracing.DefaultPort(tracingProto)
+		}
 		shutdown, err := tracing.NewTraceConfig(
 			logger,
 			name,
-			net.JoinHostPort(tracingAddress, tracingPort),
+			net.JoinHostPort(tracingAddress, port),
 			tracingCreds,
 			kubeClient,
+			tracing.WithProtocol(tracingProto),
+			tracing.WithHeaders(headers),
+			tracing.WithSamplingRatio(tracingRatio),
 		)
 		checkError(logger, err, "failed to setup tracing")
 		return shutdown
 	}
 	return func() {}
 }
+
+func parseTracingHeaders(in string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, header := range strings.Split(in, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(header, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid tracing header %q, expected key=value", header)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
@@ -37,9 +37,13 @@ import (
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	updaterequestmetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/updaterequest"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
+	policylatencycontroller "github.com/kyverno/kyverno/pkg/controllers/policylatency"
+	policysourcecontroller "github.com/kyverno/kyverno/pkg/controllers/policysource"
 	policystatuscontroller "github.com/kyverno/kyverno/pkg/controllers/policystatus"
+	rolloutcontroller "github.com/kyverno/kyverno/pkg/controllers/rollout"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/latency"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/informers"
@@ -93,6 +97,8 @@ var (
 	caSecretName                 string
 	tlsSecretName                string
 	disableCertManagerController bool
+	externalCertificates         bool
+	enablePolicySources          bool
 )
 
 func showWarnings(ctx context.Context, logger logr.Logger) {
@@ -132,6 +138,7 @@ func createNonLeaderControllers(
 func createrLeaderControllers(
 	admissionReports bool,
 	serverIP string,
+	backgroundServiceAccountName string,
 	reportsServiceAccountName string,
 	webhookTimeout int,
 	autoUpdateWebhooks bool,
@@ -151,9 +158,34 @@ func createrLeaderControllers(
 	configuration config.Configuration,
 	eventGenerator event.Interface,
 	stateRecorder webhookcontroller.StateRecorder,
+	policyLatencyBudget time.Duration,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	var leaderControllers []internal.Controller
-	if !disableCertManagerController {
+	if policyLatencyBudget > 0 {
+		latencyStatusController := policylatencycontroller.NewStatusController(
+			kyvernoClient,
+			kubeClient.CoordinationV1(),
+			kubeKyvernoInformer.Coordination().V1().Leases(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			config.KyvernoNamespace(),
+			eventGenerator,
+			policyLatencyBudget,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(policylatencycontroller.StatusControllerName, latencyStatusController, policylatencycontroller.Workers))
+	}
+	if externalCertificates {
+		certController := certmanager.NewExternalController(
+			caInformer,
+			tlsInformer,
+			kubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+			caSecretName,
+			tlsSecretName,
+			config.KyvernoNamespace(),
+			config.InClusterServiceName(config.KyvernoServiceName(), config.KyvernoNamespace()),
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(certmanager.ExternalControllerName, certController, certmanager.Workers))
+	} else if !disableCertManagerController {
 		certManager := certmanager.NewController(
 			caInformer,
 			tlsInformer,
@@ -294,6 +326,27 @@ func createrLeaderControllers(
 	leaderControllers = append(leaderControllers, internal.NewController(celExceptionWebhookControllerName, celExceptionWebhookController, 1))
 	leaderControllers = append(leaderControllers, internal.NewController(gctxWebhookControllerName, gctxWebhookController, 1))
 	leaderControllers = append(leaderControllers, internal.NewController(policystatuscontroller.ControllerName, policyStatusController, policystatuscontroller.Workers))
+	rolloutController := rolloutcontroller.NewController(
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		kyvernoInformer.Kyverno().V1().Policies(),
+		kyvernoInformer.Policies().V1beta1().ValidatingPolicies(),
+		kyvernoInformer.Policies().V1beta1().NamespacedValidatingPolicies(),
+	)
+	leaderControllers = append(leaderControllers, internal.NewController(rolloutcontroller.ControllerName, rolloutController, rolloutcontroller.Workers))
+	if enablePolicySources {
+		policySourceController := policysourcecontroller.NewController(
+			kyvernoClient,
+			dynamicClient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicySources(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			kubeKyvernoInformer.Core().V1().Secrets().Lister(),
+			backgroundServiceAccountName,
+			reportsServiceAccountName,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(policysourcecontroller.ControllerName, policySourceController, policysourcecontroller.Workers))
+	}
 
 	vapsRegistered, _ := admissionpolicy.IsValidatingAdmissionPolicyRegistered(kubeClient)
 	mapVersion, mapVersionErr := admissionpolicy.PreferredMutatingAdmissionPolicyVersion(kubeClient)
@@ -375,6 +428,8 @@ func main() {
 		webhookRegistrationTimeout      time.Duration
 		admissionReports                bool
 		dumpPayload                     bool
+		captureDir                      string
+		captureLimit                    int
 		servicePort                     int
 		webhookServerHost               string
 		webhookServerPort               int
@@ -389,9 +444,15 @@ func main() {
 		maxGlobalContextEntries         int
 		controllerRuntimeMetricsAddress string
 		tlsKeyAlgorithm                 string
+		policyLatencyBudget             time.Duration
+		policyLatencyShortCircuit       bool
+		policyLatencyCooldown           time.Duration
+		updateRequestBatchWindow        time.Duration
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
+	flagset.StringVar(&captureDir, "captureAdmissionRequests", "", "Directory where admission requests served by the MUTATE, VALIDATE, VPOL and NVPOL webhooks are captured for offline replay, capture is disabled when empty.")
+	flagset.IntVar(&captureLimit, "captureAdmissionRequestsLimit", 1000, "Maximum number of admission requests captured in the capture directory, zero or less means no limit.")
 	flagset.IntVar(&webhookTimeout, "webhookTimeout", webhookcontroller.DefaultWebhookTimeout, "Timeout for webhook configurations (number of seconds, integer).")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
@@ -416,6 +477,8 @@ func main() {
 	flagset.StringVar(&caSecretName, "caSecretName", "", "Name of the secret containing CA.")
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
 	flagset.BoolVar(&disableCertManagerController, "disableCertManagerController", false, "Disable the in-process certificate manager controller.")
+	flagset.BoolVar(&externalCertificates, "externalCertificates", false, "Use the TLS secret issued by an external certificate provider instead of self-signed certificates, the secret must contain the issuing CA in ca.crt.")
+	flagset.BoolVar(&enablePolicySources, "enablePolicySources", false, "Enable the controller synchronizing policies from PolicySource resources (OCI images and Git repositories).")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 10*1000*1000, "Configure the value of maximum allowed GET response size from API Calls")
 	flagset.DurationVar(&apiCallTimeout, "apiCallTimeout", 30*time.Second, "Timeout for HTTP API calls made by policies. A value of 0 means no timeout.")
 	flagset.DurationVar(&renewBefore, "renewBefore", 15*24*time.Hour, "The certificate renewal time before expiration")
@@ -425,6 +488,10 @@ func main() {
 	flagset.IntVar(&maxGlobalContextEntries, "maxGlobalContextEntries", 0, "Maximum number of entries in the global context store. When the limit is reached, new entries are rejected and retried. A value of 0 means unbounded.")
 	flagset.StringVar(&controllerRuntimeMetricsAddress, "controllerRuntimeMetricsAddress", "", `Bind address for controller-runtime metrics server. It will be defaulted to ":8080" if unspecified. Set this to "0" to disable the metrics server.`)
 	flagset.StringVar(&tlsKeyAlgorithm, "tlsKeyAlgorithm", "RSA", "Key algorithm for self-signed TLS certificates (RSA, ECDSA, Ed25519)")
+	flagset.DurationVar(&policyLatencyBudget, "policyLatencyBudget", 0, "Latency budget of policy rules and context entries, a rule or context entry with a p95 latency above the budget marks the policy as slow. A value of 0 disables latency tracking.")
+	flagset.BoolVar(&policyLatencyShortCircuit, "policyLatencyShortCircuit", false, "Set this flag to 'true' to skip evaluation of rules exceeding the latency budget and apply their failure policy instead.")
+	flagset.DurationVar(&policyLatencyCooldown, "policyLatencyCooldown", 5*time.Minute, "Duration a rule exceeding the latency budget is short circuited before being evaluated again.")
+	flagset.DurationVar(&updateRequestBatchWindow, "updateRequestBatchWindow", 0, "Window during which update requests for the same policy, rules and trigger are coalesced, only the most recent one is created. A value of 0 disables batching.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -520,6 +587,14 @@ func main() {
 			tlsSecretName,
 			keyAlgorithm,
 		)
+		var certValidator tls.CertValidator = certRenewer
+		if externalCertificates {
+			certValidator = tls.NewExternalCertValidator(
+				setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+				tlsSecretName,
+				config.InClusterServiceName(config.KyvernoServiceName(), config.KyvernoNamespace()),
+			)
+		}
 		policyCache := policycache.NewCache()
 		notifyChan := make(chan string)
 		stateRecorder := webhookcontroller.NewStateRecorder(notifyChan)
@@ -555,6 +630,27 @@ func main() {
 			eventGenerator,
 			event.Workers,
 		)
+		var latencyController internal.Controller
+		var latencyTracker latency.Tracker
+		if policyLatencyBudget > 0 {
+			var latencyOptions []latency.Option
+			if policyLatencyShortCircuit {
+				latencyOptions = append(latencyOptions, latency.WithShortCircuit(policyLatencyCooldown))
+			}
+			controller := policylatencycontroller.NewController(
+				setup.KubeClient.CoordinationV1(),
+				config.KyvernoNamespace(),
+				config.KyvernoPodName(),
+				policyLatencyBudget,
+				latencyOptions...,
+			)
+			latencyTracker = controller.Tracker()
+			latencyController = internal.NewController(
+				policylatencycontroller.ControllerName,
+				controller,
+				policylatencycontroller.Workers,
+			)
+		}
 		// this controller only subscribe to events, nothing is returned...
 		policymetricscontroller.NewController(
 			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
@@ -581,7 +677,7 @@ func main() {
 			setup.Logger.WithName("runtime-checks"),
 			serverIP,
 			kubeKyvernoInformer.Apps().V1().Deployments(),
-			certRenewer,
+			certValidator,
 		)
 		// engine
 		engine := internal.NewEngine(
@@ -597,6 +693,7 @@ func main() {
 			apicall.NewAPICallConfiguration(maxAPICallResponseLength, apiCallTimeout),
 			polexCache,
 			gcstore,
+			latencyTracker,
 		)
 		// create non leader controllers
 		nonLeaderControllers, nonLeaderBootstrap := createNonLeaderControllers(
@@ -633,6 +730,7 @@ func main() {
 				leaderControllers, warmup, err := createrLeaderControllers(
 					admissionReports,
 					serverIP,
+					backgroundServiceAccountName,
 					reportsServiceAccountName,
 					webhookTimeout,
 					autoUpdateWebhooks,
@@ -652,6 +750,7 @@ func main() {
 					setup.Configuration,
 					eventGenerator,
 					stateRecorder,
+					policyLatencyBudget,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
@@ -660,9 +759,9 @@ func main() {
 				// Ensure informers are synced before starting leader controllers.
 				// Informers were already started earlier, but this ensures any newly registered
 				// handlers from leader controller creation have received initial data.
-				// Note: Informers remain running after leadership loss (shared with webhook handlers);
-				// only controllers stop because they use ctx (leader context).
-				if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
+				// Note: Informers are started with signalCtx and remain running after leadership loss (shared
+				// with webhook handlers); only controllers stop because they use ctx (leader context).
+				if !internal.StartInformersAndWaitForCacheSync(signalCtx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 					os.Exit(1)
 				}
@@ -686,12 +785,14 @@ func main() {
 			setup.Logger.Error(err, "failed to initialize leader election")
 			os.Exit(1)
 		}
+		internal.SetupLeaderElectionAdmin(setup.Logger, le)
 		urGenerator := generator.NewUpdateRequestGenerator(setup.Configuration, setup.MetadataClient)
 		// create webhooks server
 		urgen := webhookgenerate.NewGenerator(
 			setup.KyvernoClient,
 			kyvernoInformer.Kyverno().V2().UpdateRequests(),
 			urGenerator,
+			webhookgenerate.WithBatchWindow(updateRequestBatchWindow),
 		)
 		policyHandlers := webhookspolicy.NewHandlers(
 			setup.KyvernoDynamicClient,
@@ -864,6 +965,7 @@ func main() {
 			setup.KyvernoClient,
 			admissionReports,
 			eventGenerator,
+			nsLister,
 		)
 		ivpolHandlers := ivpol.New(
 			ivpolEngine,
@@ -914,7 +1016,9 @@ func main() {
 			setup.Configuration,
 			setup.MetricsManager,
 			webhooks.DebugModeOptions{
-				DumpPayload: dumpPayload,
+				DumpPayload:  dumpPayload,
+				CaptureDir:   captureDir,
+				CaptureLimit: captureLimit,
 			},
 			func() ([]byte, []byte, error) {
 				secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tlsSecretName)
@@ -945,6 +1049,9 @@ func main() {
 		// start non leader controllers
 		eventController.Run(signalCtx, setup.Logger, &wg)
 		gceController.Run(signalCtx, setup.Logger, &wg)
+		if latencyController != nil {
+			latencyController.Run(signalCtx, setup.Logger, &wg)
+		}
 		if polexController != nil {
 			polexController.Run(signalCtx, setup.Logger, &wg)
 		}
@@ -952,7 +1059,7 @@ func main() {
 			controller.Run(signalCtx, setup.Logger.WithName("controllers"), &wg)
 		}
 		// start leader election
-		le.Run(signalCtx)
+		internal.RunLeaderElection(signalCtx, setup.Logger, le)
 	}()
 	// wait for everything to shut down and exit
 	wg.Wait()
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/admissionpolicy"
 	"github.com/kyverno/kyverno/pkg/breaker"
@@ -22,7 +24,10 @@ import (
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
+	promotioncontroller "github.com/kyverno/kyverno/pkg/controllers/report/promotion"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	reportsinkcontroller "github.com/kyverno/kyverno/pkg/controllers/report/sink"
+	reporttrendcontroller "github.com/kyverno/kyverno/pkg/controllers/report/trend"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
@@ -33,8 +38,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/toggle"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	openreportsversioned "github.com/openreports/reports-api/pkg/client/clientset/versioned"
 	openreportsclient "github.com/openreports/reports-api/pkg/client/clientset/versioned/typed/openreports.io/v1alpha1"
+	openreportsinformers "github.com/openreports/reports-api/pkg/client/informers/externalversions"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating/patch"
 	"k8s.io/client-go/discovery/cached/memory"
@@ -90,6 +99,7 @@ func createReportControllers(
 	gcstore store.Store,
 	typeConverter patch.TypeConverterManager,
 	secretLister corev1listers.SecretLister,
+	resultSink reportsinkcontroller.Interface,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
@@ -175,6 +185,7 @@ func createReportControllers(
 					mapV1Informer,
 					mapBetaInformer,
 					mapAlphaInformer,
+					resultSink,
 				),
 				aggregationWorkers,
 			))
@@ -248,6 +259,7 @@ func createrLeaderControllers(
 	backgroundScanWorkers int,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	orInformer openreportsinformers.SharedInformerFactory,
 	metadataInformer metadatainformers.SharedInformerFactory,
 	metaClient metaclient.UpstreamInterface,
 	kyvernoClient versioned.Interface,
@@ -260,6 +272,10 @@ func createrLeaderControllers(
 	gcstore store.Store,
 	typeConverter patch.TypeConverterManager,
 	secretLister corev1listers.SecretLister,
+	resultSink reportsinkcontroller.Interface,
+	reportTrendInterval time.Duration,
+	reportTrendRetention time.Duration,
+	auditPromotionInterval time.Duration,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
@@ -285,7 +301,48 @@ func createrLeaderControllers(
 		gcstore,
 		typeConverter,
 		secretLister,
+		resultSink,
 	)
+	if reportTrendInterval > 0 {
+		reportControllers = append(reportControllers, internal.NewController(
+			reporttrendcontroller.ControllerName,
+			reporttrendcontroller.NewController(
+				kyvernoClient,
+				orClient,
+				reportTrendInterval,
+				reportTrendRetention,
+			),
+			reporttrendcontroller.Workers,
+		))
+	}
+	if auditPromotionInterval > 0 {
+		var reportLister promotioncontroller.ReportLister
+		if orInformer != nil {
+			reportLister = promotioncontroller.NewOpenreportsReportLister(
+				orInformer.Openreports().V1alpha1().Reports(),
+				orInformer.Openreports().V1alpha1().ClusterReports(),
+			)
+		} else {
+			reportLister = promotioncontroller.NewWgpolicyReportLister(
+				kyvernoInformer.Wgpolicyk8s().V1alpha2().PolicyReports(),
+				kyvernoInformer.Wgpolicyk8s().V1alpha2().ClusterPolicyReports(),
+			)
+		}
+		reportControllers = append(reportControllers, internal.NewController(
+			promotioncontroller.ControllerName,
+			promotioncontroller.NewController(
+				kyvernoClient,
+				kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+				kyvernoInformer.Kyverno().V1().Policies(),
+				kyvernoInformer.Policies().V1beta1().ValidatingPolicies(),
+				kyvernoInformer.Policies().V1beta1().NamespacedValidatingPolicies(),
+				reportLister,
+				eventGenerator,
+				auditPromotionInterval,
+			),
+			promotioncontroller.Workers,
+		))
+	}
 	return reportControllers, warmup, nil
 }
 
@@ -308,6 +365,15 @@ func main() {
 		apiCallTimeout                   time.Duration
 		maxBackgroundReports             int
 		maxGlobalContextEntries          int
+		resultSinkType                   string
+		resultSinkEndpoint               string
+		resultSinkQueueSize              int
+		resultSinkBatchSize              int
+		resultSinkFlushInterval          time.Duration
+		resultSinkTimeout                time.Duration
+		reportTrendInterval              time.Duration
+		reportTrendRetention             time.Duration
+		auditPromotionInterval           time.Duration
 	)
 	flagset := flag.NewFlagSet("reports-controller", flag.ExitOnError)
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable background scan.")
@@ -326,6 +392,15 @@ func main() {
 	flagset.DurationVar(&apiCallTimeout, "apiCallTimeout", 30*time.Second, "Timeout for HTTP API calls made by policies. A value of 0 means no timeout.")
 	flagset.IntVar(&maxBackgroundReports, "maxBackgroundReports", 10000, "Maximum number of ephemeralreports created for the background policies before we stop creating new ones")
 	flagset.IntVar(&maxGlobalContextEntries, "maxGlobalContextEntries", 0, "Maximum number of entries in the global context store. When the limit is reached, new entries are rejected and retried. A value of 0 means unbounded.")
+	flagset.StringVar(&resultSinkType, "resultSink", "", "Publish policy report result changes to an external sink, supported values are cloudevents and jsonl.")
+	flagset.StringVar(&resultSinkEndpoint, "resultSinkEndpoint", "", "HTTP endpoint receiving CloudEvents when resultSink is cloudevents, or file path when resultSink is jsonl (- for stdout).")
+	flagset.IntVar(&resultSinkQueueSize, "resultSinkQueueSize", 10000, "Maximum number of result changes buffered before report aggregation is slowed down.")
+	flagset.IntVar(&resultSinkBatchSize, "resultSinkBatchSize", 100, "Maximum number of result changes published in a single batch.")
+	flagset.DurationVar(&resultSinkFlushInterval, "resultSinkFlushInterval", 5*time.Second, "Maximum time a result change stays buffered before being published.")
+	flagset.DurationVar(&resultSinkTimeout, "resultSinkTimeout", reportsinkcontroller.DefaultHTTPTimeout, "Timeout of the requests publishing result changes when resultSink is cloudevents.")
+	flagset.DurationVar(&reportTrendInterval, "reportTrendInterval", 0, "Interval at which policy report summaries are snapshotted per namespace and policy and exposed as metrics. A value of 0 disables report trends.")
+	flagset.DurationVar(&reportTrendRetention, "reportTrendRetention", 24*time.Hour, "Duration a report trend series is still reported (as zero) after its results disappeared.")
+	flagset.DurationVar(&auditPromotionInterval, "auditPromotionInterval", 0, "Interval at which policies opted in with the policies.kyverno.io/promotion annotation are checked for promotion from audit to enforce. A value of 0 disables audit promotion.")
 	flagset.BoolVar(&reportsCRDsSanityChecks, "reportsCRDsSanityChecks", true, "Enable or disable sanity checks for policy reports and ephemeral reports CRDs.")
 	flagset.Func(toggle.AllowHTTPInNamespacedPoliciesFlagName, toggle.AllowHTTPInNamespacedPoliciesDescription, toggle.AllowHTTPInNamespacedPolicies.Parse)
 	flagset.Func(toggle.HTTPBlocklistFlagName, toggle.HTTPBlocklistDescription, toggle.HTTPBlocklist.Parse)
@@ -418,6 +493,36 @@ func main() {
 			eventGenerator,
 			event.Workers,
 		)
+		// result sink
+		var resultSink reportsinkcontroller.Interface
+		var resultSinkController internal.Controller
+		if resultSinkType != "" {
+			var sink reportsinkcontroller.Sink
+			switch resultSinkType {
+			case reportsinkcontroller.TypeCloudEvents:
+				sink, err = reportsinkcontroller.NewHTTPSink(&http.Client{Timeout: resultSinkTimeout}, resultSinkEndpoint, "kyverno-reports-controller")
+				if err != nil {
+					setup.Logger.Error(err, "failed to create result sink")
+					os.Exit(1)
+				}
+			case reportsinkcontroller.TypeJSONLines:
+				sink, err = reportsinkcontroller.NewFileSink(resultSinkEndpoint, "kyverno-reports-controller")
+				if err != nil {
+					setup.Logger.Error(err, "failed to create result sink")
+					os.Exit(1)
+				}
+			default:
+				setup.Logger.Error(fmt.Errorf("unsupported result sink %s", resultSinkType), "failed to create result sink")
+				os.Exit(1)
+			}
+			controller := reportsinkcontroller.NewController(sink, resultSinkQueueSize, resultSinkBatchSize, resultSinkFlushInterval)
+			resultSink = controller
+			resultSinkController = internal.NewController(
+				reportsinkcontroller.ControllerName,
+				controller,
+				reportsinkcontroller.Workers,
+			)
+		}
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
 			globalcontextcontroller.NewController(
@@ -448,6 +553,7 @@ func main() {
 			apicall.NewAPICallConfiguration(maxAPICallResponseLength, apiCallTimeout),
 			polexCache,
 			gcstore,
+			nil,
 		)
 		// start informers and wait for cache sync
 		if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer) {
@@ -504,6 +610,16 @@ func main() {
 				kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(setup.KubeClient, setup.ResyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
 				kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
 				metadataInformer := metadatainformers.NewSharedInformerFactory(setup.MetadataClient, setup.ResyncPeriod)
+				var orInformer openreportsinformers.SharedInformerFactory
+				if setup.OpenreportsClient != nil {
+					orInformer = openreportsinformers.NewSharedInformerFactoryWithOptions(
+						openreportsversioned.New(setup.OpenreportsClient.RESTClient()),
+						setup.ResyncPeriod,
+						openreportsinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+							options.LabelSelector = labels.SelectorFromSet(labels.Set{kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp}).String()
+						}),
+					)
+				}
 				// create leader controllers
 				leaderControllers, warmup, err := createrLeaderControllers(
 					engine,
@@ -518,6 +634,7 @@ func main() {
 					backgroundScanWorkers,
 					kubeInformer,
 					kyvernoInformer,
+					orInformer,
 					metadataInformer,
 					setup.MetadataClient,
 					setup.KyvernoClient,
@@ -530,6 +647,10 @@ func main() {
 					gcstore,
 					typeConverter,
 					setup.RegistrySecretLister,
+					resultSink,
+					reportTrendInterval,
+					reportTrendRetention,
+					auditPromotionInterval,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
@@ -540,6 +661,10 @@ func main() {
 					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 					os.Exit(1)
 				}
+				if orInformer != nil && !internal.StartInformersAndWaitForCacheSync(ctx, logger, orInformer) {
+					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+					os.Exit(1)
+				}
 				internal.StartInformers(ctx, metadataInformer)
 				if !internal.CheckCacheSync(logger, metadataInformer.WaitForCacheSync(ctx.Done())) {
 					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -563,14 +688,18 @@ func main() {
 			setup.Logger.Error(err, "failed to initialize leader election")
 			os.Exit(1)
 		}
+		internal.SetupLeaderElectionAdmin(setup.Logger, le)
 		// start non leader controllers
 		eventController.Run(ctx, setup.Logger, &wg)
 		gceController.Run(ctx, setup.Logger, &wg)
+		if resultSinkController != nil {
+			resultSinkController.Run(ctx, setup.Logger, &wg)
+		}
 		if polexController != nil {
 			polexController.Run(ctx, setup.Logger, &wg)
 		}
 		// start leader election
-		le.Run(ctx)
+		internal.RunLeaderElection(ctx, setup.Logger, le)
 	}()
 	// wait for everything to shut down and exit
 	wg.Wait()
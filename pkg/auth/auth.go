@@ -51,6 +51,20 @@ func NewCanI(discovery Discovery, sarClient authorizationv1client.SubjectAccessR
 	}
 }
 
+// NewCanIWithChecker returns a new instance of operation access controller evaluator
+// delegating the access review to the given checker
+func NewCanIWithChecker(discovery Discovery, checker checker.AuthChecker, gvk, namespace, name, verb, subresource string) CanIOptions {
+	return &canIOptions{
+		name:        name,
+		namespace:   namespace,
+		verb:        verb,
+		gvk:         gvk,
+		subresource: subresource,
+		discovery:   discovery,
+		checker:     checker,
+	}
+}
+
 // RunAccessCheck checks if the caller can perform the operation
 // - operation is a combination of namespace, kind, verb
 // - can only evaluate a single verb
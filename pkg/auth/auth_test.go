@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/kyverno/kyverno/pkg/auth/checker"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/authorization/v1"
@@ -237,3 +238,26 @@ func TestCanIOptions_RunAccessCheck(t *testing.T) {
 		})
 	}
 }
+
+type recordingChecker struct {
+	namespace string
+	resource  string
+	verb      string
+}
+
+func (c *recordingChecker) Check(_ context.Context, _, _, resource, _, namespace, _, verb string) (*checker.AuthResult, error) {
+	c.namespace, c.resource, c.verb = namespace, resource, verb
+	return &checker.AuthResult{Allowed: namespace == "team-a"}, nil
+}
+
+func TestNewCanIWithChecker(t *testing.T) {
+	authChecker := &recordingChecker{}
+	discovery := dclient.NewEmptyFakeClient().Discovery()
+	got, _, err := NewCanIWithChecker(discovery, authChecker, "Deployment", "team-a", "", "list", "").RunAccessCheck(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, got)
+	assert.Equal(t, &recordingChecker{namespace: "team-a", resource: "deployments", verb: "list"}, authChecker)
+	got, _, err = NewCanIWithChecker(discovery, authChecker, "Deployment", "team-b", "", "list", "").RunAccessCheck(context.TODO())
+	assert.NoError(t, err)
+	assert.False(t, got)
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+)
+
+// UpdateRequestKey returns a key identifying the policy, rules, trigger resources and context of an update request.
+// Update requests sharing the same key supersede each other: background processing always reads the
+// current state of the trigger, only the most recent update request needs to be processed.
+// Update requests made in a different admission context, or differing in downstream deletion or cache restore, are kept apart.
+func UpdateRequestKey(spec kyvernov2.UpdateRequestSpec) string {
+	parts := []string{
+		string(spec.GetRequestType()),
+		spec.Policy,
+		spec.Rule,
+		resourceKey(spec.Resource),
+		strconv.FormatBool(spec.DeleteDownstream),
+	}
+	for _, ruleContext := range spec.RuleContext {
+		parts = append(parts,
+			ruleContext.Rule,
+			resourceKey(ruleContext.Trigger),
+			strconv.FormatBool(ruleContext.DeleteDownstream),
+			strconv.FormatBool(ruleContext.CacheRestore),
+		)
+	}
+	parts = append(parts, contextKey(spec.Context))
+	return strings.Join(parts, "|")
+}
+
+func resourceKey(resource kyvernov1.ResourceSpec) string {
+	return strings.Join([]string{resource.APIVersion, resource.Kind, resource.Namespace, resource.Name, string(resource.UID)}, "/")
+}
+
+// contextKey hashes the parts of the admission context used by background processing,
+// the UID of the admission request is left out as it differs for every request.
+func contextKey(context kyvernov2.UpdateRequestSpecContext) string {
+	h := sha256.New()
+	encoder := json.NewEncoder(h)
+	_ = encoder.Encode(context.UserRequestInfo)
+	_ = encoder.Encode(context.AdmissionRequestInfo.Operation)
+	if request := context.AdmissionRequestInfo.AdmissionRequest; request != nil {
+		_ = encoder.Encode(request.Operation)
+		_ = encoder.Encode(request.UserInfo)
+		_ = encoder.Encode(request.SubResource)
+		_ = encoder.Encode(request.DryRun)
+		h.Write(request.Object.Raw)
+		h.Write([]byte{0})
+		h.Write(request.OldObject.Raw)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
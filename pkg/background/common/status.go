@@ -2,11 +2,16 @@ package common
 
 import (
 	"context"
+	"fmt"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // StatusControlInterface provides interface to update status subresource
@@ -14,6 +19,7 @@ type StatusControlInterface interface {
 	Failed(name string, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error)
 	Success(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error)
 	Skip(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error)
+	WaitForApproval(name string, changeSets []kyvernov2.ChangeSet) (*kyvernov2.UpdateRequest, error)
 }
 
 // statusControl is default implementaation of GRStatusControlInterface
@@ -43,3 +49,20 @@ func (sc *statusControl) Success(name string, genResources []kyvernov1.ResourceS
 func (sc *statusControl) Skip(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
 	return UpdateStatus(context.TODO(), sc.client, sc.urLister, name, kyvernov2.Skip, "", genResources)
 }
+
+// WaitForApproval sets the ur status.state to waiting for approval and stores the changes to approve
+func (sc *statusControl) WaitForApproval(name string, changeSets []kyvernov2.ChangeSet) (*kyvernov2.UpdateRequest, error) {
+	ur, err := sc.client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return ur, errors.Wrapf(err, "failed to fetch update request")
+	}
+	latest := ur.DeepCopy()
+	latest.Status.State = kyvernov2.WaitingForApproval
+	latest.Status.Message = fmt.Sprintf("%d change(s) waiting for approval", len(changeSets))
+	latest.Status.ChangeSets = changeSets
+	if _, err := sc.client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{}); err != nil {
+		return ur, errors.Wrapf(err, "failed to update ur status to %s", string(kyvernov2.WaitingForApproval))
+	}
+	logging.V(3).Info("updated update request status", "name", name, "status", string(kyvernov2.WaitingForApproval))
+	return ur, nil
+}
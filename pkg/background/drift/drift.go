@@ -0,0 +1,212 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Mode controls what happens when a synchronized downstream resource is modified outside of Kyverno.
+type Mode string
+
+const (
+	// ModeRevert records the drift and reverts the downstream resource to its desired state
+	ModeRevert Mode = "revert"
+	// ModeReport records the drift in an event and a policy report and leaves the downstream resource untouched
+	ModeReport Mode = "report"
+)
+
+// ParseMode parses a drift mode, an empty string defaults to ModeRevert.
+func ParseMode(mode string) (Mode, error) {
+	switch Mode(mode) {
+	case "", ModeRevert:
+		return ModeRevert, nil
+	case ModeReport:
+		return ModeReport, nil
+	default:
+		return "", fmt.Errorf("invalid drift mode %q, must be one of %q or %q", mode, ModeRevert, ModeReport)
+	}
+}
+
+// DesiredHashAnnotation records, in report mode, the hash of the desired state Kyverno last applied
+// to a downstream resource. It tells a change of the desired state, which must still be synchronized,
+// apart from a drift introduced by another writer.
+const DesiredHashAnnotation = "generate.kyverno.io/desired-hash"
+
+// Drift describes the changes made to a downstream resource outside of Kyverno.
+type Drift struct {
+	// Diff is the JSON patch turning the desired resource into the actual one
+	Diff string
+	// Manager is the field manager of the last write to the downstream resource, as recorded in managedFields
+	Manager string
+	// Operation is the operation of the last write, Update or Apply
+	Operation string
+	// Time is the time of the last write, zero if unknown
+	Time time.Time
+}
+
+// Message returns a human readable description of the drift.
+func (d Drift) Message() string {
+	manager := d.Manager
+	if manager == "" {
+		manager = "an unknown manager"
+	}
+	return fmt.Sprintf("downstream resource was modified by %s: %s", manager, d.Diff)
+}
+
+// system managed fields are never considered when comparing resources
+var ignoredMetadata = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// free form maps are compared in full, other maps are only compared on the fields of the desired resource
+// so that defaults set by the API server are not reported as drift
+var freeFormMaps = [][]string{
+	{"metadata", "labels"},
+	{"metadata", "annotations"},
+	{"data"},
+	{"stringData"},
+	{"binaryData"},
+}
+
+// Detect compares the desired state of a downstream resource with its actual state.
+// It returns nil when the actual resource does not deviate from the desired one.
+func Detect(desired, actual *unstructured.Unstructured) (*Drift, error) {
+	want := normalize(desired)
+	got := normalize(actual)
+	prune(got, want, nil)
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		return nil, err
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatch.CreatePatch(wantJSON, gotJSON)
+	if err != nil {
+		return nil, err
+	}
+	if len(patch) == 0 {
+		return nil, nil
+	}
+	diff, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	drift := Drift{Diff: string(diff)}
+	drift.Manager, drift.Operation, drift.Time = LastModifier(actual)
+	return &drift, nil
+}
+
+// LastModifier returns the field manager, operation and time of the most recent write to the resource
+// according to its managedFields. Writes to subresources are ignored.
+func LastModifier(obj *unstructured.Unstructured) (string, string, time.Time) {
+	var manager, operation string
+	var last time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if manager == "" || !entry.Time.Time.Before(last) {
+			manager = entry.Manager
+			operation = string(entry.Operation)
+			last = entry.Time.Time
+		}
+	}
+	return manager, operation, last
+}
+
+// IsKyvernoManager returns true if the field manager belongs to Kyverno.
+func IsKyvernoManager(manager string) bool {
+	return strings.HasPrefix(strings.ToLower(manager), "kyverno")
+}
+
+// SetDesiredHash records the hash of the desired state of a downstream resource in the DesiredHashAnnotation.
+func SetDesiredHash(desired *unstructured.Unstructured) {
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DesiredHashAnnotation] = desiredHash(desired)
+	desired.SetAnnotations(annotations)
+}
+
+// DesiredChanged returns true if the desired state differs from the one Kyverno last applied to the actual
+// resource. A resource without the DesiredHashAnnotation was not synchronized in report mode yet and is
+// considered changed.
+func DesiredChanged(desired, actual *unstructured.Unstructured) bool {
+	applied, ok := actual.GetAnnotations()[DesiredHashAnnotation]
+	return !ok || applied != desiredHash(desired)
+}
+
+func desiredHash(desired *unstructured.Unstructured) string {
+	obj := desired.DeepCopy()
+	removeDesiredHash(obj.Object)
+	return reportutils.CalculateResourceHash(*obj)
+}
+
+func removeDesiredHash(content map[string]any) {
+	unstructured.RemoveNestedField(content, "metadata", "annotations", DesiredHashAnnotation)
+	if annotations, ok, _ := unstructured.NestedMap(content, "metadata", "annotations"); ok && len(annotations) == 0 {
+		unstructured.RemoveNestedField(content, "metadata", "annotations")
+	}
+}
+
+func normalize(obj *unstructured.Unstructured) map[string]any {
+	content := obj.DeepCopy().UnstructuredContent()
+	delete(content, "status")
+	removeDesiredHash(content)
+	if metadata, ok := content["metadata"].(map[string]any); ok {
+		for _, field := range ignoredMetadata {
+			delete(metadata, field)
+		}
+	}
+	return content
+}
+
+func prune(got, want map[string]any, path []string) {
+	if isFreeForm(path) {
+		return
+	}
+	for key, value := range got {
+		wantValue, ok := want[key]
+		if !ok {
+			if !isFreeForm(append(path, key)) {
+				delete(got, key)
+			}
+			continue
+		}
+		gotMap, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		wantMap, ok := wantValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		prune(gotMap, wantMap, append(path, key))
+	}
+}
+
+func isFreeForm(path []string) bool {
+	for _, freeForm := range freeFormMaps {
+		if slices.Equal(path, freeForm) {
+			return true
+		}
+	}
+	return false
+}
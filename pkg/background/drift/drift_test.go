@@ -0,0 +1,136 @@
+package drift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newConfigMap(data map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      "config",
+			"namespace": "team-a",
+			"labels": map[string]any{
+				"app.kubernetes.io/managed-by": "kyverno",
+			},
+		},
+		"data": data,
+	}}
+	return obj
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, ModeRevert, mode)
+	mode, err = ParseMode("report")
+	assert.NoError(t, err)
+	assert.Equal(t, ModeReport, mode)
+	_, err = ParseMode("ignore")
+	assert.Error(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	desired := newConfigMap(map[string]any{"foo": "bar"})
+
+	actual := newConfigMap(map[string]any{"foo": "bar"})
+	actual.SetResourceVersion("42")
+	actual.SetUID("uid")
+	actual.Object["status"] = map[string]any{"phase": "Active"}
+	drift, err := Detect(desired, actual)
+	assert.NoError(t, err)
+	assert.Nil(t, drift, "system fields are ignored")
+
+	actual = newConfigMap(map[string]any{"foo": "baz", "extra": "value"})
+	drift, err = Detect(desired, actual)
+	assert.NoError(t, err)
+	assert.NotNil(t, drift)
+	assert.Contains(t, drift.Diff, `{"op":"replace","path":"/data/foo","value":"baz"}`)
+	assert.Contains(t, drift.Diff, `{"op":"add","path":"/data/extra","value":"value"}`)
+}
+
+func TestDetect_IgnoresDefaults(t *testing.T) {
+	desired := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]any{"name": "svc", "namespace": "team-a"},
+		"spec":       map[string]any{"type": "ClusterIP"},
+	}}
+	actual := desired.DeepCopy()
+	actual.Object["spec"].(map[string]any)["clusterIP"] = "10.0.0.1"
+	drift, err := Detect(desired, actual)
+	assert.NoError(t, err)
+	assert.Nil(t, drift)
+}
+
+func TestLastModifier(t *testing.T) {
+	now := time.Now()
+	obj := newConfigMap(nil)
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:   "Kyverno",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		Time:      &metav1.Time{Time: now.Add(-time.Hour)},
+	}, {
+		Manager:   "kubectl-edit",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		Time:      &metav1.Time{Time: now},
+	}, {
+		Manager:     "status-writer",
+		Operation:   metav1.ManagedFieldsOperationUpdate,
+		Subresource: "status",
+		Time:        &metav1.Time{Time: now.Add(time.Hour)},
+	}})
+	manager, operation, last := LastModifier(obj)
+	assert.Equal(t, "kubectl-edit", manager)
+	assert.Equal(t, "Update", operation)
+	assert.Equal(t, now.Unix(), last.Unix())
+	assert.True(t, IsKyvernoManager("Kyverno"))
+	assert.True(t, IsKyvernoManager("kyverno-generate"))
+	assert.False(t, IsKyvernoManager(manager))
+}
+
+func TestDetect_FreeFormMaps(t *testing.T) {
+	desired := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "config", "namespace": "team-a"},
+	}}
+	actual := desired.DeepCopy()
+	actual.Object["data"] = map[string]any{"foo": "bar"}
+	actual.SetAnnotations(map[string]string{"owner": "me"})
+	drift, err := Detect(desired, actual)
+	assert.NoError(t, err)
+	assert.NotNil(t, drift)
+	assert.Contains(t, drift.Diff, `"path":"/data"`)
+	assert.Contains(t, drift.Diff, `"path":"/metadata/annotations"`)
+}
+
+func TestDesiredChanged(t *testing.T) {
+	desired := newConfigMap(map[string]any{"foo": "bar"})
+	SetDesiredHash(desired)
+	assert.Contains(t, desired.GetAnnotations(), DesiredHashAnnotation)
+
+	drifted := desired.DeepCopy()
+	drifted.Object["data"] = map[string]any{"foo": "baz"}
+	assert.False(t, DesiredChanged(newConfigMap(map[string]any{"foo": "bar"}), drifted), "a drift does not change the desired state")
+
+	updated := newConfigMap(map[string]any{"foo": "qux"})
+	assert.True(t, DesiredChanged(updated, drifted), "a new desired state must be synchronized")
+
+	assert.True(t, DesiredChanged(desired, newConfigMap(map[string]any{"foo": "bar"})), "a resource without desired hash is considered changed")
+}
+
+func TestDetect_IgnoresDesiredHash(t *testing.T) {
+	desired := newConfigMap(map[string]any{"foo": "bar"})
+	actual := desired.DeepCopy()
+	SetDesiredHash(actual)
+	d, err := Detect(desired, actual)
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+}
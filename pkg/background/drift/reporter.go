@@ -0,0 +1,84 @@
+package drift
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/event"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Reporter records drifts of synchronized downstream resources.
+type Reporter interface {
+	// Mode returns the configured drift mode
+	Mode() Mode
+	// Report emits a drift event, and in report mode records the drift in a policy report
+	Report(ctx context.Context, logger logr.Logger, policy engineapi.GenericPolicy, rule string, downstream unstructured.Unstructured, drift Drift)
+}
+
+type reporter struct {
+	mode          Mode
+	kyvernoClient versioned.Interface
+	eventGen      event.Interface
+}
+
+func NewReporter(mode Mode, kyvernoClient versioned.Interface, eventGen event.Interface) Reporter {
+	return &reporter{
+		mode:          mode,
+		kyvernoClient: kyvernoClient,
+		eventGen:      eventGen,
+	}
+}
+
+func (r *reporter) Mode() Mode {
+	return r.mode
+}
+
+func (r *reporter) Report(ctx context.Context, logger logr.Logger, policy engineapi.GenericPolicy, rule string, downstream unstructured.Unstructured, drift Drift) {
+	logger.V(2).Info("downstream resource drifted", "kind", downstream.GetKind(), "namespace", downstream.GetNamespace(), "name", downstream.GetName(), "manager", drift.Manager, "mode", r.mode)
+	if r.eventGen != nil {
+		r.eventGen.Add(event.NewResourceDriftEvent(policy, rule, downstream, drift.Message(), r.mode == ModeRevert))
+	}
+	if r.mode != ModeReport || r.kyvernoClient == nil {
+		return
+	}
+	if downstream.GetName() == "" || downstream.GetUID() == "" || !reportutils.IsGvkSupported(downstream.GroupVersionKind()) {
+		return
+	}
+	report := reportutils.BuildGenerateReport(
+		downstream.GetNamespace(),
+		downstream.GroupVersionKind(),
+		downstream.GetName(),
+		downstream.GetUID(),
+		NewEngineResponse(policy, rule, downstream, drift),
+	)
+	err := breaker.GetReportsBreaker().Do(ctx, func(ctx context.Context) error {
+		_, err := reportutils.CreateEphemeralReport(ctx, report, r.kyvernoClient)
+		return err
+	})
+	if err != nil {
+		logger.Error(err, "failed to report drift", "kind", downstream.GetKind(), "namespace", downstream.GetNamespace(), "name", downstream.GetName())
+	}
+}
+
+// NewEngineResponse returns a failed generation response describing the drift of a downstream resource.
+func NewEngineResponse(policy engineapi.GenericPolicy, rule string, downstream unstructured.Unstructured, drift Drift) engineapi.EngineResponse {
+	if rule == "" {
+		rule = policy.GetName()
+	}
+	properties := map[string]string{
+		"drift-diff": drift.Diff,
+	}
+	if drift.Manager != "" {
+		properties["drift-manager"] = drift.Manager
+	}
+	return engineapi.NewEngineResponse(downstream, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+		Rules: []engineapi.RuleResponse{
+			*engineapi.RuleFail(rule, engineapi.Generation, drift.Message(), properties),
+		},
+	})
+}
@@ -14,6 +14,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/drift"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
@@ -52,6 +53,7 @@ type GenerateController struct {
 
 	configuration config.Configuration
 	eventGen      event.Interface
+	driftReporter drift.Reporter
 
 	log logr.Logger
 	jp  jmespath.Interface
@@ -69,6 +71,7 @@ func NewGenerateController(
 	nsLister corev1listers.NamespaceLister,
 	dynamicConfig config.Configuration,
 	eventGen event.Interface,
+	driftReporter drift.Reporter,
 	log logr.Logger,
 	jp jmespath.Interface,
 ) *GenerateController {
@@ -83,6 +86,7 @@ func NewGenerateController(
 		nsLister:      nsLister,
 		configuration: dynamicConfig,
 		eventGen:      eventGen,
+		driftReporter: driftReporter,
 		log:           log,
 		jp:            jp,
 	}
@@ -295,10 +299,10 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 		}
 
 		if rule.Generation.ForEachGeneration != nil {
-			g := newForeachGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader)
+			g := newForeachGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader, c.driftReporter)
 			genResource, err = g.generateForeach()
 		} else {
-			g := newGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader)
+			g := newGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader, c.driftReporter)
 			genResource, err = g.generate()
 		}
 
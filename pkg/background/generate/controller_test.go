@@ -103,6 +103,10 @@ func (f *fakeStatusControl) Skip(name string, genResources []kyvernov1.ResourceS
 	return nil, f.skipErr
 }
 
+func (f *fakeStatusControl) WaitForApproval(name string, changeSets []kyvernov2.ChangeSet) (*kyvernov2.UpdateRequest, error) {
+	return nil, nil
+}
+
 // TestProcessUR_NilPolicy_NoEventPanic tests that when a policy is deleted (NotFound)
 // and an error occurs during downstream cleanup, the controller does not panic
 // when trying to create a background failed event with a nil policy.
@@ -8,6 +8,7 @@ import (
 	gojmespath "github.com/kyverno/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/drift"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
@@ -31,6 +32,7 @@ type generator struct {
 	forEach          []kyvernov1.ForEachGeneration
 	pattern          kyvernov1.GeneratePattern
 	contextLoader    engineapi.EngineContextLoader
+	driftReporter    drift.Reporter
 }
 
 func newGenerator(client dclient.Interface,
@@ -43,6 +45,7 @@ func newGenerator(client dclient.Interface,
 	trigger unstructured.Unstructured,
 	pattern kyvernov1.GeneratePattern,
 	contextLoader engineapi.EngineContextLoader,
+	driftReporter drift.Reporter,
 ) *generator {
 	return &generator{
 		client:           client,
@@ -55,6 +58,7 @@ func newGenerator(client dclient.Interface,
 		trigger:          trigger,
 		pattern:          pattern,
 		contextLoader:    contextLoader,
+		driftReporter:    driftReporter,
 	}
 }
 
@@ -68,6 +72,7 @@ func newForeachGenerator(client dclient.Interface,
 	trigger unstructured.Unstructured,
 	forEach []kyvernov1.ForEachGeneration,
 	contextLoader engineapi.EngineContextLoader,
+	driftReporter drift.Reporter,
 ) *generator {
 	return &generator{
 		client:           client,
@@ -80,6 +85,7 @@ func newForeachGenerator(client dclient.Interface,
 		trigger:          trigger,
 		forEach:          forEach,
 		contextLoader:    contextLoader,
+		driftReporter:    driftReporter,
 	}
 }
 
@@ -153,6 +159,10 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 
 		newResource.SetAPIVersion(targetMeta.GetAPIVersion())
 		common.ManageLabels(newResource, g.trigger, g.policy, g.rule.Name)
+		reportDrift := g.driftReporter != nil && g.driftReporter.Mode() == drift.ModeReport
+		if reportDrift {
+			drift.SetDesiredHash(newResource)
+		}
 		if response.GetAction() == Create {
 			newResource.SetResourceVersion("")
 			if g.policy.GetSpec().UseServerSideApply {
@@ -204,6 +214,18 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 					}
 				}
 
+				if reportDrift {
+					// a changed desired state (policy, trigger or source update) is synchronized even if the
+					// resource drifted, otherwise the difference is a drift that is reported and left untouched
+					if !drift.DesiredChanged(newResource, generatedObj) {
+						g.driftDetected(logger, newResource, generatedObj)
+						logger.V(4).Info("desired state unchanged, skip reverting changes")
+						continue
+					}
+				} else {
+					g.driftDetected(logger, newResource, generatedObj)
+				}
+
 				logger.V(4).Info("updating existing resource")
 
 				if g.policy.GetSpec().UseServerSideApply {
@@ -271,7 +293,8 @@ func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elemen
 			foreach.AnyAllConditions,
 			g.trigger,
 			foreach.GeneratePattern,
-			g.contextLoader).
+			g.contextLoader,
+			g.driftReporter).
 			generate()
 		if err != nil {
 			errors = append(errors, fmt.Errorf("failed to process %v element: %v", index, err))
@@ -283,6 +306,24 @@ func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elemen
 	return genResources, multierr.Combine(errors...)
 }
 
+// driftDetected records a drift when the generated resource was last modified outside of Kyverno.
+// When Kyverno is the last writer, the difference comes from a policy or source change and is not a drift.
+func (g *generator) driftDetected(logger logr.Logger, desired, actual *unstructured.Unstructured) bool {
+	if g.driftReporter == nil {
+		return false
+	}
+	d, err := drift.Detect(desired, actual)
+	if err != nil {
+		logger.Error(err, "failed to detect drift")
+		return false
+	}
+	if d == nil || drift.IsKyvernoManager(d.Manager) {
+		return false
+	}
+	g.driftReporter.Report(context.TODO(), logger, engineapi.NewKyvernoPolicy(g.policy), g.rule.Name, *actual, *d)
+	return true
+}
+
 func (g *generator) loadContext(ctx context.Context) error {
 	if err := g.contextLoader(ctx, g.contextEntries, g.policyContext.JSONContext()); err != nil {
 		if _, ok := err.(gojmespath.NotFoundError); ok {
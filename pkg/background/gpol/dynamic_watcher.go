@@ -7,14 +7,19 @@ import (
 	"sync"
 
 	"github.com/go-logr/logr"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/drift"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
@@ -45,19 +50,32 @@ type WatchManager struct {
 	// refCount tracks the number of policies that generates the same resource.
 	refCount map[schema.GroupVersionResource]int
 
+	// driftReporter records the drifts of downstream resources, drifts are silently reverted when nil.
+	driftReporter drift.Reporter
+
 	log  logr.Logger
 	lock sync.Mutex
 }
 
+type Option func(*WatchManager)
+
+// WithDriftReporter records the changes made to downstream resources outside of Kyverno
+// before reverting them, or instead of reverting them in report mode.
+func WithDriftReporter(reporter drift.Reporter) Option {
+	return func(wm *WatchManager) {
+		wm.driftReporter = reporter
+	}
+}
+
 type watcher struct {
 	watcher       watch.Interface
 	metadataCache map[types.UID]Resource
 }
 
-func NewWatchManager(log logr.Logger, client dclient.Interface) *WatchManager {
+func NewWatchManager(log logr.Logger, client dclient.Interface, opts ...Option) *WatchManager {
 	apiGroupResources, _ := restmapper.GetAPIGroupResources(client.GetKubeClient().Discovery())
 	restMapper := restmapper.NewDiscoveryRESTMapper(apiGroupResources)
-	return &WatchManager{
+	wm := &WatchManager{
 		log:             log,
 		client:          client,
 		restMapper:      restMapper,
@@ -65,6 +83,10 @@ func NewWatchManager(log logr.Logger, client dclient.Interface) *WatchManager {
 		policyRefs:      map[string][]schema.GroupVersionResource{},
 		refCount:        map[schema.GroupVersionResource]int{},
 	}
+	for _, opt := range opts {
+		opt(wm)
+	}
+	return wm
 }
 
 // SyncWatchers reconciles the watchers and the metadata cache with the resources
@@ -485,8 +507,24 @@ func (wm *WatchManager) handleAdd(obj *unstructured.Unstructured, gvr schema.Gro
 
 func (wm *WatchManager) handleUpdate(obj *unstructured.Unstructured, gvr schema.GroupVersionResource) {
 	wm.lock.Lock()
-	defer wm.lock.Unlock()
+	drifted := wm.update(obj, gvr)
+	wm.lock.Unlock()
+	// the policy of a drifted resource is fetched from the API server, it is done without holding the lock
+	if drifted != nil {
+		wm.reportDrift(drifted)
+	}
+}
 
+// driftedResource is a downstream resource that drifted from its desired state.
+type driftedResource struct {
+	obj    *unstructured.Unstructured
+	policy string
+	drift  drift.Drift
+}
+
+// update syncs the downstreams of an updated resource, it must be called with the lock held.
+// It returns the drift to report when the resource is a downstream resource updated by a user.
+func (wm *WatchManager) update(obj *unstructured.Unstructured, gvr schema.GroupVersionResource) *driftedResource {
 	wm.log.Info("Resource updated", "name", obj.GetName())
 	watcher, exists := wm.dynamicWatchers[gvr]
 	if exists {
@@ -548,7 +586,23 @@ func (wm *WatchManager) handleUpdate(obj *unstructured.Unstructured, gvr schema.
 			hash := reportutils.CalculateResourceHash(*obj)
 			// if the hash of the resource is different from the one in the cache
 			// then we need to revert the downstream resource as it means that it has been updated by the user.
-			if hash != watcher.metadataCache[uid].Hash {
+			if cached := watcher.metadataCache[uid]; hash != cached.Hash {
+				// changes written by kyverno are the desired state, record them instead of reverting them
+				if manager, _, _ := drift.LastModifier(obj); drift.IsKyvernoManager(manager) {
+					cached.Hash = hash
+					cached.Data = obj.DeepCopy()
+					watcher.metadataCache[uid] = cached
+					wm.log.V(4).Info("downstream resource updated by kyverno", "name", obj.GetName(), "namespace", obj.GetNamespace())
+					return nil
+				}
+				drifted := wm.detectDrift(obj, cached)
+				if drifted != nil && wm.driftReporter.Mode() == drift.ModeReport {
+					// remember the drifted state so that the same drift is only reported once
+					cached.Hash = hash
+					watcher.metadataCache[uid] = cached
+					wm.log.V(4).Info("downstream resource updated by user, drift reported", "name", obj.GetName(), "namespace", obj.GetNamespace())
+					return drifted
+				}
 				wm.log.V(4).Info("downstream resource updated by user, reverting changes", "name", obj.GetName(), "namespace", obj.GetNamespace())
 				// create a copy of the resource to avoid modifying the cache
 				downstream := watcher.metadataCache[uid].Data.DeepCopy()
@@ -564,9 +618,63 @@ func (wm *WatchManager) handleUpdate(obj *unstructured.Unstructured, gvr schema.
 				} else {
 					wm.log.V(4).Info("downstream resource reverted", "name", obj.GetName(), "namespace", obj.GetNamespace())
 				}
+				return drifted
 			}
 		}
 	}
+	return nil
+}
+
+// detectDrift returns the drift of a downstream resource from its cached desired state.
+func (wm *WatchManager) detectDrift(obj *unstructured.Unstructured, cached Resource) *driftedResource {
+	if wm.driftReporter == nil || cached.Data == nil {
+		return nil
+	}
+	d, err := drift.Detect(cached.Data, obj)
+	if err != nil {
+		wm.log.Error(err, "failed to detect drift", "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil
+	}
+	if d == nil {
+		return nil
+	}
+	return &driftedResource{obj: obj, policy: cached.Labels[common.GeneratePolicyLabel], drift: *d}
+}
+
+// reportDrift records the drift of a downstream resource, it must be called without holding the lock.
+func (wm *WatchManager) reportDrift(drifted *driftedResource) {
+	policy, err := wm.getPolicy(drifted.policy, drifted.obj.GetNamespace())
+	if err != nil {
+		wm.log.Error(err, "failed to fetch policy of drifted resource", "name", drifted.obj.GetName(), "namespace", drifted.obj.GetNamespace())
+		return
+	}
+	wm.driftReporter.Report(context.TODO(), wm.log, policy, "", *drifted.obj, drifted.drift)
+}
+
+// getPolicy fetches the generating policy of a downstream resource. Downstream resources are only
+// labelled with the policy name, a namespaced policy is looked up in the namespace of the downstream resource.
+func (wm *WatchManager) getPolicy(name, namespace string) (engineapi.GenericPolicy, error) {
+	apiVersion := policiesv1beta1.SchemeGroupVersion.String()
+	obj, err := wm.client.GetResource(context.TODO(), apiVersion, "GeneratingPolicy", "", name)
+	if err == nil {
+		var policy policiesv1beta1.GeneratingPolicy
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &policy); err != nil {
+			return nil, err
+		}
+		return engineapi.NewGeneratingPolicy(&policy), nil
+	}
+	if !apierrors.IsNotFound(err) || namespace == "" {
+		return nil, err
+	}
+	obj, err = wm.client.GetResource(context.TODO(), apiVersion, "NamespacedGeneratingPolicy", namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var policy policiesv1beta1.NamespacedGeneratingPolicy
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &policy); err != nil {
+		return nil, err
+	}
+	return engineapi.NewNamespacedGeneratingPolicy(&policy), nil
 }
 
 func (wm *WatchManager) handleDelete(obj *unstructured.Unstructured, gvr schema.GroupVersionResource) {
@@ -9,9 +9,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/drift"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/logging"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/stretchr/testify/assert"
@@ -1407,3 +1410,138 @@ func TestWatcherCleanup_RestartPreservesMetadataCache(t *testing.T) {
 	assert.True(t, hasFirst)
 	assert.True(t, hasSecond)
 }
+
+type driftClient struct {
+	MockClient
+	updated int
+	// lock is the watch manager lock, it must not be held while fetching resources
+	lock       *sync.Mutex
+	lockedGets int
+}
+
+func (c *driftClient) GetResource(ctx context.Context, apiVersion string, kind string, namespace, name string, subresources ...string) (*unstructured.Unstructured, error) {
+	if c.lock != nil {
+		if c.lock.TryLock() {
+			c.lock.Unlock()
+		} else {
+			c.lockedGets++
+		}
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetAPIVersion(apiVersion)
+	policy.SetKind(kind)
+	policy.SetName(name)
+	return policy, nil
+}
+
+func (c *driftClient) UpdateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool, subresource ...string) (*unstructured.Unstructured, error) {
+	c.updated++
+	return nil, nil
+}
+
+type fakeDriftReporter struct {
+	mode    drift.Mode
+	reports []drift.Drift
+}
+
+func (r *fakeDriftReporter) Mode() drift.Mode {
+	return r.mode
+}
+
+func (r *fakeDriftReporter) Report(ctx context.Context, logger logr.Logger, policy engineapi.GenericPolicy, rule string, downstream unstructured.Unstructured, d drift.Drift) {
+	r.reports = append(r.reports, d)
+}
+
+func TestHandleUpdate_Drift(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
+	downstream := &unstructured.Unstructured{}
+	downstream.SetAPIVersion("v1")
+	downstream.SetKind("ConfigMap")
+	downstream.SetUID("down-uid")
+	downstream.SetName("config")
+	downstream.SetNamespace("default")
+	downstream.SetLabels(map[string]string{common.GeneratePolicyLabel: "sync-config"})
+	modified := downstream.DeepCopy()
+	modified.Object["data"] = map[string]any{"foo": "bar"}
+
+	for _, mode := range []drift.Mode{drift.ModeRevert, drift.ModeReport} {
+		t.Run(string(mode), func(t *testing.T) {
+			client := &driftClient{}
+			reporter := &fakeDriftReporter{mode: mode}
+			wm := &WatchManager{
+				client:        client,
+				driftReporter: reporter,
+				log:           logging.WithName("test"),
+				dynamicWatchers: map[schema.GroupVersionResource]*watcher{
+					gvr: {metadataCache: map[types.UID]Resource{
+						"down-uid": {
+							Name:      downstream.GetName(),
+							Namespace: downstream.GetNamespace(),
+							Labels:    downstream.GetLabels(),
+							Hash:      reportutils.CalculateResourceHash(*downstream),
+							Data:      downstream,
+						},
+					}},
+				},
+			}
+			client.lock = &wm.lock
+			wm.handleUpdate(modified, gvr)
+			require.Len(t, reporter.reports, 1)
+			assert.Equal(t, 0, client.lockedGets, "the policy is fetched without holding the lock")
+			assert.Equal(t, `[{"op":"add","path":"/data","value":{"foo":"bar"}}]`, reporter.reports[0].Diff)
+			if mode == drift.ModeReport {
+				assert.Equal(t, 0, client.updated)
+				// the same drift is not reported again
+				wm.handleUpdate(modified.DeepCopy(), gvr)
+				assert.Len(t, reporter.reports, 1)
+				assert.Equal(t, 0, client.updated)
+			} else {
+				assert.Equal(t, 1, client.updated)
+			}
+		})
+	}
+}
+
+func TestHandleUpdate_KyvernoManager(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
+	downstream := &unstructured.Unstructured{}
+	downstream.SetAPIVersion("v1")
+	downstream.SetKind("ConfigMap")
+	downstream.SetUID("down-uid")
+	downstream.SetName("config")
+	downstream.SetNamespace("default")
+	downstream.SetLabels(map[string]string{common.GeneratePolicyLabel: "sync-config"})
+	modified := downstream.DeepCopy()
+	modified.Object["data"] = map[string]any{"foo": "bar"}
+	now := metav1.Now()
+	modified.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kyverno", Operation: metav1.ManagedFieldsOperationUpdate, Time: &now}})
+
+	for _, mode := range []drift.Mode{drift.ModeRevert, drift.ModeReport} {
+		t.Run(string(mode), func(t *testing.T) {
+			client := &driftClient{}
+			reporter := &fakeDriftReporter{mode: mode}
+			wm := &WatchManager{
+				client:        client,
+				driftReporter: reporter,
+				log:           logging.WithName("test"),
+				dynamicWatchers: map[schema.GroupVersionResource]*watcher{
+					gvr: {metadataCache: map[types.UID]Resource{
+						"down-uid": {
+							Name:      downstream.GetName(),
+							Namespace: downstream.GetNamespace(),
+							Labels:    downstream.GetLabels(),
+							Hash:      reportutils.CalculateResourceHash(*downstream),
+							Data:      downstream,
+						},
+					}},
+				},
+			}
+			wm.handleUpdate(modified, gvr)
+			assert.Empty(t, reporter.reports)
+			assert.Equal(t, 0, client.updated)
+			cached := wm.dynamicWatchers[gvr].metadataCache["down-uid"]
+			assert.Equal(t, reportutils.CalculateResourceHash(*modified), cached.Hash)
+			assert.Equal(t, modified, cached.Data)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/history"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
@@ -23,6 +24,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/restmapper"
 )
@@ -47,6 +49,7 @@ type CELGenerateController struct {
 	breaker.Breaker
 
 	eventGen event.Interface
+	recorder history.Recorder
 
 	log logr.Logger
 }
@@ -63,6 +66,7 @@ func NewCELGenerateController(
 	eventGen event.Interface,
 	log logr.Logger,
 	configuration config.Configuration,
+	recorder history.Recorder,
 ) *CELGenerateController {
 	apiGroupResources, _ := restmapper.GetAPIGroupResources(client.GetKubeClient().Discovery())
 	restMapper := restmapper.NewDiscoveryRESTMapper(apiGroupResources)
@@ -78,6 +82,7 @@ func NewCELGenerateController(
 		eventGen:      eventGen,
 		log:           log,
 		configuration: configuration,
+		recorder:      recorder,
 	}
 }
 
@@ -181,6 +186,10 @@ func (c *CELGenerateController) ProcessUR(ur *kyvernov2.UpdateRequest) error {
 						Namespace:  resource.GetNamespace(),
 					})
 				}
+				// a cache restore doesn't change the downstream resources
+				if !ur.Spec.RuleContext[i].CacheRestore {
+					c.record(logger, workerCtx, policy.Policy, resourcesToSync)
+				}
 
 				if res.Result.Status() == engineapi.RuleStatusPass &&
 					isSync &&
@@ -290,6 +299,27 @@ func (c *CELGenerateController) needsReports(trigger unstructured.Unstructured)
 	return createReport
 }
 
+// record keeps track of the generated resources and their prior state so that the generation can be rolled back
+func (c *CELGenerateController) record(logger logr.Logger, libsCtx libs.Context, policy metav1.Object, resources []*unstructured.Unstructured) {
+	if c.recorder == nil {
+		return
+	}
+	kind := "GeneratingPolicy"
+	if policy.GetNamespace() != "" {
+		kind = "NamespacedGeneratingPolicy"
+	}
+	for _, resource := range resources {
+		record, err := history.NewGeneration(history.NewPolicyRef(kind, policy), libsCtx.GetPriorResource(resource.GetUID()), resource)
+		if err != nil {
+			logger.Error(err, "failed to create rollback record", "kind", resource.GetKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+			continue
+		}
+		if err := c.recorder.Record(context.TODO(), *record); err != nil {
+			logger.Error(err, "failed to store rollback record", "kind", resource.GetKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+		}
+	}
+}
+
 func updateURStatus(statusControl common.StatusControlInterface, ur kyvernov2.UpdateRequest, err error, genResources []kyvernov1.ResourceSpec) error {
 	if err != nil {
 		if _, err := statusControl.Failed(ur.GetName(), err.Error(), genResources); err != nil {
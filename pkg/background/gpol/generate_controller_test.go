@@ -82,6 +82,10 @@ func (testStatusControl) Skip(string, []kyvernov1.ResourceSpec) (*kyvernov2.Upda
 	return nil, nil
 }
 
+func (testStatusControl) WaitForApproval(string, []kyvernov2.ChangeSet) (*kyvernov2.UpdateRequest, error) {
+	return nil, nil
+}
+
 type testEventGen struct{}
 
 func (testEventGen) Add(...event.Info) {}
@@ -119,6 +123,10 @@ func (r *recordingStatusControl) Skip(string, []kyvernov1.ResourceSpec) (*kyvern
 	return nil, nil
 }
 
+func (r *recordingStatusControl) WaitForApproval(string, []kyvernov2.ChangeSet) (*kyvernov2.UpdateRequest, error) {
+	return nil, nil
+}
+
 type triggerClient struct {
 	*MockClient
 	trigger *unstructured.Unstructured
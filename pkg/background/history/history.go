@@ -0,0 +1,293 @@
+package history
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	createpatch "gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	labelRecord          = "rollback.kyverno.io/record"
+	labelPolicyKind      = "rollback.kyverno.io/policy-kind"
+	labelPolicyNamespace = "rollback.kyverno.io/policy-namespace"
+	labelPolicyName      = "rollback.kyverno.io/policy-name"
+	labelPolicyVersion   = "rollback.kyverno.io/policy-version"
+	dataKey              = "record"
+	namePrefix           = "kyverno-rollback-"
+	pruneInterval        = 10 * time.Minute
+)
+
+// Operation is the background operation that changed a resource.
+type Operation string
+
+const (
+	// OperationMutate is the update of an existing resource by a mutating policy
+	OperationMutate Operation = "mutate"
+	// OperationGenerate is the creation or update of a downstream resource by a generating policy
+	OperationGenerate Operation = "generate"
+)
+
+// PolicyRef identifies the version of a policy that changed a resource.
+type PolicyRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Version is the generation of the policy
+	Version int64 `json:"version"`
+}
+
+// NewPolicyRef returns the reference of the current version of a policy.
+func NewPolicyRef(kind string, policy metav1.Object) PolicyRef {
+	return PolicyRef{
+		Kind:      kind,
+		Namespace: policy.GetNamespace(),
+		Name:      policy.GetName(),
+		Version:   policy.GetGeneration(),
+	}
+}
+
+// Record is a change made to a resource by background processing.
+type Record struct {
+	// Name is the name of the config map storing the record
+	Name      string                 `json:"-"`
+	Policy    PolicyRef              `json:"policy"`
+	Operation Operation              `json:"operation"`
+	Resource  kyvernov1.ResourceSpec `json:"resource"`
+	// Subresource is the subresource that was updated, if any
+	Subresource string `json:"subresource,omitempty"`
+	// Patch is the JSON patch reverting the resource to its prior state,
+	// it is empty for generated resources
+	Patch string `json:"patch,omitempty"`
+	// Prior is the state of a generated resource before the generation,
+	// it is empty when the generation created the resource
+	Prior string `json:"prior,omitempty"`
+	// ResourceVersion is the version of a generated resource after the generation
+	ResourceVersion string      `json:"resourceVersion,omitempty"`
+	Timestamp       metav1.Time `json:"timestamp"`
+}
+
+// NewMutation returns the record of a background mutation of original into updated.
+func NewMutation(policy PolicyRef, original, updated *unstructured.Unstructured, subresource string) (*Record, error) {
+	before, err := json.Marshal(withoutSystemMetadata(original).Object)
+	if err != nil {
+		return nil, err
+	}
+	after, err := json.Marshal(withoutSystemMetadata(updated).Object)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := createpatch.CreatePatch(after, before)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+	return &Record{
+		Policy:      policy,
+		Operation:   OperationMutate,
+		Resource:    common.ResourceSpecFromUnstructured(*updated),
+		Subresource: subresource,
+		Patch:       string(patch),
+		Timestamp:   metav1.Now(),
+	}, nil
+}
+
+// NewGeneration returns the record of a resource generated in the background.
+// The prior state is nil when the generation created the resource.
+func NewGeneration(policy PolicyRef, prior, generated *unstructured.Unstructured) (*Record, error) {
+	record := &Record{
+		Policy:          policy,
+		Operation:       OperationGenerate,
+		Resource:        common.ResourceSpecFromUnstructured(*generated),
+		ResourceVersion: generated.GetResourceVersion(),
+		Timestamp:       metav1.Now(),
+	}
+	if prior != nil {
+		data, err := json.Marshal(withoutSystemMetadata(prior).Object)
+		if err != nil {
+			return nil, err
+		}
+		record.Prior = string(data)
+	}
+	return record, nil
+}
+
+// Recorder records the changes made to resources by background processing.
+type Recorder interface {
+	Record(ctx context.Context, record Record) error
+}
+
+// Store keeps the records in config maps, records older than the retention
+// and the oldest records above the limit are pruned.
+type Store struct {
+	client    corev1client.ConfigMapsGetter
+	namespace string
+	retention time.Duration
+	limit     int
+}
+
+func NewStore(client corev1client.ConfigMapsGetter, namespace string, retention time.Duration, limit int) *Store {
+	return &Store{
+		client:    client,
+		namespace: namespace,
+		retention: retention,
+		limit:     limit,
+	}
+}
+
+// Record stores a record. Generations are recorded once per policy version and resource version.
+func (s *Store) Record(ctx context.Context, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+				labelRecord:               "true",
+				labelPolicyKind:           record.Policy.Kind,
+				labelPolicyNamespace:      record.Policy.Namespace,
+				labelPolicyName:           trimByLength(record.Policy.Name, 63),
+				labelPolicyVersion:        strconv.FormatInt(record.Policy.Version, 10),
+			},
+		},
+		Data: map[string]string{
+			dataKey: string(data),
+		},
+	}
+	if record.Operation == OperationGenerate {
+		cm.SetName(namePrefix + hash(record.Policy, record.Resource, record.ResourceVersion))
+	} else {
+		cm.SetGenerateName(namePrefix)
+	}
+	if _, err := s.client.ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// List returns the records of a policy, oldest first. A zero version returns the records of all versions.
+func (s *Store) List(ctx context.Context, kind, namespace, name string, version int64) ([]Record, error) {
+	selector := labels.Set{
+		labelRecord:          "true",
+		labelPolicyKind:      kind,
+		labelPolicyNamespace: namespace,
+		labelPolicyName:      trimByLength(name, 63),
+	}
+	if version != 0 {
+		selector[labelPolicyVersion] = strconv.FormatInt(version, 10)
+	}
+	list, err := s.client.ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	for _, cm := range list.Items {
+		var record Record
+		if err := json.Unmarshal([]byte(cm.Data[dataKey]), &record); err != nil {
+			return nil, fmt.Errorf("failed to decode rollback record %s: %w", cm.GetName(), err)
+		}
+		// the name label may be truncated
+		if record.Policy.Name != name {
+			continue
+		}
+		record.Name = cm.GetName()
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(&records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// Delete deletes a record.
+func (s *Store) Delete(ctx context.Context, record Record) error {
+	if err := s.client.ConfigMaps(s.namespace).Delete(ctx, record.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Prune deletes the records older than the retention, then the oldest records above the limit.
+func (s *Store) Prune(ctx context.Context) error {
+	selector := labels.Set{labelRecord: "true"}
+	list, err := s.client.ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(-s.retention)
+	var expired, kept []string
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		return list.Items[i].CreationTimestamp.Before(&list.Items[j].CreationTimestamp)
+	})
+	for _, cm := range list.Items {
+		if cm.GetCreationTimestamp().After(deadline) {
+			kept = append(kept, cm.GetName())
+		} else {
+			expired = append(expired, cm.GetName())
+		}
+	}
+	if s.limit > 0 && len(kept) > s.limit {
+		expired = append(expired, kept[:len(kept)-s.limit]...)
+	}
+	for _, name := range expired {
+		if err := s.client.ConfigMaps(s.namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run periodically prunes the records.
+func (s *Store) Run(ctx context.Context, _ int) {
+	interval := min(s.retention, pruneInterval)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.Prune(ctx); err != nil {
+			logger.Error(err, "failed to prune rollback records")
+		}
+	}, interval)
+}
+
+// withoutSystemMetadata removes the metadata managed by the API server, it must not be reverted
+func withoutSystemMetadata(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	for _, field := range []string{"resourceVersion", "managedFields", "generation", "creationTimestamp", "uid"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	return obj
+}
+
+func hash(policy PolicyRef, resource kyvernov1.ResourceSpec, resourceVersion string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s/%s/%s/%d", policy.Kind, policy.Namespace, policy.Name, policy.Version)
+	fmt.Fprintf(h, "%s/%s/%s/%s/%s", resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), resourceVersion)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func trimByLength(value string, length int) string {
+	if len(value) > length {
+		return value[:length]
+	}
+	return value
+}
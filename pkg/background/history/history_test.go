@@ -0,0 +1,159 @@
+package history
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newConfigMap(labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":            "config",
+			"namespace":       "team-a",
+			"uid":             "uid",
+			"resourceVersion": "1",
+		},
+	}}
+	if labels != nil {
+		obj.SetLabels(labels)
+	}
+	return obj
+}
+
+func TestNewMutation(t *testing.T) {
+	original := newConfigMap(nil)
+	updated := newConfigMap(map[string]string{"team": "a"})
+	updated.SetResourceVersion("2")
+	policy := PolicyRef{Kind: "MutatingPolicy", Name: "add-labels", Version: 3}
+	record, err := NewMutation(policy, original, updated, "")
+	assert.NoError(t, err)
+	assert.Equal(t, OperationMutate, record.Operation)
+	assert.Equal(t, policy, record.Policy)
+	assert.Equal(t, "config", record.Resource.Name)
+	assert.Equal(t, "uid", string(record.Resource.UID))
+	assert.Equal(t, `[{"op":"remove","path":"/metadata/labels"}]`, record.Patch, "system metadata is not reverted")
+}
+
+func TestNewGeneration(t *testing.T) {
+	policy := PolicyRef{Kind: "GeneratingPolicy", Name: "generate-netpol", Version: 1}
+	generated := newConfigMap(map[string]string{"team": "a"})
+	record, err := NewGeneration(policy, nil, generated)
+	assert.NoError(t, err)
+	assert.Equal(t, OperationGenerate, record.Operation)
+	assert.Empty(t, record.Prior, "the generation created the resource")
+	assert.Equal(t, "1", record.ResourceVersion)
+
+	record, err = NewGeneration(policy, newConfigMap(nil), generated)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"team-a"}}`, record.Prior, "system metadata is not restored")
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset()
+	store := NewStore(client.CoreV1(), "kyverno", time.Hour, 0)
+	v1 := PolicyRef{Kind: "GeneratingPolicy", Name: "generate-netpol", Version: 1}
+	v2 := PolicyRef{Kind: "GeneratingPolicy", Name: "generate-netpol", Version: 2}
+	other := PolicyRef{Kind: "GeneratingPolicy", Name: "other", Version: 1}
+	resource := newConfigMap(nil)
+	updated := newConfigMap(map[string]string{"team": "a"})
+	updated.SetResourceVersion("2")
+	generation := func(policy PolicyRef, prior, generated *unstructured.Unstructured) Record {
+		record, err := NewGeneration(policy, prior, generated)
+		assert.NoError(t, err)
+		return *record
+	}
+
+	assert.NoError(t, store.Record(ctx, generation(v1, nil, resource)))
+	assert.NoError(t, store.Record(ctx, generation(v1, nil, resource)), "generations are recorded once per resource version")
+	assert.NoError(t, store.Record(ctx, generation(v1, resource, updated)))
+	assert.NoError(t, store.Record(ctx, generation(v2, nil, resource)))
+	assert.NoError(t, store.Record(ctx, generation(other, nil, resource)))
+
+	records, err := store.List(ctx, "GeneratingPolicy", "", "generate-netpol", 1)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2, "successive generations of a resource are recorded")
+
+	records, err = store.List(ctx, "GeneratingPolicy", "", "generate-netpol", 0)
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	for _, record := range records {
+		assert.True(t, strings.HasPrefix(record.Name, namePrefix))
+	}
+
+	records, err = store.List(ctx, "GeneratingPolicy", "", "generate-netpol", 2)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, v2, records[0].Policy)
+
+	assert.NoError(t, store.Delete(ctx, records[0]))
+	records, err = store.List(ctx, "GeneratingPolicy", "", "generate-netpol", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestStore_Prune(t *testing.T) {
+	ctx := context.TODO()
+	record := func(name string, age time.Duration) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "kyverno",
+				Labels:            map[string]string{labelRecord: "true"},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		record("old", 2*time.Hour),
+		record("recent", time.Minute),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kyverno", Namespace: "kyverno"}},
+	)
+	store := NewStore(client.CoreV1(), "kyverno", time.Hour, 0)
+	assert.NoError(t, store.Prune(ctx))
+	list, err := client.CoreV1().ConfigMaps("kyverno").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	var names []string
+	for _, cm := range list.Items {
+		names = append(names, cm.GetName())
+	}
+	assert.ElementsMatch(t, []string{"recent", "kyverno"}, names)
+}
+
+func TestStore_PruneLimit(t *testing.T) {
+	ctx := context.TODO()
+	record := func(name string, age time.Duration) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "kyverno",
+				Labels:            map[string]string{labelRecord: "true"},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		record("third", time.Minute),
+		record("first", 3*time.Minute),
+		record("second", 2*time.Minute),
+	)
+	store := NewStore(client.CoreV1(), "kyverno", time.Hour, 2)
+	assert.NoError(t, store.Prune(ctx))
+	list, err := client.CoreV1().ConfigMaps("kyverno").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	var names []string
+	for _, cm := range list.Items {
+		names = append(names, cm.GetName())
+	}
+	assert.ElementsMatch(t, []string{"second", "third"}, names, "the oldest records are pruned")
+}
@@ -0,0 +1,5 @@
+package history
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName("background.history")
@@ -0,0 +1,124 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Client is the subset of the dynamic client used to roll back changes.
+type Client interface {
+	GetResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, subresources ...string) (*unstructured.Unstructured, error)
+	UpdateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool, subresources ...string) (*unstructured.Unstructured, error)
+	DeleteResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool, options metav1.DeleteOptions) error
+}
+
+// Rollback reverts the changes of the records, newest first.
+// Mutated resources are patched back to their prior state. Generated resources still owned by the policy
+// are restored to their prior state, or deleted when the generation created them.
+// The records that were rolled back successfully are returned.
+func Rollback(ctx context.Context, client Client, records []Record, dryRun bool) ([]Record, error) {
+	var done []Record
+	var failures []error
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		var err error
+		switch record.Operation {
+		case OperationMutate:
+			err = revertMutation(ctx, client, record, dryRun)
+		case OperationGenerate:
+			err = revertGeneration(ctx, client, record, dryRun)
+		default:
+			err = fmt.Errorf("unknown operation %q", record.Operation)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Errorf("failed to roll back %s of %s: %w", record.Operation, record.Resource.String(), err))
+			continue
+		}
+		done = append(done, record)
+	}
+	return done, multierr.Combine(failures...)
+}
+
+func revertMutation(ctx context.Context, client Client, record Record, dryRun bool) error {
+	resource := record.Resource
+	object, err := client.GetResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	if err != nil {
+		// the resource was deleted since it was mutated
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if resource.UID != "" && object.GetUID() != resource.UID {
+		// the resource was recreated since it was mutated
+		return nil
+	}
+	patch, err := jsonpatch.DecodePatch([]byte(record.Patch))
+	if err != nil {
+		return err
+	}
+	current, err := json.Marshal(object.Object)
+	if err != nil {
+		return err
+	}
+	reverted, err := patch.Apply(current)
+	if err != nil {
+		return err
+	}
+	var prior unstructured.Unstructured
+	if err := json.Unmarshal(reverted, &prior.Object); err != nil {
+		return err
+	}
+	prior.SetResourceVersion(object.GetResourceVersion())
+	var subresources []string
+	if record.Subresource != "" {
+		subresources = append(subresources, record.Subresource)
+	}
+	if _, err := client.UpdateResource(ctx, prior.GetAPIVersion(), prior.GetKind(), prior.GetNamespace(), prior.Object, dryRun, subresources...); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func revertGeneration(ctx context.Context, client Client, record Record, dryRun bool) error {
+	resource := record.Resource
+	object, err := client.GetResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if resource.UID != "" && object.GetUID() != resource.UID {
+		// the resource was recreated since it was generated
+		return nil
+	}
+	// don't revert a resource that is not owned by the policy anymore
+	if object.GetLabels()[common.GeneratePolicyLabel] != record.Policy.Name {
+		return nil
+	}
+	// the resource was created by the generation
+	if record.Prior == "" {
+		if err := client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), dryRun, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	var prior unstructured.Unstructured
+	if err := json.Unmarshal([]byte(record.Prior), &prior.Object); err != nil {
+		return err
+	}
+	prior.SetResourceVersion(object.GetResourceVersion())
+	if _, err := client.UpdateResource(ctx, prior.GetAPIVersion(), prior.GetKind(), prior.GetNamespace(), prior.Object, dryRun); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
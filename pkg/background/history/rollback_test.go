@@ -0,0 +1,115 @@
+package history
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeClient struct {
+	objects map[string]*unstructured.Unstructured
+	deleted []string
+}
+
+func (c *fakeClient) GetResource(_ context.Context, _ string, kind string, _ string, name string, _ ...string) (*unstructured.Unstructured, error) {
+	obj, ok := c.objects[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+	}
+	return obj.DeepCopy(), nil
+}
+
+func (c *fakeClient) UpdateResource(_ context.Context, _ string, _ string, _ string, obj interface{}, dryRun bool, _ ...string) (*unstructured.Unstructured, error) {
+	updated := &unstructured.Unstructured{Object: obj.(map[string]any)}
+	if !dryRun {
+		c.objects[updated.GetName()] = updated
+	}
+	return updated, nil
+}
+
+func (c *fakeClient) DeleteResource(_ context.Context, _ string, _ string, _ string, name string, dryRun bool, _ metav1.DeleteOptions) error {
+	if !dryRun {
+		delete(c.objects, name)
+		c.deleted = append(c.deleted, name)
+	}
+	return nil
+}
+
+func TestRollback(t *testing.T) {
+	policy := PolicyRef{Kind: "MutatingPolicy", Name: "add-labels", Version: 1}
+
+	original := newConfigMap(nil)
+	first := newConfigMap(map[string]string{"team": "a"})
+	second := newConfigMap(map[string]string{"team": "a", "env": "prod"})
+	mutation1, err := NewMutation(policy, original, first, "")
+	assert.NoError(t, err)
+	mutation2, err := NewMutation(policy, first, second, "")
+	assert.NoError(t, err)
+
+	generated := newConfigMap(map[string]string{common.GeneratePolicyLabel: "add-labels"})
+	generated.SetName("generated")
+	foreign := newConfigMap(map[string]string{common.GeneratePolicyLabel: "another-policy"})
+	foreign.SetName("foreign")
+	prior := newConfigMap(map[string]string{common.GeneratePolicyLabel: "add-labels"})
+	prior.SetName("updated")
+	prior.Object["data"] = map[string]any{"foo": "bar"}
+	updated := prior.DeepCopy()
+	updated.Object["data"] = map[string]any{"foo": "baz"}
+	generation := func(prior, generated *unstructured.Unstructured) Record {
+		record, err := NewGeneration(policy, prior, generated)
+		assert.NoError(t, err)
+		return *record
+	}
+
+	current := second.DeepCopy()
+	current.Object["data"] = map[string]any{"foo": "bar"}
+	client := &fakeClient{objects: map[string]*unstructured.Unstructured{
+		"config":    current,
+		"generated": generated,
+		"foreign":   foreign,
+		"updated":   updated,
+	}}
+	records := []Record{
+		*mutation1,
+		*mutation2,
+		generation(nil, generated),
+		generation(nil, foreign),
+		generation(nil, newConfigMap(nil).DeepCopy()),
+		generation(prior, updated),
+	}
+	records[4].Resource.Name = "missing"
+
+	// dry run doesn't change anything
+	done, err := Rollback(context.TODO(), client, records, true)
+	assert.NoError(t, err)
+	assert.Len(t, done, 6)
+	assert.Equal(t, current, client.objects["config"])
+	assert.Equal(t, updated, client.objects["updated"])
+
+	done, err = Rollback(context.TODO(), client, records, false)
+	assert.NoError(t, err)
+	assert.Len(t, done, 6)
+	assert.Empty(t, client.objects["config"].GetLabels(), "mutations are reverted")
+	assert.Equal(t, map[string]any{"foo": "bar"}, client.objects["config"].Object["data"], "later changes are preserved")
+	assert.Equal(t, []string{"generated"}, client.deleted, "only resources created by the policy are deleted")
+	assert.Equal(t, map[string]any{"foo": "bar"}, client.objects["updated"].Object["data"], "updated resources are restored")
+}
+
+func TestRollback_RecreatedResource(t *testing.T) {
+	policy := PolicyRef{Kind: "MutatingPolicy", Name: "add-labels", Version: 1}
+	mutation, err := NewMutation(policy, newConfigMap(nil), newConfigMap(map[string]string{"team": "a"}), "")
+	assert.NoError(t, err)
+	recreated := newConfigMap(map[string]string{"team": "a"})
+	recreated.SetUID("another-uid")
+	client := &fakeClient{objects: map[string]*unstructured.Unstructured{"config": recreated}}
+	done, err := Rollback(context.TODO(), client, []Record{*mutation}, false)
+	assert.NoError(t, err)
+	assert.Len(t, done, 1)
+	assert.Equal(t, map[string]string{"team": "a"}, client.objects["config"].GetLabels())
+}
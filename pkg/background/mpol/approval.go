@@ -0,0 +1,177 @@
+package mpol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/config"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"go.uber.org/multierr"
+	createpatch "gomodules.xyz/jsonpatch/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// requiresApproval returns true if the changes computed by the policy must be approved before being applied
+func requiresApproval(mpol v1beta1.MutatingPolicyLike) bool {
+	return mpol.GetAnnotations()[kyverno.AnnotationPolicyRequireApproval] == "true"
+}
+
+// newChangeSet computes the JSON patch turning the target into the patched resource, the target is recorded as the base of the patch
+func newChangeSet(target, patched *unstructured.Unstructured, subresource string) (*kyvernov2.ChangeSet, error) {
+	original, err := json.Marshal(target.Object)
+	if err != nil {
+		return nil, err
+	}
+	base := target.DeepCopy()
+	base.SetManagedFields(nil)
+	baseJSON, err := json.Marshal(base.Object)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := json.Marshal(patched.Object)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := createpatch.CreatePatch(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+	return &kyvernov2.ChangeSet{
+		Resource:        common.ResourceSpecFromUnstructured(*target),
+		Subresource:     subresource,
+		Patch:           string(patch),
+		ResourceVersion: target.GetResourceVersion(),
+		Base:            string(baseJSON),
+	}, nil
+}
+
+// waitForApproval stores the change sets in the update request status until they are approved.
+// If the same change sets are already waiting for approval in another update request, the update request is completed.
+func (p *processor) waitForApproval(ur *kyvernov2.UpdateRequest, changeSets []kyvernov2.ChangeSet) error {
+	urs, err := p.urLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, other := range urs {
+		if other.GetName() == ur.GetName() || other.Status.State != kyvernov2.WaitingForApproval {
+			continue
+		}
+		if other.Spec.GetRequestType() != ur.Spec.GetRequestType() || other.Spec.GetPolicyKey() != ur.Spec.GetPolicyKey() {
+			continue
+		}
+		if datautils.DeepEqual(other.Status.ChangeSets, changeSets) {
+			logger.V(3).Info("changes already waiting for approval", "ur", ur.GetName(), "pending", other.GetName())
+			_, err := p.statusControl.Success(ur.GetName(), nil)
+			return err
+		}
+	}
+	logger.V(2).Info("changes waiting for approval", "ur", ur.GetName(), "mpol", ur.Spec.GetPolicyKey(), "changes", len(changeSets))
+	_, err = p.statusControl.WaitForApproval(ur.GetName(), changeSets)
+	return err
+}
+
+// staleChangeSets returns true if a target resource changed since its change set was computed.
+// Deleted targets are not considered stale, there is nothing left to change.
+func (p *processor) staleChangeSets(ur *kyvernov2.UpdateRequest) (bool, error) {
+	for _, changeSet := range ur.Status.ChangeSets {
+		if changeSet.ResourceVersion == "" {
+			continue
+		}
+		resource := changeSet.Resource
+		object, err := p.client.GetResource(context.TODO(), resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if object.GetResourceVersion() != changeSet.ResourceVersion {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// revokeApproval removes the approval of an update request, its changes must be computed and approved again
+func (p *processor) revokeApproval(ur *kyvernov2.UpdateRequest) (*kyvernov2.UpdateRequest, error) {
+	latest, err := p.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), ur.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	latest = latest.DeepCopy()
+	annotations := latest.GetAnnotations()
+	delete(annotations, kyvernov2.URApprovedAnnotation)
+	latest.SetAnnotations(annotations)
+	return p.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Update(context.TODO(), latest, metav1.UpdateOptions{})
+}
+
+// applyChangeSets applies the approved change sets to the current state of the target resources
+func (p *processor) applyChangeSets(ur *kyvernov2.UpdateRequest, mpol v1beta1.MutatingPolicyLike) error {
+	var failures []error
+	for _, changeSet := range ur.Status.ChangeSets {
+		if err := p.applyChangeSet(changeSet, mpol); err != nil {
+			failures = append(failures, fmt.Errorf("failed to apply approved change to %s for mpol %s: %v", changeSet.Resource.String(), ur.Spec.GetPolicyKey(), err))
+		}
+	}
+	return multierr.Combine(failures...)
+}
+
+func (p *processor) applyChangeSet(changeSet kyvernov2.ChangeSet, mpol v1beta1.MutatingPolicyLike) error {
+	resource := changeSet.Resource
+	object, err := p.client.GetResource(context.TODO(), resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	if err != nil {
+		// the target was deleted since the change was computed
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	patch, err := jsonpatch.DecodePatch([]byte(changeSet.Patch))
+	if err != nil {
+		return err
+	}
+	original, err := json.Marshal(object.Object)
+	if err != nil {
+		return err
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return err
+	}
+	var new unstructured.Unstructured
+	if err := json.Unmarshal(patched, &new.Object); err != nil {
+		return err
+	}
+	// the update conflicts if the target changed since the change set was approved
+	if changeSet.ResourceVersion != "" {
+		new.SetResourceVersion(changeSet.ResourceVersion)
+	} else {
+		new.SetResourceVersion(object.GetResourceVersion())
+	}
+	var subresources []string
+	if changeSet.Subresource != "" {
+		subresources = append(subresources, changeSet.Subresource)
+	}
+	updated, err := p.client.UpdateResource(context.TODO(), new.GetAPIVersion(), new.GetKind(), new.GetNamespace(), new.Object, false, subresources...)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	p.record(mpol, object, updated, changeSet.Subresource)
+	return nil
+}
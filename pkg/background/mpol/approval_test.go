@@ -0,0 +1,203 @@
+package mpol
+
+import (
+	"context"
+	"testing"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/history"
+	"github.com/kyverno/kyverno/pkg/cel/libs"
+	mpolengine "github.com/kyverno/kyverno/pkg/cel/policies/mpol/engine"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNewChangeSet(t *testing.T) {
+	target := &unstructured.Unstructured{}
+	target.SetAPIVersion("v1")
+	target.SetKind("ConfigMap")
+	target.SetNamespace("default")
+	target.SetName("cm")
+	target.SetResourceVersion("42")
+	target.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+	patched := target.DeepCopy()
+	patched.SetLabels(map[string]string{"team": "a"})
+
+	changeSet, err := newChangeSet(target, patched, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "ConfigMap", changeSet.Resource.Kind)
+	assert.Equal(t, "default", changeSet.Resource.Namespace)
+	assert.Equal(t, "cm", changeSet.Resource.Name)
+	assert.JSONEq(t, `[{"op":"add","path":"/metadata/labels","value":{"team":"a"}}]`, changeSet.Patch)
+	assert.Equal(t, "42", changeSet.ResourceVersion)
+	assert.JSONEq(t, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default","resourceVersion":"42"}}`, changeSet.Base)
+}
+
+func newApprovalProcessor(t *testing.T, cm *unstructured.Unstructured, urs ...*kyvernov2.UpdateRequest) (*processor, *fakeStatusControl, dclient.Interface, *fakeRecorder) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMapList"}, &unstructured.UnstructuredList{})
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Group: "", Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+	fakeClient, err := dclient.NewFakeClient(scheme, gvrToListKind, cm)
+	assert.NoError(t, err)
+	fakeClient.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	objects := []runtime.Object{
+		&policiesv1beta1.MutatingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "bgpol",
+				Annotations: map[string]string{kyverno.AnnotationPolicyRequireApproval: "true"},
+			},
+			Spec: policiesv1beta1.MutatingPolicySpec{
+				MatchConstraints: &admissionregistrationv1.MatchResources{
+					ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"configmaps"},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, ur := range urs {
+		objects = append(objects, ur)
+		assert.NoError(t, indexer.Add(ur))
+	}
+	kyvernoClient := fake.NewSimpleClientset(objects...)
+
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+	patched := cm.DeepCopy()
+	patched.SetLabels(map[string]string{"team": "a"})
+	eng := &fakeEngine{}
+	eng.On("Evaluate").Return(mpolengine.EngineResponse{PatchedResource: patched}, nil)
+	sc := &fakeStatusControl{}
+	recorder := &fakeRecorder{}
+	p := NewProcessor(
+		fakeClient,
+		kyvernoClient,
+		eng,
+		restMapper,
+		&libs.FakeContextProvider{},
+		sc,
+		kyvernov2listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+		event.NewFake(),
+		nil,
+		recorder,
+	)
+	return p, sc, fakeClient, recorder
+}
+
+func newTargetConfigMap(resourceVersion string) *unstructured.Unstructured {
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetNamespace("default")
+	cm.SetName("target-cm")
+	cm.SetResourceVersion(resourceVersion)
+	return cm
+}
+
+func newApprovalUpdateRequest() *kyvernov2.UpdateRequest {
+	return &kyvernov2.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "ur-approval", Namespace: config.KyvernoNamespace()},
+		Spec: kyvernov2.UpdateRequestSpec{
+			Type:   kyvernov2.CELMutate,
+			Policy: "bgpol",
+		},
+		Status: kyvernov2.UpdateRequestStatus{State: kyvernov2.Pending},
+	}
+}
+
+func TestProcess_RequireApproval(t *testing.T) {
+	p, sc, fakeClient, recorder := newApprovalProcessor(t, newTargetConfigMap(""))
+	ur := newApprovalUpdateRequest()
+
+	// changes are stored, the target is left untouched
+	assert.NoError(t, p.Process(ur))
+	assert.True(t, sc.waitCalled)
+	assert.False(t, sc.successCalled)
+	assert.Len(t, sc.changeSets, 1)
+	current, err := fakeClient.GetResource(context.TODO(), "v1", "ConfigMap", "default", "target-cm")
+	assert.NoError(t, err)
+	assert.Empty(t, current.GetLabels())
+
+	// nothing happens until the update request is approved
+	ur.Status = kyvernov2.UpdateRequestStatus{State: kyvernov2.WaitingForApproval, ChangeSets: sc.changeSets}
+	assert.NoError(t, p.Process(ur))
+	assert.False(t, sc.successCalled)
+
+	// approved changes are applied
+	ur.SetAnnotations(map[string]string{kyvernov2.URApprovedAnnotation: "true"})
+	assert.NoError(t, p.Process(ur))
+	assert.True(t, sc.successCalled)
+	current, err = fakeClient.GetResource(context.TODO(), "v1", "ConfigMap", "default", "target-cm")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "a"}, current.GetLabels())
+	assert.Len(t, recorder.records, 1)
+	assert.Equal(t, history.OperationMutate, recorder.records[0].Operation)
+	assert.Equal(t, "MutatingPolicy", recorder.records[0].Policy.Kind)
+}
+
+func TestProcess_RequireApprovalStaleTarget(t *testing.T) {
+	ur := newApprovalUpdateRequest()
+	p, sc, fakeClient, _ := newApprovalProcessor(t, newTargetConfigMap("1"), ur)
+
+	assert.NoError(t, p.Process(ur.DeepCopy()))
+	assert.True(t, sc.waitCalled)
+	assert.Len(t, sc.changeSets, 1)
+	assert.Equal(t, "1", sc.changeSets[0].ResourceVersion)
+
+	// the target changes after the changes were computed
+	changed := newTargetConfigMap("2")
+	changed.SetAnnotations(map[string]string{"edited": "true"})
+	_, err := fakeClient.UpdateResource(context.TODO(), "v1", "ConfigMap", "default", changed, false)
+	assert.NoError(t, err)
+
+	// the approval is revoked and the changes are computed again
+	sc.waitCalled = false
+	approved := ur.DeepCopy()
+	approved.SetAnnotations(map[string]string{kyvernov2.URApprovedAnnotation: "true"})
+	approved.Status = kyvernov2.UpdateRequestStatus{State: kyvernov2.WaitingForApproval, ChangeSets: sc.changeSets}
+	assert.NoError(t, p.Process(approved))
+	assert.True(t, sc.waitCalled)
+	assert.False(t, sc.successCalled)
+	assert.Equal(t, "2", sc.changeSets[0].ResourceVersion)
+	current, err := fakeClient.GetResource(context.TODO(), "v1", "ConfigMap", "default", "target-cm")
+	assert.NoError(t, err)
+	assert.Empty(t, current.GetLabels())
+	latest, err := p.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), ur.GetName(), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.False(t, latest.IsApproved())
+}
+
+type fakeRecorder struct {
+	records []history.Record
+}
+
+func (r *fakeRecorder) Record(_ context.Context, record history.Record) error {
+	r.records = append(r.records, record)
+	return nil
+}
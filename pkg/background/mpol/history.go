@@ -0,0 +1,28 @@
+package mpol
+
+import (
+	"context"
+
+	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/history"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// record keeps the prior state of a mutated resource so that the mutation can be rolled back
+func (p *processor) record(mpol v1beta1.MutatingPolicyLike, original, updated *unstructured.Unstructured, subresource string) {
+	if p.recorder == nil || updated == nil {
+		return
+	}
+	kind := "MutatingPolicy"
+	if mpol.GetNamespace() != "" {
+		kind = "NamespacedMutatingPolicy"
+	}
+	record, err := history.NewMutation(history.NewPolicyRef(kind, mpol), original, updated, subresource)
+	if err != nil {
+		logger.Error(err, "failed to compute rollback record", "mpol", mpol.GetName(), "resource", original.GetName())
+		return
+	}
+	if err := p.recorder.Record(context.TODO(), *record); err != nil {
+		logger.Error(err, "failed to store rollback record", "mpol", mpol.GetName(), "resource", original.GetName())
+	}
+}
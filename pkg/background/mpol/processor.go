@@ -15,11 +15,13 @@ import (
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/admissionpolicy"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/history"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
 	mpolengine "github.com/kyverno/kyverno/pkg/cel/policies/mpol/engine"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -53,9 +55,11 @@ type processor struct {
 	mapper        meta.RESTMapper
 	context       libs.Context
 	statusControl common.StatusControlInterface
+	urLister      kyvernov2listers.UpdateRequestNamespaceLister
 	configuration config.Configuration
 
 	eventGen event.Interface
+	recorder history.Recorder
 }
 
 type gvkItem struct {
@@ -77,8 +81,10 @@ func NewProcessor(client dclient.Interface,
 	mapper meta.RESTMapper,
 	context libs.Context,
 	statusControl common.StatusControlInterface,
+	urLister kyvernov2listers.UpdateRequestNamespaceLister,
 	eventGen event.Interface,
 	configuration config.Configuration,
+	recorder history.Recorder,
 ) *processor {
 	return &processor{
 		client:        client,
@@ -87,18 +93,43 @@ func NewProcessor(client dclient.Interface,
 		mapper:        mapper,
 		context:       context,
 		statusControl: statusControl,
+		urLister:      urLister,
 		eventGen:      eventGen,
 		configuration: configuration,
+		recorder:      recorder,
 	}
 }
 
 func (p *processor) Process(ur *kyvernov2.UpdateRequest) error {
 	var (
-		err      error
-		failures []error
-		targets  []resolvedTarget
+		err        error
+		failures   []error
+		targets    []resolvedTarget
+		changeSets []kyvernov2.ChangeSet
 	)
 
+	if ur.Status.State == kyvernov2.WaitingForApproval {
+		if !ur.IsApproved() {
+			return nil
+		}
+		stale, err := p.staleChangeSets(ur)
+		if err != nil {
+			return updateURStatus(p.statusControl, *ur, err, nil)
+		}
+		if !stale {
+			mpol, err := p.GetPolicy(ur)
+			if mpol == nil {
+				return err
+			}
+			return updateURStatus(p.statusControl, *ur, p.applyChangeSets(ur, mpol), nil)
+		}
+		// the targets changed since the changes were computed, compute them again and wait for a new approval
+		logger.V(2).Info("targets changed since approval, approval required again", "ur", ur.GetName(), "mpol", ur.Spec.GetPolicyKey())
+		if ur, err = p.revokeApproval(ur); err != nil {
+			return err
+		}
+	}
+
 	if ur.Spec.Policy == "" {
 		return updateURStatus(p.statusControl, *ur, fmt.Errorf("update request %s has empty policy key", ur.GetName()), nil)
 	}
@@ -171,6 +202,7 @@ func (p *processor) Process(ur *kyvernov2.UpdateRequest) error {
 	// but URs created by older versions may still carry the bare policy name; deriving scope from
 	// the resolved policy keeps both compatible.
 	policyName := mpol.GetName()
+	approval := requiresApproval(mpol) && !ur.IsApproved()
 	scopePredicate := mpolengine.ClusteredPolicy()
 	if ns := mpol.GetNamespace(); ns != "" {
 		scopePredicate = mpolengine.NamespacedPolicy(ns)
@@ -242,6 +274,15 @@ func (p *processor) Process(ur *kyvernov2.UpdateRequest) error {
 				}
 				continue
 			}
+			if approval {
+				changeSet, err := newChangeSet(object, response.PatchedResource, target.subresource)
+				if err != nil {
+					failures = append(failures, fmt.Errorf("failed to compute changes for mpol %s: %v", ur.Spec.GetPolicyKey(), err))
+					continue
+				}
+				changeSets = append(changeSets, *changeSet)
+				continue
+			}
 			object, err = p.client.GetResource(context.TODO(), object.GetAPIVersion(), object.GetKind(), object.GetNamespace(), object.GetName())
 			if err != nil {
 				// The target may have been deleted between resolution and update
@@ -258,7 +299,8 @@ func (p *processor) Process(ur *kyvernov2.UpdateRequest) error {
 			if target.subresource != "" {
 				subresources = append(subresources, target.subresource)
 			}
-			if _, err := p.client.UpdateResource(context.TODO(), new.GetAPIVersion(), new.GetKind(), new.GetNamespace(), new.Object, false, subresources...); err != nil {
+			updated, err := p.client.UpdateResource(context.TODO(), new.GetAPIVersion(), new.GetKind(), new.GetNamespace(), new.Object, false, subresources...)
+			if err != nil {
 				// The target may have been deleted between the re-fetch and the update; don't fail the UR.
 				if apierrors.IsNotFound(err) {
 					continue
@@ -266,13 +308,19 @@ func (p *processor) Process(ur *kyvernov2.UpdateRequest) error {
 				failures = append(failures, fmt.Errorf("failed to update target resource for mpol %s: %v", ur.Spec.GetPolicyKey(), err))
 				continue
 			}
+			p.record(mpol, object, updated, target.subresource)
 
-			err := p.audit(object, &response)
-			if err != nil {
+			if err := p.audit(object, &response); err != nil {
 				logger.Error(err, "failed to create reports for mpol", "mpol", ur.Spec.GetPolicyKey())
 			}
 		}
 	}
+	if len(changeSets) != 0 {
+		if err := multierr.Combine(failures...); err != nil {
+			logger.Error(err, "failed to compute changes for some targets", "mpol", ur.Spec.GetPolicyKey())
+		}
+		return p.waitForApproval(ur, changeSets)
+	}
 	return updateURStatus(p.statusControl, *ur, multierr.Combine(failures...), nil)
 }
 
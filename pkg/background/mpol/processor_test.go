@@ -49,6 +49,8 @@ var (
 type fakeStatusControl struct {
 	failedCalled  bool
 	successCalled bool
+	waitCalled    bool
+	changeSets    []kyvernov2.ChangeSet
 }
 
 func (f *fakeStatusControl) Failed(name, msg string, genResources []kyvernov1.ResourceSpec) (*kyvernov2.UpdateRequest, error) {
@@ -63,6 +65,11 @@ func (f *fakeStatusControl) Skip(name string, genResources []kyvernov1.ResourceS
 	f.successCalled = true
 	return &kyvernov2.UpdateRequest{}, nil
 }
+func (f *fakeStatusControl) WaitForApproval(name string, changeSets []kyvernov2.ChangeSet) (*kyvernov2.UpdateRequest, error) {
+	f.waitCalled = true
+	f.changeSets = changeSets
+	return &kyvernov2.UpdateRequest{}, nil
+}
 
 type fakeEngine struct {
 	mock.Mock
@@ -102,7 +109,9 @@ func TestProcess_NoPolicyFound(t *testing.T) {
 		meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "kyverno.io", Version: "v1"}}),
 		&libs.FakeContextProvider{},
 		&fakeStatusControl{},
+		nil,
 		event.NewFake(),
+		nil,
 		nil)
 
 	ur := &kyvernov2.UpdateRequest{
@@ -153,8 +162,10 @@ func TestProcess_EngineEvaluateError(t *testing.T) {
 		meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "", Version: "v1"}}),
 		&libs.FakeContextProvider{},
 		&fakeStatusControl{},
+		nil,
 		event.NewFake(),
 		nil,
+		nil,
 	)
 
 	ur := &kyvernov2.UpdateRequest{
@@ -225,8 +236,10 @@ func TestProcess_FilteredTargetSkipsEngine(t *testing.T) {
 		restMapper,
 		&libs.FakeContextProvider{},
 		sc,
+		nil,
 		event.NewFake(),
 		cfg,
+		nil,
 	)
 
 	ur := &kyvernov2.UpdateRequest{
@@ -295,8 +308,10 @@ func TestProcess_NilAdmissionRequest_DoesNotPanic(t *testing.T) {
 		restMapper,
 		&libs.FakeContextProvider{},
 		&fakeStatusControl{},
+		nil,
 		event.NewFake(),
 		nil,
+		nil,
 	)
 
 	// UR has no AdmissionRequest — this is the background-scan case.
@@ -411,8 +426,10 @@ func TestGetPolicy_NamespacedMutatingPolicy(t *testing.T) {
 		meta.NewDefaultRESTMapper(nil),
 		&libs.FakeContextProvider{},
 		sc,
+		nil,
 		event.NewFake(),
 		nil,
+		nil,
 	)
 
 	ur := &kyvernov2.UpdateRequest{
@@ -440,8 +457,10 @@ func TestProcess_EmptyPolicyKey(t *testing.T) {
 		meta.NewDefaultRESTMapper(nil),
 		&libs.FakeContextProvider{},
 		sc,
+		nil,
 		event.NewFake(),
 		nil,
+		nil,
 	)
 
 	ur := &kyvernov2.UpdateRequest{
@@ -477,8 +496,10 @@ func TestGetPolicy_BareNameFallback_NamespacedMutatingPolicy(t *testing.T) {
 		meta.NewDefaultRESTMapper(nil),
 		&libs.FakeContextProvider{},
 		sc,
+		nil,
 		event.NewFake(),
 		nil,
+		nil,
 	)
 
 	// UR uses bare name (as created by webhook handler), with AdmissionRequest carrying the namespace.
@@ -530,8 +551,10 @@ func TestGetTargetsFromExpression_DeleteUsesOldObject(t *testing.T) {
 		meta.NewDefaultRESTMapper(nil),
 		&libs.FakeContextProvider{},
 		&fakeStatusControl{},
+		nil,
 		event.NewFake(),
 		nil,
+		nil,
 	)
 
 	trigger := map[string]any{
@@ -596,8 +619,10 @@ func TestProcess_TargetExpressionInvalidURMarksFailed(t *testing.T) {
 		meta.NewDefaultRESTMapper(nil),
 		&libs.FakeContextProvider{},
 		sc,
+		nil,
 		event.NewFake(),
 		nil,
+		nil,
 	)
 
 	ur := &kyvernov2.UpdateRequest{
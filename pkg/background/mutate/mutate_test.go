@@ -38,6 +38,10 @@ func (m *mockStatusControl) Skip(name string, genResources []kyvernov1.ResourceS
 	return nil, m.returnError
 }
 
+func (m *mockStatusControl) WaitForApproval(name string, changeSets []kyvernov2.ChangeSet) (*kyvernov2.UpdateRequest, error) {
+	return nil, m.returnError
+}
+
 func TestUpdateURStatus_SuccessCase(t *testing.T) {
 	mock := &mockStatusControl{}
 	ur := kyvernov2.UpdateRequest{
@@ -8,8 +8,10 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	common "github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/drift"
 	"github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/background/gpol"
+	"github.com/kyverno/kyverno/pkg/background/history"
 	"github.com/kyverno/kyverno/pkg/background/mpol"
 	"github.com/kyverno/kyverno/pkg/background/mutate"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
@@ -25,10 +27,12 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1informers "k8s.io/client-go/informers/core/v1"
@@ -72,6 +76,8 @@ type controller struct {
 	mpolEngine    mpolengine.Engine
 	restMapper    meta.RESTMapper
 	eventGen      event.Interface
+	driftReporter drift.Reporter
+	recorder      history.Recorder
 	configuration config.Configuration
 	jp            jmespath.Interface
 }
@@ -92,6 +98,8 @@ func NewController(
 	mpolEngine mpolengine.Engine,
 	restMapper meta.RESTMapper,
 	eventGen event.Interface,
+	driftReporter drift.Reporter,
+	recorder history.Recorder,
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
@@ -116,6 +124,8 @@ func NewController(
 		mpolEngine:    mpolEngine,
 		restMapper:    restMapper,
 		eventGen:      eventGen,
+		driftReporter: driftReporter,
+		recorder:      recorder,
 		configuration: configuration,
 		jp:            jp,
 	}
@@ -209,11 +219,28 @@ func (c *controller) syncUpdateRequest(key string) error {
 	}
 
 	if ur.Status.State == kyvernov2.Pending {
+		superseded, err := c.isSuperseded(ur)
+		if err != nil {
+			return err
+		}
+		if superseded {
+			return c.compactUR(ur)
+		}
+		if urMetrics := metrics.GetUpdateRequestMetrics(); urMetrics != nil {
+			urMetrics.RecordAge(context.TODO(), string(ur.Spec.GetRequestType()), time.Since(ur.GetCreationTimestamp().Time))
+		}
 		if err := c.processUR(ur); err != nil {
 			return fmt.Errorf("failed to process UR %s: %v", key, err)
 		}
 	}
 
+	// changes waiting for approval are applied once the update request is approved
+	if ur.Status.State == kyvernov2.WaitingForApproval && ur.IsApproved() {
+		if err := c.processUR(ur); err != nil {
+			return fmt.Errorf("failed to process approved UR %s: %v", key, err)
+		}
+	}
+
 	urStatus, err := c.reconcileURStatus(ur)
 	if err != nil {
 		return err
@@ -223,6 +250,57 @@ func (c *controller) syncUpdateRequest(key string) error {
 	return nil
 }
 
+// isSuperseded returns true if a more recent update request exists for the same policy, rules and trigger,
+// processing it would produce the same result so the current one can be dropped.
+func (c *controller) isSuperseded(ur *kyvernov2.UpdateRequest) (bool, error) {
+	var selector labels.Set
+	switch ur.Spec.GetRequestType() {
+	case kyvernov2.Mutate:
+		selector = common.MutateLabelsSet(ur.Spec.Policy, ur.Spec.GetResource())
+	case kyvernov2.Generate:
+		selector = common.GenerateLabelsSet(ur.Spec.Policy)
+	}
+	candidates, err := c.urLister.List(labels.SelectorFromSet(selector))
+	if err != nil {
+		return false, err
+	}
+	key := common.UpdateRequestKey(ur.Spec)
+	for _, candidate := range candidates {
+		if candidate.GetName() == ur.GetName() {
+			continue
+		}
+		if candidate.Status.State == kyvernov2.Completed || candidate.Status.State == kyvernov2.Skip {
+			continue
+		}
+		if !isNewer(candidate, ur) {
+			continue
+		}
+		if common.UpdateRequestKey(candidate.Spec) == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isNewer(a, b *kyvernov2.UpdateRequest) bool {
+	aTime, bTime := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if aTime.Equal(&bTime) {
+		return a.GetName() > b.GetName()
+	}
+	return bTime.Before(&aTime)
+}
+
+func (c *controller) compactUR(ur *kyvernov2.UpdateRequest) error {
+	logger.V(3).Info("deleting update request superseded by a more recent one", "name", ur.GetName())
+	if err := c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if urMetrics := metrics.GetUpdateRequestMetrics(); urMetrics != nil {
+		urMetrics.RecordCompacted(context.TODO(), string(ur.Spec.GetRequestType()))
+	}
+	return nil
+}
+
 func (c *controller) enqueueUpdateRequest(obj interface{}) {
 	key, err := cache.MetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -253,13 +331,13 @@ func (c *controller) processUR(ur *kyvernov2.UpdateRequest) error {
 		ctrl := mutate.NewMutateExistingController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
 		return ctrl.ProcessUR(ur)
 	case kyvernov2.Generate:
-		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
+		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, c.driftReporter, logger, c.jp)
 		return ctrl.ProcessUR(ur)
 	case kyvernov2.CELGenerate:
-		ctrl := gpol.NewCELGenerateController(c.client, c.kyvernoClient, c.context, c.gpolEngine, c.gpolProvider, c.watchManager, statusControl, c.eventGen, logger, c.configuration)
+		ctrl := gpol.NewCELGenerateController(c.client, c.kyvernoClient, c.context, c.gpolEngine, c.gpolProvider, c.watchManager, statusControl, c.eventGen, logger, c.configuration, c.recorder)
 		return ctrl.ProcessUR(ur)
 	case kyvernov2.CELMutate:
-		processor := mpol.NewProcessor(c.client, c.kyvernoClient, c.mpolEngine, c.restMapper, c.context, statusControl, c.eventGen, c.configuration)
+		processor := mpol.NewProcessor(c.client, c.kyvernoClient, c.mpolEngine, c.restMapper, c.context, statusControl, c.urLister, c.eventGen, c.configuration, c.recorder)
 		return processor.Process(ur)
 	}
 	return nil
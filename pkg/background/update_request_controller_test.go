@@ -3,9 +3,12 @@ package background
 import (
 	"errors"
 	"testing"
+	"time"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/stretchr/testify/assert"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -13,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -373,3 +377,87 @@ func TestMaxRetries_Constant(t *testing.T) {
 	// Verify maxRetries constant is set to expected value
 	assert.Equal(t, 10, maxRetries)
 }
+
+func TestIsSuperseded(t *testing.T) {
+	now := metav1.Now()
+	later := metav1.NewTime(now.Add(time.Second))
+	newUR := func(name string, created metav1.Time, state kyvernov2.UpdateRequestState, trigger string) *kyvernov2.UpdateRequest {
+		ur := &kyvernov2.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         config.KyvernoNamespace(),
+				CreationTimestamp: created,
+				Labels:            map[string]string{kyvernov2.URGeneratePolicyLabel: "policy"},
+			},
+			Spec: kyvernov2.UpdateRequestSpec{
+				Type:   kyvernov2.Generate,
+				Policy: "policy",
+				RuleContext: []kyvernov2.RuleContext{{
+					Rule:    "rule",
+					Trigger: kyvernov1.ResourceSpec{Kind: "Namespace", Name: trigger},
+				}},
+			},
+			Status: kyvernov2.UpdateRequestStatus{State: state},
+		}
+		if trigger == "other-user" {
+			ur.Spec.RuleContext[0].Trigger.Name = "ns"
+			ur.Spec.Context.UserRequestInfo.AdmissionUserInfo.Username = "alice"
+		}
+		return ur
+	}
+	tests := []struct {
+		name   string
+		ur     *kyvernov2.UpdateRequest
+		others []*kyvernov2.UpdateRequest
+		want   bool
+	}{{
+		name: "alone",
+		ur:   newUR("ur-1", now, kyvernov2.Pending, "ns"),
+		want: false,
+	}, {
+		name:   "newer with same key",
+		ur:     newUR("ur-1", now, kyvernov2.Pending, "ns"),
+		others: []*kyvernov2.UpdateRequest{newUR("ur-2", later, kyvernov2.Pending, "ns")},
+		want:   true,
+	}, {
+		name:   "older with same key",
+		ur:     newUR("ur-2", later, kyvernov2.Pending, "ns"),
+		others: []*kyvernov2.UpdateRequest{newUR("ur-1", now, kyvernov2.Pending, "ns")},
+		want:   false,
+	}, {
+		name:   "newer with different trigger",
+		ur:     newUR("ur-1", now, kyvernov2.Pending, "ns"),
+		others: []*kyvernov2.UpdateRequest{newUR("ur-2", later, kyvernov2.Pending, "other")},
+		want:   false,
+	}, {
+		name:   "newer with different context",
+		ur:     newUR("ur-1", now, kyvernov2.Pending, "ns"),
+		others: []*kyvernov2.UpdateRequest{newUR("ur-2", later, kyvernov2.Pending, "other-user")},
+		want:   false,
+	}, {
+		name:   "newer already completed",
+		ur:     newUR("ur-1", now, kyvernov2.Pending, "ns"),
+		others: []*kyvernov2.UpdateRequest{newUR("ur-2", later, kyvernov2.Completed, "ns")},
+		want:   false,
+	}, {
+		name:   "same timestamp, greater name",
+		ur:     newUR("ur-1", now, kyvernov2.Pending, "ns"),
+		others: []*kyvernov2.UpdateRequest{newUR("ur-2", now, kyvernov2.Pending, "ns")},
+		want:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.NoError(t, indexer.Add(tt.ur))
+			for _, other := range tt.others {
+				assert.NoError(t, indexer.Add(other))
+			}
+			c := &controller{
+				urLister: kyvernov2listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+			}
+			got, err := c.isSuperseded(tt.ur)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
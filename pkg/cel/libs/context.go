@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -52,6 +53,7 @@ type Context interface {
 
 	GetHTTPMocks() map[string]interface{}
 	GetGeneratedResources() []*unstructured.Unstructured
+	GetPriorResource(uid types.UID) *unstructured.Unstructured
 	ClearGeneratedResources()
 	SetGenerateContext(polName, policyNamespace, triggerName, triggerNamespace, triggerAPIVersion, triggerGroup, triggerKind, triggerUID string, restoreCache, useServerSideApply bool)
 	Clone() Context
@@ -75,6 +77,7 @@ type contextProvider struct {
 	imagedata          imagedataloader.Fetcher
 	gctxStore          gctxstore.Store
 	generatedResources []*unstructured.Unstructured
+	priorResources     map[types.UID]*unstructured.Unstructured
 	genCtx             generateContext
 	cliEvaluation      bool // if true, libraries that create resources (like the generator library) don't post the created resource to an actual cluster
 	restMapper         meta.RESTMapper
@@ -323,6 +326,7 @@ func (cp *contextProvider) GenerateResources(namespace string, dataList []map[st
 				continue
 			}
 			if cp.genCtx.useServerSideApply {
+				cp.setPriorResource(existing)
 				generatedRes, err := cp.client.ApplyResource(
 					context.TODO(),
 					item.GetAPIVersion(),
@@ -345,6 +349,7 @@ func (cp *contextProvider) GenerateResources(namespace string, dataList []map[st
 			// match the existing object; copy them from the fetched resource.
 			item.SetUID(existing.GetUID())
 			item.SetResourceVersion(existing.GetResourceVersion())
+			cp.setPriorResource(existing)
 			generatedRes, err := cp.client.UpdateResource(
 				context.TODO(),
 				item.GetAPIVersion(),
@@ -416,12 +421,31 @@ func (cp *contextProvider) SetGenerateContext(
 	cp.genCtx.triggerUID = triggerUID
 	cp.genCtx.restoreCache = restoreCache
 	cp.genCtx.useServerSideApply = useServerSideApply
+	cp.priorResources = nil
 }
 
 func (cp *contextProvider) GetGeneratedResources() []*unstructured.Unstructured {
 	return cp.generatedResources
 }
 
+// GetPriorResource returns the state of a generated resource before it was updated
+// in the current generate context, it returns nil if the resource was created.
+// Unlike the generated resources, prior resources are kept until the generate context is reset
+// so that callers can still read them once the evaluation completed.
+func (cp *contextProvider) GetPriorResource(uid types.UID) *unstructured.Unstructured {
+	return cp.priorResources[uid]
+}
+
+func (cp *contextProvider) setPriorResource(obj *unstructured.Unstructured) {
+	if cp.priorResources == nil {
+		cp.priorResources = map[types.UID]*unstructured.Unstructured{}
+	}
+	// keep the state before the first update of the context
+	if _, ok := cp.priorResources[obj.GetUID()]; !ok {
+		cp.priorResources[obj.GetUID()] = obj
+	}
+}
+
 func (cp *contextProvider) ToGVR(apiVersion, kind string) (*schema.GroupVersionResource, error) {
 	groupVersion, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
@@ -486,6 +510,7 @@ func (cp *contextProvider) Clone() Context {
 
 	// generatedResources is per-evaluation state. Ensure each worker starts with a clean slate.
 	clone.generatedResources = make([]*unstructured.Unstructured, 0)
+	clone.priorResources = nil
 
 	return &clone
 }
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type FakeContextProvider struct {
@@ -176,6 +177,10 @@ func (cp *FakeContextProvider) GetGeneratedResources() []*unstructured.Unstructu
 	return cp.generatedResources
 }
 
+func (cp *FakeContextProvider) GetPriorResource(uid types.UID) *unstructured.Unstructured {
+	return nil
+}
+
 func (cp *FakeContextProvider) ClearGeneratedResources() {
 	cp.generatedResources = make([]*unstructured.Unstructured, 0)
 }
@@ -0,0 +1,157 @@
+package pss
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/pss"
+	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/pod-security-admission/api"
+)
+
+type impl struct {
+	types.Adapter
+}
+
+func (c *impl) evaluate_dyn_string_string(args ...ref.Val) ref.Val {
+	if len(args) != 3 {
+		return types.NewErr("expected 3 arguments, got %d", len(args))
+	}
+	return c.evaluate(args[0], args[1], args[2], nil)
+}
+
+func (c *impl) evaluate_dyn_string_string_list(args ...ref.Val) ref.Val {
+	if len(args) != 4 {
+		return types.NewErr("expected 4 arguments, got %d", len(args))
+	}
+	return c.evaluate(args[0], args[1], args[2], args[3])
+}
+
+func (c *impl) evaluate(object, level, version, exclusions ref.Val) ref.Val {
+	levelVersion, err := parseLevelVersion(level, version)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	var obj map[string]any
+	if err := convert(object, &obj); err != nil {
+		return types.WrapErr(fmt.Errorf("invalid object: %w", err))
+	}
+	var excludes []kyvernov1.PodSecurityStandard
+	if exclusions != nil {
+		if err := convert(exclusions, &excludes); err != nil {
+			return types.WrapErr(fmt.Errorf("invalid exclusions: %w", err))
+		}
+	}
+	pod, err := podFromObject(obj)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	return c.NativeToValue(Evaluate(levelVersion, excludes, pod))
+}
+
+// Evaluate applies the pod security standards checks to the pod, exempting the given exclusions,
+// and returns the result as a CEL friendly structure.
+func Evaluate(levelVersion *api.LevelVersion, excludes []kyvernov1.PodSecurityStandard, pod *corev1.Pod) map[string]any {
+	if levelVersion.Level == api.LevelPrivileged {
+		return result(true, nil)
+	}
+	allowed, checks := pss.EvaluatePod(levelVersion, excludes, pod)
+	return result(allowed, checks)
+}
+
+func result(allowed bool, checks []pssutils.PSSCheckResult) map[string]any {
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].ID < checks[j].ID
+	})
+	results := make([]any, 0, len(checks))
+	for _, check := range checks {
+		fields := []any{}
+		if check.CheckResult.ErrList != nil {
+			for _, err := range *check.CheckResult.ErrList {
+				fields = append(fields, err.Field)
+			}
+		}
+		results = append(results, map[string]any{
+			"id":     check.ID,
+			"reason": check.CheckResult.ForbiddenReason,
+			"detail": check.CheckResult.ForbiddenDetail,
+			"fields": fields,
+		})
+	}
+	message := ""
+	if !allowed {
+		message = pss.FormatChecksPrint(checks)
+	}
+	return map[string]any{
+		"allowed": allowed,
+		"checks":  results,
+		"message": message,
+	}
+}
+
+func parseLevelVersion(level, version ref.Val) (*api.LevelVersion, error) {
+	l, ok := level.(types.String)
+	if !ok {
+		return nil, fmt.Errorf("invalid level type %s", level.Type())
+	}
+	v, ok := version.(types.String)
+	if !ok {
+		return nil, fmt.Errorf("invalid version type %s", version.Type())
+	}
+	parsedLevel, err := api.ParseLevel(string(l))
+	if err != nil {
+		return nil, err
+	}
+	return pss.ParseVersion(parsedLevel, string(v))
+}
+
+// convert converts a CEL value into the given go type using its JSON representation.
+func convert(val ref.Val, out any) error {
+	native, err := val.ConvertToNative(reflect.TypeFor[*structpb.Value]())
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(native.(*structpb.Value).AsInterface())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// podFromObject returns the pod corresponding to the object, for pod controllers
+// the pod is built from the pod template.
+func podFromObject(object map[string]any) (*corev1.Pod, error) {
+	kind, _, _ := unstructured.NestedString(object, "kind")
+	var template map[string]any
+	switch kind {
+	case "", "Pod":
+		template = object
+	case "CronJob":
+		template, _, _ = unstructured.NestedMap(object, "spec", "jobTemplate", "spec", "template")
+	default:
+		template, _, _ = unstructured.NestedMap(object, "spec", "template")
+	}
+	if template == nil {
+		return nil, fmt.Errorf("object of kind %s has no pod template", kind)
+	}
+	data, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	var pod corev1.Pod
+	if err := json.Unmarshal(data, &pod); err != nil {
+		return nil, err
+	}
+	if pod.Namespace == "" {
+		pod.Namespace, _, _ = unstructured.NestedString(object, "metadata", "namespace")
+	}
+	return &pod, nil
+}
@@ -0,0 +1,70 @@
+package pss
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+const libraryName = "kyverno.pss"
+
+type lib struct {
+	version *version.Version
+}
+
+func Latest() *version.Version {
+	return version.MajorMinor(1, 0)
+}
+
+// Lib builds the pod security standards CEL library, exposing `pss.evaluate(object, level, version)`
+// and `pss.evaluate(object, level, version, exclusions)`.
+func Lib(v *version.Version) cel.EnvOption {
+	// create the cel lib env option
+	return cel.Lib(&lib{
+		version: v,
+	})
+}
+
+func (*lib) LibraryName() string {
+	return libraryName
+}
+
+func (c *lib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		c.extendEnv,
+	}
+}
+
+func (*lib) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{}
+}
+
+func (c *lib) extendEnv(env *cel.Env) (*cel.Env, error) {
+	impl := impl{
+		Adapter: env.CELTypeAdapter(),
+	}
+	// build our function overloads
+	libraryDecls := map[string][]cel.FunctionOpt{
+		"pss.evaluate": {
+			cel.Overload(
+				"pss_evaluate_dyn_string_string",
+				[]*cel.Type{types.DynType, types.StringType, types.StringType},
+				types.DynType,
+				cel.FunctionBinding(impl.evaluate_dyn_string_string),
+			),
+			cel.Overload(
+				"pss_evaluate_dyn_string_string_list",
+				[]*cel.Type{types.DynType, types.StringType, types.StringType, types.NewListType(types.DynType)},
+				types.DynType,
+				cel.FunctionBinding(impl.evaluate_dyn_string_string_list),
+			),
+		},
+	}
+	// create env options corresponding to our function overloads
+	options := []cel.EnvOption{}
+	for name, overloads := range libraryDecls {
+		options = append(options, cel.Function(name, overloads...))
+	}
+	// extend environment with our function overloads
+	return env.Extend(options...)
+}
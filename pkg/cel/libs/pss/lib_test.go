@@ -0,0 +1,109 @@
+package pss
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+)
+
+func evaluate(t *testing.T, expression string, object map[string]any) any {
+	t.Helper()
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		Lib(Latest()),
+	)
+	assert.NoError(t, err)
+	ast, issues := env.Compile(expression)
+	assert.Nil(t, issues)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	out, _, err := prog.Eval(map[string]any{"object": object})
+	assert.NoError(t, err)
+	return out.Value()
+}
+
+func pod(securityContext map[string]any) map[string]any {
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"containers": []any{
+				map[string]any{
+					"name":            "nginx",
+					"image":           "nginx",
+					"securityContext": securityContext,
+				},
+			},
+		},
+	}
+}
+
+func TestLib_Evaluate(t *testing.T) {
+	privileged := pod(map[string]any{"privileged": true})
+	assert.Equal(t, true, evaluate(t, `pss.evaluate(object, "baseline", "latest").allowed`, pod(nil)))
+	assert.Equal(t, false, evaluate(t, `pss.evaluate(object, "baseline", "latest").allowed`, privileged))
+	assert.Equal(t, true, evaluate(t, `pss.evaluate(object, "privileged", "latest").allowed`, privileged))
+	assert.Equal(t, true, evaluate(t, `pss.evaluate(object, "baseline", "v1.29").checks.map(c, c.id) == ["privileged"]`, privileged))
+	assert.Equal(t, true, evaluate(t, `pss.evaluate(object, "baseline", "latest").message.contains("privileged")`, privileged))
+	assert.Equal(t, false, evaluate(t, `pss.evaluate(object, "restricted", "latest").allowed`, pod(nil)))
+}
+
+func TestLib_EvaluateExclusions(t *testing.T) {
+	privileged := pod(map[string]any{"privileged": true})
+	assert.Equal(t, true, evaluate(t, `pss.evaluate(object, "baseline", "latest", [{"controlName": "Privileged Containers", "images": ["nginx"], "restrictedField": "spec.containers[*].securityContext.privileged", "values": ["true"]}]).allowed`, privileged))
+	assert.Equal(t, false, evaluate(t, `pss.evaluate(object, "baseline", "latest", [{"controlName": "Privileged Containers", "images": ["busybox"], "restrictedField": "spec.containers[*].securityContext.privileged", "values": ["true"]}]).allowed`, privileged))
+}
+
+func TestLib_EvaluatePodController(t *testing.T) {
+	deployment := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "test", "namespace": "default"},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": pod(map[string]any{"privileged": true})["spec"],
+			},
+		},
+	}
+	assert.Equal(t, false, evaluate(t, `pss.evaluate(object, "baseline", "latest").allowed`, deployment))
+	cronjob := map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"metadata":   map[string]any{"name": "test", "namespace": "default"},
+		"spec": map[string]any{
+			"jobTemplate": map[string]any{
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": pod(nil)["spec"],
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, true, evaluate(t, `pss.evaluate(object, "baseline", "latest").allowed`, cronjob))
+}
+
+func TestLib_EvaluateErrors(t *testing.T) {
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		Lib(Latest()),
+	)
+	assert.NoError(t, err)
+	for _, expression := range []string{
+		`pss.evaluate(object, "unknown", "latest")`,
+		`pss.evaluate(object, "baseline", "invalid")`,
+		`pss.evaluate({"kind": "ConfigMap"}, "baseline", "latest")`,
+	} {
+		ast, issues := env.Compile(expression)
+		assert.Nil(t, issues)
+		prog, err := env.Program(ast)
+		assert.NoError(t, err)
+		_, _, err = prog.Eval(map[string]any{"object": pod(nil)})
+		assert.Error(t, err, expression)
+	}
+}
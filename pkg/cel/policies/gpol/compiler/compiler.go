@@ -44,6 +44,13 @@ func NewCompiler() Compiler {
 	return &compilerImpl{}
 }
 
+// NewEnv returns the CEL environment generating policies of the given namespace are compiled with,
+// along with the provider variables must be registered in before they can be referenced.
+func NewEnv(namespace string) (*cel.Env, *compiler.VariablesProvider, error) {
+	var c compilerImpl
+	return c.createBaseGpolEnv(libs.GetLibsCtx(), namespace)
+}
+
 type compilerImpl struct{}
 
 func (c *compilerImpl) createBaseGpolEnv(libsctx libs.Context, namespace string) (*cel.Env, *compiler.VariablesProvider, error) {
@@ -1,7 +1,9 @@
 package gpol
 
 import (
+	"github.com/aptible/supercronic/cronexpr"
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	gpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/gpol/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/policies/gpol/template"
@@ -32,6 +34,14 @@ func Validate(gpol v1beta1.GeneratingPolicyLike) ([]string, error) {
 		err = append(err, field.Required(field.NewPath("spec").Child("matchConstraints"), "a matchConstraints with at least one resource rule is required"))
 	}
 
+	// Validate the schedule with the same parser the background controller uses to
+	// compute execution times, so a policy that is admitted can always be scheduled.
+	if schedule := gpol.GetAnnotations()[kyverno.AnnotationPolicySchedule]; schedule != "" {
+		if _, parseErr := cronexpr.Parse(schedule); parseErr != nil {
+			err = append(err, field.Invalid(field.NewPath("metadata").Child("annotations").Key(kyverno.AnnotationPolicySchedule), schedule, "schedule is not in proper cron format: "+parseErr.Error()))
+		}
+	}
+
 	if gpol.GetNamespace() != "" && !toggle.AllowHTTPInNamespacedPolicies.Enabled() {
 		if compiler.ExpressionsUseHTTP(gpolExpressions(spec)...) {
 			err = append(err, field.Forbidden(field.NewPath("spec"), "http.* is not allowed in namespaced policies; set --allowHTTPInNamespacedPolicies to enable"))
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,32 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid schedule",
+			pol: &v1beta1.GeneratingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "invalid-schedule",
+					Annotations: map[string]string{
+						kyverno.AnnotationPolicySchedule: "every week",
+					},
+				},
+				Spec: v1beta1.GeneratingPolicySpec{
+					MatchConstraints: &v1.MatchResources{
+						ResourceRules: []v1.NamedRuleWithOperations{
+							{
+								RuleWithOperations: v1.RuleWithOperations{
+									Rule: v1.Rule{
+										APIGroups: []string{"apps"},
+										Resources: []string{"deployments"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing matchConstraints",
 			pol: &v1beta1.GeneratingPolicy{
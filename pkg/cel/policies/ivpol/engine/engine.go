@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -16,8 +17,10 @@ import (
 	imageverifycache "github.com/kyverno/kyverno/pkg/image/verification/cache"
 	eval "github.com/kyverno/kyverno/pkg/image/verification/evaluator"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	"github.com/kyverno/sdk/extensions/imagedataloader"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -390,7 +393,15 @@ func (e *engineImpl) evaluatePolicies(
 			responses[ivpol.Policy.GetName()] = response
 			continue
 		}
-		result, err := compiled.Evaluate(ctx, ictx, attr, request, namespace, true, libctx)
+		result, err := tracing.ChildSpan2(
+			ctx,
+			"pkg/cel/policies/ivpol/engine",
+			fmt.Sprintf("POLICY %s", ivpol.Policy.GetName()),
+			func(ctx context.Context, _ trace.Span) (*eval.EvaluationResult, error) {
+				return compiled.Evaluate(ctx, ictx, attr, request, namespace, true, libctx)
+			},
+			trace.WithAttributes(tracing.PolicyAttributes(ivpol.Policy.GetKind(), ivpol.Policy.GetNamespace(), ivpol.Policy.GetName())...),
+		)
 		if err != nil {
 			response.Result = *engineapi.RuleError("evaluation", engineapi.ImageVerify, "failed to evaluate policy", err, nil)
 			response.Result = response.Result.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
@@ -51,6 +51,13 @@ func NewCompiler() Compiler {
 	return &compilerImpl{}
 }
 
+// NewEnv returns the CEL environment mutating policies of the given namespace are compiled with,
+// along with the provider variables must be registered in before they can be referenced.
+func NewEnv(namespace string) (*cel.Env, *compiler.VariablesProvider, error) {
+	var c compilerImpl
+	return c.newExtendedEnv(libs.GetLibsCtx(), namespace)
+}
+
 type compilerImpl struct{}
 
 func (c *compilerImpl) Compile(policy policiesv1beta1.MutatingPolicyLike, exceptions []*policiesv1beta1.PolicyException) (*Policy, field.ErrorList) {
@@ -15,8 +15,10 @@ import (
 	"github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel/trace"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -179,6 +181,13 @@ func (e *engineImpl) Handle(ctx context.Context, request engine.EngineRequest, p
 }
 
 func (e *engineImpl) handlePolicy(ctx context.Context, mpol Policy, attr admission.Attributes, request admissionv1.AdmissionRequest, namespace *corev1.Namespace, target bool) (MutatingPolicyResponse, *unstructured.Unstructured) {
+	ctx, span := tracing.StartChildSpan(
+		ctx,
+		"pkg/cel/policies/mpol/engine",
+		fmt.Sprintf("POLICY %s", mpol.Policy.GetName()),
+		trace.WithAttributes(tracing.PolicyAttributes(mpol.Policy.GetKind(), mpol.Policy.GetNamespace(), mpol.Policy.GetName())...),
+	)
+	defer span.End()
 	ruleResponse := MutatingPolicyResponse{
 		Policy: mpol.Policy,
 		Rules:  []engineapi.RuleResponse{},
@@ -1,7 +1,9 @@
 package mpol
 
 import (
+	"github.com/aptible/supercronic/cronexpr"
 	"github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	mpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
 	"github.com/kyverno/kyverno/pkg/toggle"
@@ -37,6 +39,14 @@ func Validate(mpol v1beta1.MutatingPolicyLike) ([]string, error) {
 		err = append(err, field.Forbidden(field.NewPath("spec").Child("evaluation"), "disabling both admission and mutateExisting evaluation modes is not allowed"))
 	}
 
+	// Validate the schedule with the same parser the background controller uses to
+	// compute execution times, so a policy that is admitted can always be scheduled.
+	if schedule := mpol.GetAnnotations()[kyverno.AnnotationPolicySchedule]; schedule != "" {
+		if _, parseErr := cronexpr.Parse(schedule); parseErr != nil {
+			err = append(err, field.Invalid(field.NewPath("metadata").Child("annotations").Key(kyverno.AnnotationPolicySchedule), schedule, "schedule is not in proper cron format: "+parseErr.Error()))
+		}
+	}
+
 	if mpol.GetNamespace() != "" && !toggle.AllowHTTPInNamespacedPolicies.Enabled() {
 		if compiler.ExpressionsUseHTTP(mpolExpressions(spec)...) {
 			err = append(err, field.Forbidden(field.NewPath("spec"), "http.* is not allowed in namespaced policies; set --allowHTTPInNamespacedPolicies to enable"))
@@ -12,6 +12,7 @@ import (
 	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
+	"github.com/kyverno/kyverno/pkg/cel/libs/pss"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/sdk/extensions/cel/libs/globalcontext"
 	"github.com/kyverno/sdk/extensions/cel/libs/gzip"
@@ -52,6 +53,21 @@ func NewCompiler() Compiler {
 	return &compilerImpl{}
 }
 
+// NewEnv returns the CEL environment validating policies of the given namespace are compiled with,
+// along with the provider variables must be registered in before they can be referenced.
+func NewEnv(namespace string) (*cel.Env, *compiler.VariablesProvider, error) {
+	var c compilerImpl
+	envSet, variablesProvider, err := c.createBaseVpolEnv(libs.GetLibsCtx(), namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	env, err := envSet.Env(environment.StoredExpressions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return env, variablesProvider, nil
+}
+
 type compilerImpl struct{}
 
 func (c *compilerImpl) Compile(policy policiesv1beta1.ValidatingPolicyLike, exceptions []*policiesv1beta1.PolicyException) (*Policy, field.ErrorList) {
@@ -293,6 +309,9 @@ func (c *compilerImpl) createBaseVpolEnv(libsctx libs.Context, namespace string)
 			http.Context{ContextInterface: libs.NewMockAwareHTTPContext(compiler.NewLazyCELHTTPContext(namespace), libsctx.GetHTTPMocks())},
 			http.Latest(),
 		),
+		pss.Lib(
+			pss.Latest(),
+		),
 	}
 
 	extendedBase, err := base.Extend(
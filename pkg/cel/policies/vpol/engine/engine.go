@@ -15,8 +15,10 @@ import (
 	"github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel/trace"
 	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -112,6 +114,13 @@ func (e *engineImpl) Handle(ctx context.Context, request EngineRequest, predicat
 }
 
 func (e *engineImpl) handlePolicy(ctx context.Context, policy Policy, jsonPayload any, attr admission.Attributes, request *admissionv1.AdmissionRequest, namespace runtime.Object, context libs.Context) engine.ValidatingPolicyResponse {
+	ctx, span := tracing.StartChildSpan(
+		ctx,
+		"pkg/cel/policies/vpol/engine",
+		fmt.Sprintf("POLICY %s", policy.Policy.GetName()),
+		trace.WithAttributes(tracing.PolicyAttributes(policy.Policy.GetKind(), policy.Policy.GetNamespace(), policy.Policy.GetName())...),
+	)
+	defer span.End()
 	response := engine.ValidatingPolicyResponse{
 		Actions: policy.Actions,
 		Policy:  policy.Policy,
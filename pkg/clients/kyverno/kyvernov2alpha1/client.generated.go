@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v2alpha1"
 	globalcontextentries "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/globalcontextentries"
+	policysources "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policysources"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8sclientgorest "k8s.io/client-go/rest"
 )
@@ -33,6 +34,10 @@ func (c *withMetrics) GlobalContextEntries() github_com_kyverno_kyverno_pkg_clie
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "GlobalContextEntry", c.clientType)
 	return globalcontextentries.WithMetrics(c.inner.GlobalContextEntries(), recorder)
 }
+func (c *withMetrics) PolicySources() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySourceInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicySource", c.clientType)
+	return policysources.WithMetrics(c.inner.PolicySources(), recorder)
+}
 
 type withTracing struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -45,6 +50,9 @@ func (c *withTracing) RESTClient() k8sclientgorest.Interface {
 func (c *withTracing) GlobalContextEntries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GlobalContextEntryInterface {
 	return globalcontextentries.WithTracing(c.inner.GlobalContextEntries(), c.client, "GlobalContextEntry")
 }
+func (c *withTracing) PolicySources() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySourceInterface {
+	return policysources.WithTracing(c.inner.PolicySources(), c.client, "PolicySource")
+}
 
 type withLogging struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -57,3 +65,6 @@ func (c *withLogging) RESTClient() k8sclientgorest.Interface {
 func (c *withLogging) GlobalContextEntries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.GlobalContextEntryInterface {
 	return globalcontextentries.WithLogging(c.inner.GlobalContextEntries(), c.logger.WithValues("resource", "GlobalContextEntries"))
 }
+func (c *withLogging) PolicySources() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySourceInterface {
+	return policysources.WithLogging(c.inner.PolicySources(), c.logger.WithValues("resource", "PolicySources"))
+}
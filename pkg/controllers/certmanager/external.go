@@ -0,0 +1,143 @@
+package certmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/tls"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// ExternalControllerName is the name of the controller consuming certificates issued by an external provider
+const ExternalControllerName = "external-certificates-controller"
+
+type externalController struct {
+	client corev1client.SecretInterface
+
+	// listers
+	caLister  corev1listers.SecretLister
+	tlsLister corev1listers.SecretLister
+
+	// queue
+	queue      workqueue.TypedRateLimitingInterface[any]
+	tlsEnqueue controllerutils.EnqueueFunc
+
+	caSecretName  string
+	tlsSecretName string
+	namespace     string
+	serverName    string
+}
+
+// NewExternalController returns a controller consuming the TLS secret issued by an external certificate provider
+// (corporate PKI, cert-manager, ...) instead of generating self-signed certificates. The certificate is validated
+// and the issuing CA stored in ca.crt is copied to the CA secret, from where it gets injected in webhook configurations.
+// When the provider rotates its CA, the previous CA is published along with the new one for tls.CARotationGracePeriod.
+// The TLS secret itself is never written, it is up to the external provider to renew it.
+func NewExternalController(
+	caInformer corev1informers.SecretInformer,
+	tlsInformer corev1informers.SecretInformer,
+	client corev1client.SecretInterface,
+	caSecretName string,
+	tlsSecretName string,
+	namespace string,
+	serverName string,
+) controllers.Controller {
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[any](),
+		workqueue.TypedRateLimitingQueueConfig[any]{Name: ExternalControllerName},
+	)
+	// the CA secret is derived from the TLS secret, changes to any of them trigger a reconcile
+	controllerutils.AddDefaultEventHandlers(externalLogger, caInformer.Informer(), queue)
+	tlsEnqueue, _, _ := controllerutils.AddDefaultEventHandlers(externalLogger, tlsInformer.Informer(), queue)
+	c := externalController{
+		client:        client,
+		caLister:      caInformer.Lister(),
+		tlsLister:     tlsInformer.Lister(),
+		queue:         queue,
+		tlsEnqueue:    tlsEnqueue,
+		caSecretName:  caSecretName,
+		tlsSecretName: tlsSecretName,
+		namespace:     namespace,
+		serverName:    serverName,
+	}
+	return &c
+}
+
+func (c *externalController) Run(ctx context.Context, workers int) {
+	if err := c.tlsEnqueue(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      c.tlsSecretName,
+		},
+	}); err != nil {
+		externalLogger.Error(err, "failed to enqueue secret", "name", c.tlsSecretName)
+	}
+	controllerutils.Run(ctx, externalLogger, ExternalControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.ticker)
+}
+
+func (c *externalController) ticker(ctx context.Context, logger logr.Logger) {
+	// expiry is checked periodically as the secret doesn't change when the certificate is not renewed
+	ticker := time.NewTicker(tls.CertRenewalInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.queue.Add(c.namespace + "/" + c.tlsSecretName)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *externalController) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	if namespace != c.namespace {
+		return nil
+	}
+	if name != c.caSecretName && name != c.tlsSecretName {
+		return nil
+	}
+	secret, err := c.tlsLister.Secrets(c.namespace).Get(c.tlsSecretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("waiting for the external certificate provider to create the TLS secret", "name", c.tlsSecretName)
+			return nil
+		}
+		return err
+	}
+	now := time.Now()
+	caBundle, cert, err := tls.ValidateExternalSecret(secret, now, c.serverName)
+	if err != nil {
+		// the secret is not retried, it will be reconciled again when the external provider updates it
+		logger.Error(err, "invalid TLS secret from external certificate provider, keeping the current CA", "name", c.tlsSecretName)
+		return nil
+	}
+	if expiresSoon(cert.NotBefore, cert.NotAfter, now) {
+		logger.Info("TLS certificate expires soon and was not renewed by the external certificate provider", "name", c.tlsSecretName, "notAfter", cert.NotAfter)
+	}
+	_, err = controllerutils.CreateOrUpdate(ctx, c.caSecretName, c.caLister.Secrets(c.namespace), c.client, func(secret *corev1.Secret) error {
+		if secret.Type == "" {
+			secret.Type = corev1.SecretTypeOpaque
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[corev1.TLSCertKey] = tls.MergeCABundle(secret.Data[corev1.TLSCertKey], caBundle, cert.NotBefore, now)
+		return nil
+	})
+	return err
+}
+
+// expiresSoon returns true when less than a third of the certificate validity remains,
+// external providers like cert-manager renew certificates when two thirds have elapsed
+func expiresSoon(notBefore, notAfter, now time.Time) bool {
+	return notAfter.Sub(now) < notAfter.Sub(notBefore)/3
+}
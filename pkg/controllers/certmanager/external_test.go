@@ -0,0 +1,171 @@
+package certmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/tls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testNamespace  = "kyverno"
+	testCASecret   = "kyverno-svc.kyverno.svc.kyverno-tls-ca"
+	testTLSSecret  = "external-tls"
+	testServerName = "kyverno-svc.kyverno.svc"
+)
+
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+}
+
+func newTestExternalSecret(t *testing.T, notBefore, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+	ca, caKey, caPem, _ := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "corporate-ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, nil)
+	_, _, certPem, keyPem := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "kyverno-svc"},
+		DNSNames:     []string{testServerName},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: testTLSSecret, Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPem,
+			corev1.TLSPrivateKeyKey: keyPem,
+			"ca.crt":                caPem,
+		},
+	}
+}
+
+func newTestExternalController(t *testing.T, objects ...*corev1.Secret) (*externalController, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(client, 0, kubeinformers.WithNamespace(testNamespace))
+	informer := factory.Core().V1().Secrets()
+	for _, object := range objects {
+		_, err := client.CoreV1().Secrets(testNamespace).Create(context.TODO(), object, metav1.CreateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, informer.Informer().GetIndexer().Add(object))
+	}
+	c := NewExternalController(informer, informer, client.CoreV1().Secrets(testNamespace), testCASecret, testTLSSecret, testNamespace, testServerName)
+	return c.(*externalController), client
+}
+
+func TestExternalControllerReconcile(t *testing.T) {
+	now := time.Now()
+	t.Run("copies CA", func(t *testing.T) {
+		secret := newTestExternalSecret(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+		c, client := newTestExternalController(t, secret)
+		require.NoError(t, c.reconcile(context.TODO(), logr.Discard(), "", testNamespace, testTLSSecret))
+		ca, err := client.CoreV1().Secrets(testNamespace).Get(context.TODO(), testCASecret, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, secret.Data["ca.crt"], ca.Data[corev1.TLSCertKey])
+		assert.NotContains(t, ca.Data, corev1.TLSPrivateKeyKey)
+		assert.Equal(t, corev1.SecretTypeOpaque, ca.Type)
+	})
+	t.Run("updates CA", func(t *testing.T) {
+		secret := newTestExternalSecret(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testCASecret, Namespace: testNamespace, ResourceVersion: "1"},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("old"),
+				corev1.TLSPrivateKeyKey: []byte("old"),
+			},
+		}
+		c, client := newTestExternalController(t, secret, existing)
+		require.NoError(t, c.reconcile(context.TODO(), logr.Discard(), "", testNamespace, testCASecret))
+		ca, err := client.CoreV1().Secrets(testNamespace).Get(context.TODO(), testCASecret, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, secret.Data["ca.crt"], ca.Data[corev1.TLSCertKey])
+		assert.Equal(t, corev1.SecretTypeTLS, ca.Type)
+	})
+	t.Run("rotates CA", func(t *testing.T) {
+		previous := newTestExternalSecret(t, now.Add(-48*time.Hour), now.Add(24*time.Hour))
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testCASecret, Namespace: testNamespace, ResourceVersion: "1"},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{corev1.TLSCertKey: previous.Data["ca.crt"]},
+		}
+		// the previous CA is published along with the new one during the grace period
+		secret := newTestExternalSecret(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+		c, client := newTestExternalController(t, secret, existing)
+		require.NoError(t, c.reconcile(context.TODO(), logr.Discard(), "", testNamespace, testTLSSecret))
+		ca, err := client.CoreV1().Secrets(testNamespace).Get(context.TODO(), testCASecret, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, append(bytes.Clone(previous.Data["ca.crt"]), secret.Data["ca.crt"]...), ca.Data[corev1.TLSCertKey])
+		// the previous CA is dropped once the grace period elapsed
+		secret = newTestExternalSecret(t, now.Add(-tls.CARotationGracePeriod-time.Hour), now.Add(24*time.Hour))
+		c, client = newTestExternalController(t, secret, existing)
+		require.NoError(t, c.reconcile(context.TODO(), logr.Discard(), "", testNamespace, testTLSSecret))
+		ca, err = client.CoreV1().Secrets(testNamespace).Get(context.TODO(), testCASecret, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, secret.Data["ca.crt"], ca.Data[corev1.TLSCertKey])
+	})
+	t.Run("missing secret", func(t *testing.T) {
+		c, client := newTestExternalController(t)
+		require.NoError(t, c.reconcile(context.TODO(), logr.Discard(), "", testNamespace, testTLSSecret))
+		_, err := client.CoreV1().Secrets(testNamespace).Get(context.TODO(), testCASecret, metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+	t.Run("expired certificate", func(t *testing.T) {
+		secret := newTestExternalSecret(t, now.Add(-2*time.Hour), now.Add(-time.Hour))
+		c, client := newTestExternalController(t, secret)
+		require.NoError(t, c.reconcile(context.TODO(), logr.Discard(), "", testNamespace, testTLSSecret))
+		_, err := client.CoreV1().Secrets(testNamespace).Get(context.TODO(), testCASecret, metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+	t.Run("other secret", func(t *testing.T) {
+		secret := newTestExternalSecret(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+		c, client := newTestExternalController(t, secret)
+		require.NoError(t, c.reconcile(context.TODO(), logr.Discard(), "", testNamespace, "other"))
+		_, err := client.CoreV1().Secrets(testNamespace).Get(context.TODO(), testCASecret, metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestExpiresSoon(t *testing.T) {
+	now := time.Now()
+	assert.False(t, expiresSoon(now.Add(-time.Hour), now.Add(2*time.Hour), now))
+	assert.True(t, expiresSoon(now.Add(-2*time.Hour), now.Add(30*time.Minute), now))
+}
@@ -3,3 +3,5 @@ package certmanager
 import "github.com/kyverno/kyverno/pkg/logging"
 
 var logger = logging.ControllerLogger(ControllerName)
+
+var externalLogger = logging.ControllerLogger(ExternalControllerName)
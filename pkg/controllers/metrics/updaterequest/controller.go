@@ -3,6 +3,7 @@ package updaterequest
 import (
 	"context"
 
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/config"
@@ -37,5 +38,19 @@ func (c *controller) report(ctx context.Context, observer metric.Observer) error
 	}
 
 	c.urMetrics.RecordTotal(ctx, config.KyvernoNamespace(), int64(len(urs)), observer)
+	for requestType, depth := range queueDepth(urs) {
+		c.urMetrics.RecordQueueDepth(ctx, string(requestType), depth, observer)
+	}
 	return nil
 }
+
+// queueDepth counts the update requests waiting for background processing per request type.
+func queueDepth(urs []*kyvernov2.UpdateRequest) map[kyvernov2.RequestType]int64 {
+	depth := map[kyvernov2.RequestType]int64{}
+	for _, ur := range urs {
+		if ur.Status.State == "" || ur.Status.State == kyvernov2.Pending {
+			depth[ur.Spec.GetRequestType()]++
+		}
+	}
+	return depth
+}
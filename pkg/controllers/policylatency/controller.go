@@ -0,0 +1,188 @@
+package policylatency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine/latency"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.opentelemetry.io/otel/metric"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "policy-latency-controller"
+	maxRetries     = 10
+	leasePrefix    = "kyverno-policy-latency-"
+	// labelReplica is set on the leases publishing the slow series of a replica
+	labelReplica = "latency.kyverno.io/replica"
+	// annotationSlowSeries holds the slow series of a replica
+	annotationSlowSeries = "latency.kyverno.io/slow-series"
+	// publishInterval is the interval at which replicas renew their lease
+	publishInterval = time.Minute
+	// leaseDuration is the duration after which the lease of a replica that stopped renewing it is ignored
+	leaseDuration = 3 * publishInterval
+)
+
+type Controller interface {
+	controllers.Controller
+	// Tracker returns the tracker the engine should record latencies in
+	Tracker() latency.Tracker
+}
+
+type controller struct {
+	// clients
+	leaseClient coordinationv1client.LeasesGetter
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	namespace      string
+	identity       string
+	tracker        latency.Tracker
+	latencyMetrics metrics.PolicyLatencyMetrics
+}
+
+// NewController returns a controller tracking the latencies of the rules and context entries of policies
+// evaluated by this replica. The slow series of the replica are published in a lease, the status of the
+// policies is maintained by the status controller from the leases of all replicas.
+func NewController(
+	leaseClient coordinationv1client.LeasesGetter,
+	namespace string,
+	identity string,
+	budget time.Duration,
+	opts ...latency.Option,
+) Controller {
+	c := &controller{
+		leaseClient: leaseClient,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		namespace:      namespace,
+		identity:       identity,
+		latencyMetrics: metrics.GetPolicyLatencyMetrics(),
+	}
+	c.tracker = latency.NewTracker(budget, append(opts, latency.WithNotifier(c.notify))...)
+	if c.latencyMetrics != nil {
+		if _, err := c.latencyMetrics.RegisterCallback(c.report); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	return c
+}
+
+func (c *controller) Tracker() latency.Tracker {
+	return c.tracker
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.renew)
+}
+
+func (c *controller) notify(latency.Stats) {
+	c.queue.Add(leasePrefix + c.identity)
+}
+
+// renew periodically publishes the slow series so that the lease of the replica doesn't expire
+func (c *controller) renew(ctx context.Context, _ logr.Logger) {
+	wait.UntilWithContext(ctx, func(context.Context) {
+		c.queue.Add(leasePrefix + c.identity)
+	}, publishInterval)
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	for _, stats := range c.tracker.Snapshot() {
+		c.latencyMetrics.RecordLatency(ctx, observer, stats.PolicyKind, stats.PolicyNamespace, stats.PolicyName, stats.Rule, stats.ContextEntry, "0.95", stats.P95.Seconds())
+		c.latencyMetrics.RecordLatency(ctx, observer, stats.PolicyKind, stats.PolicyNamespace, stats.PolicyName, stats.Rule, stats.ContextEntry, "0.99", stats.P99.Seconds())
+		c.latencyMetrics.RecordSlow(ctx, observer, stats.PolicyKind, stats.PolicyNamespace, stats.PolicyName, stats.Rule, stats.ContextEntry, stats.Slow)
+	}
+	return nil
+}
+
+func (c *controller) reconcile(ctx context.Context, _ logr.Logger, _, _, name string) error {
+	var slow []latency.Stats
+	for _, stats := range c.tracker.Snapshot() {
+		if stats.Slow {
+			slow = append(slow, stats)
+		}
+	}
+	data, err := json.Marshal(slow)
+	if err != nil {
+		return err
+	}
+	build := func(lease *coordinationv1.Lease) {
+		controllerutils.SetManagedByKyvernoLabel(lease)
+		controllerutils.SetLabel(lease, labelReplica, "true")
+		controllerutils.SetAnnotation(lease, annotationSlowSeries, string(data))
+		lease.Spec.HolderIdentity = ptr.To(c.identity)
+		lease.Spec.LeaseDurationSeconds = ptr.To(int32(leaseDuration.Seconds()))
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now()}
+	}
+	leases := c.leaseClient.Leases(c.namespace)
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: c.namespace}}
+		build(lease)
+		_, err := leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	build(lease)
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// slowSeries returns the slow series of the given policy, sorted by rule and context entry.
+func slowSeries(snapshot []latency.Stats, policy kyvernov1.PolicyInterface) []latency.Stats {
+	var slow []latency.Stats
+	for _, stats := range snapshot {
+		if !stats.Slow {
+			continue
+		}
+		if stats.PolicyKind != policy.GetKind() || stats.PolicyNamespace != policy.GetNamespace() || stats.PolicyName != policy.GetName() {
+			continue
+		}
+		slow = append(slow, stats)
+	}
+	slices.SortFunc(slow, func(a, b latency.Stats) int {
+		if c := strings.Compare(a.Rule, b.Rule); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ContextEntry, b.ContextEntry)
+	})
+	return slow
+}
+
+func buildMessage(slow []latency.Stats, budget time.Duration) string {
+	if len(slow) == 0 {
+		return fmt.Sprintf("Policy is within the latency budget of %s.", budget)
+	}
+	var parts []string
+	for _, stats := range slow {
+		if stats.ContextEntry == "" {
+			parts = append(parts, fmt.Sprintf("rule %s (p95 %s, p99 %s)", stats.Rule, stats.P95, stats.P99))
+		} else {
+			parts = append(parts, fmt.Sprintf("rule %s context entry %s (p95 %s, p99 %s)", stats.Rule, stats.ContextEntry, stats.P95, stats.P99))
+		}
+	}
+	return fmt.Sprintf("Policy exceeds the latency budget of %s: %s.", budget, strings.Join(parts, ", "))
+}
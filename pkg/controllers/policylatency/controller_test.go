@@ -0,0 +1,45 @@
+package policylatency
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/latency"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_slowSeries(t *testing.T) {
+	policy := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "test"}}
+	key := func(rule, entry string) latency.Key {
+		return latency.ContextEntryKey(policy, rule, entry)
+	}
+	snapshot := []latency.Stats{
+		{Key: key("b", ""), Slow: true},
+		{Key: key("a", "entry"), Slow: true},
+		{Key: key("a", ""), Slow: true},
+		{Key: key("c", ""), Slow: false},
+		{Key: latency.Key{PolicyKind: "ClusterPolicy", PolicyName: "test", Rule: "a"}, Slow: true},
+		{Key: latency.Key{PolicyKind: "Policy", PolicyNamespace: "other", PolicyName: "test", Rule: "a"}, Slow: true},
+	}
+	slow := slowSeries(snapshot, policy)
+	assert.Equal(t, []latency.Stats{
+		{Key: key("a", ""), Slow: true},
+		{Key: key("a", "entry"), Slow: true},
+		{Key: key("b", ""), Slow: true},
+	}, slow)
+}
+
+func Test_buildMessage(t *testing.T) {
+	assert.Equal(t, "Policy is within the latency budget of 100ms.", buildMessage(nil, 100*time.Millisecond))
+	slow := []latency.Stats{
+		{Key: latency.Key{Rule: "a"}, P95: 200 * time.Millisecond, P99: time.Second},
+		{Key: latency.Key{Rule: "a", ContextEntry: "deployments"}, P95: 150 * time.Millisecond, P99: 300 * time.Millisecond},
+	}
+	assert.Equal(
+		t,
+		"Policy exceeds the latency budget of 100ms: rule a (p95 200ms, p99 1s), rule a context entry deployments (p95 150ms, p99 300ms).",
+		buildMessage(slow, 100*time.Millisecond),
+	)
+}
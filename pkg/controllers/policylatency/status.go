@@ -0,0 +1,258 @@
+package policylatency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine/latency"
+	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationv1informers "k8s.io/client-go/informers/coordination/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	coordinationv1listers "k8s.io/client-go/listers/coordination/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// StatusControllerName is the name of the controller maintaining the slow condition of policies
+const StatusControllerName = "policy-latency-status-controller"
+
+type statusController struct {
+	// clients
+	client      versioned.Interface
+	leaseClient coordinationv1client.LeasesGetter
+
+	// listers
+	leaseLister coordinationv1listers.LeaseNamespaceLister
+	cpolLister  kyvernov1listers.ClusterPolicyLister
+	polLister   kyvernov1listers.PolicyLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	namespace string
+	eventGen  event.Interface
+	budget    time.Duration
+}
+
+// NewStatusController returns a controller maintaining the slow condition of policies whose rules or
+// context entries exceed the latency budget on any replica, and emitting an event every time a policy
+// becomes slow or recovers. It must only run on the leader.
+func NewStatusController(
+	client versioned.Interface,
+	leaseClient coordinationv1client.LeasesGetter,
+	leaseInformer coordinationv1informers.LeaseInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	namespace string,
+	eventGen event.Interface,
+	budget time.Duration,
+) controllers.Controller {
+	c := &statusController{
+		client:      client,
+		leaseClient: leaseClient,
+		leaseLister: leaseInformer.Lister().Leases(namespace),
+		cpolLister:  cpolInformer.Lister(),
+		polLister:   polInformer.Lister(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: StatusControllerName},
+		),
+		namespace: namespace,
+		eventGen:  eventGen,
+		budget:    budget,
+	}
+	if _, err := controllerutils.AddEventHandlersT(
+		leaseInformer.Informer(),
+		func(lease *coordinationv1.Lease) { c.enqueueLease(lease) },
+		func(old, lease *coordinationv1.Lease) {
+			c.enqueueLease(old)
+			c.enqueueLease(lease)
+		},
+		func(lease *coordinationv1.Lease) { c.enqueueLease(lease) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	// slow policies are reconciled when the controller starts, the replicas reporting them may have gone away
+	enqueueSlowPolicy := func(policy kyvernov1.PolicyInterface) {
+		if policy.GetStatus().IsSlow() {
+			c.enqueuePolicy(policy.GetNamespace(), policy.GetName())
+		}
+	}
+	if _, err := controllerutils.AddEventHandlersT(
+		cpolInformer.Informer(),
+		func(policy *kyvernov1.ClusterPolicy) { enqueueSlowPolicy(policy) },
+		func(_, policy *kyvernov1.ClusterPolicy) { enqueueSlowPolicy(policy) },
+		nil,
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	if _, err := controllerutils.AddEventHandlersT(
+		polInformer.Informer(),
+		func(policy *kyvernov1.Policy) { enqueueSlowPolicy(policy) },
+		func(_, policy *kyvernov1.Policy) { enqueueSlowPolicy(policy) },
+		nil,
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *statusController) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, StatusControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.expire)
+}
+
+func (c *statusController) enqueueLease(lease *coordinationv1.Lease) {
+	if lease.GetLabels()[labelReplica] != "true" {
+		return
+	}
+	for _, stats := range decodeSlowSeries(lease) {
+		c.enqueuePolicy(stats.PolicyNamespace, stats.PolicyName)
+	}
+}
+
+func (c *statusController) enqueuePolicy(namespace, name string) {
+	if namespace == "" {
+		c.queue.Add(name)
+	} else {
+		c.queue.Add(namespace + "/" + name)
+	}
+}
+
+// expire periodically deletes the leases of the replicas that stopped renewing them,
+// the deletion enqueues the policies they reported as slow
+func (c *statusController) expire(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		leases, err := c.leaseLister.List(labels.SelectorFromSet(labels.Set{labelReplica: "true"}))
+		if err != nil {
+			logger.Error(err, "failed to list leases")
+			return
+		}
+		for _, lease := range leases {
+			if !isExpired(lease, time.Now()) {
+				continue
+			}
+			logger.V(2).Info("deleting expired policy latency lease", "name", lease.GetName())
+			if err := c.leaseClient.Leases(c.namespace).Delete(ctx, lease.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				logger.Error(err, "failed to delete expired lease", "name", lease.GetName())
+			}
+		}
+	}, publishInterval)
+}
+
+func (c *statusController) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	policy, err := c.loadPolicy(namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	leases, err := c.leaseLister.List(labels.SelectorFromSet(labels.Set{labelReplica: "true"}))
+	if err != nil {
+		return err
+	}
+	slow := slowSeries(aggregate(leases, time.Now()), policy)
+	message := buildMessage(slow, c.budget)
+	wasSlow := policy.GetStatus().IsSlow()
+	if err := c.updateStatus(ctx, policy, len(slow) != 0, message); err != nil {
+		return err
+	}
+	if wasSlow != (len(slow) != 0) {
+		logger.V(2).Info("policy latency changed", "slow", len(slow) != 0, "message", message)
+		c.eventGen.Add(event.NewPolicySlowEvent(policy, len(slow) != 0, message))
+	}
+	return nil
+}
+
+func (c *statusController) updateStatus(ctx context.Context, policy kyvernov1.PolicyInterface, slow bool, message string) error {
+	if cpol, ok := policy.(*kyvernov1.ClusterPolicy); ok {
+		return controllerutils.UpdateStatus(
+			ctx,
+			cpol,
+			c.client.KyvernoV1().ClusterPolicies(),
+			func(policy *kyvernov1.ClusterPolicy) error {
+				policy.GetStatus().SetSlow(slow, message)
+				return nil
+			},
+			func(a *kyvernov1.ClusterPolicy, b *kyvernov1.ClusterPolicy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+	}
+	return controllerutils.UpdateStatus(
+		ctx,
+		policy.(*kyvernov1.Policy),
+		c.client.KyvernoV1().Policies(policy.GetNamespace()),
+		func(policy *kyvernov1.Policy) error {
+			policy.GetStatus().SetSlow(slow, message)
+			return nil
+		},
+		func(a *kyvernov1.Policy, b *kyvernov1.Policy) bool {
+			return datautils.DeepEqual(a.Status, b.Status)
+		},
+	)
+}
+
+func (c *statusController) loadPolicy(namespace, name string) (kyvernov1.PolicyInterface, error) {
+	if namespace == "" {
+		return c.cpolLister.Get(name)
+	} else {
+		return c.polLister.Policies(namespace).Get(name)
+	}
+}
+
+// aggregate returns the slow series reported by the replicas whose lease didn't expire,
+// a series reported by several replicas keeps its highest latencies.
+func aggregate(leases []*coordinationv1.Lease, now time.Time) []latency.Stats {
+	merged := map[latency.Key]latency.Stats{}
+	for _, lease := range leases {
+		if isExpired(lease, now) {
+			continue
+		}
+		for _, stats := range decodeSlowSeries(lease) {
+			if existing, ok := merged[stats.Key]; ok {
+				stats.P95 = max(stats.P95, existing.P95)
+				stats.P99 = max(stats.P99, existing.P99)
+			}
+			merged[stats.Key] = stats
+		}
+	}
+	aggregated := make([]latency.Stats, 0, len(merged))
+	for _, stats := range merged {
+		aggregated = append(aggregated, stats)
+	}
+	return aggregated
+}
+
+func decodeSlowSeries(lease *coordinationv1.Lease) []latency.Stats {
+	data := lease.GetAnnotations()[annotationSlowSeries]
+	if data == "" {
+		return nil
+	}
+	var slow []latency.Stats
+	if err := json.Unmarshal([]byte(data), &slow); err != nil {
+		logger.Error(err, "failed to decode slow series", "lease", lease.GetName())
+		return nil
+	}
+	return slow
+}
+
+func isExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second).Before(now)
+}
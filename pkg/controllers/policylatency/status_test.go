@@ -0,0 +1,42 @@
+package policylatency
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/engine/latency"
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_aggregate(t *testing.T) {
+	now := time.Now()
+	newLease := func(name string, renewed time.Time, slow ...latency.Stats) *coordinationv1.Lease {
+		data, err := json.Marshal(slow)
+		assert.NoError(t, err)
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{annotationSlowSeries: string(data)},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				RenewTime:            &metav1.MicroTime{Time: renewed},
+				LeaseDurationSeconds: ptr.To(int32(leaseDuration.Seconds())),
+			},
+		}
+	}
+	a := latency.Key{PolicyKind: "ClusterPolicy", PolicyName: "test", Rule: "a"}
+	b := latency.Key{PolicyKind: "ClusterPolicy", PolicyName: "test", Rule: "b"}
+	leases := []*coordinationv1.Lease{
+		newLease("replica-1", now, latency.Stats{Key: a, P95: time.Second, P99: 3 * time.Second, Slow: true}),
+		newLease("replica-2", now, latency.Stats{Key: a, P95: 2 * time.Second, P99: 2 * time.Second, Slow: true}),
+		newLease("expired", now.Add(-2*leaseDuration), latency.Stats{Key: b, P95: time.Second, Slow: true}),
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-renewed"}},
+	}
+	assert.Equal(t, []latency.Stats{
+		{Key: a, P95: 2 * time.Second, P99: 3 * time.Second, Slow: true},
+	}, aggregate(leases, now), "the highest latencies of the replicas that didn't expire are kept")
+}
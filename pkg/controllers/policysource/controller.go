@@ -0,0 +1,501 @@
+package policysource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	dpolvalidation "github.com/kyverno/kyverno/pkg/cel/policies/dpol"
+	gpolvalidation "github.com/kyverno/kyverno/pkg/cel/policies/gpol"
+	mpolvalidation "github.com/kyverno/kyverno/pkg/cel/policies/mpol"
+	vpolvalidation "github.com/kyverno/kyverno/pkg/cel/policies/vpol"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	eval "github.com/kyverno/kyverno/pkg/image/verification/evaluator"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	policyvalidate "github.com/kyverno/kyverno/pkg/validation/policy"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers         = 1
+	ControllerName  = "policy-source-controller"
+	maxRetries      = 10
+	defaultInterval = 5 * time.Minute
+)
+
+type controller struct {
+	// clients
+	client  versioned.Interface
+	dclient dclient.Interface
+
+	// listers
+	sourceLister kyvernov2alpha1listers.PolicySourceLister
+	cpolLister   kyvernov1listers.ClusterPolicyLister
+	polLister    kyvernov1listers.PolicyLister
+	secretLister corev1listers.SecretLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// config
+	backgroundServiceAccountName string
+	reportsServiceAccountName    string
+
+	// polls holds the pending poll of each source, a source is polled once per interval
+	// whatever the number of times it was reconciled in between
+	lock  sync.Mutex
+	polls map[string]*time.Timer
+}
+
+// NewController returns a controller synchronizing the policies of policy sources.
+// Sources are polled at their interval, fetched Kyverno and CEL policies are validated like in the policy
+// webhook and applied all at once, policies removed from a source are pruned.
+func NewController(
+	client versioned.Interface,
+	dclient dclient.Interface,
+	sourceInformer kyvernov2alpha1informers.PolicySourceInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	secretLister corev1listers.SecretLister,
+	backgroundServiceAccountName string,
+	reportsServiceAccountName string,
+) controllers.Controller {
+	c := &controller{
+		client:       client,
+		dclient:      dclient,
+		sourceLister: sourceInformer.Lister(),
+		cpolLister:   cpolInformer.Lister(),
+		polLister:    polInformer.Lister(),
+		secretLister: secretLister,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		backgroundServiceAccountName: backgroundServiceAccountName,
+		reportsServiceAccountName:    reportsServiceAccountName,
+		polls:                        map[string]*time.Timer{},
+	}
+	if _, err := controllerutils.AddEventHandlersT(sourceInformer.Informer(), c.addSource, c.updateSource, nil); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) addSource(obj *kyvernov2alpha1.PolicySource) {
+	c.enqueue(obj)
+}
+
+func (c *controller) updateSource(old, obj *kyvernov2alpha1.PolicySource) {
+	if old.GetGeneration() == obj.GetGeneration() {
+		return
+	}
+	c.enqueue(obj)
+}
+
+func (c *controller) enqueue(obj *kyvernov2alpha1.PolicySource) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Error(err, "failed to enqueue policy source")
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, poll := range c.polls {
+		poll.Stop()
+		delete(c.polls, key)
+	}
+}
+
+// schedulePoll replaces the pending poll of a source, a negative delay only cancels it.
+func (c *controller) schedulePoll(key string, after time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if poll, ok := c.polls[key]; ok {
+		poll.Stop()
+		delete(c.polls, key)
+	}
+	if after < 0 {
+		return
+	}
+	c.polls[key] = time.AfterFunc(after, func() { c.queue.Add(key) })
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	source, err := c.sourceLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// applied policies are owned by the source and garbage collected
+			c.schedulePoll(key, -1)
+			return nil
+		}
+		return err
+	}
+	if source.Spec.Suspend {
+		logger.V(4).Info("policy source suspended")
+		c.schedulePoll(key, -1)
+		return nil
+	}
+	revision, policies, unsupported, syncErr := c.sync(ctx, logger, source)
+	if syncErr != nil {
+		logger.Error(syncErr, "failed to synchronize policy source")
+	}
+	if err := c.updateStatus(ctx, source, revision, policies, unsupported, syncErr); err != nil {
+		return err
+	}
+	c.schedulePoll(key, interval(source))
+	return nil
+}
+
+func interval(source *kyvernov2alpha1.PolicySource) time.Duration {
+	if source.Spec.Interval == nil || source.Spec.Interval.Duration <= 0 {
+		return defaultInterval
+	}
+	return source.Spec.Interval.Duration
+}
+
+func (c *controller) fetch(ctx context.Context, source *kyvernov2alpha1.PolicySource) (string, [][]byte, error) {
+	if errs := source.Validate(); len(errs) != 0 {
+		return "", nil, errs.ToAggregate()
+	}
+	if source.Spec.IsOCI() {
+		return c.fetchOCI(ctx, source.Spec.OCI)
+	}
+	return c.fetchGit(ctx, source.Spec.Git)
+}
+
+// sync fetches, validates and applies the policies of a source, nothing is applied if any policy is invalid.
+// Policies of unsupported kinds are not applied, they are returned to be reported in the status.
+func (c *controller) sync(ctx context.Context, logger logr.Logger, source *kyvernov2alpha1.PolicySource) (string, []kyvernov2alpha1.PolicySourceEntry, []kyvernov2alpha1.PolicySourceEntry, error) {
+	revision, documents, err := c.fetch(ctx, source)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	policies, celPolicies, unsupported, err := loadPolicies(documents)
+	if err != nil {
+		return revision, nil, nil, fmt.Errorf("loading policies: %w", err)
+	}
+	for _, entry := range unsupported {
+		logger.V(2).Info("policy kind not supported by policy sources, skipping", "kind", entry.Kind, "namespace", entry.Namespace, "name", entry.Name)
+	}
+	objects := make([]policyObject, 0, len(policies)+len(celPolicies))
+	for _, policy := range policies {
+		objects = append(objects, policy)
+	}
+	for _, policy := range celPolicies {
+		objects = append(objects, policy)
+	}
+	entries, err := entriesOf(objects)
+	if err != nil {
+		return revision, nil, nil, err
+	}
+	for _, policy := range policies {
+		if err := c.validate(source, policy); err != nil {
+			return revision, nil, nil, err
+		}
+	}
+	for _, policy := range celPolicies {
+		if err := c.validateCEL(ctx, source, policy); err != nil {
+			return revision, nil, nil, err
+		}
+	}
+	for _, policy := range policies {
+		if err := c.apply(ctx, source, revision, policy); err != nil {
+			return revision, nil, nil, fmt.Errorf("applying %s %s: %w", policy.GetKind(), policyName(policy), err)
+		}
+	}
+	for _, policy := range celPolicies {
+		if err := c.applyCEL(ctx, source, revision, policy); err != nil {
+			return revision, nil, nil, fmt.Errorf("applying %s %s: %w", policy.GetKind(), policyName(policy), err)
+		}
+	}
+	if source.Spec.PruneEnabled() {
+		if err := c.prune(ctx, logger, source, entries); err != nil {
+			return revision, entries, unsupported, fmt.Errorf("pruning policies: %w", err)
+		}
+	}
+	logger.V(2).Info("policy source synchronized", "revision", revision, "policies", len(entries), "unsupported", len(unsupported))
+	return revision, entries, unsupported, nil
+}
+
+// policyObject is a Kyverno or CEL policy fetched from a source
+type policyObject interface {
+	metav1.Object
+	GetKind() string
+}
+
+// entriesOf lists the policies of a source, a source can't hold the same policy twice.
+func entriesOf(policies []policyObject) ([]kyvernov2alpha1.PolicySourceEntry, error) {
+	seen := sets.New[kyvernov2alpha1.PolicySourceEntry]()
+	entries := make([]kyvernov2alpha1.PolicySourceEntry, 0, len(policies))
+	for _, policy := range policies {
+		entry := kyvernov2alpha1.PolicySourceEntry{
+			Kind:      policy.GetKind(),
+			Namespace: policy.GetNamespace(),
+			Name:      policy.GetName(),
+		}
+		if seen.Has(entry) {
+			return nil, fmt.Errorf("%s %s is defined more than once", entry.Kind, policyName(policy))
+		}
+		seen.Insert(entry)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func policyName(policy metav1.Object) string {
+	if policy.GetNamespace() == "" {
+		return policy.GetName()
+	}
+	return policy.GetNamespace() + "/" + policy.GetName()
+}
+
+func (c *controller) validate(source *kyvernov2alpha1.PolicySource, policy kyvernov1.PolicyInterface) error {
+	var old kyvernov1.PolicyInterface
+	var err error
+	if policy.IsNamespaced() {
+		old, err = c.polLister.Policies(policy.GetNamespace()).Get(policy.GetName())
+	} else {
+		old, err = c.cpolLister.Get(policy.GetName())
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		old = nil
+	} else if err := checkOwner(source, old); err != nil {
+		return err
+	}
+	if _, err := policyvalidate.Validate(policy, old, c.dclient, false, c.backgroundServiceAccountName, c.reportsServiceAccountName); err != nil {
+		return fmt.Errorf("%s %s is invalid: %w", policy.GetKind(), policyName(policy), err)
+	}
+	return nil
+}
+
+// validateCEL validates a CEL policy like the policy webhook does.
+func (c *controller) validateCEL(ctx context.Context, source *kyvernov2alpha1.PolicySource, policy *unstructured.Unstructured) error {
+	old, err := c.dclient.GetResource(ctx, policy.GetAPIVersion(), policy.GetKind(), policy.GetNamespace(), policy.GetName())
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else if err := checkOwner(source, old); err != nil {
+		return err
+	}
+	raw, err := policy.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	generic, err := admissionutils.UnmarshalPolicy(policy.GetKind(), raw)
+	if err != nil {
+		return fmt.Errorf("%s %s is invalid: %w", policy.GetKind(), policyName(policy), err)
+	}
+	switch {
+	case generic.AsValidatingPolicyLike() != nil:
+		_, err = vpolvalidation.Validate(generic.AsValidatingPolicyLike())
+	case generic.AsImageValidatingPolicyLike() != nil:
+		_, err = eval.Validate(generic.AsImageValidatingPolicyLike(), c.secretLister)
+	case generic.AsMutatingPolicyLike() != nil:
+		_, err = mpolvalidation.Validate(generic.AsMutatingPolicyLike())
+	case generic.AsGeneratingPolicyLike() != nil:
+		_, err = gpolvalidation.Validate(generic.AsGeneratingPolicyLike())
+	case generic.AsDeletingPolicy() != nil:
+		_, err = dpolvalidation.Validate(generic.AsDeletingPolicy())
+	}
+	if err != nil {
+		return fmt.Errorf("%s %s is invalid: %w", policy.GetKind(), policyName(policy), err)
+	}
+	return nil
+}
+
+// checkOwner prevents a source from taking over policies it didn't create.
+func checkOwner(source *kyvernov2alpha1.PolicySource, policy metav1.Object) error {
+	if owner := controllerutils.GetLabel(policy, kyverno.LabelPolicySource); owner != source.GetName() {
+		if owner == "" {
+			return fmt.Errorf("policy %s already exists and is not managed by a policy source", policyName(policy))
+		}
+		return fmt.Errorf("policy %s is managed by the policy source %s", policyName(policy), owner)
+	}
+	return nil
+}
+
+func (c *controller) apply(ctx context.Context, source *kyvernov2alpha1.PolicySource, revision string, policy kyvernov1.PolicyInterface) error {
+	switch policy := policy.(type) {
+	case *kyvernov1.ClusterPolicy:
+		_, err := controllerutils.CreateOrUpdate(ctx, policy.GetName(), c.cpolLister, c.client.KyvernoV1().ClusterPolicies(), func(obj *kyvernov1.ClusterPolicy) error {
+			if !outdated(obj, revision) {
+				return nil
+			}
+			policy.Spec.DeepCopyInto(&obj.Spec)
+			setMetadata(source, revision, obj, policy)
+			return nil
+		})
+		return err
+	case *kyvernov1.Policy:
+		_, err := controllerutils.CreateOrUpdate(ctx, policy.GetName(), c.polLister.Policies(policy.GetNamespace()), c.client.KyvernoV1().Policies(policy.GetNamespace()), func(obj *kyvernov1.Policy) error {
+			if !outdated(obj, revision) {
+				return nil
+			}
+			obj.SetNamespace(policy.GetNamespace())
+			policy.Spec.DeepCopyInto(&obj.Spec)
+			setMetadata(source, revision, obj, policy)
+			return nil
+		})
+		return err
+	}
+	return errors.New("unsupported policy type")
+}
+
+func (c *controller) applyCEL(ctx context.Context, source *kyvernov2alpha1.PolicySource, revision string, policy *unstructured.Unstructured) error {
+	obj, err := c.dclient.GetResource(ctx, policy.GetAPIVersion(), policy.GetKind(), policy.GetNamespace(), policy.GetName())
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obj = &unstructured.Unstructured{}
+		obj.SetAPIVersion(policy.GetAPIVersion())
+		obj.SetKind(policy.GetKind())
+		obj.SetNamespace(policy.GetNamespace())
+		obj.SetName(policy.GetName())
+	}
+	if !outdated(obj, revision) {
+		return nil
+	}
+	obj = obj.DeepCopy()
+	if spec, ok := policy.Object["spec"]; ok {
+		obj.Object["spec"] = runtime.DeepCopyJSONValue(spec)
+	} else {
+		delete(obj.Object, "spec")
+	}
+	setMetadata(source, revision, obj, policy)
+	if obj.GetResourceVersion() == "" {
+		_, err = c.dclient.CreateResource(ctx, obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj, false)
+	} else {
+		_, err = c.dclient.UpdateResource(ctx, obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj, false)
+	}
+	return err
+}
+
+// outdated returns true if the policy was not applied from the revision yet,
+// policies are only updated when the source changes so that they are not rewritten at every poll.
+func outdated(obj metav1.Object, revision string) bool {
+	return obj.GetResourceVersion() == "" || controllerutils.GetAnnotation(obj, kyverno.AnnotationPolicySourceRevision) != revision
+}
+
+func setMetadata(source *kyvernov2alpha1.PolicySource, revision string, obj, policy metav1.Object) {
+	obj.SetLabels(maps.Clone(policy.GetLabels()))
+	obj.SetAnnotations(maps.Clone(policy.GetAnnotations()))
+	controllerutils.SetLabel(obj, kyverno.LabelPolicySource, source.GetName())
+	controllerutils.SetAnnotation(obj, kyverno.AnnotationPolicySourceRevision, revision)
+	controllerutils.SetOwner(obj, kyvernov2alpha1.SchemeGroupVersion.String(), "PolicySource", source.GetName(), source.GetUID())
+}
+
+// prune deletes the policies applied from the source which are no longer part of it.
+func (c *controller) prune(ctx context.Context, logger logr.Logger, source *kyvernov2alpha1.PolicySource, entries []kyvernov2alpha1.PolicySourceEntry) error {
+	keep := sets.New(entries...)
+	selector := labels.SelectorFromSet(labels.Set{kyverno.LabelPolicySource: source.GetName()})
+	cpols, err := c.cpolLister.List(selector)
+	if err != nil {
+		return err
+	}
+	for _, cpol := range cpols {
+		if !ownedBy(source, cpol) || keep.Has(kyvernov2alpha1.PolicySourceEntry{Kind: "ClusterPolicy", Name: cpol.GetName()}) {
+			continue
+		}
+		logger.V(2).Info("pruning policy", "kind", "ClusterPolicy", "name", cpol.GetName())
+		if err := c.client.KyvernoV1().ClusterPolicies().Delete(ctx, cpol.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	pols, err := c.polLister.List(selector)
+	if err != nil {
+		return err
+	}
+	for _, pol := range pols {
+		if !ownedBy(source, pol) || keep.Has(kyvernov2alpha1.PolicySourceEntry{Kind: "Policy", Namespace: pol.GetNamespace(), Name: pol.GetName()}) {
+			continue
+		}
+		logger.V(2).Info("pruning policy", "kind", "Policy", "namespace", pol.GetNamespace(), "name", pol.GetName())
+		if err := c.client.KyvernoV1().Policies(pol.GetNamespace()).Delete(ctx, pol.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	apiVersion := policiesv1beta1.GroupVersion.String()
+	for _, kind := range celKinds {
+		list, err := c.dclient.ListResource(ctx, apiVersion, kind, metav1.NamespaceAll, metav1.SetAsLabelSelector(labels.Set{kyverno.LabelPolicySource: source.GetName()}))
+		if err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return err
+		}
+		for i := range list.Items {
+			policy := &list.Items[i]
+			if !ownedBy(source, policy) || keep.Has(kyvernov2alpha1.PolicySourceEntry{Kind: kind, Namespace: policy.GetNamespace(), Name: policy.GetName()}) {
+				continue
+			}
+			logger.V(2).Info("pruning policy", "kind", kind, "namespace", policy.GetNamespace(), "name", policy.GetName())
+			if err := c.dclient.DeleteResource(ctx, apiVersion, kind, policy.GetNamespace(), policy.GetName(), false, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ownedBy returns true if the policy is owned by the source, the label alone is not enough
+// as it could have been set on a policy the source never applied.
+func ownedBy(source *kyvernov2alpha1.PolicySource, policy metav1.Object) bool {
+	return slices.ContainsFunc(policy.GetOwnerReferences(), func(owner metav1.OwnerReference) bool {
+		return owner.UID == source.GetUID()
+	})
+}
+
+func (c *controller) updateStatus(ctx context.Context, source *kyvernov2alpha1.PolicySource, revision string, entries, unsupported []kyvernov2alpha1.PolicySourceEntry, syncErr error) error {
+	return controllerutils.UpdateStatus(ctx, source, c.client.KyvernoV2alpha1().PolicySources(), func(source *kyvernov2alpha1.PolicySource) error {
+		if syncErr != nil {
+			source.Status.SetReady(false, syncErr.Error())
+			return nil
+		}
+		source.Status.Revision = revision
+		source.Status.Policies = entries
+		source.Status.Unsupported = unsupported
+		source.Status.UpdateSyncTime()
+		message := fmt.Sprintf("Synchronized %d policies from revision %s", len(entries), revision)
+		if len(unsupported) != 0 {
+			message += fmt.Sprintf(", skipped %d policies of unsupported kinds", len(unsupported))
+		}
+		source.Status.SetReady(true, message)
+		return nil
+	}, nil)
+}
@@ -0,0 +1,205 @@
+package policysource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules: []
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: require-team
+spec:
+  rules: []
+---
+apiVersion: policies.kyverno.io/v1beta1
+kind: ValidatingPolicy
+metadata:
+  name: check-labels
+spec:
+  validations:
+  - expression: "true"
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: policies.kyverno.io/v1beta1
+  kind: NamespacedMutatingPolicy
+  metadata:
+    name: add-labels
+- apiVersion: policies.kyverno.io/v1beta1
+  kind: PolicyException
+  metadata:
+    name: ignored
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicy
+metadata:
+  name: check-replicas
+spec:
+  validations:
+  - expression: "true"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func Test_loadPolicies(t *testing.T) {
+	loaded, celPolicies, unsupported, err := loadPolicies([][]byte{[]byte(policies)})
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, "ClusterPolicy", loaded[0].GetKind())
+	assert.Equal(t, "", loaded[0].GetNamespace())
+	assert.Equal(t, "Policy", loaded[1].GetKind())
+	assert.Equal(t, "default", loaded[1].GetNamespace())
+	require.Len(t, celPolicies, 2)
+	assert.Equal(t, "ValidatingPolicy", celPolicies[0].GetKind())
+	assert.Equal(t, "", celPolicies[0].GetNamespace())
+	assert.Equal(t, "NamespacedMutatingPolicy", celPolicies[1].GetKind())
+	assert.Equal(t, "default", celPolicies[1].GetNamespace())
+	assert.Equal(t, []kyvernov2alpha1.PolicySourceEntry{{Kind: "ValidatingAdmissionPolicy", Name: "check-replicas"}}, unsupported)
+}
+
+func Test_entriesOf(t *testing.T) {
+	loaded, celPolicies, _, err := loadPolicies([][]byte{[]byte(policies)})
+	require.NoError(t, err)
+	entries, err := entriesOf([]policyObject{loaded[0], loaded[1], celPolicies[0], celPolicies[1]})
+	require.NoError(t, err)
+	assert.Equal(t, []kyvernov2alpha1.PolicySourceEntry{
+		{Kind: "ClusterPolicy", Name: "require-labels"},
+		{Kind: "Policy", Namespace: "default", Name: "require-team"},
+		{Kind: "ValidatingPolicy", Name: "check-labels"},
+		{Kind: "NamespacedMutatingPolicy", Namespace: "default", Name: "add-labels"},
+	}, entries)
+
+	loaded, _, _, err = loadPolicies([][]byte{[]byte(policies), []byte(policies)})
+	require.NoError(t, err)
+	_, err = entriesOf([]policyObject{loaded[0], loaded[2]})
+	assert.EqualError(t, err, "ClusterPolicy require-labels is defined more than once")
+}
+
+func Test_readYamls(t *testing.T) {
+	fs := memfs.New()
+	require.NoError(t, util.WriteFile(fs, "policies/a.yaml", []byte("a"), 0o600))
+	require.NoError(t, util.WriteFile(fs, "policies/nested/b.yml", []byte("b"), 0o600))
+	require.NoError(t, util.WriteFile(fs, "policies/README.md", []byte("readme"), 0o600))
+	require.NoError(t, util.WriteFile(fs, "other/c.yaml", []byte("c"), 0o600))
+
+	documents, err := readYamls(fs, "policies")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]byte{[]byte("a"), []byte("b")}, documents)
+
+	documents, err = readYamls(fs, "")
+	require.NoError(t, err)
+	assert.Len(t, documents, 3)
+
+	_, err = readYamls(fs, "missing")
+	assert.Error(t, err)
+}
+
+func Test_attestor(t *testing.T) {
+	key := attestor(&kyvernov2alpha1.OCISignatureVerification{PublicKey: "-----BEGIN PUBLIC KEY-----\n..."})
+	require.NotNil(t, key.Cosign.Key)
+	assert.Equal(t, "-----BEGIN PUBLIC KEY-----\n...", key.Cosign.Key.Data)
+
+	kms := attestor(&kyvernov2alpha1.OCISignatureVerification{PublicKey: "awskms:///arn:aws:kms:us-east-1:111122223333:key/1234"})
+	require.NotNil(t, kms.Cosign.Key)
+	assert.Equal(t, "awskms:///arn:aws:kms:us-east-1:111122223333:key/1234", kms.Cosign.Key.KMS)
+
+	keyless := attestor(&kyvernov2alpha1.OCISignatureVerification{Keyless: &kyvernov2alpha1.KeylessIdentity{Subject: "subject", Issuer: "issuer"}})
+	require.NotNil(t, keyless.Cosign.Keyless)
+	assert.Equal(t, "subject", keyless.Cosign.Keyless.Identities[0].Subject)
+	assert.Equal(t, "issuer", keyless.Cosign.Keyless.Identities[0].Issuer)
+}
+
+func Test_checkOwner(t *testing.T) {
+	source := &kyvernov2alpha1.PolicySource{ObjectMeta: metav1.ObjectMeta{Name: "baseline"}}
+	owned := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{kyverno.LabelPolicySource: "baseline"}}}
+	assert.NoError(t, checkOwner(source, owned))
+
+	unmanaged := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	assert.EqualError(t, checkOwner(source, unmanaged), "policy a already exists and is not managed by a policy source")
+
+	other := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "a", Labels: map[string]string{kyverno.LabelPolicySource: "restricted"}}}
+	assert.EqualError(t, checkOwner(source, other), "policy ns/a is managed by the policy source restricted")
+}
+
+func Test_ownedBy(t *testing.T) {
+	source := &kyvernov2alpha1.PolicySource{ObjectMeta: metav1.ObjectMeta{Name: "baseline", UID: "uid"}}
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{kyverno.LabelPolicySource: "baseline"}}}
+	assert.False(t, ownedBy(source, policy), "the label alone doesn't make the source the owner")
+	setMetadata(source, "sha256:abc", policy, policy.DeepCopy())
+	assert.True(t, ownedBy(source, policy))
+	recreated := &kyvernov2alpha1.PolicySource{ObjectMeta: metav1.ObjectMeta{Name: "baseline", UID: "other"}}
+	assert.False(t, ownedBy(recreated, policy))
+}
+
+func Test_schedulePoll(t *testing.T) {
+	c := &controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		polls: map[string]*time.Timer{},
+	}
+	defer c.queue.ShutDown()
+	c.schedulePoll("baseline", time.Hour)
+	c.schedulePoll("baseline", time.Millisecond)
+	assert.Len(t, c.polls, 1, "a source has a single pending poll")
+	assert.Eventually(t, func() bool { return c.queue.Len() == 1 }, time.Second, time.Millisecond)
+	c.schedulePoll("baseline", -1)
+	assert.Empty(t, c.polls)
+}
+
+func Test_setMetadata(t *testing.T) {
+	source := &kyvernov2alpha1.PolicySource{ObjectMeta: metav1.ObjectMeta{Name: "baseline", UID: "uid"}}
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:        "a",
+		Labels:      map[string]string{"team": "platform"},
+		Annotations: map[string]string{"policies.kyverno.io/title": "A"},
+	}}
+	obj := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:            "a",
+		ResourceVersion: "1",
+		Labels:          map[string]string{"stale": "true"},
+	}}
+	assert.True(t, outdated(obj, "sha256:abc"))
+
+	setMetadata(source, "sha256:abc", obj, policy)
+	assert.Equal(t, map[string]string{"team": "platform", kyverno.LabelPolicySource: "baseline"}, obj.GetLabels())
+	assert.Equal(t, "sha256:abc", obj.GetAnnotations()[kyverno.AnnotationPolicySourceRevision])
+	assert.Equal(t, "A", obj.GetAnnotations()["policies.kyverno.io/title"])
+	require.Len(t, obj.GetOwnerReferences(), 1)
+	assert.Equal(t, "PolicySource", obj.GetOwnerReferences()[0].Kind)
+	assert.Equal(t, "baseline", obj.GetOwnerReferences()[0].Name)
+	assert.False(t, outdated(obj, "sha256:abc"))
+	assert.True(t, outdated(obj, "sha256:def"))
+	assert.NotContains(t, policy.GetLabels(), kyverno.LabelPolicySource, "the fetched policy is not modified")
+}
+
+func Test_interval(t *testing.T) {
+	source := &kyvernov2alpha1.PolicySource{}
+	assert.Equal(t, defaultInterval, interval(source))
+	source.Spec.Interval = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, interval(source))
+}
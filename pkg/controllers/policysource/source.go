@@ -0,0 +1,271 @@
+package policysource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/policy/bundle"
+	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/kyverno/sdk/extensions/regcreds"
+	"github.com/kyverno/sdk/extensions/registryclient"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// fetchOCI pulls the policy layers of an image, the image signature and its signed manifest are verified
+// against the pulled digest unless verification is disabled. The revision is the image digest.
+func (c *controller) fetchOCI(ctx context.Context, source *kyvernov2alpha1.OCIPolicySource) (string, [][]byte, error) {
+	authOpts, nameOpts := registryclient.GlobalOptsOrDefault(ctx)
+	if len(source.ImagePullSecrets) != 0 {
+		authOpts, nameOpts = regcreds.RemoteOptsFromIvpolCredentials(c.secretLister, policiesv1beta1.Credentials{Secrets: source.ImagePullSecrets}, config.KyvernoNamespace())
+	}
+	ref, err := name.ParseReference(source.Image, nameOpts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing image reference: %w", err)
+	}
+	remoteOpts := append([]remote.Option{remote.WithContext(ctx)}, authOpts...)
+	desc, err := remote.Get(ref, remoteOpts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("getting image: %w", err)
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return "", nil, fmt.Errorf("getting image: %w", err)
+	}
+	if !source.InsecureSkipVerify {
+		if source.Verify == nil {
+			return "", nil, errors.New("signature verification is required")
+		}
+		digest := ref.Context().Digest(desc.Digest.String())
+		manifest, err := bundle.Verify(ctx, digest.String(), attestor(source.Verify), remoteOpts...)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := manifest.Verify(img, bundle.PolicyLayerMediaType); err != nil {
+			return "", nil, fmt.Errorf("verifying policy manifest: %w", err)
+		}
+	}
+	layers, err := bundle.PolicyLayers(img)
+	if err != nil {
+		return "", nil, err
+	}
+	return desc.Digest.String(), layers, nil
+}
+
+// attestor converts the verification settings of a policy source to a cosign attestor.
+func attestor(verify *kyvernov2alpha1.OCISignatureVerification) *policiesv1beta1.Attestor {
+	attestor := &policiesv1beta1.Attestor{
+		Name:   "policy-source",
+		Cosign: &policiesv1beta1.Cosign{},
+	}
+	switch {
+	case verify.Keyless != nil:
+		attestor.Cosign.Keyless = &policiesv1beta1.Keyless{
+			Identities: []policiesv1beta1.Identity{{
+				Subject: verify.Keyless.Subject,
+				Issuer:  verify.Keyless.Issuer,
+			}},
+		}
+	case strings.Contains(verify.PublicKey, "://") && !strings.Contains(verify.PublicKey, "-----BEGIN"):
+		attestor.Cosign.Key = &policiesv1beta1.Key{KMS: verify.PublicKey}
+	default:
+		attestor.Cosign.Key = &policiesv1beta1.Key{Data: verify.PublicKey}
+	}
+	return attestor
+}
+
+// fetchGit clones a repository and reads the yaml files under the configured path, the head commit signature
+// is verified when public keys are configured. The revision is the head commit SHA.
+func (c *controller) fetchGit(ctx context.Context, source *kyvernov2alpha1.GitPolicySource) (string, [][]byte, error) {
+	var auth http.BasicAuth
+	if source.SecretRef != "" {
+		secret, err := c.secretLister.Secrets(config.KyvernoNamespace()).Get(source.SecretRef)
+		if err != nil {
+			return "", nil, fmt.Errorf("getting secret %s: %w", source.SecretRef, err)
+		}
+		auth.Username = string(secret.Data["username"])
+		auth.Password = string(secret.Data["password"])
+	}
+	branch := source.Branch
+	if branch == "" {
+		branch = "main"
+	}
+	fs := memfs.New()
+	repo, err := gitutils.CloneContext(ctx, source.URL, fs, branch, auth)
+	if err != nil {
+		return "", nil, fmt.Errorf("cloning repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", nil, fmt.Errorf("getting head: %w", err)
+	}
+	if source.Verify != nil {
+		commit, err := repo.CommitObject(head.Hash())
+		if err != nil {
+			return "", nil, fmt.Errorf("getting head commit: %w", err)
+		}
+		if _, err := commit.Verify(source.Verify.PublicKeys); err != nil {
+			return "", nil, fmt.Errorf("verifying commit %s signature: %w", head.Hash(), err)
+		}
+	}
+	documents, err := readYamls(fs, source.Path)
+	if err != nil {
+		return "", nil, err
+	}
+	return head.Hash().String(), documents, nil
+}
+
+func readYamls(fs billy.Filesystem, path string) ([][]byte, error) {
+	if path == "" {
+		path = "/"
+	}
+	files, err := gitutils.ListYamls(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("listing files in %s: %w", path, err)
+	}
+	var documents [][]byte
+	for _, file := range files {
+		content, err := readFile(fs, file)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, content)
+	}
+	return documents, nil
+}
+
+func readFile(fs billy.Filesystem, path string) ([]byte, error) {
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening file %s: %w", path, err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading file %s: %w", path, err)
+	}
+	return content, nil
+}
+
+// celKinds are the CEL policy kinds of the policies.kyverno.io group synchronized from policy sources.
+var celKinds = []string{
+	"ValidatingPolicy",
+	"NamespacedValidatingPolicy",
+	"ImageValidatingPolicy",
+	"NamespacedImageValidatingPolicy",
+	"MutatingPolicy",
+	"NamespacedMutatingPolicy",
+	"GeneratingPolicy",
+	"NamespacedGeneratingPolicy",
+	"DeletingPolicy",
+	"NamespacedDeletingPolicy",
+}
+
+// loadPolicies decodes the Kyverno and CEL policies from the fetched documents, other resources are ignored.
+// Namespaced policies without a namespace go to the default namespace.
+// Kubernetes admission policies can't be synchronized from a source, they are returned as unsupported.
+func loadPolicies(documents [][]byte) ([]kyvernov1.PolicyInterface, []*unstructured.Unstructured, []kyvernov2alpha1.PolicySourceEntry, error) {
+	var policies []kyvernov1.PolicyInterface
+	var celPolicies []*unstructured.Unstructured
+	var unsupported []kyvernov2alpha1.PolicySourceEntry
+	for _, document := range documents {
+		objects, err := decode(document)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, obj := range objects {
+			if obj.GroupVersionKind().Group == policiesv1beta1.GroupVersion.Group {
+				if !slices.Contains(celKinds, obj.GetKind()) {
+					continue
+				}
+				if strings.HasPrefix(obj.GetKind(), "Namespaced") && obj.GetNamespace() == "" {
+					obj.SetNamespace("default")
+				}
+				celPolicies = append(celPolicies, obj)
+				continue
+			}
+			raw, err := obj.MarshalJSON()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			loaded, vaps, vapBindings, _, _, maps, mapBindings, err := yamlutils.GetPolicy(raw)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			for _, policy := range loaded {
+				if policy.IsNamespaced() && policy.GetNamespace() == "" {
+					policy.SetNamespace("default")
+				}
+				policies = append(policies, policy)
+			}
+			for i := range vaps {
+				unsupported = append(unsupported, unsupportedEntry("ValidatingAdmissionPolicy", &vaps[i]))
+			}
+			for i := range vapBindings {
+				unsupported = append(unsupported, unsupportedEntry("ValidatingAdmissionPolicyBinding", &vapBindings[i]))
+			}
+			for i := range maps {
+				unsupported = append(unsupported, unsupportedEntry("MutatingAdmissionPolicy", &maps[i]))
+			}
+			for i := range mapBindings {
+				unsupported = append(unsupported, unsupportedEntry("MutatingAdmissionPolicyBinding", &mapBindings[i]))
+			}
+		}
+	}
+	return policies, celPolicies, unsupported, nil
+}
+
+// decode splits a document into objects, lists are expanded.
+func decode(document []byte) ([]*unstructured.Unstructured, error) {
+	documents, err := extyaml.SplitDocuments(document)
+	if err != nil {
+		return nil, err
+	}
+	var objects []*unstructured.Unstructured
+	for _, document := range documents {
+		raw, err := yaml.ToJSON(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to JSON: %w", err)
+		}
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+		if !obj.IsList() {
+			objects = append(objects, &obj)
+			continue
+		}
+		list, err := obj.ToList()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode list: %w", err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+	return objects, nil
+}
+
+func unsupportedEntry(kind string, policy metav1.Object) kyvernov2alpha1.PolicySourceEntry {
+	return kyvernov2alpha1.PolicySourceEntry{
+		Kind:      kind,
+		Namespace: policy.GetNamespace(),
+		Name:      policy.GetName(),
+	}
+}
@@ -18,6 +18,7 @@ import (
 	policiesv1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/sink"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	"github.com/kyverno/kyverno/pkg/openreports"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
@@ -79,6 +80,9 @@ type controller struct {
 	ephrLister     cache.GenericLister
 	cephrLister    cache.GenericLister
 
+	// resultSink receives result changes, it is optional
+	resultSink sink.Interface
+
 	// reportUUIDToPolicyCache maps report UUIDs to policies that affect them for targeted reconciliation.
 	// This avoids processing all reports when a single policy changes.
 	cacheMu                 *sync.Mutex
@@ -116,6 +120,7 @@ func NewController(
 	mapV1Informer admissionregistrationv1informers.MutatingAdmissionPolicyInformer,
 	mapInformer admissionregistrationv1beta1informers.MutatingAdmissionPolicyInformer,
 	mapAlphaInformer admissionregistrationv1alpha1informers.MutatingAdmissionPolicyInformer,
+	resultSink sink.Interface,
 ) controllers.Controller {
 	ephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("ephemeralreports"))
 	cephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("clusterephemeralreports"))
@@ -186,6 +191,7 @@ func NewController(
 		cephrLister:             cephrInformer.Lister(),
 		cacheMu:                 cacheMu,
 		reportUUIDToPolicyCache: reportUUIDToPolicyCache,
+		resultSink:              resultSink,
 		frontQueue: workqueue.NewTypedRateLimitingQueueWithConfig(
 			workqueue.DefaultTypedControllerRateLimiter[any](),
 			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
@@ -829,6 +835,11 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 	if report != nil {
 		reports = append(reports, report)
 	}
+	// keep track of the previous results to publish changes
+	var previousResults []openreportsv1alpha1.ReportResult
+	if report != nil {
+		previousResults = append(previousResults, report.GetResults()...)
+	}
 	// get ephemeral reports
 	ephemeralReports, err := c.findOwnedEphemeralReports(ctx, namespace, name)
 	if err != nil {
@@ -898,7 +909,10 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 
 	if len(results) == 0 {
 		if report != nil {
-			return deleteReport(ctx, report, c.client, c.orClient)
+			if err := deleteReport(ctx, report, c.client, c.orClient); err != nil {
+				return err
+			}
+			return c.publishChanges(ctx, report, previousResults, nil)
 		}
 	} else {
 		if report == nil {
@@ -934,6 +948,28 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 			c.reportUUIDToPolicyCache[string(uuid)] = policySet
 			c.cacheMu.Unlock()
 		}
+		return c.publishChanges(ctx, report, previousResults, results)
 	}
 	return nil
 }
+
+func (c *controller) publishChanges(ctx context.Context, report reportsv1.ReportInterface, previous, current []openreportsv1alpha1.ReportResult) error {
+	if c.resultSink == nil {
+		return nil
+	}
+	var resource *corev1.ObjectReference
+	if owners := report.GetOwnerReferences(); len(owners) != 0 {
+		resource = &corev1.ObjectReference{
+			Kind:       owners[0].Kind,
+			Namespace:  report.GetNamespace(),
+			Name:       owners[0].Name,
+			UID:        owners[0].UID,
+			APIVersion: owners[0].APIVersion,
+		}
+	}
+	events := sink.Diff(report.GetNamespace(), report.GetName(), resource, previous, current, time.Now())
+	if len(events) == 0 {
+		return nil
+	}
+	return c.resultSink.Add(ctx, events...)
+}
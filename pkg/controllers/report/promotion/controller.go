@@ -0,0 +1,384 @@
+package promotion
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	policiesv1beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policies.kyverno.io/v1beta1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	policiesv1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1beta1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.uber.org/multierr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "audit-promotion-controller"
+)
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	cpolLister   kyvernov1listers.ClusterPolicyLister
+	polLister    kyvernov1listers.PolicyLister
+	vpolLister   policiesv1beta1listers.ValidatingPolicyLister
+	nvpolLister  policiesv1beta1listers.NamespacedValidatingPolicyLister
+	reportLister ReportLister
+
+	// events
+	eventGen event.Interface
+
+	// config
+	interval time.Duration
+
+	now func() time.Time
+}
+
+// NewController returns a controller promoting audited policies that opted in with the policies.kyverno.io/promotion
+// annotation. Every interval it summarizes the policy reports, once a policy has no failure for the configured period
+// and resource count it is switched to enforce (or a switch is recommended), the decision is recorded in the
+// AuditPromotion condition of the policy.
+func NewController(
+	client versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	vpolInformer policiesv1beta1informers.ValidatingPolicyInformer,
+	nvpolInformer policiesv1beta1informers.NamespacedValidatingPolicyInformer,
+	reportLister ReportLister,
+	eventGen event.Interface,
+	interval time.Duration,
+) *controller {
+	return &controller{
+		client:       client,
+		cpolLister:   cpolInformer.Lister(),
+		polLister:    polInformer.Lister(),
+		vpolLister:   vpolInformer.Lister(),
+		nvpolLister:  nvpolInformer.Lister(),
+		reportLister: reportLister,
+		eventGen:     eventGen,
+		interval:     interval,
+		now:          time.Now,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.promote(ctx); err != nil {
+			logger.Error(err, "failed to promote policies")
+		}
+	}, c.interval)
+}
+
+func (c *controller) promote(ctx context.Context) error {
+	reports, err := c.reportLister.List(labels.SelectorFromSet(labels.Set{
+		kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+	}))
+	if err != nil {
+		return err
+	}
+	summaries := Summarize(reports...)
+	now := c.now()
+	var errs []error
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, cpol := range cpols {
+		errs = append(errs, c.reconcileClusterPolicy(ctx, cpol, summaries, now))
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, pol := range pols {
+		errs = append(errs, c.reconcilePolicy(ctx, pol, summaries, now))
+	}
+	vpols, err := c.vpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, vpol := range vpols {
+		errs = append(errs, c.reconcileValidatingPolicy(ctx, vpol, summaries, now))
+	}
+	nvpols, err := c.nvpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, nvpol := range nvpols {
+		errs = append(errs, c.reconcileNamespacedValidatingPolicy(ctx, nvpol, summaries, now))
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *controller) reconcileClusterPolicy(ctx context.Context, cpol *kyvernov1.ClusterPolicy, summaries map[Key]Summary, now time.Time) error {
+	audit := inAudit(cpol.GetSpec())
+	previous := meta.FindStatusCondition(cpol.GetStatus().Conditions, ConditionAuditPromotion).DeepCopy()
+	condition := evaluate(cpol, reportutils.SourceKyverno, audit, previous, summaries, now)
+	if audit && condition != nil && condition.Reason == ReasonPromoted {
+		updated, err := controllerutils.Update(ctx, cpol, c.client.KyvernoV1().ClusterPolicies(), func(cpol *kyvernov1.ClusterPolicy) error {
+			enforce(cpol.GetSpec())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		cpol = updated
+	}
+	if err := controllerutils.UpdateStatus(
+		ctx,
+		cpol,
+		c.client.KyvernoV1().ClusterPolicies(),
+		func(cpol *kyvernov1.ClusterPolicy) error {
+			setCondition(&cpol.GetStatus().Conditions, condition)
+			return nil
+		},
+		func(a *kyvernov1.ClusterPolicy, b *kyvernov1.ClusterPolicy) bool {
+			return datautils.DeepEqual(a.Status, b.Status)
+		},
+	); err != nil {
+		return err
+	}
+	c.emitEvent(engineapi.NewKyvernoPolicy(cpol), previous, condition)
+	return nil
+}
+
+func (c *controller) reconcilePolicy(ctx context.Context, pol *kyvernov1.Policy, summaries map[Key]Summary, now time.Time) error {
+	audit := inAudit(pol.GetSpec())
+	previous := meta.FindStatusCondition(pol.GetStatus().Conditions, ConditionAuditPromotion).DeepCopy()
+	condition := evaluate(pol, reportutils.SourceKyverno, audit, previous, summaries, now)
+	if audit && condition != nil && condition.Reason == ReasonPromoted {
+		updated, err := controllerutils.Update(ctx, pol, c.client.KyvernoV1().Policies(pol.GetNamespace()), func(pol *kyvernov1.Policy) error {
+			enforce(pol.GetSpec())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		pol = updated
+	}
+	if err := controllerutils.UpdateStatus(
+		ctx,
+		pol,
+		c.client.KyvernoV1().Policies(pol.GetNamespace()),
+		func(pol *kyvernov1.Policy) error {
+			setCondition(&pol.GetStatus().Conditions, condition)
+			return nil
+		},
+		func(a *kyvernov1.Policy, b *kyvernov1.Policy) bool {
+			return datautils.DeepEqual(a.Status, b.Status)
+		},
+	); err != nil {
+		return err
+	}
+	c.emitEvent(engineapi.NewKyvernoPolicy(pol), previous, condition)
+	return nil
+}
+
+func (c *controller) reconcileValidatingPolicy(ctx context.Context, vpol *policiesv1beta1.ValidatingPolicy, summaries map[Key]Summary, now time.Time) error {
+	audit := inAuditActions(vpol.Spec.ValidationActions())
+	previous := meta.FindStatusCondition(vpol.Status.ConditionStatus.Conditions, ConditionAuditPromotion).DeepCopy()
+	condition := evaluate(vpol, reportutils.SourceValidatingPolicy, audit, previous, summaries, now)
+	if audit && condition != nil && condition.Reason == ReasonPromoted {
+		updated, err := controllerutils.Update(ctx, vpol, c.client.PoliciesV1beta1().ValidatingPolicies(), func(vpol *policiesv1beta1.ValidatingPolicy) error {
+			vpol.Spec.ValidationAction = enforceActions(vpol.Spec.ValidationActions())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		vpol = updated
+	}
+	if err := controllerutils.UpdateStatus(
+		ctx,
+		vpol,
+		c.client.PoliciesV1beta1().ValidatingPolicies(),
+		func(vpol *policiesv1beta1.ValidatingPolicy) error {
+			setCondition(&vpol.Status.ConditionStatus.Conditions, condition)
+			return nil
+		},
+		func(a *policiesv1beta1.ValidatingPolicy, b *policiesv1beta1.ValidatingPolicy) bool {
+			return datautils.DeepEqual(a.Status, b.Status)
+		},
+	); err != nil {
+		return err
+	}
+	c.emitEvent(engineapi.NewValidatingPolicy(vpol), previous, condition)
+	return nil
+}
+
+func (c *controller) reconcileNamespacedValidatingPolicy(ctx context.Context, nvpol *policiesv1beta1.NamespacedValidatingPolicy, summaries map[Key]Summary, now time.Time) error {
+	audit := inAuditActions(nvpol.Spec.ValidationActions())
+	previous := meta.FindStatusCondition(nvpol.Status.ConditionStatus.Conditions, ConditionAuditPromotion).DeepCopy()
+	condition := evaluate(nvpol, reportutils.SourceValidatingPolicy, audit, previous, summaries, now)
+	if audit && condition != nil && condition.Reason == ReasonPromoted {
+		updated, err := controllerutils.Update(ctx, nvpol, c.client.PoliciesV1beta1().NamespacedValidatingPolicies(nvpol.GetNamespace()), func(nvpol *policiesv1beta1.NamespacedValidatingPolicy) error {
+			nvpol.Spec.ValidationAction = enforceActions(nvpol.Spec.ValidationActions())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		nvpol = updated
+	}
+	if err := controllerutils.UpdateStatus(
+		ctx,
+		nvpol,
+		c.client.PoliciesV1beta1().NamespacedValidatingPolicies(nvpol.GetNamespace()),
+		func(nvpol *policiesv1beta1.NamespacedValidatingPolicy) error {
+			setCondition(&nvpol.Status.ConditionStatus.Conditions, condition)
+			return nil
+		},
+		func(a *policiesv1beta1.NamespacedValidatingPolicy, b *policiesv1beta1.NamespacedValidatingPolicy) bool {
+			return datautils.DeepEqual(a.Status, b.Status)
+		},
+	); err != nil {
+		return err
+	}
+	c.emitEvent(engineapi.NewNamespacedValidatingPolicy(nvpol), previous, condition)
+	return nil
+}
+
+// emitEvent emits an event when a policy becomes recommended for promotion or gets promoted
+func (c *controller) emitEvent(policy engineapi.GenericPolicy, previous, condition *metav1.Condition) {
+	if condition == nil || (condition.Reason != ReasonRecommended && condition.Reason != ReasonPromoted) {
+		return
+	}
+	if previous != nil && previous.Reason == condition.Reason {
+		return
+	}
+	c.eventGen.Add(event.NewPolicyPromotionEvent(policy, condition.Message))
+}
+
+// evaluate returns the promotion condition of a policy, nil when the policy has no promotion condition.
+// Once a policy left audit, the condition is kept only if the policy was promoted by this controller.
+func evaluate(policy metav1.Object, source string, audit bool, previous *metav1.Condition, summaries map[Key]Summary, now time.Time) *metav1.Condition {
+	config, err := ConfigFromAnnotations(policy.GetAnnotations())
+	if err != nil {
+		condition := metav1.Condition{
+			Type:               ConditionAuditPromotion,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonInvalid,
+			Message:            err.Error(),
+			LastTransitionTime: metav1.NewTime(now.Truncate(time.Second)),
+		}
+		if previous != nil && previous.Reason == ReasonInvalid {
+			condition.LastTransitionTime = previous.LastTransitionTime
+		}
+		return &condition
+	}
+	if config == nil {
+		return nil
+	}
+	if !audit {
+		if previous != nil && previous.Reason == ReasonPromoted {
+			return previous
+		}
+		return nil
+	}
+	key, _ := cache.MetaNamespaceKeyFunc(policy)
+	condition := Evaluate(*config, summaries[Key{Source: source, Policy: key}], previous, now)
+	return &condition
+}
+
+// setCondition sets the promotion condition, it is always true so that it doesn't affect the readiness of the policy
+func setCondition(conditions *[]metav1.Condition, condition *metav1.Condition) {
+	if condition == nil {
+		meta.RemoveStatusCondition(conditions, ConditionAuditPromotion)
+		return
+	}
+	// the transition time is managed by Evaluate, it changes with the reason and not with the status
+	if current := meta.FindStatusCondition(*conditions, ConditionAuditPromotion); current != nil {
+		*current = *condition
+		return
+	}
+	meta.SetStatusCondition(conditions, *condition)
+}
+
+// inAudit returns true if at least one validate rule of the policy is audited, in all or some namespaces
+func inAudit(spec *kyvernov1.Spec) bool {
+	for _, rule := range spec.Rules {
+		if !rule.HasValidate() {
+			continue
+		}
+		if !ruleFailureAction(spec, rule).Enforce() || auditOverrides(rule.Validation.FailureActionOverrides) || auditOverrides(spec.ValidationFailureActionOverrides) {
+			return true
+		}
+	}
+	return false
+}
+
+// enforce switches all the validate rules of the policy and their namespace overrides to enforce
+func enforce(spec *kyvernov1.Spec) {
+	for i := range spec.Rules {
+		rule := &spec.Rules[i]
+		if !rule.HasValidate() {
+			continue
+		}
+		if !ruleFailureAction(spec, *rule).Enforce() {
+			rule.Validation.FailureAction = ptr.To(kyvernov1.Enforce)
+		}
+		enforceOverrides(rule.Validation.FailureActionOverrides)
+	}
+	if spec.ValidationFailureAction != "" && !spec.ValidationFailureAction.Enforce() {
+		spec.ValidationFailureAction = kyvernov1.Enforce
+	}
+	enforceOverrides(spec.ValidationFailureActionOverrides)
+}
+
+func auditOverrides(overrides []kyvernov1.ValidationFailureActionOverride) bool {
+	return slices.ContainsFunc(overrides, func(override kyvernov1.ValidationFailureActionOverride) bool {
+		return !override.Action.Enforce()
+	})
+}
+
+// enforceOverrides switches the namespace overrides to enforce, their selectors are kept
+func enforceOverrides(overrides []kyvernov1.ValidationFailureActionOverride) {
+	for i := range overrides {
+		if !overrides[i].Action.Enforce() {
+			overrides[i].Action = kyvernov1.Enforce
+		}
+	}
+}
+
+func ruleFailureAction(spec *kyvernov1.Spec, rule kyvernov1.Rule) kyvernov1.ValidationFailureAction {
+	if rule.Validation.FailureAction != nil {
+		return *rule.Validation.FailureAction
+	}
+	return spec.ValidationFailureAction
+}
+
+func inAuditActions(actions []admissionregistrationv1.ValidationAction) bool {
+	return slices.Contains(actions, admissionregistrationv1.Audit) && !slices.Contains(actions, admissionregistrationv1.Deny)
+}
+
+// enforceActions replaces the audit action with the deny action
+func enforceActions(actions []admissionregistrationv1.ValidationAction) []admissionregistrationv1.ValidationAction {
+	var enforced []admissionregistrationv1.ValidationAction
+	for _, action := range actions {
+		if action == admissionregistrationv1.Audit {
+			action = admissionregistrationv1.Deny
+		}
+		enforced = append(enforced, action)
+	}
+	return enforced
+}
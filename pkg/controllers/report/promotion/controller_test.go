@@ -0,0 +1,114 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_enforce(t *testing.T) {
+	spec := &kyvernov1.Spec{
+		ValidationFailureAction: kyvernov1.Audit,
+		Rules: []kyvernov1.Rule{{
+			Name:       "inherited",
+			Validation: &kyvernov1.Validation{Message: "inherited"},
+		}, {
+			Name:       "audit",
+			Validation: &kyvernov1.Validation{Message: "audit", FailureAction: ptr.To(kyvernov1.Audit)},
+		}, {
+			Name:       "enforce",
+			Validation: &kyvernov1.Validation{Message: "enforce", FailureAction: ptr.To(kyvernov1.Enforce)},
+		}, {
+			Name: "mutate",
+		}},
+	}
+	assert.True(t, inAudit(spec))
+	enforce(spec)
+	assert.False(t, inAudit(spec))
+	assert.Equal(t, kyvernov1.Enforce, spec.ValidationFailureAction)
+	assert.Equal(t, kyvernov1.Enforce, *spec.Rules[0].Validation.FailureAction)
+	assert.Equal(t, kyvernov1.Enforce, *spec.Rules[1].Validation.FailureAction)
+	assert.Nil(t, spec.Rules[3].Validation)
+
+	spec = &kyvernov1.Spec{
+		ValidationFailureAction: kyvernov1.Enforce,
+		ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{{
+			Action:     kyvernov1.Audit,
+			Namespaces: []string{"dev"},
+		}},
+		Rules: []kyvernov1.Rule{{
+			Name: "override",
+			Validation: &kyvernov1.Validation{
+				Message: "override",
+				FailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{{
+					Action:     kyvernov1.Audit,
+					Namespaces: []string{"test"},
+				}},
+			},
+		}},
+	}
+	assert.True(t, inAudit(spec), "policies audited in some namespaces are in audit")
+	enforce(spec)
+	assert.False(t, inAudit(spec))
+	assert.Equal(t, kyvernov1.Enforce, spec.ValidationFailureActionOverrides[0].Action)
+	assert.Equal(t, []string{"dev"}, spec.ValidationFailureActionOverrides[0].Namespaces)
+	assert.Equal(t, kyvernov1.Enforce, spec.Rules[0].Validation.FailureActionOverrides[0].Action)
+}
+
+func Test_enforceActions(t *testing.T) {
+	assert.True(t, inAuditActions([]admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit, admissionregistrationv1.Warn}))
+	assert.False(t, inAuditActions([]admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}))
+	assert.Equal(t,
+		[]admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny, admissionregistrationv1.Warn},
+		enforceActions([]admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit, admissionregistrationv1.Warn}),
+	)
+}
+
+func Test_evaluate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}}
+	summaries := map[Key]Summary{
+		{Source: reportutils.SourceKyverno, Policy: "require-labels"}:          {Resources: 5},
+		{Source: reportutils.SourceValidatingPolicy, Policy: "require-labels"}: {Resources: 5, Failures: 1},
+	}
+
+	assert.Nil(t, evaluate(policy, reportutils.SourceKyverno, true, nil, summaries, now), "policies without annotation are ignored")
+
+	policy.Annotations = map[string]string{"policies.kyverno.io/promotion": "Enforce", "policies.kyverno.io/promotion-period": "0s"}
+	condition := evaluate(policy, reportutils.SourceKyverno, true, nil, summaries, now)
+	assert.Equal(t, ReasonPromoted, condition.Reason)
+	assert.Equal(t, condition, evaluate(policy, reportutils.SourceKyverno, false, condition, summaries, now), "promoted condition is kept")
+
+	clean := &metav1.Condition{Type: ConditionAuditPromotion, Reason: ReasonClean}
+	assert.Nil(t, evaluate(policy, reportutils.SourceKyverno, false, clean, summaries, now), "condition is removed when the policy left audit")
+
+	vpol := &policiesv1beta1.ValidatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Annotations: policy.Annotations}}
+	assert.Equal(t, ReasonFailing, evaluate(vpol, reportutils.SourceValidatingPolicy, true, nil, summaries, now).Reason, "policies of other kinds with the same name are summarized apart")
+
+	policy.Annotations["policies.kyverno.io/promotion"] = "Always"
+	assert.Equal(t, ReasonInvalid, evaluate(policy, reportutils.SourceKyverno, true, nil, summaries, now).Reason)
+}
+
+func Test_setCondition(t *testing.T) {
+	conditions := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Succeeded"}}
+	first := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	second := metav1.NewTime(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	setCondition(&conditions, &metav1.Condition{Type: ConditionAuditPromotion, Status: metav1.ConditionTrue, Reason: ReasonFailing, LastTransitionTime: first})
+	setCondition(&conditions, &metav1.Condition{Type: ConditionAuditPromotion, Status: metav1.ConditionTrue, Reason: ReasonClean, LastTransitionTime: second})
+	condition := meta.FindStatusCondition(conditions, ConditionAuditPromotion)
+	assert.Equal(t, ReasonClean, condition.Reason)
+	assert.Equal(t, second, condition.LastTransitionTime, "transition time follows the reason")
+
+	setCondition(&conditions, nil)
+	assert.Nil(t, meta.FindStatusCondition(conditions, ConditionAuditPromotion))
+	assert.Len(t, conditions, 1, "other conditions are preserved")
+}
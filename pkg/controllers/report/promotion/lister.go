@@ -0,0 +1,87 @@
+package promotion
+
+import (
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	policyreportv1alpha2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha2"
+	policyreportv1alpha2listers "github.com/kyverno/kyverno/pkg/client/listers/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsv1alpha1informers "github.com/openreports/reports-api/pkg/client/informers/externalversions/openreports.io/v1alpha1"
+	openreportsv1alpha1listers "github.com/openreports/reports-api/pkg/client/listers/openreports.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ReportLister lists the policy and cluster policy reports from the informers cache,
+// the reports returned are shared with the cache and must not be modified.
+type ReportLister interface {
+	List(selector labels.Selector) ([]reportsv1.ReportInterface, error)
+}
+
+type wgpolicyReportLister struct {
+	polrLister  policyreportv1alpha2listers.PolicyReportLister
+	cpolrLister policyreportv1alpha2listers.ClusterPolicyReportLister
+}
+
+// NewWgpolicyReportLister returns a ReportLister for the wgpolicyk8s.io reports
+func NewWgpolicyReportLister(
+	polrInformer policyreportv1alpha2informers.PolicyReportInformer,
+	cpolrInformer policyreportv1alpha2informers.ClusterPolicyReportInformer,
+) ReportLister {
+	return &wgpolicyReportLister{
+		polrLister:  polrInformer.Lister(),
+		cpolrLister: cpolrInformer.Lister(),
+	}
+}
+
+func (l *wgpolicyReportLister) List(selector labels.Selector) ([]reportsv1.ReportInterface, error) {
+	polrs, err := l.polrLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	cpolrs, err := l.cpolrLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]reportsv1.ReportInterface, 0, len(polrs)+len(cpolrs))
+	for _, polr := range polrs {
+		reports = append(reports, openreports.NewWGPolAdapter(polr))
+	}
+	for _, cpolr := range cpolrs {
+		reports = append(reports, openreports.NewWGCpolAdapter(cpolr))
+	}
+	return reports, nil
+}
+
+type openreportsReportLister struct {
+	reportLister        openreportsv1alpha1listers.ReportLister
+	clusterReportLister openreportsv1alpha1listers.ClusterReportLister
+}
+
+// NewOpenreportsReportLister returns a ReportLister for the openreports.io reports
+func NewOpenreportsReportLister(
+	reportInformer openreportsv1alpha1informers.ReportInformer,
+	clusterReportInformer openreportsv1alpha1informers.ClusterReportInformer,
+) ReportLister {
+	return &openreportsReportLister{
+		reportLister:        reportInformer.Lister(),
+		clusterReportLister: clusterReportInformer.Lister(),
+	}
+}
+
+func (l *openreportsReportLister) List(selector labels.Selector) ([]reportsv1.ReportInterface, error) {
+	polrs, err := l.reportLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	cpolrs, err := l.clusterReportLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]reportsv1.ReportInterface, 0, len(polrs)+len(cpolrs))
+	for _, polr := range polrs {
+		reports = append(reports, &openreports.ReportAdapter{Report: polr})
+	}
+	for _, cpolr := range cpolrs {
+		reports = append(reports, &openreports.ClusterReportAdapter{ClusterReport: cpolr})
+	}
+	return reports, nil
+}
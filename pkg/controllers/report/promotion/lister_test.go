@@ -0,0 +1,36 @@
+package promotion
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestWgpolicyReportLister(t *testing.T) {
+	managed := map[string]string{kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp}
+	client := fake.NewSimpleClientset(
+		&policyreportv1alpha2.PolicyReport{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "apps", Labels: managed}},
+		&policyreportv1alpha2.PolicyReport{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "apps"}},
+		&policyreportv1alpha2.ClusterPolicyReport{ObjectMeta: metav1.ObjectMeta{Name: "managed", Labels: managed}},
+	)
+	informers := kyvernoinformers.NewSharedInformerFactory(client, 0)
+	lister := NewWgpolicyReportLister(
+		informers.Wgpolicyk8s().V1alpha2().PolicyReports(),
+		informers.Wgpolicyk8s().V1alpha2().ClusterPolicyReports(),
+	)
+	informers.Start(t.Context().Done())
+	informers.WaitForCacheSync(t.Context().Done())
+
+	reports, err := lister.List(labels.SelectorFromSet(managed))
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "apps", reports[0].GetNamespace())
+	assert.Equal(t, "", reports[1].GetNamespace())
+}
@@ -0,0 +1,162 @@
+package promotion
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Mode defines what happens when a policy audited without failures becomes eligible for promotion.
+type Mode string
+
+const (
+	// ModeEnforce switches the policy to enforce
+	ModeEnforce Mode = "Enforce"
+	// ModeRecommend only recommends to switch the policy to enforce with an event and a condition
+	ModeRecommend Mode = "Recommend"
+
+	defaultPeriod       = 7 * 24 * time.Hour
+	defaultMinResources = 1
+)
+
+const (
+	// ConditionAuditPromotion is the condition recording the promotion of a policy from audit to enforce
+	ConditionAuditPromotion = "AuditPromotion"
+
+	ReasonInvalid     = "Invalid"
+	ReasonFailing     = "Failing"
+	ReasonClean       = "Clean"
+	ReasonRecommended = "Recommended"
+	ReasonPromoted    = "Promoted"
+)
+
+// Config is the promotion configuration of a policy.
+type Config struct {
+	Mode         Mode
+	Period       time.Duration
+	MinResources int
+}
+
+// ConfigFromAnnotations returns the promotion configuration of a policy, it returns nil when the policy
+// doesn't opt in with the policies.kyverno.io/promotion annotation.
+func ConfigFromAnnotations(annotations map[string]string) (*Config, error) {
+	mode := Mode(annotations[kyverno.AnnotationPolicyPromotion])
+	if mode == "" {
+		return nil, nil
+	}
+	if mode != ModeEnforce && mode != ModeRecommend {
+		return nil, fmt.Errorf("invalid %s annotation %q, must be %s or %s", kyverno.AnnotationPolicyPromotion, mode, ModeEnforce, ModeRecommend)
+	}
+	config := &Config{
+		Mode:         mode,
+		Period:       defaultPeriod,
+		MinResources: defaultMinResources,
+	}
+	if value := annotations[kyverno.AnnotationPolicyPromotionPeriod]; value != "" {
+		period, err := time.ParseDuration(value)
+		if err != nil || period < 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q, must be a positive duration", kyverno.AnnotationPolicyPromotionPeriod, value)
+		}
+		config.Period = period
+	}
+	if value := annotations[kyverno.AnnotationPolicyPromotionResources]; value != "" {
+		resources, err := strconv.Atoi(value)
+		if err != nil || resources < 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q, must be a positive integer", kyverno.AnnotationPolicyPromotionResources, value)
+		}
+		config.MinResources = resources
+	}
+	return config, nil
+}
+
+// Summary is the report history of a policy at a point in time.
+type Summary struct {
+	// Resources is the number of resources the policy was evaluated on
+	Resources int
+	// Failures is the number of failed or errored results
+	Failures int
+}
+
+// Key identifies a policy in report results, the source tells policy kinds sharing the same name apart.
+type Key struct {
+	Source string
+	Policy string
+}
+
+// Summarize computes the summary of the given reports per policy, policies are keyed by the source and policy of report results.
+func Summarize(reports ...reportsv1.ReportInterface) map[Key]Summary {
+	resources := map[Key]sets.Set[string]{}
+	failures := map[Key]int{}
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		resource := string(reportutils.GetResourceUid(report))
+		if resource == "" {
+			resource = report.GetNamespace() + "/" + report.GetName()
+		}
+		for _, result := range report.GetResults() {
+			key := Key{Source: result.Source, Policy: result.Policy}
+			if resources[key] == nil {
+				resources[key] = sets.New[string]()
+			}
+			resources[key].Insert(resource)
+			if result.Result == openreports.StatusFail || result.Result == openreports.StatusError {
+				failures[key]++
+			}
+		}
+	}
+	summaries := make(map[Key]Summary, len(resources))
+	for key, set := range resources {
+		summaries[key] = Summary{Resources: set.Len(), Failures: failures[key]}
+	}
+	return summaries
+}
+
+// Evaluate returns the promotion condition of a policy from its previous condition and its current report summary.
+// A policy is clean since the condition transitioned to Clean, it becomes eligible for promotion once it has been
+// clean for the configured period and it was evaluated on enough resources.
+func Evaluate(config Config, summary Summary, current *metav1.Condition, now time.Time) metav1.Condition {
+	condition := metav1.Condition{
+		Type:   ConditionAuditPromotion,
+		Status: metav1.ConditionTrue,
+	}
+	switch {
+	case summary.Failures > 0:
+		condition.Reason = ReasonFailing
+		condition.Message = fmt.Sprintf("%d failures reported on %d resources, the policy stays in audit.", summary.Failures, summary.Resources)
+	case current != nil && current.Reason == ReasonRecommended:
+		condition.Reason = ReasonRecommended
+		condition.Message = fmt.Sprintf("No failure reported on %d resources, switching the policy to enforce is recommended.", summary.Resources)
+	default:
+		cleanSince := now
+		if current != nil && current.Reason == ReasonClean {
+			cleanSince = current.LastTransitionTime.Time
+		}
+		if now.Sub(cleanSince) >= config.Period && summary.Resources >= config.MinResources {
+			if config.Mode == ModeEnforce {
+				condition.Reason = ReasonPromoted
+				condition.Message = fmt.Sprintf("No failure reported on %d resources for %s, the policy was switched to enforce.", summary.Resources, config.Period)
+			} else {
+				condition.Reason = ReasonRecommended
+				condition.Message = fmt.Sprintf("No failure reported on %d resources for %s, switching the policy to enforce is recommended.", summary.Resources, config.Period)
+			}
+		} else {
+			condition.Reason = ReasonClean
+			condition.Message = fmt.Sprintf("No failure reported on %d resources since %s, promotion requires %d resources and a clean history of %s.",
+				summary.Resources, cleanSince.UTC().Format(time.RFC3339), config.MinResources, config.Period)
+		}
+	}
+	condition.LastTransitionTime = metav1.NewTime(now.Truncate(time.Second))
+	if current != nil && current.Reason == condition.Reason {
+		condition.LastTransitionTime = current.LastTransitionTime
+	}
+	return condition
+}
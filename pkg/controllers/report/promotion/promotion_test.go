@@ -0,0 +1,103 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newReport(namespace, name string, results ...openreportsv1alpha1.ReportResult) reportsv1.ReportInterface {
+	return &openreports.ReportAdapter{
+		Report: &openreportsv1alpha1.Report{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Results:    results,
+		},
+	}
+}
+
+func newResult(policy string, result string) openreportsv1alpha1.ReportResult {
+	return openreportsv1alpha1.ReportResult{Source: reportutils.SourceKyverno, Policy: policy, Result: openreportsv1alpha1.Result(result)}
+}
+
+func TestConfigFromAnnotations(t *testing.T) {
+	config, err := ConfigFromAnnotations(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = ConfigFromAnnotations(map[string]string{kyverno.AnnotationPolicyPromotion: "Recommend"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Mode: ModeRecommend, Period: defaultPeriod, MinResources: defaultMinResources}, config)
+
+	config, err = ConfigFromAnnotations(map[string]string{
+		kyverno.AnnotationPolicyPromotion:          "Enforce",
+		kyverno.AnnotationPolicyPromotionPeriod:    "72h",
+		kyverno.AnnotationPolicyPromotionResources: "20",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Mode: ModeEnforce, Period: 72 * time.Hour, MinResources: 20}, config)
+
+	_, err = ConfigFromAnnotations(map[string]string{kyverno.AnnotationPolicyPromotion: "Always"})
+	assert.Error(t, err)
+	_, err = ConfigFromAnnotations(map[string]string{kyverno.AnnotationPolicyPromotion: "Enforce", kyverno.AnnotationPolicyPromotionPeriod: "week"})
+	assert.Error(t, err)
+	_, err = ConfigFromAnnotations(map[string]string{kyverno.AnnotationPolicyPromotion: "Enforce", kyverno.AnnotationPolicyPromotionResources: "-1"})
+	assert.Error(t, err)
+}
+
+func TestSummarize(t *testing.T) {
+	summaries := Summarize(
+		newReport("foo", "a", newResult("pol-a", openreports.StatusPass), newResult("pol-a", openreports.StatusPass), newResult("foo/pol-b", openreports.StatusFail)),
+		newReport("foo", "b", newResult("pol-a", openreports.StatusError)),
+		newReport("bar", "a", newResult("pol-a", openreports.StatusWarn)),
+		newReport("bar", "b", openreportsv1alpha1.ReportResult{Source: reportutils.SourceValidatingPolicy, Policy: "pol-a", Result: openreports.StatusFail}),
+		nil,
+	)
+	assert.Equal(t, map[Key]Summary{
+		{Source: reportutils.SourceKyverno, Policy: "pol-a"}:          {Resources: 3, Failures: 1},
+		{Source: reportutils.SourceKyverno, Policy: "foo/pol-b"}:      {Resources: 1, Failures: 1},
+		{Source: reportutils.SourceValidatingPolicy, Policy: "pol-a"}: {Resources: 1, Failures: 1},
+	}, summaries)
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := Config{Mode: ModeEnforce, Period: 24 * time.Hour, MinResources: 2}
+
+	condition := Evaluate(config, Summary{Resources: 3, Failures: 1}, nil, now)
+	assert.Equal(t, ReasonFailing, condition.Reason)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "1 failures reported on 3 resources, the policy stays in audit.", condition.Message)
+
+	condition = Evaluate(config, Summary{Resources: 3}, &condition, now.Add(time.Hour))
+	assert.Equal(t, ReasonClean, condition.Reason)
+	assert.Equal(t, now.Add(time.Hour), condition.LastTransitionTime.Time)
+	assert.Equal(t, "No failure reported on 3 resources since 2026-01-01T01:00:00Z, promotion requires 2 resources and a clean history of 24h0m0s.", condition.Message)
+
+	clean := Evaluate(config, Summary{Resources: 3}, &condition, now.Add(12*time.Hour))
+	assert.Equal(t, ReasonClean, clean.Reason)
+	assert.Equal(t, now.Add(time.Hour), clean.LastTransitionTime.Time, "clean since is preserved")
+
+	notEnough := Evaluate(config, Summary{Resources: 1}, &clean, now.Add(48*time.Hour))
+	assert.Equal(t, ReasonClean, notEnough.Reason)
+
+	promoted := Evaluate(config, Summary{Resources: 3}, &clean, now.Add(25*time.Hour))
+	assert.Equal(t, ReasonPromoted, promoted.Reason)
+	assert.Equal(t, now.Add(25*time.Hour), promoted.LastTransitionTime.Time)
+
+	config.Mode = ModeRecommend
+	recommended := Evaluate(config, Summary{Resources: 3}, &clean, now.Add(25*time.Hour))
+	assert.Equal(t, ReasonRecommended, recommended.Reason)
+	recommended = Evaluate(config, Summary{Resources: 3}, &recommended, now.Add(48*time.Hour))
+	assert.Equal(t, ReasonRecommended, recommended.Reason)
+	assert.Equal(t, now.Add(25*time.Hour), recommended.LastTransitionTime.Time)
+
+	failing := Evaluate(config, Summary{Resources: 3, Failures: 2}, &recommended, now.Add(49*time.Hour))
+	assert.Equal(t, ReasonFailing, failing.Reason)
+}
@@ -0,0 +1,124 @@
+package sink
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "report-sink-controller"
+	maxRetries     = 5
+	retryDelay     = time.Second
+)
+
+// Interface accepts result events for publication.
+type Interface interface {
+	// Add queues events for publication, it blocks while the queue is full
+	// and returns an error if the context is cancelled before all events were queued.
+	Add(context.Context, ...Event) error
+}
+
+type controller struct {
+	sink          Sink
+	events        chan Event
+	batchSize     int
+	flushInterval time.Duration
+}
+
+// NewController returns a controller batching events and publishing them to the given sink.
+// At most queueSize events are buffered, Add blocks when the buffer is full so that the caller
+// slows down instead of growing memory unbounded.
+func NewController(sink Sink, queueSize int, batchSize int, flushInterval time.Duration) *controller {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &controller{
+		sink:          sink,
+		events:        make(chan Event, queueSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+	}
+}
+
+func (c *controller) Add(ctx context.Context, events ...Event) error {
+	for _, event := range events {
+		select {
+		case c.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	var group wait.Group
+	for i := 0; i < workers; i++ {
+		group.StartWithContext(ctx, c.worker)
+	}
+	<-ctx.Done()
+	group.Wait()
+}
+
+func (c *controller) worker(ctx context.Context) {
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+	batch := make([]Event, 0, c.batchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		c.send(ctx, batch)
+		batch = make([]Event, 0, c.batchSize)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			// give pending events a last chance to be published
+			flushCtx, cancel := context.WithTimeout(context.Background(), c.flushInterval)
+			for {
+				select {
+				case event := <-c.events:
+					batch = append(batch, event)
+					continue
+				default:
+				}
+				break
+			}
+			flush(flushCtx)
+			cancel()
+			return
+		case event := <-c.events:
+			batch = append(batch, event)
+			if len(batch) >= c.batchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+func (c *controller) send(ctx context.Context, batch []Event) {
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if err = c.sink.Send(ctx, batch); err == nil {
+			return
+		}
+		logger.V(3).Info("failed to publish events, retrying", "count", len(batch), "attempt", attempt, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			logger.Error(ctx.Err(), "dropping events", "count", len(batch))
+			return
+		case <-time.After(retryDelay << attempt):
+		}
+	}
+	logger.Error(err, "failed to publish events, dropping them", "count", len(batch))
+}
@@ -0,0 +1,113 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type fakeSink struct {
+	lock    sync.Mutex
+	batches [][]Event
+}
+
+func (s *fakeSink) Send(_ context.Context, events []Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+func (s *fakeSink) count() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var count int
+	for _, batch := range s.batches {
+		count += len(batch)
+	}
+	return count
+}
+
+func TestControllerBatches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := &fakeSink{}
+	c := NewController(sink, 10, 2, time.Hour)
+	go c.Run(ctx, Workers)
+	assert.NoError(t, c.Add(ctx, Event{Type: EventTypeFailed}, Event{Type: EventTypeResolved}, Event{Type: EventTypeChanged}))
+	assert.NoError(t, wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return sink.count() == 2, nil
+	}))
+	sink.lock.Lock()
+	assert.Len(t, sink.batches, 1)
+	sink.lock.Unlock()
+}
+
+func TestControllerBackpressure(t *testing.T) {
+	c := NewController(&fakeSink{}, 1, 1, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	// the controller is not running, the second event can't be queued
+	err := c.Add(ctx, Event{}, Event{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var received []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, cloudEventsBatchType, r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	sink, err := NewHTTPSink(server.Client(), server.URL, "kyverno")
+	assert.NoError(t, err)
+	events := Diff("default", "report", nil, nil, []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusFail)}, time.Now())
+	assert.NoError(t, sink.Send(context.Background(), events))
+	assert.Len(t, received, 1)
+	assert.Equal(t, "1.0", received[0]["specversion"])
+	assert.Equal(t, EventTypeFailed, received[0]["type"])
+	assert.Equal(t, "default/report", received[0]["subject"])
+}
+
+func TestHTTPSinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	sink, err := NewHTTPSink(server.Client(), server.URL, "kyverno")
+	assert.NoError(t, err)
+	assert.Error(t, sink.Send(context.Background(), []Event{{Type: EventTypeFailed}}))
+}
+
+func TestNewHTTPSink(t *testing.T) {
+	sink, err := NewHTTPSink(nil, "https://sink.example.com/events", "kyverno")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultHTTPTimeout, sink.(*httpSink).client.Timeout)
+	for _, endpoint := range []string{"", "sink.example.com", "ftp://sink.example.com", "http://", "http://[::1"} {
+		_, err := NewHTTPSink(nil, endpoint, "kyverno")
+		assert.Error(t, err, endpoint)
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buffer bytes.Buffer
+	sink := NewWriterSink(&buffer, "kyverno")
+	assert.NoError(t, sink.Send(context.Background(), []Event{{Type: EventTypeFailed}, {Type: EventTypeResolved}}))
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 2)
+	var event map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, EventTypeResolved, event["type"])
+}
@@ -0,0 +1,87 @@
+package sink
+
+import (
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// EventTypeFailed is emitted when a result starts failing (new fail or error)
+	EventTypeFailed = "io.kyverno.report.result.failed"
+	// EventTypeResolved is emitted when a previously failing result passes, is skipped or disappears
+	EventTypeResolved = "io.kyverno.report.result.resolved"
+	// EventTypeChanged is emitted for any other result change
+	EventTypeChanged = "io.kyverno.report.result.changed"
+)
+
+// Event describes a change of a single policy report result.
+type Event struct {
+	// Type is one of EventTypeFailed, EventTypeResolved or EventTypeChanged
+	Type string `json:"-"`
+	// Time is the time the change was observed
+	Time time.Time `json:"-"`
+	// Namespace is the namespace of the report, empty for cluster reports
+	Namespace string `json:"namespace,omitempty"`
+	// Report is the name of the report
+	Report string `json:"report"`
+	// Resource is the resource the report is about
+	Resource *corev1.ObjectReference `json:"resource,omitempty"`
+	// Previous is the result status before the change, empty if the result is new
+	Previous openreportsv1alpha1.Result `json:"previous,omitempty"`
+	// Result is the current result, or the last known result if it was removed
+	Result openreportsv1alpha1.ReportResult `json:"result"`
+}
+
+func resultKey(result openreportsv1alpha1.ReportResult) string {
+	return result.Source + "/" + result.Policy + "/" + result.Rule
+}
+
+func isFailing(result openreportsv1alpha1.Result) bool {
+	return result == openreports.StatusFail || result == openreports.StatusError
+}
+
+// Diff computes the events describing the transition of a report from the previous results
+// to the current ones. Results are matched by source, policy and rule.
+func Diff(namespace, report string, resource *corev1.ObjectReference, previous, current []openreportsv1alpha1.ReportResult, now time.Time) []Event {
+	before := make(map[string]openreportsv1alpha1.ReportResult, len(previous))
+	for _, result := range previous {
+		before[resultKey(result)] = result
+	}
+	var events []Event
+	newEvent := func(eventType string, previous openreportsv1alpha1.Result, result openreportsv1alpha1.ReportResult) Event {
+		return Event{
+			Type:      eventType,
+			Time:      now,
+			Namespace: namespace,
+			Report:    report,
+			Resource:  resource,
+			Previous:  previous,
+			Result:    result,
+		}
+	}
+	for _, result := range current {
+		key := resultKey(result)
+		old, exists := before[key]
+		delete(before, key)
+		if exists && old.Result == result.Result {
+			continue
+		}
+		switch {
+		case isFailing(result.Result) && !isFailing(old.Result):
+			events = append(events, newEvent(EventTypeFailed, old.Result, result))
+		case isFailing(old.Result) && !isFailing(result.Result):
+			events = append(events, newEvent(EventTypeResolved, old.Result, result))
+		default:
+			events = append(events, newEvent(EventTypeChanged, old.Result, result))
+		}
+	}
+	for _, result := range previous {
+		if _, removed := before[resultKey(result)]; removed && isFailing(result.Result) {
+			events = append(events, newEvent(EventTypeResolved, result.Result, result))
+		}
+	}
+	return events
+}
@@ -0,0 +1,71 @@
+package sink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func result(policy, rule, status string) openreportsv1alpha1.ReportResult {
+	return openreportsv1alpha1.ReportResult{
+		Source: "kyverno",
+		Policy: policy,
+		Rule:   rule,
+		Result: openreportsv1alpha1.Result(status),
+	}
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		previous []openreportsv1alpha1.ReportResult
+		current  []openreportsv1alpha1.ReportResult
+		want     []string
+	}{{
+		name:    "new fail",
+		current: []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusFail)},
+		want:    []string{EventTypeFailed},
+	}, {
+		name:    "new pass",
+		current: []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusPass)},
+		want:    []string{EventTypeChanged},
+	}, {
+		name:     "unchanged",
+		previous: []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusFail)},
+		current:  []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusFail)},
+	}, {
+		name:     "resolved",
+		previous: []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusFail)},
+		current:  []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusPass)},
+		want:     []string{EventTypeResolved},
+	}, {
+		name:     "removed fail",
+		previous: []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusFail)},
+		want:     []string{EventTypeResolved},
+	}, {
+		name:     "removed pass",
+		previous: []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusPass)},
+	}, {
+		name:     "error to fail",
+		previous: []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusError)},
+		current:  []openreportsv1alpha1.ReportResult{result("pol", "rule", openreports.StatusFail)},
+		want:     []string{EventTypeChanged},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := Diff("default", "report", nil, tt.previous, tt.current, now)
+			var got []string
+			for _, event := range events {
+				assert.Equal(t, "default", event.Namespace)
+				assert.Equal(t, "report", event.Report)
+				assert.Equal(t, now, event.Time)
+				got = append(got, event.Type)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
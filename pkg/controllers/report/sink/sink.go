@@ -0,0 +1,158 @@
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// TypeCloudEvents publishes batches of CloudEvents over HTTP
+	TypeCloudEvents = "cloudevents"
+	// TypeJSONLines writes one CloudEvent per line to a file or stdout
+	TypeJSONLines = "jsonl"
+
+	// DefaultHTTPTimeout is the timeout of the HTTP client used when none is provided
+	DefaultHTTPTimeout = 10 * time.Second
+
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsBatchType   = "application/cloudevents-batch+json"
+)
+
+// Sink publishes result events to an external system.
+type Sink interface {
+	Send(context.Context, []Event) error
+}
+
+// cloudEvent is the structured mode representation of a CloudEvent (v1.0).
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Event     `json:"data"`
+}
+
+func toCloudEvent(source string, event Event) cloudEvent {
+	subject := event.Report
+	if event.Namespace != "" {
+		subject = event.Namespace + "/" + event.Report
+	}
+	return cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          source,
+		Type:            event.Type,
+		Subject:         subject,
+		Time:            event.Time.UTC(),
+		DataContentType: "application/json",
+		Data:            event,
+	}
+}
+
+type httpSink struct {
+	client   *http.Client
+	endpoint string
+	source   string
+}
+
+// NewHTTPSink returns a sink posting batches of CloudEvents to the given http or https endpoint.
+// A client with DefaultHTTPTimeout is used when client is nil.
+func NewHTTPSink(client *http.Client, endpoint string, source string) (Sink, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint %q: scheme must be http or https", endpoint)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: host is required", endpoint)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	return &httpSink{
+		client:   client,
+		endpoint: endpoint,
+		source:   source,
+	}, nil
+}
+
+func (s *httpSink) Send(ctx context.Context, events []Event) error {
+	batch := make([]cloudEvent, 0, len(events))
+	for _, event := range events {
+		batch = append(batch, toCloudEvent(s.source, event))
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", cloudEventsBatchType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to publish %d events: unexpected status code %d", len(events), resp.StatusCode)
+	}
+	return nil
+}
+
+type writerSink struct {
+	lock   sync.Mutex
+	writer io.Writer
+	source string
+}
+
+// NewWriterSink returns a sink writing one CloudEvent per line to the given writer.
+func NewWriterSink(writer io.Writer, source string) Sink {
+	return &writerSink{
+		writer: writer,
+		source: source,
+	}
+}
+
+// NewFileSink returns a sink writing JSON lines to the given file, "-" stands for stdout.
+func NewFileSink(path string, source string) (Sink, error) {
+	if path == "" || path == "-" {
+		return NewWriterSink(os.Stdout, source), nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterSink(file, source), nil
+}
+
+func (s *writerSink) Send(_ context.Context, events []Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	writer := bufio.NewWriter(s.writer)
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		if err := encoder.Encode(toCloudEvent(s.source, event)); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
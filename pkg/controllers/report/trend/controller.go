@@ -0,0 +1,148 @@
+package trend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsclient "github.com/openreports/reports-api/pkg/client/clientset/versioned/typed/openreports.io/v1alpha1"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "report-trend-controller"
+)
+
+type controller struct {
+	// clients
+	client   versioned.Interface
+	orClient openreportsclient.OpenreportsV1alpha1Interface
+
+	// config
+	interval  time.Duration
+	retention time.Duration
+
+	// metrics
+	trendMetrics metrics.ReportTrendMetrics
+
+	lock   sync.Mutex
+	series map[Key]series
+}
+
+// NewController returns a controller snapshotting policy report summaries per namespace and policy
+// every interval and exposing them as metrics so that compliance can be tracked over time.
+// Series that disappear are reported as zero until they have not been seen for longer than retention.
+func NewController(
+	client versioned.Interface,
+	orClient openreportsclient.OpenreportsV1alpha1Interface,
+	interval time.Duration,
+	retention time.Duration,
+) *controller {
+	c := &controller{
+		client:       client,
+		orClient:     orClient,
+		interval:     interval,
+		retention:    retention,
+		trendMetrics: metrics.GetReportTrendMetrics(),
+		series:       map[Key]series{},
+	}
+	return c
+}
+
+// Run snapshots policy reports until the context is done. The metrics callback is only registered
+// while the controller runs so that the series stop being exposed when leadership is lost.
+func (c *controller) Run(ctx context.Context, _ int) {
+	if c.trendMetrics != nil {
+		registration, err := c.trendMetrics.RegisterCallback(c.report)
+		if err != nil {
+			logger.Error(err, "failed to register callback")
+		} else if registration != nil {
+			defer func() {
+				if err := registration.Unregister(); err != nil {
+					logger.Error(err, "failed to unregister callback")
+				}
+			}()
+		}
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.snapshot(ctx); err != nil {
+			logger.Error(err, "failed to snapshot policy reports")
+		}
+	}, c.interval)
+}
+
+func (c *controller) snapshot(ctx context.Context) error {
+	reports, err := c.listReports(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot := Summarize(reports...)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.series = merge(c.series, snapshot, time.Now(), c.retention)
+	return nil
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, s := range c.series {
+		c.trendMetrics.RecordReportSummary(ctx, observer, key.Namespace, key.Policy, openreports.StatusPass, int64(s.summary.Pass))
+		c.trendMetrics.RecordReportSummary(ctx, observer, key.Namespace, key.Policy, openreports.StatusFail, int64(s.summary.Fail))
+		c.trendMetrics.RecordReportSummary(ctx, observer, key.Namespace, key.Policy, openreports.StatusWarn, int64(s.summary.Warn))
+		c.trendMetrics.RecordReportSummary(ctx, observer, key.Namespace, key.Policy, openreports.StatusError, int64(s.summary.Error))
+		c.trendMetrics.RecordReportSummary(ctx, observer, key.Namespace, key.Policy, openreports.StatusSkip, int64(s.summary.Skip))
+	}
+	return nil
+}
+
+func (c *controller) listReports(ctx context.Context) ([]reportsv1.ReportInterface, error) {
+	var reports []reportsv1.ReportInterface
+	options := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+		}).String(),
+	}
+	if c.orClient != nil {
+		polrs, err := c.orClient.Reports(metav1.NamespaceAll).List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range polrs.Items {
+			reports = append(reports, &openreports.ReportAdapter{Report: &polrs.Items[i]})
+		}
+		cpolrs, err := c.orClient.ClusterReports().List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range cpolrs.Items {
+			reports = append(reports, &openreports.ClusterReportAdapter{ClusterReport: &cpolrs.Items[i]})
+		}
+		return reports, nil
+	}
+	polrs, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range polrs.Items {
+		reports = append(reports, openreports.NewWGPolAdapter(&polrs.Items[i]))
+	}
+	cpolrs, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cpolrs.Items {
+		reports = append(reports, openreports.NewWGCpolAdapter(&cpolrs.Items[i]))
+	}
+	return reports, nil
+}
@@ -0,0 +1,52 @@
+package trend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+type fakeRegistration struct {
+	embedded.Registration
+	metrics *fakeTrendMetrics
+}
+
+func (r fakeRegistration) Unregister() error {
+	r.metrics.registered--
+	return nil
+}
+
+type fakeTrendMetrics struct {
+	calls      int
+	registered int
+}
+
+func (m *fakeTrendMetrics) RecordReportSummary(context.Context, metric.Observer, string, string, string, int64) {
+}
+
+func (m *fakeTrendMetrics) RegisterCallback(metric.Callback) (metric.Registration, error) {
+	m.calls++
+	m.registered++
+	return fakeRegistration{metrics: m}, nil
+}
+
+func TestControllerRunRegistersCallbackWhileRunning(t *testing.T) {
+	metrics := &fakeTrendMetrics{}
+	c := &controller{
+		interval:     time.Hour,
+		trendMetrics: metrics,
+		series:       map[Key]series{},
+	}
+	// leadership is acquired and lost several times
+	for range 3 {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		c.Run(ctx, Workers)
+		assert.Equal(t, 0, metrics.registered)
+	}
+	assert.Equal(t, 3, metrics.calls)
+}
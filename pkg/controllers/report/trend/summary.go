@@ -0,0 +1,59 @@
+package trend
+
+import (
+	"time"
+
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+)
+
+// Key identifies a series, namespace is empty for cluster reports.
+type Key struct {
+	Namespace string
+	Policy    string
+}
+
+type series struct {
+	summary  openreportsv1alpha1.ReportSummary
+	lastSeen time.Time
+}
+
+// Summarize computes the summary of the given reports per namespace and policy.
+func Summarize(reports ...reportsv1.ReportInterface) map[Key]openreportsv1alpha1.ReportSummary {
+	results := map[Key][]openreportsv1alpha1.ReportResult{}
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		for _, result := range report.GetResults() {
+			key := Key{Namespace: report.GetNamespace(), Policy: result.Policy}
+			results[key] = append(results[key], result)
+		}
+	}
+	summaries := make(map[Key]openreportsv1alpha1.ReportSummary, len(results))
+	for key, results := range results {
+		summaries[key] = reportutils.CalculateSummary(results)
+	}
+	return summaries
+}
+
+// merge updates the known series with a new snapshot.
+// Series missing from the snapshot are reset to zero so that dashboards see violations going away,
+// they are forgotten once they haven't been seen for longer than retention.
+func merge(known map[Key]series, snapshot map[Key]openreportsv1alpha1.ReportSummary, now time.Time, retention time.Duration) map[Key]series {
+	merged := make(map[Key]series, len(snapshot))
+	for key, summary := range snapshot {
+		merged[key] = series{summary: summary, lastSeen: now}
+	}
+	for key, s := range known {
+		if _, ok := merged[key]; ok {
+			continue
+		}
+		if now.Sub(s.lastSeen) > retention {
+			continue
+		}
+		merged[key] = series{lastSeen: s.lastSeen}
+	}
+	return merged
+}
@@ -0,0 +1,63 @@
+package trend
+
+import (
+	"testing"
+	"time"
+
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newReport(namespace string, results ...openreportsv1alpha1.ReportResult) reportsv1.ReportInterface {
+	return &openreports.ReportAdapter{
+		Report: &openreportsv1alpha1.Report{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "report"},
+			Results:    results,
+		},
+	}
+}
+
+func newResult(policy string, result string) openreportsv1alpha1.ReportResult {
+	return openreportsv1alpha1.ReportResult{Policy: policy, Result: openreportsv1alpha1.Result(result)}
+}
+
+func TestSummarize(t *testing.T) {
+	summaries := Summarize(
+		newReport("foo", newResult("pol-a", openreports.StatusFail), newResult("pol-b", openreports.StatusPass)),
+		newReport("foo", newResult("pol-a", openreports.StatusFail), newResult("pol-a", openreports.StatusPass)),
+		newReport("bar", newResult("pol-a", openreports.StatusWarn)),
+		nil,
+	)
+	assert.Equal(t, map[Key]openreportsv1alpha1.ReportSummary{
+		{Namespace: "foo", Policy: "pol-a"}: {Fail: 2, Pass: 1},
+		{Namespace: "foo", Policy: "pol-b"}: {Pass: 1},
+		{Namespace: "bar", Policy: "pol-a"}: {Warn: 1},
+	}, summaries)
+}
+
+func TestMerge(t *testing.T) {
+	now := time.Now()
+	retention := time.Hour
+	known := map[Key]series{
+		{Namespace: "foo", Policy: "current"}: {summary: openreportsv1alpha1.ReportSummary{Fail: 3}, lastSeen: now.Add(-time.Minute)},
+		{Namespace: "foo", Policy: "recent"}:  {summary: openreportsv1alpha1.ReportSummary{Fail: 1}, lastSeen: now.Add(-time.Minute)},
+		{Namespace: "foo", Policy: "expired"}: {lastSeen: now.Add(-2 * time.Hour)},
+	}
+	snapshot := map[Key]openreportsv1alpha1.ReportSummary{
+		{Namespace: "foo", Policy: "current"}: {Fail: 2},
+		{Namespace: "foo", Policy: "new"}:     {Pass: 1},
+	}
+	merged := merge(known, snapshot, now, retention)
+	assert.Len(t, merged, 3)
+	assert.Equal(t, openreportsv1alpha1.ReportSummary{Fail: 2}, merged[Key{Namespace: "foo", Policy: "current"}].summary)
+	assert.Equal(t, now, merged[Key{Namespace: "foo", Policy: "current"}].lastSeen)
+	assert.Equal(t, openreportsv1alpha1.ReportSummary{Pass: 1}, merged[Key{Namespace: "foo", Policy: "new"}].summary)
+	// series not seen anymore are reset to zero but keep their last seen time
+	assert.Equal(t, openreportsv1alpha1.ReportSummary{}, merged[Key{Namespace: "foo", Policy: "recent"}].summary)
+	assert.Equal(t, now.Add(-time.Minute), merged[Key{Namespace: "foo", Policy: "recent"}].lastSeen)
+	_, ok := merged[Key{Namespace: "foo", Policy: "expired"}]
+	assert.False(t, ok)
+}
@@ -0,0 +1,282 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	policiesv1beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policies.kyverno.io/v1beta1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	policiesv1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/rollout"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "rollout-controller"
+	maxRetries     = 10
+
+	// ConditionEnforcementRollout is the condition reporting the progression of the staged rollout of CEL policies
+	ConditionEnforcementRollout = "EnforcementRollout"
+
+	clusterPolicyKind              = "ClusterPolicy"
+	policyKind                     = "Policy"
+	validatingPolicyKind           = "ValidatingPolicy"
+	namespacedValidatingPolicyKind = "NamespacedValidatingPolicy"
+)
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	cpolLister  kyvernov1listers.ClusterPolicyLister
+	polLister   kyvernov1listers.PolicyLister
+	vpolLister  policiesv1beta1listers.ValidatingPolicyLister
+	nvpolLister policiesv1beta1listers.NamespacedValidatingPolicyLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	now func() time.Time
+}
+
+// NewController returns a controller tracking the progression of the staged enforcement rollout of policies.
+// Kyverno policies report it in status.rollout, CEL validating policies in the EnforcementRollout condition.
+// Policies are reconciled again when their next stage starts.
+func NewController(
+	client versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	vpolInformer policiesv1beta1informers.ValidatingPolicyInformer,
+	nvpolInformer policiesv1beta1informers.NamespacedValidatingPolicyInformer,
+) controllers.Controller {
+	c := &controller{
+		client:      client,
+		cpolLister:  cpolInformer.Lister(),
+		polLister:   polInformer.Lister(),
+		vpolLister:  vpolInformer.Lister(),
+		nvpolLister: nvpolInformer.Lister(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		now: time.Now,
+	}
+	if _, _, err := controllerutils.AddExplicitEventHandlers(logger, cpolInformer.Informer(), c.queue, func(obj *kyvernov1.ClusterPolicy) cache.ExplicitKey {
+		return buildKey(clusterPolicyKind, "", obj.GetName())
+	}); err != nil {
+		logger.Error(err, "failed to register event handlers for ClusterPolicy")
+	}
+	if _, _, err := controllerutils.AddExplicitEventHandlers(logger, polInformer.Informer(), c.queue, func(obj *kyvernov1.Policy) cache.ExplicitKey {
+		return buildKey(policyKind, obj.GetNamespace(), obj.GetName())
+	}); err != nil {
+		logger.Error(err, "failed to register event handlers for Policy")
+	}
+	if _, _, err := controllerutils.AddExplicitEventHandlers(logger, vpolInformer.Informer(), c.queue, func(obj *policiesv1beta1.ValidatingPolicy) cache.ExplicitKey {
+		return buildKey(validatingPolicyKind, "", obj.GetName())
+	}); err != nil {
+		logger.Error(err, "failed to register event handlers for ValidatingPolicy")
+	}
+	if _, _, err := controllerutils.AddExplicitEventHandlers(logger, nvpolInformer.Informer(), c.queue, func(obj *policiesv1beta1.NamespacedValidatingPolicy) cache.ExplicitKey {
+		return buildKey(namespacedValidatingPolicyKind, obj.GetNamespace(), obj.GetName())
+	}); err != nil {
+		logger.Error(err, "failed to register event handlers for NamespacedValidatingPolicy")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	kind, namespace, name, err := parseKey(key)
+	if err != nil {
+		logger.Error(err, "invalid key")
+		return nil
+	}
+	var next time.Duration
+	switch kind {
+	case clusterPolicyKind, policyKind:
+		next, err = c.reconcilePolicy(ctx, namespace, name)
+	case validatingPolicyKind, namespacedValidatingPolicyKind:
+		next, err = c.reconcileValidatingPolicy(ctx, namespace, name)
+	}
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if next > 0 {
+		logger.V(4).Info("rollout stage scheduled", "in", next)
+		c.queue.AddAfter(cache.ExplicitKey(key), next)
+	}
+	return nil
+}
+
+func (c *controller) reconcilePolicy(ctx context.Context, namespace, name string) (time.Duration, error) {
+	var policy kyvernov1.PolicyInterface
+	var err error
+	if namespace == "" {
+		policy, err = c.cpolLister.Get(name)
+	} else {
+		policy, err = c.polLister.Policies(namespace).Get(name)
+	}
+	if err != nil {
+		return 0, err
+	}
+	now := c.now()
+	policyRollout := policy.GetSpec().Rollout
+	status := rollout.Status(policyRollout, now)
+	if cpol, ok := policy.(*kyvernov1.ClusterPolicy); ok {
+		err = controllerutils.UpdateStatus(
+			ctx,
+			cpol,
+			c.client.KyvernoV1().ClusterPolicies(),
+			func(policy *kyvernov1.ClusterPolicy) error {
+				policy.GetStatus().Rollout = status
+				return nil
+			},
+			func(a *kyvernov1.ClusterPolicy, b *kyvernov1.ClusterPolicy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+	} else {
+		err = controllerutils.UpdateStatus(
+			ctx,
+			policy.(*kyvernov1.Policy),
+			c.client.KyvernoV1().Policies(namespace),
+			func(policy *kyvernov1.Policy) error {
+				policy.GetStatus().Rollout = status
+				return nil
+			},
+			func(a *kyvernov1.Policy, b *kyvernov1.Policy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return rollout.NextTransition(policyRollout, now), nil
+}
+
+func (c *controller) reconcileValidatingPolicy(ctx context.Context, namespace, name string) (time.Duration, error) {
+	now := c.now()
+	if namespace == "" {
+		vpol, err := c.vpolLister.Get(name)
+		if err != nil {
+			return 0, err
+		}
+		policyRollout, err := rollout.FromAnnotations(vpol.GetAnnotations())
+		if err != nil {
+			return 0, err
+		}
+		err = controllerutils.UpdateStatus(
+			ctx,
+			vpol,
+			c.client.PoliciesV1beta1().ValidatingPolicies(),
+			func(vpol *policiesv1beta1.ValidatingPolicy) error {
+				setCondition(&vpol.Status.ConditionStatus.Conditions, policyRollout, now)
+				return nil
+			},
+			func(a *policiesv1beta1.ValidatingPolicy, b *policiesv1beta1.ValidatingPolicy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+		if err != nil {
+			return 0, err
+		}
+		return rollout.NextTransition(policyRollout, now), nil
+	}
+	nvpol, err := c.nvpolLister.NamespacedValidatingPolicies(namespace).Get(name)
+	if err != nil {
+		return 0, err
+	}
+	policyRollout, err := rollout.FromAnnotations(nvpol.GetAnnotations())
+	if err != nil {
+		return 0, err
+	}
+	err = controllerutils.UpdateStatus(
+		ctx,
+		nvpol,
+		c.client.PoliciesV1beta1().NamespacedValidatingPolicies(namespace),
+		func(nvpol *policiesv1beta1.NamespacedValidatingPolicy) error {
+			setCondition(&nvpol.Status.ConditionStatus.Conditions, policyRollout, now)
+			return nil
+		},
+		func(a *policiesv1beta1.NamespacedValidatingPolicy, b *policiesv1beta1.NamespacedValidatingPolicy) bool {
+			return datautils.DeepEqual(a.Status, b.Status)
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return rollout.NextTransition(policyRollout, now), nil
+}
+
+// setCondition sets the rollout condition, it is always true so that it doesn't affect the readiness of the policy
+func setCondition(conditions *[]metav1.Condition, policyRollout *kyvernov1.Rollout, now time.Time) {
+	status := rollout.Status(policyRollout, now)
+	if status == nil {
+		meta.RemoveStatusCondition(conditions, ConditionEnforcementRollout)
+		return
+	}
+	reason := "InProgress"
+	if status.Completed {
+		reason = "Completed"
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ConditionEnforcementRollout,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            buildMessage(status),
+		LastTransitionTime: metav1.NewTime(now.Truncate(time.Second)),
+	})
+}
+
+func buildMessage(status *kyvernov1.RolloutStatus) string {
+	if status.StartedStages == 0 {
+		if status.NextStageTime != nil {
+			return fmt.Sprintf("No stage started, the policy is audited until %s.", status.NextStageTime.UTC().Format(time.RFC3339))
+		}
+		return "No stage started, the policy is audited."
+	}
+	if status.Completed {
+		return fmt.Sprintf("All %d stages started, stage %s is the last stage.", status.TotalStages, status.CurrentStage)
+	}
+	message := fmt.Sprintf("Stage %s started (%d of %d).", status.CurrentStage, status.StartedStages, status.TotalStages)
+	if status.NextStageTime != nil {
+		message += fmt.Sprintf(" Next stage starts at %s.", status.NextStageTime.UTC().Format(time.RFC3339))
+	}
+	return message
+}
+
+func buildKey(kind, namespace, name string) cache.ExplicitKey {
+	return cache.ExplicitKey(kind + "/" + namespace + "/" + name)
+}
+
+func parseKey(key string) (kind, namespace, name string, err error) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid key %s", key)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,63 @@
+package rollout
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_setCondition(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	policyRollout := &kyvernov1.Rollout{Stages: []kyvernov1.RolloutStage{{
+		Name:              "canary",
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+	}, {
+		Name:      "all",
+		StartTime: ptr.To(metav1.NewTime(now.Add(time.Hour))),
+	}}}
+	conditions := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Succeeded"}}
+
+	setCondition(&conditions, policyRollout, now)
+	condition := meta.FindStatusCondition(conditions, ConditionEnforcementRollout)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "InProgress", condition.Reason)
+	assert.Equal(t, "Stage canary started (1 of 2). Next stage starts at 2026-01-01T01:00:00Z.", condition.Message)
+
+	setCondition(&conditions, policyRollout, now.Add(time.Hour))
+	condition = meta.FindStatusCondition(conditions, ConditionEnforcementRollout)
+	assert.Equal(t, "Completed", condition.Reason)
+	assert.Equal(t, "All 2 stages started, stage all is the last stage.", condition.Message)
+
+	setCondition(&conditions, nil, now)
+	assert.Nil(t, meta.FindStatusCondition(conditions, ConditionEnforcementRollout))
+	assert.Len(t, conditions, 1, "other conditions are preserved")
+}
+
+func Test_buildMessage(t *testing.T) {
+	next := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "No stage started, the policy is audited until 2026-01-01T00:00:00Z.", buildMessage(&kyvernov1.RolloutStatus{TotalStages: 1, NextStageTime: &next}))
+	assert.Equal(t, "No stage started, the policy is audited.", buildMessage(&kyvernov1.RolloutStatus{TotalStages: 1}))
+}
+
+func Test_parseKey(t *testing.T) {
+	kind, namespace, name, err := parseKey(string(buildKey(policyKind, "team-a", "require-labels")))
+	assert.NoError(t, err)
+	assert.Equal(t, policyKind, kind)
+	assert.Equal(t, "team-a", namespace)
+	assert.Equal(t, "require-labels", name)
+
+	kind, namespace, name, err = parseKey(string(buildKey(clusterPolicyKind, "", "require-labels")))
+	assert.NoError(t, err)
+	assert.Equal(t, clusterPolicyKind, kind)
+	assert.Empty(t, namespace)
+	assert.Equal(t, "require-labels", name)
+
+	_, _, _, err = parseKey("invalid")
+	assert.Error(t, err)
+}
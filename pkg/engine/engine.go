@@ -14,12 +14,14 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/latency"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	imageverifycache "github.com/kyverno/kyverno/pkg/image/verification/cache"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -34,6 +36,17 @@ type engine struct {
 	contextLoader     engineapi.ContextLoaderFactory
 	exceptionSelector engineapi.PolicyExceptionSelector
 	metrics           metrics.PolicyEngineMetrics
+	latency           latency.Tracker
+}
+
+type Option func(*engine)
+
+// WithLatencyTracker records rule latencies in the given tracker and short circuits
+// rules the tracker reports as slow to their failure policy.
+func WithLatencyTracker(tracker latency.Tracker) Option {
+	return func(e *engine) {
+		e.latency = tracker
+	}
 }
 
 type handlerFactory = func() (handlers.Handler, error)
@@ -47,12 +60,13 @@ func NewEngine(
 	contextLoader engineapi.ContextLoaderFactory,
 	exceptionSelector engineapi.PolicyExceptionSelector,
 	isCluster *bool,
+	opts ...Option,
 ) engineapi.Engine {
 	if isCluster == nil {
 		defaultCluster := true
 		isCluster = &defaultCluster
 	}
-	return &engine{
+	e := &engine{
 		configuration:     configuration,
 		jp:                jp,
 		client:            client,
@@ -63,6 +77,10 @@ func NewEngine(
 		exceptionSelector: exceptionSelector,
 		metrics:           metrics.GetPolicyEngineMetrics(),
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 func (e *engine) Validate(
@@ -247,6 +265,16 @@ func (e *engine) invokeRuleHandler(
 			} else if handler, err := handlerFactory(); err != nil {
 				return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", err)
 			} else if handler != nil {
+				if e.latency != nil {
+					key := latency.RuleKey(policyContext.Policy(), rule.Name)
+					if e.latency.ShortCircuit(key) {
+						return resource, e.shortCircuit(ctx, policyContext, rule, ruleType)
+					}
+					startTime := time.Now()
+					defer func() {
+						e.latency.Observe(key, time.Since(startTime))
+					}()
+				}
 				policyContext.JSONContext().Checkpoint()
 				defer func() {
 					policyContext.JSONContext().Restore()
@@ -291,5 +319,28 @@ func (e *engine) invokeRuleHandler(
 			}
 			return resource, nil
 		},
+		trace.WithAttributes(ruleAttributes(policyContext.Policy(), rule.Name)...),
 	)
 }
+
+// shortCircuit returns the response of a rule skipped because it exceeds the latency budget,
+// according to the policy failure policy.
+func (e *engine) shortCircuit(
+	ctx context.Context,
+	policyContext engineapi.PolicyContext,
+	rule kyvernov1.Rule,
+	ruleType engineapi.RuleType,
+) []engineapi.RuleResponse {
+	msg := fmt.Sprintf("rule exceeds the latency budget of %s", e.latency.Budget())
+	if policy := policyContext.Policy(); policy != nil && policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Ignore {
+		return handlers.WithSkip(rule, ruleType, msg)
+	}
+	return handlers.WithError(rule, ruleType, msg, nil)
+}
+
+func ruleAttributes(policy kyvernov1.PolicyInterface, rule string) []attribute.KeyValue {
+	if policy == nil {
+		return []attribute.KeyValue{tracing.RuleNameKey.String(rule)}
+	}
+	return tracing.RuleAttributes(policy.GetKind(), policy.GetNamespace(), policy.GetName(), rule)
+}
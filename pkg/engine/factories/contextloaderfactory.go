@@ -3,6 +3,7 @@ package factories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -11,6 +12,7 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/latency"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/toggle"
 )
@@ -18,7 +20,7 @@ import (
 type ContextLoaderFactoryOptions func(*contextLoader)
 
 func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ...ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
-	return func(policy kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
+	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
 		policyNamespace := ""
 		if policy != nil && policy.IsNamespaced() {
 			policyNamespace = policy.GetNamespace()
@@ -27,6 +29,8 @@ func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ..
 			logger:          logging.WithName("DefaultContextLoaderFactory"),
 			cmResolver:      cmResolver,
 			policyNamespace: policyNamespace,
+			policy:          policy,
+			rule:            rule.Name,
 		}
 		for _, o := range opts {
 			o(cl)
@@ -47,6 +51,13 @@ func WithAPICallConfig(config apicall.APICallConfiguration) ContextLoaderFactory
 	}
 }
 
+// WithLatencyTracker records the time spent loading each context entry in the given tracker.
+func WithLatencyTracker(tracker latency.Tracker) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.latency = tracker
+	}
+}
+
 func WithGlobalContextStore(gctxStore loaders.Store) ContextLoaderFactoryOptions {
 	return func(cl *contextLoader) {
 		cl.gctxStore = gctxStore
@@ -60,6 +71,9 @@ type contextLoader struct {
 	apiCallConfig   apicall.APICallConfiguration
 	gctxStore       loaders.Store
 	policyNamespace string
+	policy          kyvernov1.PolicyInterface
+	rule            string
+	latency         latency.Tracker
 }
 
 func (l *contextLoader) Load(
@@ -99,6 +113,13 @@ func (l *contextLoader) loadContextEntries(
 			return fmt.Errorf("failed to create deferred loader for context entry %s", entry.Name)
 		}
 		if loader != nil {
+			if l.latency != nil {
+				loader = &timedLoader{
+					DeferredLoader: loader,
+					tracker:        l.latency,
+					key:            latency.ContextEntryKey(l.policy, l.rule, entry.Name),
+				}
+			}
 			if toggle.FromContext(ctx).EnableDeferredLoading() {
 				if err := jsonContext.AddDeferredLoader(loader); err != nil {
 					return err
@@ -113,6 +134,25 @@ func (l *contextLoader) loadContextEntries(
 	return nil
 }
 
+// timedLoader records the time spent loading data, whether the loader is deferred or not.
+// Reloads of previously loaded data are not recorded as they usually hit the loader cache.
+type timedLoader struct {
+	enginecontext.DeferredLoader
+	tracker latency.Tracker
+	key     latency.Key
+}
+
+func (l *timedLoader) LoadData() error {
+	if l.DeferredLoader.HasLoaded() {
+		return l.DeferredLoader.LoadData()
+	}
+	startTime := time.Now()
+	defer func() {
+		l.tracker.Observe(l.key, time.Since(startTime))
+	}()
+	return l.DeferredLoader.LoadData()
+}
+
 // RunContextLoaderInitializers runs WithInitializer callbacks registered on the context loader.
 func RunContextLoaderInitializers(l engineapi.ContextLoader, jsonContext enginecontext.Interface) error {
 	cl, ok := l.(*contextLoader)
@@ -0,0 +1,269 @@
+package latency
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+const (
+	defaultWindow     = 100
+	defaultMinSamples = 20
+)
+
+// Key identifies a latency series, either a policy rule or a context entry of a policy rule.
+type Key struct {
+	PolicyKind      string
+	PolicyNamespace string
+	PolicyName      string
+	Rule            string
+	// ContextEntry is empty for rule level series
+	ContextEntry string
+}
+
+// Stats is a point in time view of a latency series.
+type Stats struct {
+	Key
+	Count int
+	P95   time.Duration
+	P99   time.Duration
+	// Slow is true when the p95 latency of the series exceeds the budget
+	Slow      bool
+	SlowSince time.Time
+}
+
+// Notifier is invoked every time a series becomes slow or recovers.
+type Notifier func(Stats)
+
+// Tracker records policy rule and context entry latencies and detects series exceeding the budget.
+type Tracker interface {
+	// Budget returns the configured latency budget
+	Budget() time.Duration
+	// Observe records a new latency sample for the given series
+	Observe(key Key, duration time.Duration)
+	// ShortCircuit returns true when the rule should not be evaluated and fall back to its failure policy
+	ShortCircuit(key Key) bool
+	// Snapshot returns the stats of all known series
+	Snapshot() []Stats
+}
+
+type Option func(*tracker)
+
+// WithWindow sets the number of most recent samples used to compute percentiles.
+func WithWindow(window int) Option {
+	return func(t *tracker) {
+		if window > 0 {
+			t.window = window
+		}
+	}
+}
+
+// WithMinSamples sets the number of samples required before a series can be flagged as slow.
+func WithMinSamples(minSamples int) Option {
+	return func(t *tracker) {
+		if minSamples > 0 {
+			t.minSamples = minSamples
+		}
+	}
+}
+
+// WithShortCircuit enables short circuiting slow rules for the given cooldown.
+// Once the cooldown elapses the rule is evaluated again until enough samples are collected
+// to decide if it is still slow.
+func WithShortCircuit(cooldown time.Duration) Option {
+	return func(t *tracker) {
+		t.shortCircuit = true
+		t.cooldown = cooldown
+	}
+}
+
+// WithNotifier registers a notifier called when a series becomes slow or recovers.
+func WithNotifier(notifier Notifier) Option {
+	return func(t *tracker) {
+		t.notifiers = append(t.notifiers, notifier)
+	}
+}
+
+func withClock(now func() time.Time) Option {
+	return func(t *tracker) {
+		t.now = now
+	}
+}
+
+type series struct {
+	samples   []time.Duration
+	next      int
+	p95       time.Duration
+	p99       time.Duration
+	slow      bool
+	slowSince time.Time
+	probing   bool
+}
+
+func (s *series) add(duration time.Duration, window int) {
+	if len(s.samples) < window {
+		s.samples = append(s.samples, duration)
+	} else {
+		s.samples[s.next] = duration
+		s.next = (s.next + 1) % window
+	}
+	sorted := slices.Clone(s.samples)
+	slices.Sort(sorted)
+	s.p95 = percentile(sorted, 95)
+	s.p99 = percentile(sorted, 99)
+}
+
+func (s *series) reset() {
+	s.samples = s.samples[:0]
+	s.next = 0
+	s.p95 = 0
+	s.p99 = 0
+}
+
+func (s *series) stats(key Key) Stats {
+	return Stats{
+		Key:       key,
+		Count:     len(s.samples),
+		P95:       s.p95,
+		P99:       s.p99,
+		Slow:      s.slow,
+		SlowSince: s.slowSince,
+	}
+}
+
+type tracker struct {
+	budget       time.Duration
+	window       int
+	minSamples   int
+	shortCircuit bool
+	cooldown     time.Duration
+	notifiers    []Notifier
+	now          func() time.Time
+
+	lock   sync.Mutex
+	series map[Key]*series
+}
+
+// NewTracker returns a tracker flagging series whose p95 latency exceeds budget.
+func NewTracker(budget time.Duration, opts ...Option) Tracker {
+	t := &tracker{
+		budget:     budget,
+		window:     defaultWindow,
+		minSamples: defaultMinSamples,
+		now:        time.Now,
+		series:     map[Key]*series{},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.minSamples > t.window {
+		t.minSamples = t.window
+	}
+	return t
+}
+
+func (t *tracker) Budget() time.Duration {
+	return t.budget
+}
+
+func (t *tracker) Observe(key Key, duration time.Duration) {
+	if stats, changed := t.observe(key, duration); changed {
+		for _, notify := range t.notifiers {
+			notify(stats)
+		}
+	}
+}
+
+func (t *tracker) observe(key Key, duration time.Duration) (Stats, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	s := t.series[key]
+	if s == nil {
+		s = &series{}
+		t.series[key] = s
+	}
+	s.add(duration, t.window)
+	if len(s.samples) < t.minSamples {
+		return Stats{}, false
+	}
+	slow := s.p95 > t.budget
+	if s.probing {
+		s.probing = false
+		if slow {
+			// still slow after the cooldown, short circuit again without notifying
+			s.slowSince = t.now()
+			return Stats{}, false
+		}
+	}
+	if slow == s.slow {
+		return Stats{}, false
+	}
+	s.slow = slow
+	if slow {
+		s.slowSince = t.now()
+	} else {
+		s.slowSince = time.Time{}
+	}
+	return s.stats(key), true
+}
+
+func (t *tracker) ShortCircuit(key Key) bool {
+	if !t.shortCircuit {
+		return false
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	s := t.series[key]
+	if s == nil || !s.slow || s.probing {
+		return false
+	}
+	if t.now().Sub(s.slowSince) < t.cooldown {
+		return true
+	}
+	// cooldown elapsed, let the rule run again to collect fresh samples
+	s.reset()
+	s.probing = true
+	return false
+}
+
+func (t *tracker) Snapshot() []Stats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	stats := make([]Stats, 0, len(t.series))
+	for key, s := range t.series {
+		stats = append(stats, s.stats(key))
+	}
+	return stats
+}
+
+// percentile returns the nearest rank percentile of sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// RuleKey returns the key of the latency series of a policy rule.
+func RuleKey(policy kyvernov1.PolicyInterface, rule string) Key {
+	key := Key{Rule: rule}
+	if policy != nil {
+		key.PolicyKind = policy.GetKind()
+		key.PolicyNamespace = policy.GetNamespace()
+		key.PolicyName = policy.GetName()
+	}
+	return key
+}
+
+// ContextEntryKey returns the key of the latency series of a policy rule context entry.
+func ContextEntryKey(policy kyvernov1.PolicyInterface, rule string, entry string) Key {
+	key := RuleKey(policy, rule)
+	key.ContextEntry = entry
+	return key
+}
@@ -0,0 +1,89 @@
+package latency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_percentile(t *testing.T) {
+	var samples []time.Duration
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, time.Duration(0), percentile(nil, 95))
+	assert.Equal(t, 95*time.Millisecond, percentile(samples, 95))
+	assert.Equal(t, 99*time.Millisecond, percentile(samples, 99))
+	assert.Equal(t, 5*time.Millisecond, percentile(samples[:5], 99))
+}
+
+func TestTracker_Observe(t *testing.T) {
+	var notified []Stats
+	key := Key{PolicyKind: "ClusterPolicy", PolicyName: "test", Rule: "rule"}
+	tracker := NewTracker(
+		10*time.Millisecond,
+		WithWindow(10),
+		WithMinSamples(5),
+		WithNotifier(func(stats Stats) { notified = append(notified, stats) }),
+	)
+	for i := 0; i < 4; i++ {
+		tracker.Observe(key, time.Second)
+	}
+	assert.Empty(t, notified, "not enough samples")
+	tracker.Observe(key, time.Second)
+	assert.Len(t, notified, 1)
+	assert.True(t, notified[0].Slow)
+	assert.Equal(t, time.Second, notified[0].P95)
+	assert.Equal(t, key, notified[0].Key)
+	for i := 0; i < 10; i++ {
+		tracker.Observe(key, time.Millisecond)
+	}
+	assert.Len(t, notified, 2)
+	assert.False(t, notified[1].Slow)
+	snapshot := tracker.Snapshot()
+	assert.Len(t, snapshot, 1)
+	assert.Equal(t, 10, snapshot[0].Count)
+	assert.Equal(t, time.Millisecond, snapshot[0].P99)
+}
+
+func TestTracker_ShortCircuit(t *testing.T) {
+	now := time.Now()
+	key := Key{PolicyKind: "ClusterPolicy", PolicyName: "test", Rule: "rule"}
+	var notified []Stats
+	tracker := NewTracker(
+		10*time.Millisecond,
+		WithWindow(10),
+		WithMinSamples(2),
+		WithShortCircuit(time.Minute),
+		WithNotifier(func(stats Stats) { notified = append(notified, stats) }),
+		withClock(func() time.Time { return now }),
+	)
+	assert.False(t, tracker.ShortCircuit(key))
+	tracker.Observe(key, time.Second)
+	tracker.Observe(key, time.Second)
+	assert.True(t, tracker.ShortCircuit(key))
+	// cooldown elapsed, rule is probed again
+	now = now.Add(2 * time.Minute)
+	assert.False(t, tracker.ShortCircuit(key))
+	tracker.Observe(key, time.Second)
+	assert.False(t, tracker.ShortCircuit(key), "still probing")
+	tracker.Observe(key, time.Second)
+	assert.True(t, tracker.ShortCircuit(key), "still slow after probing")
+	assert.Len(t, notified, 1)
+	// recovers after probing
+	now = now.Add(2 * time.Minute)
+	assert.False(t, tracker.ShortCircuit(key))
+	tracker.Observe(key, time.Millisecond)
+	tracker.Observe(key, time.Millisecond)
+	assert.False(t, tracker.ShortCircuit(key))
+	assert.Len(t, notified, 2)
+	assert.False(t, notified[1].Slow)
+}
+
+func TestTracker_ShortCircuitDisabled(t *testing.T) {
+	key := Key{PolicyKind: "ClusterPolicy", PolicyName: "test", Rule: "rule"}
+	tracker := NewTracker(time.Millisecond, WithMinSamples(1))
+	tracker.Observe(key, time.Second)
+	assert.False(t, tracker.ShortCircuit(key))
+}
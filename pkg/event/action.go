@@ -9,5 +9,6 @@ const (
 	ResourceGenerated Action = "Resource Generated"
 	ResourceMutated   Action = "Resource Mutated"
 	ResourceCleanedUp Action = "Resource Cleaned Up"
+	ResourceReverted  Action = "Resource Reverted"
 	None              Action = "None"
 )
@@ -447,3 +447,75 @@ func resourceKey(resource unstructured.Unstructured) string {
 
 	return strings.Join([]string{resource.GetKind(), resource.GetName()}, "/")
 }
+
+func NewPolicySlowEvent(policy kyvernov1.PolicyInterface, slow bool, message string) Info {
+	eventType := corev1.EventTypeWarning
+	if !slow {
+		eventType = corev1.EventTypeNormal
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: kyvernov1.SchemeGroupVersion.String(),
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  AdmissionController,
+		Reason:  PolicySlow,
+		Message: message,
+		Action:  None,
+		Type:    eventType,
+	}
+}
+
+func NewPolicyPromotionEvent(policy engineapi.GenericPolicy, message string) Info {
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: policy.GetAPIVersion(),
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  PolicyController,
+		Reason:  PolicyPromotion,
+		Message: message,
+		Action:  None,
+		Type:    corev1.EventTypeNormal,
+	}
+}
+
+func NewResourceDriftEvent(policy engineapi.GenericPolicy, rule string, resource unstructured.Unstructured, message string, reverted bool) Info {
+	action := None
+	if reverted {
+		action = ResourceReverted
+	}
+	var msg string
+	if rule == "" {
+		msg = fmt.Sprintf("policy %s: %s", policy.GetName(), message)
+	} else {
+		msg = fmt.Sprintf("policy %s/%s: %s", policy.GetName(), rule, message)
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Name:       resource.GetName(),
+			Namespace:  resource.GetNamespace(),
+			UID:        resource.GetUID(),
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: policy.GetAPIVersion(),
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  GeneratePolicyController,
+		Reason:  ResourceDrifted,
+		Message: msg,
+		Action:  action,
+		Type:    corev1.EventTypeWarning,
+	}
+}
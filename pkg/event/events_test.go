@@ -369,3 +369,28 @@ func Test_NewPolicyAppliedEvent_NamespacedMutatingPolicy(t *testing.T) {
 	assert.Contains(t, ev.Message, "Secret team-a/credentials is successfully mutated")
 	assert.Equal(t, "team-a", ev.Regarding.Namespace)
 }
+
+func Test_NewResourceDriftEvent(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "kyverno.io/v1", Kind: "ClusterPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: "sync-config"},
+	}
+	resource := unstructured.Unstructured{}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("ConfigMap")
+	resource.SetNamespace("team-a")
+	resource.SetName("config")
+
+	reverted := NewResourceDriftEvent(engineapi.NewKyvernoPolicy(policy), "clone", resource, "modified", true)
+	assert.Equal(t, "ConfigMap", reverted.Regarding.Kind)
+	assert.Equal(t, "team-a", reverted.Regarding.Namespace)
+	assert.Equal(t, "ClusterPolicy", reverted.Related.Kind)
+	assert.Equal(t, ResourceDrifted, reverted.Reason)
+	assert.Equal(t, ResourceReverted, reverted.Action)
+	assert.Equal(t, corev1.EventTypeWarning, reverted.Type)
+	assert.Equal(t, "policy sync-config/clone: modified", reverted.Message)
+
+	reported := NewResourceDriftEvent(engineapi.NewKyvernoPolicy(policy), "", resource, "modified", false)
+	assert.Equal(t, None, reported.Action)
+	assert.Equal(t, "policy sync-config: modified", reported.Message)
+}
@@ -8,4 +8,7 @@ const (
 	PolicyApplied   Reason = "PolicyApplied"
 	PolicyError     Reason = "PolicyError"
 	PolicySkipped   Reason = "PolicySkipped"
+	PolicySlow      Reason = "PolicySlow"
+	PolicyPromotion Reason = "PolicyPromotion"
+	ResourceDrifted Reason = "ResourceDrifted"
 )
@@ -0,0 +1,73 @@
+package leaderelection
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	"github.com/kyverno/kyverno/pkg/logging"
+)
+
+const (
+	AdminStatusPath  = "/leaderelection"
+	AdminReleasePath = "/leaderelection/release"
+)
+
+// Status is the leader election status served by the admin handler
+type Status struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	ID        string `json:"id"`
+	Leader    string `json:"leader"`
+	IsLeader  bool   `json:"isLeader"`
+}
+
+// NewAdminHandler returns a handler serving the status of the leader election and allowing to release
+// the leadership, typically before draining the node the leader runs on.
+func NewAdminHandler(logger logr.Logger, le Interface) http.Handler {
+	mux := httprouter.New()
+	mux.HandlerFunc("GET", AdminStatusPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(Status{
+			Name:      le.Name(),
+			Namespace: le.Namespace(),
+			ID:        le.ID(),
+			Leader:    le.GetLeader(),
+			IsLeader:  le.IsLeader(),
+		}); err != nil {
+			logger.Error(err, "failed to write leader election status")
+		}
+	})
+	mux.HandlerFunc("POST", AdminReleasePath, func(w http.ResponseWriter, _ *http.Request) {
+		if err := le.Release(); err != nil {
+			if errors.Is(err, ErrNotLeader) {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		logger.Info("leadership release requested")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	return mux
+}
+
+// StartAdmin serves the leader election admin handler on the given address.
+func StartAdmin(logger logr.Logger, address string, le Interface) {
+	logger.V(2).Info("start leader election admin server", "address", address)
+	go func() {
+		s := http.Server{
+			Addr:              address,
+			Handler:           NewAdminHandler(logger, le),
+			ErrorLog:          logging.StdLogger(logger, ""),
+			ReadHeaderTimeout: 30 * time.Second,
+		}
+		if err := s.ListenAndServe(); err != nil {
+			logger.Error(err, "failed to start leader election admin server")
+		}
+	}()
+}
@@ -0,0 +1,65 @@
+package leaderelection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeElection struct {
+	leader   bool
+	released bool
+}
+
+func (f *fakeElection) Run(context.Context) {}
+func (f *fakeElection) ID() string          { return "pod-a" }
+func (f *fakeElection) Name() string        { return "kyverno" }
+func (f *fakeElection) Namespace() string   { return "kyverno" }
+func (f *fakeElection) IsLeader() bool      { return f.leader }
+func (f *fakeElection) GetLeader() string {
+	if f.leader {
+		return "pod-a"
+	}
+	return "pod-b"
+}
+
+func (f *fakeElection) Release() error {
+	if !f.leader {
+		return ErrNotLeader
+	}
+	f.released = true
+	return nil
+}
+
+func TestAdminHandler_Status(t *testing.T) {
+	handler := NewAdminHandler(logr.Discard(), &fakeElection{leader: true})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, AdminStatusPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var status Status
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, Status{Name: "kyverno", Namespace: "kyverno", ID: "pod-a", Leader: "pod-a", IsLeader: true}, status)
+}
+
+func TestAdminHandler_Release(t *testing.T) {
+	election := &fakeElection{leader: true}
+	handler := NewAdminHandler(logr.Discard(), election)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, AdminReleasePath, nil))
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	assert.True(t, election.released)
+
+	handler = NewAdminHandler(logr.Discard(), &fakeElection{})
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, AdminReleasePath, nil))
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, AdminReleasePath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
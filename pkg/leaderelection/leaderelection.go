@@ -2,20 +2,41 @@ package leaderelection
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 )
 
 const DefaultRetryPeriod = 2 * time.Second
 
+// leadership transitions reported in metrics
+const (
+	TransitionStartedLeading = "started_leading"
+	TransitionStoppedLeading = "stopped_leading"
+	TransitionReleased       = "released"
+	TransitionNewLeader      = "new_leader"
+)
+
+// ErrNotLeader is returned when releasing the leadership of an instance that doesn't lead
+var ErrNotLeader = errors.New("this instance is not the leader")
+
 type Interface interface {
-	// Run is a blocking call that runs a leader election
+	// Run is a blocking call that runs a leader election, it returns when the context is done
+	// or when this instance stops leading. The work of a term can't be started again in the same
+	// process, callers are expected to restart to take part in the election again.
 	Run(ctx context.Context)
 
 	// ID returns this instances unique identifier
@@ -32,6 +53,10 @@ type Interface interface {
 
 	// GetLeader returns the leader ID
 	GetLeader() string
+
+	// Release voluntarily gives up the leadership, Run returns once the lease is released
+	// so that another instance can acquire it right away
+	Release() error
 }
 
 type config struct {
@@ -44,10 +69,19 @@ type config struct {
 	leaderElectionCfg leaderelection.LeaderElectionConfig
 	leaderElector     *leaderelection.LeaderElector
 	isLeader          int64
+	leadingSince      atomic.Int64
+	broadcaster       events.EventBroadcaster
+	metrics           metrics.LeaderElectionMetrics
 	log               logr.Logger
+
+	mutex    sync.Mutex
+	cancel   context.CancelFunc
+	released bool
 }
 
 func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interface, id string, retryPeriod time.Duration, startWork func(context.Context), stopWork func()) (Interface, error) {
+	// leadership changes are recorded as events on the lease
+	broadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: kubeClient.EventsV1()})
 	lock, err := resourcelock.New(
 		resourcelock.LeasesResourceLock,
 		namespace,
@@ -55,20 +89,23 @@ func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interfac
 		kubeClient.CoreV1(),
 		kubeClient.CoordinationV1(),
 		resourcelock.ResourceLockConfig{
-			Identity: id,
+			Identity:      id,
+			EventRecorder: eventRecorder{broadcaster.NewRecorder(scheme.Scheme, name)},
 		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing resource lock: %s/%s: %w", namespace, name, err)
 	}
 	e := &config{
-		name:       name,
-		namespace:  namespace,
-		kubeClient: kubeClient,
-		lock:       lock,
-		startWork:  startWork,
-		stopWork:   stopWork,
-		log:        log.WithValues("id", lock.Identity()),
+		name:        name,
+		namespace:   namespace,
+		kubeClient:  kubeClient,
+		lock:        lock,
+		startWork:   startWork,
+		stopWork:    stopWork,
+		broadcaster: broadcaster,
+		metrics:     metrics.GetLeaderElectionMetrics(),
+		log:         log.WithValues("id", lock.Identity()),
 	}
 	e.leaderElectionCfg = leaderelection.LeaderElectionConfig{
 		Lock:            e.lock,
@@ -78,14 +115,25 @@ func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interfac
 		RetryPeriod:     retryPeriod,
 		Callbacks: leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) {
+				e.leadingSince.Store(time.Now().UnixNano())
 				atomic.StoreInt64(&e.isLeader, 1)
+				e.recordLeading(ctx, true)
+				e.recordTransition(ctx, TransitionStartedLeading)
 				e.log.V(2).Info("started leading")
+				go e.recordLeadershipDuration(ctx)
 				if e.startWork != nil {
 					e.startWork(ctx)
 				}
 			},
 			OnStoppedLeading: func() {
-				atomic.StoreInt64(&e.isLeader, 0)
+				if atomic.SwapInt64(&e.isLeader, 0) == 1 {
+					ctx := context.Background()
+					e.recordLeading(ctx, false)
+					e.recordTransition(ctx, TransitionStoppedLeading)
+					if e.metrics != nil {
+						e.metrics.RecordLeadershipDuration(ctx, e.name, time.Since(time.Unix(0, e.leadingSince.Load())).Seconds())
+					}
+				}
 				e.log.V(2).Info("leadership lost, stopped leading")
 				if e.stopWork != nil {
 					e.stopWork()
@@ -95,6 +143,7 @@ func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interfac
 				if identity == e.lock.Identity() {
 					e.log.V(4).Info("still leading")
 				} else {
+					e.recordTransition(context.Background(), TransitionNewLeader)
 					e.log.V(2).Info("another instance has been elected as leader", "leader", identity)
 				}
 			},
@@ -130,6 +179,94 @@ func (e *config) GetLeader() string {
 	return e.leaderElector.GetLeader()
 }
 
+func (e *config) Release() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if !e.IsLeader() || e.cancel == nil {
+		return ErrNotLeader
+	}
+	e.released = true
+	e.cancel()
+	return nil
+}
+
 func (e *config) Run(ctx context.Context) {
-	e.leaderElector.Run(ctx)
+	e.broadcaster.StartRecordingToSink(ctx.Done())
+	defer e.broadcaster.Shutdown()
+	electionCtx, cancel := context.WithCancel(ctx)
+	e.mutex.Lock()
+	e.cancel = cancel
+	e.mutex.Unlock()
+	e.leaderElector.Run(electionCtx)
+	cancel()
+	if ctx.Err() != nil || !e.isReleased() {
+		return
+	}
+	if err := e.release(ctx); err != nil {
+		e.log.Error(err, "failed to release the lease")
+	} else {
+		e.recordTransition(ctx, TransitionReleased)
+		e.log.V(2).Info("leadership released")
+	}
+}
+
+func (e *config) isReleased() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.released
+}
+
+// recordLeadershipDuration periodically records for how long this instance has been leading, until the term ends
+func (e *config) recordLeadershipDuration(ctx context.Context) {
+	if e.metrics == nil {
+		return
+	}
+	since := time.Unix(0, e.leadingSince.Load())
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		e.metrics.RecordLeadingDuration(ctx, e.name, time.Since(since).Seconds())
+	}, e.leaderElectionCfg.RetryPeriod)
+	e.metrics.RecordLeadingDuration(context.Background(), e.name, 0)
+}
+
+// release clears the holder of the lease so that another instance can acquire it without waiting for the lease to expire
+func (e *config) release(ctx context.Context) error {
+	record, _, err := e.lock.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if record.HolderIdentity != e.lock.Identity() {
+		return nil
+	}
+	now := metav1.NewTime(time.Now())
+	if err := e.lock.Update(ctx, resourcelock.LeaderElectionRecord{
+		LeaderTransitions:    record.LeaderTransitions,
+		LeaseDurationSeconds: 1,
+		RenewTime:            now,
+		AcquireTime:          now,
+	}); err != nil {
+		return err
+	}
+	e.lock.RecordEvent("released leadership")
+	return nil
+}
+
+func (e *config) recordTransition(ctx context.Context, transition string) {
+	if e.metrics != nil {
+		e.metrics.RecordTransition(ctx, e.name, transition)
+	}
+}
+
+func (e *config) recordLeading(ctx context.Context, leading bool) {
+	if e.metrics != nil {
+		e.metrics.RecordLeading(ctx, e.name, leading)
+	}
+}
+
+// eventRecorder records the lease events with the events.k8s.io API
+type eventRecorder struct {
+	recorder events.EventRecorder
+}
+
+func (r eventRecorder) Eventf(obj runtime.Object, eventType, reason, message string, args ...interface{}) {
+	r.recorder.Eventf(obj, nil, eventType, reason, "LeaderElection", message, args...)
 }
@@ -0,0 +1,47 @@
+package leaderelection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRelease(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset()
+	started := make(chan struct{}, 2)
+	le, err := New(logr.Discard(), "kyverno", "kyverno", client, "pod-a", 50*time.Millisecond, func(ctx context.Context) {
+		started <- struct{}{}
+		<-ctx.Done()
+	}, nil)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, le.Release(), ErrNotLeader)
+	done := make(chan struct{})
+	go func() {
+		le.Run(ctx)
+		close(done)
+	}()
+
+	<-started
+	assert.Eventually(t, le.IsLeader, time.Second, 10*time.Millisecond)
+	assert.NoError(t, le.Release())
+	assert.Eventually(t, func() bool {
+		lease, err := client.CoordinationV1().Leases("kyverno").Get(ctx, "kyverno", metav1.GetOptions{})
+		return err == nil && lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == ""
+	}, time.Second, 10*time.Millisecond, "the lease is released")
+	assert.False(t, le.IsLeader())
+
+	// the instance doesn't take part in the election again
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run didn't return after release")
+	}
+	assert.Len(t, started, 0)
+}
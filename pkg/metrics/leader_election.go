@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetLeaderElectionMetrics() LeaderElectionMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.LeaderElectionMetrics()
+}
+
+type LeaderElectionMetrics interface {
+	RecordTransition(ctx context.Context, name string, transition string)
+	RecordLeading(ctx context.Context, name string, leading bool)
+	RecordLeadershipDuration(ctx context.Context, name string, seconds float64)
+	RecordLeadingDuration(ctx context.Context, name string, seconds float64)
+}
+
+type leaderElectionMetrics struct {
+	transitionsCounter metric.Int64Counter
+	leaderGauge        metric.Int64Gauge
+	durationHistogram  metric.Float64Histogram
+	leadingGauge       metric.Float64Gauge
+
+	logger logr.Logger
+}
+
+func (m *leaderElectionMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.transitionsCounter, err = meter.Int64Counter(
+		"kyverno_leader_election_transitions",
+		metric.WithDescription("can be used to track the leadership transitions (started leading, stopped leading, released, new leader observed) of a leader election."),
+	)
+	if err != nil {
+		m.logger.Error(err, "failed to register metric kyverno_leader_election_transitions")
+	}
+
+	m.leaderGauge, err = meter.Int64Gauge(
+		"kyverno_leader_election_is_leader",
+		metric.WithDescription("can be used to know whether this instance currently holds the leadership (1) or not (0)."),
+	)
+	if err != nil {
+		m.logger.Error(err, "failed to register metric kyverno_leader_election_is_leader")
+	}
+
+	m.durationHistogram, err = meter.Float64Histogram(
+		"kyverno_leader_election_leadership_duration_seconds",
+		metric.WithDescription("can be used to track the time (in seconds) this instance held the leadership, recorded when the leadership ends."),
+	)
+	if err != nil {
+		m.logger.Error(err, "failed to register metric kyverno_leader_election_leadership_duration_seconds")
+	}
+
+	m.leadingGauge, err = meter.Float64Gauge(
+		"kyverno_leader_election_leading_seconds",
+		metric.WithDescription("can be used to know for how long (in seconds) this instance has been holding the leadership, 0 when it doesn't lead."),
+	)
+	if err != nil {
+		m.logger.Error(err, "failed to register metric kyverno_leader_election_leading_seconds")
+	}
+}
+
+func (m *leaderElectionMetrics) RecordTransition(ctx context.Context, name string, transition string) {
+	if m.transitionsCounter == nil {
+		return
+	}
+
+	m.transitionsCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("leader_election_name", name),
+		attribute.String("transition", transition),
+	))
+}
+
+func (m *leaderElectionMetrics) RecordLeading(ctx context.Context, name string, leading bool) {
+	if m.leaderGauge == nil {
+		return
+	}
+
+	var value int64
+	if leading {
+		value = 1
+	}
+	m.leaderGauge.Record(ctx, value, metric.WithAttributes(
+		attribute.String("leader_election_name", name),
+	))
+}
+
+func (m *leaderElectionMetrics) RecordLeadershipDuration(ctx context.Context, name string, seconds float64) {
+	if m.durationHistogram == nil {
+		return
+	}
+
+	m.durationHistogram.Record(ctx, seconds, metric.WithAttributes(
+		attribute.String("leader_election_name", name),
+	))
+}
+
+func (m *leaderElectionMetrics) RecordLeadingDuration(ctx context.Context, name string, seconds float64) {
+	if m.leadingGauge == nil {
+		return
+	}
+
+	m.leadingGauge.Record(ctx, seconds, metric.WithAttributes(
+		attribute.String("leader_election_name", name),
+	))
+}
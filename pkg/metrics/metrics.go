@@ -36,24 +36,27 @@ func SetManager(manager MetricsConfigManager) {
 
 type MetricsConfig struct {
 	// instruments
-	policyChangesMetric  metric.Int64Counter
-	clientQueriesMetric  metric.Int64Counter
-	kyvernoInfoMetric    metric.Int64Gauge
-	breakerMetrics       *breakerMetrics
-	controllerMetrics    *controllerMetrics
-	cleanupMetrics       *cleanupMetrics
-	deletingMetrics      *deletingMetrics
-	updateRequestMetrics *updateRequestMetrics
-	policyRuleMetrics    *policyRuleMetrics
-	ttlInfoMetrics       *ttlInfoMetrics
-	policyEngineMetrics  *policyEngineMetrics
-	eventMetrics         *eventMetrics
-	admissionMetrics     *admissionMetrics
-	httpMetrics          *httpMetrics
-	vpolMetrics          *validatingMetrics
-	ivpolMetrics         *imageValidatingMetrics
-	mpolMetrics          *mutatingMetrics
-	gpolMetrics          *generatingMetrics
+	policyChangesMetric   metric.Int64Counter
+	clientQueriesMetric   metric.Int64Counter
+	kyvernoInfoMetric     metric.Int64Gauge
+	breakerMetrics        *breakerMetrics
+	controllerMetrics     *controllerMetrics
+	cleanupMetrics        *cleanupMetrics
+	deletingMetrics       *deletingMetrics
+	updateRequestMetrics  *updateRequestMetrics
+	policyRuleMetrics     *policyRuleMetrics
+	ttlInfoMetrics        *ttlInfoMetrics
+	policyEngineMetrics   *policyEngineMetrics
+	eventMetrics          *eventMetrics
+	admissionMetrics      *admissionMetrics
+	httpMetrics           *httpMetrics
+	vpolMetrics           *validatingMetrics
+	ivpolMetrics          *imageValidatingMetrics
+	mpolMetrics           *mutatingMetrics
+	gpolMetrics           *generatingMetrics
+	reportTrendMetrics    *reportTrendMetrics
+	policyLatencyMetrics  *policyLatencyMetrics
+	leaderElectionMetrics *leaderElectionMetrics
 
 	// config
 	config kconfig.MetricsConfiguration
@@ -79,6 +82,9 @@ type MetricsConfigManager interface {
 	IVPOLMetrics() ImageValidatingMetrics
 	MPOLMetrics() MutatingMetrics
 	GPOLMetrics() GeneratingMetrics
+	ReportTrendMetrics() ReportTrendMetrics
+	PolicyLatencyMetrics() PolicyLatencyMetrics
+	LeaderElectionMetrics() LeaderElectionMetrics
 }
 
 func (m *MetricsConfig) Config() kconfig.MetricsConfiguration {
@@ -145,6 +151,18 @@ func (m *MetricsConfig) GPOLMetrics() GeneratingMetrics {
 	return m.gpolMetrics
 }
 
+func (m *MetricsConfig) ReportTrendMetrics() ReportTrendMetrics {
+	return m.reportTrendMetrics
+}
+
+func (m *MetricsConfig) PolicyLatencyMetrics() PolicyLatencyMetrics {
+	return m.policyLatencyMetrics
+}
+
+func (m *MetricsConfig) LeaderElectionMetrics() LeaderElectionMetrics {
+	return m.leaderElectionMetrics
+}
+
 func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) error {
 	var err error
 	meter := meterProvider.Meter(MeterName)
@@ -185,6 +203,9 @@ func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) er
 	m.ivpolMetrics.init(meter)
 	m.mpolMetrics.init(meter)
 	m.gpolMetrics.init(meter)
+	m.reportTrendMetrics.init(meter)
+	m.policyLatencyMetrics.init(meter)
+	m.leaderElectionMetrics.init(meter)
 
 	initKyvernoInfoMetric(m)
 	return nil
@@ -331,23 +352,26 @@ func initKyvernoInfoMetric(m *MetricsConfig) {
 
 func NewMetricsConfigManager(logger logr.Logger, metricsConfiguration kconfig.MetricsConfiguration) *MetricsConfig {
 	config := &MetricsConfig{
-		Log:                  logger,
-		config:               metricsConfiguration,
-		breakerMetrics:       &breakerMetrics{logger: logger.WithName("circuit-breaker")},
-		controllerMetrics:    &controllerMetrics{logger: logger.WithName("controller")},
-		cleanupMetrics:       &cleanupMetrics{logger: logger.WithName("cleanup")},
-		deletingMetrics:      &deletingMetrics{logger: logger.WithName("deleting")},
-		updateRequestMetrics: &updateRequestMetrics{logger: logger.WithName("updaterequest")},
-		policyRuleMetrics:    &policyRuleMetrics{logger: logger.WithName("policy-rule")},
-		ttlInfoMetrics:       &ttlInfoMetrics{logger: logger.WithName("ttl-info")},
-		policyEngineMetrics:  &policyEngineMetrics{logger: logger.WithName("policy-engine")},
-		eventMetrics:         &eventMetrics{logger: logger.WithName("event")},
-		admissionMetrics:     &admissionMetrics{logger: logger.WithName("admission")},
-		httpMetrics:          &httpMetrics{logger: logger.WithName("http")},
-		vpolMetrics:          &validatingMetrics{logger: logger.WithName("validating-policy")},
-		ivpolMetrics:         &imageValidatingMetrics{logger: logger.WithName("image-validating-policy")},
-		mpolMetrics:          &mutatingMetrics{logger: logger.WithName("mutating-policy")},
-		gpolMetrics:          &generatingMetrics{logger: logger.WithName("generating-policy")},
+		Log:                   logger,
+		config:                metricsConfiguration,
+		breakerMetrics:        &breakerMetrics{logger: logger.WithName("circuit-breaker")},
+		controllerMetrics:     &controllerMetrics{logger: logger.WithName("controller")},
+		cleanupMetrics:        &cleanupMetrics{logger: logger.WithName("cleanup")},
+		deletingMetrics:       &deletingMetrics{logger: logger.WithName("deleting")},
+		updateRequestMetrics:  &updateRequestMetrics{logger: logger.WithName("updaterequest")},
+		policyRuleMetrics:     &policyRuleMetrics{logger: logger.WithName("policy-rule")},
+		ttlInfoMetrics:        &ttlInfoMetrics{logger: logger.WithName("ttl-info")},
+		policyEngineMetrics:   &policyEngineMetrics{logger: logger.WithName("policy-engine")},
+		eventMetrics:          &eventMetrics{logger: logger.WithName("event")},
+		admissionMetrics:      &admissionMetrics{logger: logger.WithName("admission")},
+		httpMetrics:           &httpMetrics{logger: logger.WithName("http")},
+		vpolMetrics:           &validatingMetrics{logger: logger.WithName("validating-policy")},
+		ivpolMetrics:          &imageValidatingMetrics{logger: logger.WithName("image-validating-policy")},
+		mpolMetrics:           &mutatingMetrics{logger: logger.WithName("mutating-policy")},
+		gpolMetrics:           &generatingMetrics{logger: logger.WithName("generating-policy")},
+		reportTrendMetrics:    &reportTrendMetrics{logger: logger.WithName("report-trend")},
+		policyLatencyMetrics:  &policyLatencyMetrics{logger: logger.WithName("policy-latency")},
+		leaderElectionMetrics: &leaderElectionMetrics{logger: logger.WithName("leader-election")},
 	}
 
 	return config
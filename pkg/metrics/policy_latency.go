@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetPolicyLatencyMetrics() PolicyLatencyMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.PolicyLatencyMetrics()
+}
+
+type PolicyLatencyMetrics interface {
+	RecordLatency(ctx context.Context, observer metric.Observer, policyKind, policyNamespace, policyName, ruleName, contextEntry, quantile string, seconds float64)
+	RecordSlow(ctx context.Context, observer metric.Observer, policyKind, policyNamespace, policyName, ruleName, contextEntry string, slow bool)
+	RegisterCallback(f metric.Callback) (metric.Registration, error)
+}
+
+type policyLatencyMetrics struct {
+	latencyMetric metric.Float64ObservableGauge
+	slowMetric    metric.Int64ObservableGauge
+	meter         metric.Meter
+	callback      metric.Callback
+
+	logger logr.Logger
+}
+
+func (m *policyLatencyMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.latencyMetric, err = meter.Float64ObservableGauge(
+		"kyverno_policy_latency_seconds",
+		metric.WithDescription("can be used to track the p95 and p99 latencies of policy rules and their context entries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_policy_latency_seconds")
+	}
+
+	m.slowMetric, err = meter.Int64ObservableGauge(
+		"kyverno_policy_slow",
+		metric.WithDescription("can be used to track policy rules and context entries exceeding the configured latency budget."),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_policy_slow")
+	}
+
+	m.meter = meter
+
+	if m.callback != nil {
+		if _, err := m.meter.RegisterCallback(m.callback, m.latencyMetric, m.slowMetric); err != nil {
+			m.logger.Error(err, "failed to register callback for policy latency metrics")
+		}
+	}
+}
+
+func (m *policyLatencyMetrics) RecordLatency(ctx context.Context, observer metric.Observer, policyKind, policyNamespace, policyName, ruleName, contextEntry, quantile string, seconds float64) {
+	if m.latencyMetric == nil {
+		return
+	}
+
+	observer.ObserveFloat64(m.latencyMetric, seconds, metric.WithAttributes(
+		append(latencyAttributes(policyKind, policyNamespace, policyName, ruleName, contextEntry), attribute.String("quantile", quantile))...,
+	))
+}
+
+func (m *policyLatencyMetrics) RecordSlow(ctx context.Context, observer metric.Observer, policyKind, policyNamespace, policyName, ruleName, contextEntry string, slow bool) {
+	if m.slowMetric == nil {
+		return
+	}
+
+	var value int64
+	if slow {
+		value = 1
+	}
+	observer.ObserveInt64(m.slowMetric, value, metric.WithAttributes(
+		latencyAttributes(policyKind, policyNamespace, policyName, ruleName, contextEntry)...,
+	))
+}
+
+func (m *policyLatencyMetrics) RegisterCallback(f metric.Callback) (metric.Registration, error) {
+	if m.meter == nil {
+		return nil, nil
+	}
+
+	m.callback = f
+	return m.meter.RegisterCallback(f, m.latencyMetric, m.slowMetric)
+}
+
+func latencyAttributes(policyKind, policyNamespace, policyName, ruleName, contextEntry string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("policy_type", policyKind),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("context_entry", contextEntry),
+	}
+}
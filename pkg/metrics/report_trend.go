@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func GetReportTrendMetrics() ReportTrendMetrics {
+	if metricsConfig == nil {
+		return nil
+	}
+
+	return metricsConfig.ReportTrendMetrics()
+}
+
+type ReportTrendMetrics interface {
+	RecordReportSummary(ctx context.Context, observer metric.Observer, namespace string, policy string, result string, count int64)
+	RegisterCallback(f metric.Callback) (metric.Registration, error)
+}
+
+type reportTrendMetrics struct {
+	resultsMetric metric.Int64ObservableGauge
+	meter         metric.Meter
+
+	// lock protects the callback and its registration, the callback is registered again when the meter changes
+	lock         sync.Mutex
+	callback     metric.Callback
+	registration *reportTrendRegistration
+
+	logger logr.Logger
+}
+
+// reportTrendRegistration forgets the callback when it is unregistered so that it is not registered again with a new meter.
+type reportTrendRegistration struct {
+	metric.Registration
+	metrics *reportTrendMetrics
+}
+
+func (r *reportTrendRegistration) Unregister() error {
+	r.metrics.lock.Lock()
+	defer r.metrics.lock.Unlock()
+	if r.metrics.registration == r {
+		r.metrics.callback = nil
+		r.metrics.registration = nil
+	}
+	return r.Registration.Unregister()
+}
+
+func (m *reportTrendMetrics) init(meter metric.Meter) {
+	var err error
+
+	m.resultsMetric, err = meter.Int64ObservableGauge(
+		"kyverno_policy_report_results",
+		metric.WithDescription("can be used to track the number of policy report results per namespace, policy and result over time."),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_policy_report_results")
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.meter = meter
+
+	if m.callback != nil {
+		registration, err := m.meter.RegisterCallback(m.callback, m.resultsMetric)
+		if err != nil {
+			m.logger.Error(err, "failed to register callback for policy report results metric")
+		} else if m.registration != nil {
+			m.registration.Registration = registration
+		}
+	}
+}
+
+func (m *reportTrendMetrics) RecordReportSummary(ctx context.Context, observer metric.Observer, namespace string, policy string, result string, count int64) {
+	if m.resultsMetric == nil {
+		return
+	}
+
+	observer.ObserveInt64(m.resultsMetric, count, metric.WithAttributes(
+		attribute.String("resource_namespace", namespace),
+		attribute.String("policy_name", policy),
+		attribute.String("policy_result", result),
+	))
+}
+
+// RegisterCallback registers the callback observing the report results, it replaces the previously registered callback.
+func (m *reportTrendMetrics) RegisterCallback(f metric.Callback) (metric.Registration, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.meter == nil {
+		return nil, nil
+	}
+
+	if m.registration != nil {
+		if err := m.registration.Registration.Unregister(); err != nil {
+			m.logger.Error(err, "failed to unregister callback for policy report results metric")
+		}
+		m.callback = nil
+		m.registration = nil
+	}
+	registration, err := m.meter.RegisterCallback(f, m.resultsMetric)
+	if err != nil {
+		return nil, err
+	}
+	m.callback = f
+	m.registration = &reportTrendRegistration{Registration: registration, metrics: m}
+	return m.registration, nil
+}
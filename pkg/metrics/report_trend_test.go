@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestReportTrendMetricsRegisterCallback(t *testing.T) {
+	collect := func(reader sdkmetric.Reader) int {
+		var data metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &data))
+		count := 0
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				if gauge, ok := m.Data.(metricdata.Gauge[int64]); ok {
+					count += len(gauge.DataPoints)
+				}
+			}
+		}
+		return count
+	}
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := &reportTrendMetrics{logger: logr.Discard()}
+	m.init(provider.Meter("kyverno"))
+	calls := 0
+	registration, err := m.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		calls++
+		m.RecordReportSummary(ctx, observer, "default", "require-labels", "fail", 3)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, registration)
+	assert.Equal(t, 1, collect(reader))
+	assert.Equal(t, 1, calls)
+
+	require.NoError(t, registration.Unregister())
+	assert.Equal(t, 0, collect(reader))
+	assert.Equal(t, 1, calls)
+	assert.Nil(t, m.callback)
+	assert.Nil(t, m.registration)
+
+	// an unregistered callback is not registered again with a new meter
+	m.init(provider.Meter("kyverno"))
+	assert.Equal(t, 0, collect(reader))
+	assert.Equal(t, 1, calls)
+}
+
+func TestReportTrendMetricsRegisterCallbackReplaces(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := &reportTrendMetrics{logger: logr.Discard()}
+	m.init(provider.Meter("kyverno"))
+	first, second := 0, 0
+	_, err := m.RegisterCallback(func(context.Context, metric.Observer) error {
+		first++
+		return nil
+	})
+	require.NoError(t, err)
+	registration, err := m.RegisterCallback(func(context.Context, metric.Observer) error {
+		second++
+		return nil
+	})
+	require.NoError(t, err)
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	assert.Equal(t, 0, first)
+	assert.Equal(t, 1, second)
+	require.NoError(t, registration.Unregister())
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/attribute"
@@ -18,13 +19,25 @@ func GetUpdateRequestMetrics() UpdateRequestMetrics {
 
 type UpdateRequestMetrics interface {
 	RecordTotal(ctx context.Context, namespace string, total int64, observer metric.Observer)
+	RecordQueueDepth(ctx context.Context, requestType string, depth int64, observer metric.Observer)
+	RecordReceived(ctx context.Context, requestType string)
+	RecordCoalesced(ctx context.Context, requestType string)
+	RecordCoalesceRatio(ctx context.Context, requestType string, ratio float64)
+	RecordCompacted(ctx context.Context, requestType string)
+	RecordAge(ctx context.Context, requestType string, age time.Duration)
 	RegisterCallback(f metric.Callback) (metric.Registration, error)
 }
 
 type updateRequestMetrics struct {
-	totalMetric metric.Int64ObservableGauge
-	meter       metric.Meter
-	callback    metric.Callback
+	totalMetric         metric.Int64ObservableGauge
+	queueDepthMetric    metric.Int64ObservableGauge
+	receivedMetric      metric.Int64Counter
+	coalescedMetric     metric.Int64Counter
+	coalesceRatioMetric metric.Float64Gauge
+	compactedMetric     metric.Int64Counter
+	ageMetric           metric.Float64Histogram
+	meter               metric.Meter
+	callback            metric.Callback
 
 	logger logr.Logger
 }
@@ -40,10 +53,59 @@ func (m *updateRequestMetrics) init(meter metric.Meter) {
 		m.logger.Error(err, "Failed to create instrument, kyverno_updaterequest_total")
 	}
 
+	m.queueDepthMetric, err = meter.Int64ObservableGauge(
+		"kyverno_updaterequest_queue_depth",
+		metric.WithDescription("can be used to track the number of updaterequests pending background processing"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_updaterequest_queue_depth")
+	}
+
+	m.receivedMetric, err = meter.Int64Counter(
+		"kyverno_updaterequest_received_total",
+		metric.WithDescription("can be used to track the number of updaterequests requested by admission reviews, before coalescing"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_updaterequest_received_total")
+	}
+
+	m.coalescedMetric, err = meter.Int64Counter(
+		"kyverno_updaterequest_coalesced_total",
+		metric.WithDescription("can be used to track the number of updaterequests superseded by a more recent one for the same policy and trigger within the batch window"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_updaterequest_coalesced_total")
+	}
+
+	m.coalesceRatioMetric, err = meter.Float64Gauge(
+		"kyverno_updaterequest_coalesce_ratio",
+		metric.WithDescription("can be used to track the ratio of updaterequests coalesced in the last batch window"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_updaterequest_coalesce_ratio")
+	}
+
+	m.compactedMetric, err = meter.Int64Counter(
+		"kyverno_updaterequest_compacted_total",
+		metric.WithDescription("can be used to track the number of pending updaterequests deleted without processing because a more recent one supersedes them"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_updaterequest_compacted_total")
+	}
+
+	m.ageMetric, err = meter.Float64Histogram(
+		"kyverno_updaterequest_age_seconds",
+		metric.WithDescription("can be used to track the time (in seconds) updaterequests wait before being processed"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		m.logger.Error(err, "Failed to create instrument, kyverno_updaterequest_age_seconds")
+	}
+
 	m.meter = meter
 
 	if m.callback != nil {
-		if _, err := m.meter.RegisterCallback(m.callback, m.totalMetric); err != nil {
+		if _, err := m.meter.RegisterCallback(m.callback, m.totalMetric, m.queueDepthMetric); err != nil {
 			m.logger.Error(err, "failed to register callback for update request total metric")
 		}
 	}
@@ -59,11 +121,61 @@ func (m *updateRequestMetrics) RecordTotal(ctx context.Context, namespace string
 	))
 }
 
+func (m *updateRequestMetrics) RecordQueueDepth(ctx context.Context, requestType string, depth int64, observer metric.Observer) {
+	if m.queueDepthMetric == nil {
+		return
+	}
+
+	observer.ObserveInt64(m.queueDepthMetric, depth, metric.WithAttributes(
+		attribute.String("request_type", requestType),
+	))
+}
+
+func (m *updateRequestMetrics) RecordReceived(ctx context.Context, requestType string) {
+	if m.receivedMetric == nil {
+		return
+	}
+
+	m.receivedMetric.Add(ctx, 1, metric.WithAttributes(attribute.String("request_type", requestType)))
+}
+
+func (m *updateRequestMetrics) RecordCoalesced(ctx context.Context, requestType string) {
+	if m.coalescedMetric == nil {
+		return
+	}
+
+	m.coalescedMetric.Add(ctx, 1, metric.WithAttributes(attribute.String("request_type", requestType)))
+}
+
+func (m *updateRequestMetrics) RecordCoalesceRatio(ctx context.Context, requestType string, ratio float64) {
+	if m.coalesceRatioMetric == nil {
+		return
+	}
+
+	m.coalesceRatioMetric.Record(ctx, ratio, metric.WithAttributes(attribute.String("request_type", requestType)))
+}
+
+func (m *updateRequestMetrics) RecordCompacted(ctx context.Context, requestType string) {
+	if m.compactedMetric == nil {
+		return
+	}
+
+	m.compactedMetric.Add(ctx, 1, metric.WithAttributes(attribute.String("request_type", requestType)))
+}
+
+func (m *updateRequestMetrics) RecordAge(ctx context.Context, requestType string, age time.Duration) {
+	if m.ageMetric == nil {
+		return
+	}
+
+	m.ageMetric.Record(ctx, age.Seconds(), metric.WithAttributes(attribute.String("request_type", requestType)))
+}
+
 func (m *updateRequestMetrics) RegisterCallback(f metric.Callback) (metric.Registration, error) {
 	if m.meter == nil {
 		return nil, nil
 	}
 
 	m.callback = f
-	return m.meter.RegisterCallback(f, m.totalMetric)
+	return m.meter.RegisterCallback(f, m.totalMetric, m.queueDepthMetric)
 }
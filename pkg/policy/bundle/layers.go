@@ -0,0 +1,45 @@
+package bundle
+
+import (
+	"fmt"
+	"io"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// PolicyLayers returns the content of the image layers holding policies.
+func PolicyLayers(img v1.Image) ([][]byte, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("getting image layers: %w", err)
+	}
+	var contents [][]byte
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer media type: %w", err)
+		}
+		if mediaType != PolicyLayerMediaType {
+			continue
+		}
+		content, err := readLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	blob, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("getting layer blob: %w", err)
+	}
+	defer blob.Close()
+	content, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, fmt.Errorf("reading layer blob: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,31 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyLayers(t *testing.T) {
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img, err := mutate.AppendLayers(img,
+		static.NewLayer([]byte("a"), PolicyLayerMediaType),
+		static.NewLayer([]byte("other"), types.OCILayer),
+		static.NewLayer([]byte("b"), PolicyLayerMediaType),
+	)
+	require.NoError(t, err)
+	layers, err := PolicyLayers(img)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, layers)
+}
+
+func TestPolicyLayersEmpty(t *testing.T) {
+	layers, err := PolicyLayers(empty.Image)
+	require.NoError(t, err)
+	assert.Empty(t, layers)
+}
@@ -0,0 +1,69 @@
+package bundle
+
+import (
+	"fmt"
+	"slices"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// ManifestPredicateType is the in-toto predicate type of the policy manifest attestation.
+	ManifestPredicateType = "https://kyverno.io/attestations/policy-manifest/v1"
+	// PolicyLayerMediaType is the media type of the image layers holding policies.
+	PolicyLayerMediaType = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
+)
+
+// ManifestEntry describes a policy stored in a policy bundle.
+type ManifestEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Digest is the digest of the image layer holding the policy.
+	Digest string `json:"digest"`
+}
+
+// Manifest lists the policies stored in a policy bundle, it is attached to the bundle image as a signed attestation.
+type Manifest struct {
+	Policies []ManifestEntry `json:"policies"`
+}
+
+// Add records a policy and the digest of the layer holding it.
+func (m *Manifest) Add(apiVersion, kind, name string, digest v1.Hash) {
+	m.Policies = append(m.Policies, ManifestEntry{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+		Digest:     digest.String(),
+	})
+}
+
+// Verify checks the layers of the given media type in the image match the manifest entries,
+// no policy layer can be added to or removed from a bundle without invalidating the manifest.
+func (m *Manifest) Verify(img v1.Image, mediaType types.MediaType) error {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return fmt.Errorf("getting image manifest: %w", err)
+	}
+	var expected []string
+	for _, entry := range m.Policies {
+		expected = append(expected, entry.Digest)
+	}
+	var actual []string
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaType {
+			continue
+		}
+		if !slices.Contains(expected, layer.Digest.String()) {
+			return fmt.Errorf("policy layer %s is not listed in the signed manifest", layer.Digest)
+		}
+		actual = append(actual, layer.Digest.String())
+	}
+	for _, entry := range m.Policies {
+		if !slices.Contains(actual, entry.Digest) {
+			return fmt.Errorf("policy %s (%s) listed in the signed manifest is missing from the image", entry.Name, entry.Digest)
+		}
+	}
+	return nil
+}
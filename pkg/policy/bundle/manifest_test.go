@@ -0,0 +1,55 @@
+package bundle
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMediaType types.MediaType = "application/vnd.test.policy+yaml"
+
+func newTestImage(t *testing.T, policies ...string) (v1.Image, Manifest) {
+	t.Helper()
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	var manifest Manifest
+	for _, policy := range policies {
+		layer := static.NewLayer([]byte(policy), testMediaType)
+		var err error
+		img, err = mutate.AppendLayers(img, layer)
+		require.NoError(t, err)
+		digest, err := layer.Digest()
+		require.NoError(t, err)
+		manifest.Add("kyverno.io/v1", "ClusterPolicy", policy, digest)
+	}
+	return img, manifest
+}
+
+func TestManifestVerify(t *testing.T) {
+	t.Run("matching", func(t *testing.T) {
+		img, manifest := newTestImage(t, "a", "b")
+		assert.NoError(t, manifest.Verify(img, testMediaType))
+	})
+	t.Run("ignores other layers", func(t *testing.T) {
+		img, manifest := newTestImage(t, "a")
+		img, err := mutate.AppendLayers(img, static.NewLayer([]byte("other"), types.OCILayer))
+		require.NoError(t, err)
+		assert.NoError(t, manifest.Verify(img, testMediaType))
+	})
+	t.Run("added layer", func(t *testing.T) {
+		img, manifest := newTestImage(t, "a")
+		img, err := mutate.AppendLayers(img, static.NewLayer([]byte("b"), testMediaType))
+		require.NoError(t, err)
+		assert.ErrorContains(t, manifest.Verify(img, testMediaType), "is not listed in the signed manifest")
+	})
+	t.Run("removed layer", func(t *testing.T) {
+		img, _ := newTestImage(t, "a")
+		_, manifest := newTestImage(t, "a", "b")
+		assert.ErrorContains(t, manifest.Verify(img, testMediaType), "is missing from the image")
+	})
+}
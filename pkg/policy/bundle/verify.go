@@ -0,0 +1,127 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/pkg/image/verifiers/ivpol/cosign"
+	"github.com/kyverno/sdk/extensions/imagedataloader"
+)
+
+const manifestAttestation = "policy-manifest"
+
+// VerifyOptions configure how the signature of a policy bundle is verified,
+// either Key or both CertificateIdentity and CertificateOIDCIssuer must be set.
+type VerifyOptions struct {
+	// Key is the path to a PEM encoded public key or a KMS URI.
+	Key string
+	// CertificateIdentity is the identity expected in keyless signing certificates.
+	CertificateIdentity string
+	// CertificateOIDCIssuer is the OIDC issuer expected in keyless signing certificates.
+	CertificateOIDCIssuer string
+}
+
+// Enabled returns true when a key or a keyless identity is configured.
+func (o VerifyOptions) Enabled() bool {
+	return o.Key != "" || o.CertificateIdentity != "" || o.CertificateOIDCIssuer != ""
+}
+
+// Validate checks the options are complete.
+func (o VerifyOptions) Validate() error {
+	if o.Key != "" && (o.CertificateIdentity != "" || o.CertificateOIDCIssuer != "") {
+		return errors.New("key and certificate identity are mutually exclusive")
+	}
+	if o.Key == "" && (o.CertificateIdentity == "" || o.CertificateOIDCIssuer == "") {
+		return errors.New("certificate identity and certificate OIDC issuer are both required for keyless verification")
+	}
+	return nil
+}
+
+// Attestor converts the options to a cosign attestor.
+func (o VerifyOptions) Attestor() (*policiesv1beta1.Attestor, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	attestor := &policiesv1beta1.Attestor{
+		Name:   "policy-bundle",
+		Cosign: &policiesv1beta1.Cosign{},
+	}
+	if o.Key == "" {
+		attestor.Cosign.Keyless = &policiesv1beta1.Keyless{
+			Identities: []policiesv1beta1.Identity{{
+				Subject: o.CertificateIdentity,
+				Issuer:  o.CertificateOIDCIssuer,
+			}},
+		}
+		return attestor, nil
+	}
+	if strings.Contains(o.Key, "://") {
+		attestor.Cosign.Key = &policiesv1beta1.Key{KMS: o.Key}
+		return attestor, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(o.Key))
+	if err != nil {
+		return nil, fmt.Errorf("reading public key %s: %w", o.Key, err)
+	}
+	attestor.Cosign.Key = &policiesv1beta1.Key{Data: string(data)}
+	return attestor, nil
+}
+
+// Verify verifies the signature of a policy bundle image and of its manifest attestation, and returns the manifest.
+// The image should be referenced by digest so that the verified image is the one being pulled.
+func Verify(ctx context.Context, image string, attestor *policiesv1beta1.Attestor, remoteOpts ...remote.Option) (*Manifest, error) {
+	loader, err := imagedataloader.New(nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	data, err := loader.FetchImageData(ctx, image, remoteOpts, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching image data: %w", err)
+	}
+	verifier := cosign.NewVerifier(nil, logr.Discard())
+	if err := verifier.VerifyImageSignature(ctx, data, attestor); err != nil {
+		return nil, fmt.Errorf("verifying image signature: %w", err)
+	}
+	attestation := policiesv1beta1.Attestation{
+		Name:   manifestAttestation,
+		InToto: &policiesv1beta1.InToto{Type: ManifestPredicateType},
+	}
+	if err := verifier.VerifyAttestationSignature(ctx, data, &attestation, attestor); err != nil {
+		return nil, fmt.Errorf("verifying policy manifest attestation: %w", err)
+	}
+	payload, err := data.GetPayload(attestation)
+	if err != nil {
+		return nil, fmt.Errorf("getting policy manifest: %w", err)
+	}
+	return manifestFromStatement(payload)
+}
+
+// manifestFromStatement decodes the manifest from an in-toto statement, or from the bare predicate.
+func manifestFromStatement(payload any) (*Manifest, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var statement struct {
+		Predicate *Manifest `json:"predicate"`
+	}
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return nil, fmt.Errorf("decoding policy manifest: %w", err)
+	}
+	if statement.Predicate != nil {
+		return statement.Predicate, nil
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding policy manifest: %w", err)
+	}
+	return &manifest, nil
+}
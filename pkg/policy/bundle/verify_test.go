@@ -0,0 +1,92 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options VerifyOptions
+		wantErr bool
+	}{{
+		name:    "key",
+		options: VerifyOptions{Key: "cosign.pub"},
+	}, {
+		name:    "keyless",
+		options: VerifyOptions{CertificateIdentity: "me@example.com", CertificateOIDCIssuer: "https://accounts.google.com"},
+	}, {
+		name:    "key and identity",
+		options: VerifyOptions{Key: "cosign.pub", CertificateIdentity: "me@example.com"},
+		wantErr: true,
+	}, {
+		name:    "identity without issuer",
+		options: VerifyOptions{CertificateIdentity: "me@example.com"},
+		wantErr: true,
+	}, {
+		name:    "empty",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyOptionsAttestor(t *testing.T) {
+	t.Run("key file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cosign.pub")
+		require.NoError(t, os.WriteFile(path, []byte("public key"), 0o600))
+		attestor, err := VerifyOptions{Key: path}.Attestor()
+		require.NoError(t, err)
+		require.NotNil(t, attestor.Cosign.Key)
+		assert.Equal(t, "public key", attestor.Cosign.Key.Data)
+	})
+	t.Run("kms", func(t *testing.T) {
+		attestor, err := VerifyOptions{Key: "awskms:///alias/policies"}.Attestor()
+		require.NoError(t, err)
+		require.NotNil(t, attestor.Cosign.Key)
+		assert.Equal(t, "awskms:///alias/policies", attestor.Cosign.Key.KMS)
+	})
+	t.Run("missing key file", func(t *testing.T) {
+		_, err := VerifyOptions{Key: filepath.Join(t.TempDir(), "missing.pub")}.Attestor()
+		assert.Error(t, err)
+	})
+	t.Run("keyless", func(t *testing.T) {
+		attestor, err := VerifyOptions{CertificateIdentity: "me@example.com", CertificateOIDCIssuer: "https://accounts.google.com"}.Attestor()
+		require.NoError(t, err)
+		require.NotNil(t, attestor.Cosign.Keyless)
+		require.Len(t, attestor.Cosign.Keyless.Identities, 1)
+		assert.Equal(t, "me@example.com", attestor.Cosign.Keyless.Identities[0].Subject)
+		assert.Equal(t, "https://accounts.google.com", attestor.Cosign.Keyless.Identities[0].Issuer)
+	})
+}
+
+func TestManifestFromStatement(t *testing.T) {
+	entry := map[string]any{"apiVersion": "kyverno.io/v1", "kind": "ClusterPolicy", "name": "require-labels", "digest": "sha256:abc"}
+	want := &Manifest{Policies: []ManifestEntry{{APIVersion: "kyverno.io/v1", Kind: "ClusterPolicy", Name: "require-labels", Digest: "sha256:abc"}}}
+	t.Run("statement", func(t *testing.T) {
+		got, err := manifestFromStatement(map[string]any{
+			"predicateType": ManifestPredicateType,
+			"predicate":     map[string]any{"policies": []any{entry}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+	t.Run("predicate", func(t *testing.T) {
+		got, err := manifestFromStatement(map[string]any{"policies": []any{entry}})
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+}
@@ -216,6 +216,11 @@ func (pc *policyController) canBackgroundProcess(p kyvernov1.PolicyInterface) bo
 		return false
 	}
 
+	// scheduled rules must be synced to be added to the queue at their next execution time
+	if p.GetSpec().HasSchedule() {
+		return true
+	}
+
 	if p.GetSpec().HasMutateExisting() {
 		val := os.Getenv("BACKGROUND_SCAN_INTERVAL")
 		interval, err := time.ParseDuration(val)
@@ -511,6 +516,10 @@ func (pc *policyController) syncPolicy(key string) error {
 		logger.V(4).Info("finished syncing policy", "key", key, "processingTime", time.Since(startTime).String())
 	}()
 
+	if strings.HasPrefix(key, scheduledKeyPrefix) {
+		return pc.syncScheduledPolicy(key)
+	}
+
 	parts := strings.SplitN(key, "/", 2)
 	polType := parts[0]
 	polName := parts[1]
@@ -524,6 +533,7 @@ func (pc *policyController) syncPolicy(key string) error {
 			}
 			return err
 		} else {
+			pc.schedulePolicy(polType, polName, engineapi.NewKyvernoPolicy(policy))
 			if err := pc.handleMutate(polName, policy); err != nil {
 				logger.Error(err, "failed to updateUR on mutate policy update")
 				errs = append(errs, err)
@@ -542,6 +552,7 @@ func (pc *policyController) syncPolicy(key string) error {
 			}
 			return err
 		}
+		pc.schedulePolicy(polType, polName, engineapi.NewGeneratingPolicy(gpol))
 		// create UR on policy events to update/generate downstream resources
 		if gpol.Spec.SynchronizationEnabled() {
 			logger.V(4).Info("creating UR on generating policy events", "name", gpol.GetName())
@@ -571,6 +582,7 @@ func (pc *policyController) syncPolicy(key string) error {
 			}
 			return err
 		}
+		pc.schedulePolicy(polType, polName, engineapi.NewNamespacedGeneratingPolicy(ngpol))
 		policyKey := ngpol.GetNamespace() + "/" + ngpol.GetName()
 		// create UR on policy events to update/generate downstream resources
 		if ngpol.Spec.SynchronizationEnabled() {
@@ -596,6 +608,7 @@ func (pc *policyController) syncPolicy(key string) error {
 			}
 			return err
 		}
+		pc.schedulePolicy(polType, polName, engineapi.NewMutatingPolicy(mpol))
 		// .background only controls background-scan reporting; it never gates
 		// mutate-existing execution (https://github.com/kyverno/kyverno/issues/16090).
 		if mpol.Spec.MutateExistingEnabled() {
@@ -617,6 +630,7 @@ func (pc *policyController) syncPolicy(key string) error {
 			}
 			return err
 		}
+		pc.schedulePolicy(polType, polName, engineapi.NewNamespacedMutatingPolicy(nmpol))
 		if nmpol.Spec.MutateExistingEnabled() {
 			policyKey := nmpol.GetNamespace() + "/" + nmpol.GetName()
 			logger.V(4).Info("creating UR for namespaced mutating policy on policy event or background scan", "name", policyKey)
@@ -0,0 +1,201 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aptible/supercronic/cronexpr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+// scheduledKeyPrefix marks the workqueue keys of scheduled executions,
+// the full key is "scheduled/<policy type>/<policy key>#<rule name>@<schedule token>".
+const scheduledKeyPrefix = "scheduled/"
+
+func scheduledKey(polType, policyKey, rule, schedule string) string {
+	return scheduledKeyPrefix + polType + "/" + policyKey + "#" + rule + "@" + scheduleToken(schedule)
+}
+
+func parseScheduledKey(key string) (polType, policyKey, rule, token string, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, scheduledKeyPrefix), "/", 2)
+	if len(parts) != 2 {
+		return "", "", "", "", fmt.Errorf("invalid scheduled key %s", key)
+	}
+	tokenIndex := strings.LastIndex(parts[1], "@")
+	if tokenIndex < 0 {
+		return "", "", "", "", fmt.Errorf("invalid scheduled key %s", key)
+	}
+	token = parts[1][tokenIndex+1:]
+	index := strings.LastIndex(parts[1][:tokenIndex], "#")
+	if index < 0 {
+		return "", "", "", "", fmt.Errorf("invalid scheduled key %s", key)
+	}
+	return parts[0], parts[1][:index], parts[1][index+1 : tokenIndex], token, nil
+}
+
+// scheduleToken identifies a schedule in the queue keys, executions queued for a previous schedule are dropped
+func scheduleToken(schedule string) string {
+	h := sha256.Sum256([]byte(schedule))
+	return hex.EncodeToString(h[:])[:8]
+}
+
+// policySchedules returns the cron schedules of a policy indexed by rule name.
+// Kyverno policies are scheduled per mutateExisting or generate rule, CEL policies are scheduled
+// as a whole using the policies.kyverno.io/schedule annotation.
+func policySchedules(policy engineapi.GenericPolicy) map[string]string {
+	schedules := map[string]string{}
+	if kpol := policy.AsKyvernoPolicy(); kpol != nil {
+		for _, rule := range kpol.GetSpec().Rules {
+			if schedule := rule.GetSchedule(); schedule != "" {
+				schedules[rule.Name] = schedule
+			}
+		}
+		return schedules
+	}
+	if schedule := policy.GetAnnotations()[kyverno.AnnotationPolicySchedule]; schedule != "" {
+		schedules[""] = schedule
+	}
+	return schedules
+}
+
+// nextExecutionTime returns the next execution time of a schedule, it uses the same cron parser as cleanup policies
+func nextExecutionTime(schedule string, after time.Time) (time.Time, error) {
+	cronExpr, err := cronexpr.Parse(schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cronExpr.Next(after), nil
+}
+
+// schedulePolicy adds the next scheduled executions of the policy to the queue.
+// The workqueue deduplicates keys waiting to be added, rescheduling an already scheduled rule is a no-op.
+// Keys include a token of the schedule, executions queued before the schedule changed are dropped when they fire.
+func (pc *policyController) schedulePolicy(polType, policyKey string, policy engineapi.GenericPolicy) {
+	now := time.Now()
+	for rule, schedule := range policySchedules(policy) {
+		next, err := nextExecutionTime(schedule, now)
+		if err != nil {
+			pc.log.Error(err, "failed to compute the next execution time", "policy", policyKey, "rule", rule, "schedule", schedule)
+			continue
+		}
+		if next.IsZero() {
+			continue
+		}
+		pc.log.V(4).Info("scheduling background execution", "policy", policyKey, "rule", rule, "next", next)
+		pc.queue.AddAfter(scheduledKey(polType, policyKey, rule, schedule), next.Sub(now))
+	}
+}
+
+// syncScheduledPolicy applies a scheduled rule to all existing resources, regardless of the
+// generateExisting and mutateExistingOnPolicyUpdate settings, then schedules the next execution.
+// The next execution is scheduled even if this one fails.
+func (pc *policyController) syncScheduledPolicy(key string) error {
+	polType, policyKey, ruleName, token, err := parseScheduledKey(key)
+	if err != nil {
+		return err
+	}
+	logger := pc.log.WithName("syncScheduledPolicy").WithValues("policy", policyKey, "rule", ruleName)
+	var policy engineapi.GenericPolicy
+	var run func() error
+	switch polType {
+	case "kpol":
+		kpol, err := pc.getPolicy(policyKey)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		policy = engineapi.NewKyvernoPolicy(kpol)
+		run = func() error {
+			for _, rule := range kpol.GetSpec().Rules {
+				if rule.Name != ruleName {
+					continue
+				}
+				scheduled := kpol.CreateDeepCopy()
+				rule := *rule.DeepCopy()
+				if rule.HasMutateExisting() {
+					rule.Mutation.MutateExistingOnPolicyUpdate = ptr.To(true)
+					scheduled.GetSpec().SetRules([]kyvernov1.Rule{rule})
+					if err := pc.handleMutate(policyKey, scheduled); err != nil {
+						return err
+					}
+				} else if rule.HasGenerate() {
+					rule.Generation.GenerateExisting = ptr.To(true)
+					scheduled.GetSpec().SetRules([]kyvernov1.Rule{rule})
+					if err := pc.handleGenerateForExisting(scheduled); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+	case "gpol":
+		gpol, err := pc.gpolLister.Get(policyKey)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		policy = engineapi.NewGeneratingPolicy(gpol)
+		run = func() error { return pc.handleGenerateExisting(gpol) }
+	case "ngpol":
+		ns, name, err := cache.SplitMetaNamespaceKey(policyKey)
+		if err != nil {
+			return err
+		}
+		ngpol, err := pc.ngpolLister.NamespacedGeneratingPolicies(ns).Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		policy = engineapi.NewNamespacedGeneratingPolicy(ngpol)
+		run = func() error { return pc.handleNamespacedGenerateExisting(ngpol) }
+	case "mpol":
+		mpol, err := pc.mpolLister.Get(policyKey)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		policy = engineapi.NewMutatingPolicy(mpol)
+		run = func() error { return pc.createURForMutatingPolicy(mpol) }
+	case "nmpol":
+		ns, name, err := cache.SplitMetaNamespaceKey(policyKey)
+		if err != nil {
+			return err
+		}
+		nmpol, err := pc.nmpolLister.NamespacedMutatingPolicies(ns).Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		policy = engineapi.NewNamespacedMutatingPolicy(nmpol)
+		run = func() error { return pc.createURForNamespacedMutatingPolicy(nmpol) }
+	default:
+		return fmt.Errorf("unknown policy type %s", polType)
+	}
+	schedule, ok := policySchedules(policy)[ruleName]
+	if !ok || scheduleToken(schedule) != token {
+		// the rule is not scheduled anymore or its schedule changed, the new schedule has its own key
+		logger.V(4).Info("dropping stale scheduled execution")
+		return nil
+	}
+	defer pc.schedulePolicy(polType, policyKey, policy)
+	logger.V(2).Info("running scheduled background execution")
+	return run()
+}
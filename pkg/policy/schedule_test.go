@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	policiesv1beta1 "github.com/kyverno/api/api/policies.kyverno.io/v1beta1"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScheduledKey(t *testing.T) {
+	tests := []struct {
+		polType   string
+		policyKey string
+		rule      string
+	}{
+		{polType: "kpol", policyKey: "add-labels", rule: "label-namespaces"},
+		{polType: "kpol", policyKey: "default/add-labels", rule: "label-pods"},
+		{polType: "ngpol", policyKey: "default/netpol", rule: ""},
+	}
+	for _, tt := range tests {
+		key := scheduledKey(tt.polType, tt.policyKey, tt.rule, "@daily")
+		polType, policyKey, rule, token, err := parseScheduledKey(key)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.polType, polType)
+		assert.Equal(t, tt.policyKey, policyKey)
+		assert.Equal(t, tt.rule, rule)
+		assert.Equal(t, scheduleToken("@daily"), token)
+	}
+	assert.NotEqual(t, scheduledKey("kpol", "add-labels", "label-pods", "@daily"), scheduledKey("kpol", "add-labels", "label-pods", "@weekly"), "keys differ when the schedule changes")
+	_, _, _, _, err := parseScheduledKey(scheduledKeyPrefix + "kpol")
+	assert.Error(t, err)
+	_, _, _, _, err = parseScheduledKey(scheduledKeyPrefix + "kpol/add-labels#label-pods")
+	assert.Error(t, err)
+}
+
+func TestPolicySchedules(t *testing.T) {
+	kpol := &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name:       "generate",
+				Generation: &kyvernov1.Generation{Schedule: "@weekly", GeneratePattern: kyvernov1.GeneratePattern{ResourceSpec: kyvernov1.ResourceSpec{Kind: "NetworkPolicy"}}},
+			}, {
+				Name:     "mutate",
+				Mutation: &kyvernov1.Mutation{Schedule: "@daily", Targets: []kyvernov1.TargetResourceSpec{}},
+			}, {
+				Name:       "not-scheduled",
+				Generation: &kyvernov1.Generation{GeneratePattern: kyvernov1.GeneratePattern{ResourceSpec: kyvernov1.ResourceSpec{Kind: "ConfigMap"}}},
+			}},
+		},
+	}
+	assert.Equal(t, map[string]string{"generate": "@weekly", "mutate": "@daily"}, policySchedules(engineapi.NewKyvernoPolicy(kpol)))
+
+	gpol := &policiesv1beta1.GeneratingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{kyverno.AnnotationPolicySchedule: "0 0 * * 0"},
+		},
+	}
+	assert.Equal(t, map[string]string{"": "0 0 * * 0"}, policySchedules(engineapi.NewGeneratingPolicy(gpol)))
+	assert.Empty(t, policySchedules(engineapi.NewMutatingPolicy(&policiesv1beta1.MutatingPolicy{})))
+}
+
+func TestNextExecutionTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	next, err := nextExecutionTime("0 0 * * *", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), next)
+	_, err = nextExecutionTime("every day", now)
+	assert.Error(t, err)
+}
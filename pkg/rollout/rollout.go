@@ -0,0 +1,122 @@
+package rollout
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// FromAnnotations returns the rollout declared by a CEL policy with the policies.kyverno.io/rollout annotation,
+// the annotation holds the rollout in JSON. It returns nil when the policy doesn't declare a rollout.
+func FromAnnotations(annotations map[string]string) (*kyvernov1.Rollout, error) {
+	value := annotations[kyverno.AnnotationPolicyRollout]
+	if value == "" {
+		return nil, nil
+	}
+	var rollout kyvernov1.Rollout
+	if err := json.Unmarshal([]byte(value), &rollout); err != nil {
+		return nil, fmt.Errorf("failed to decode rollout: %w", err)
+	}
+	return &rollout, nil
+}
+
+// ValidateAnnotations validates the rollout declared by a CEL policy, if any.
+func ValidateAnnotations(annotations map[string]string) field.ErrorList {
+	path := field.NewPath("metadata").Child("annotations").Key(kyverno.AnnotationPolicyRollout)
+	rollout, err := FromAnnotations(annotations)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, annotations[kyverno.AnnotationPolicyRollout], err.Error())}
+	}
+	if rollout == nil {
+		return nil
+	}
+	return rollout.Validate(path)
+}
+
+// Started returns the stages that have started at the given time.
+// A stage starts once its start time is reached and all the previous stages have started.
+func Started(rollout *kyvernov1.Rollout, now time.Time) []kyvernov1.RolloutStage {
+	if rollout == nil {
+		return nil
+	}
+	for i, stage := range rollout.Stages {
+		if stage.StartTime != nil && now.Before(stage.StartTime.Time) {
+			return rollout.Stages[:i]
+		}
+	}
+	return rollout.Stages
+}
+
+// Enforced returns true if the policy is enforced in the namespace at the given time.
+// Policies without a rollout are always enforced. Cluster-wide resources (empty namespace) are only
+// enforced once a stage without namespace selector covering all namespaces has started.
+func Enforced(rollout *kyvernov1.Rollout, namespace string, namespaceLabels map[string]string, now time.Time) bool {
+	if rollout == nil {
+		return true
+	}
+	for _, stage := range Started(rollout, now) {
+		if selects(stage, namespace, namespaceLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// Status returns the progression of the rollout at the given time.
+func Status(rollout *kyvernov1.Rollout, now time.Time) *kyvernov1.RolloutStatus {
+	if rollout == nil {
+		return nil
+	}
+	started := Started(rollout, now)
+	status := &kyvernov1.RolloutStatus{
+		StartedStages: len(started),
+		TotalStages:   len(rollout.Stages),
+		Completed:     len(started) == len(rollout.Stages),
+	}
+	if len(started) > 0 {
+		status.CurrentStage = started[len(started)-1].Name
+	}
+	if !status.Completed {
+		if next := rollout.Stages[len(started)].StartTime; next != nil {
+			status.NextStageTime = next.DeepCopy()
+		}
+	}
+	return status
+}
+
+// NextTransition returns the duration until the next stage starts, zero when there is no stage left to start.
+func NextTransition(rollout *kyvernov1.Rollout, now time.Time) time.Duration {
+	status := Status(rollout, now)
+	if status == nil || status.NextStageTime == nil {
+		return 0
+	}
+	return status.NextStageTime.Sub(now)
+}
+
+func selects(stage kyvernov1.RolloutStage, namespace string, namespaceLabels map[string]string) bool {
+	if namespace == "" {
+		return stage.NamespaceSelector == nil && stage.GetPercentage() >= 100
+	}
+	if stage.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(stage.NamespaceSelector)
+		if err != nil || !selector.Matches(labels.Set(namespaceLabels)) {
+			return false
+		}
+	}
+	return bucket(namespace) < stage.GetPercentage()
+}
+
+// bucket returns a stable value between 0 and 99 for a namespace, a namespace selected by a percentage
+// stays selected when the percentage is increased.
+func bucket(namespace string) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace))
+	return int32(h.Sum32() % 100) //nolint:gosec
+}
@@ -0,0 +1,94 @@
+package rollout
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newRollout(now time.Time) *kyvernov1.Rollout {
+	return &kyvernov1.Rollout{Stages: []kyvernov1.RolloutStage{{
+		Name:              "canary",
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+	}, {
+		Name:      "all",
+		StartTime: ptr.To(metav1.NewTime(now.Add(time.Hour))),
+	}}}
+}
+
+func TestEnforced(t *testing.T) {
+	now := time.Now()
+	rollout := newRollout(now)
+	canary := map[string]string{"canary": "true"}
+
+	assert.True(t, Enforced(nil, "default", nil, now), "policies without rollout are enforced")
+	assert.True(t, Enforced(rollout, "team-a", canary, now))
+	assert.False(t, Enforced(rollout, "team-b", nil, now))
+	assert.False(t, Enforced(rollout, "", nil, now), "cluster-wide resources wait for the last stage")
+
+	later := now.Add(2 * time.Hour)
+	assert.True(t, Enforced(rollout, "team-a", canary, later))
+	assert.True(t, Enforced(rollout, "team-b", nil, later))
+	assert.True(t, Enforced(rollout, "", nil, later))
+}
+
+func TestEnforced_Percentage(t *testing.T) {
+	now := time.Now()
+	half := &kyvernov1.Rollout{Stages: []kyvernov1.RolloutStage{{Name: "half", Percentage: ptr.To[int32](50)}}}
+	most := &kyvernov1.Rollout{Stages: []kyvernov1.RolloutStage{{Name: "most", Percentage: ptr.To[int32](90)}}}
+	var enforced int
+	for i := range 1000 {
+		namespace := fmt.Sprintf("namespace-%d", i)
+		if Enforced(half, namespace, nil, now) {
+			enforced++
+			assert.True(t, Enforced(most, namespace, nil, now), "namespaces stay enforced when the percentage grows")
+		}
+	}
+	assert.InDelta(t, 500, enforced, 100)
+	assert.False(t, Enforced(half, "", nil, now))
+}
+
+func TestStatus(t *testing.T) {
+	now := time.Now()
+	rollout := newRollout(now)
+
+	status := Status(rollout, now)
+	assert.Equal(t, "canary", status.CurrentStage)
+	assert.Equal(t, 1, status.StartedStages)
+	assert.Equal(t, 2, status.TotalStages)
+	assert.False(t, status.Completed)
+	assert.Equal(t, rollout.Stages[1].StartTime, status.NextStageTime)
+	assert.Equal(t, time.Hour, NextTransition(rollout, now))
+
+	status = Status(rollout, now.Add(time.Hour))
+	assert.Equal(t, "all", status.CurrentStage)
+	assert.True(t, status.Completed)
+	assert.Nil(t, status.NextStageTime)
+	assert.Zero(t, NextTransition(rollout, now.Add(time.Hour)))
+
+	assert.Nil(t, Status(nil, now))
+}
+
+func TestFromAnnotations(t *testing.T) {
+	rollout, err := FromAnnotations(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rollout)
+
+	rollout, err = FromAnnotations(map[string]string{
+		kyverno.AnnotationPolicyRollout: `{"stages":[{"name":"canary","namespaceSelector":{"matchLabels":{"canary":"true"}}},{"name":"all","startTime":"2030-01-01T00:00:00Z"}]}`,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rollout.Stages, 2)
+	assert.Equal(t, "canary", rollout.Stages[0].Name)
+
+	_, err = FromAnnotations(map[string]string{kyverno.AnnotationPolicyRollout: "stages"})
+	assert.Error(t, err)
+	assert.Len(t, ValidateAnnotations(map[string]string{kyverno.AnnotationPolicyRollout: "stages"}), 1)
+	assert.Len(t, ValidateAnnotations(map[string]string{kyverno.AnnotationPolicyRollout: `{"stages":[{"name":"a"},{"name":"a"}]}`}), 1)
+}
@@ -0,0 +1,129 @@
+package tls
+
+import (
+	"context"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// CAKey is the secret key holding the issuing CA in TLS secrets provided by external certificate providers
+	CAKey = "ca.crt"
+	// CARotationGracePeriod is how long the CAs replaced by an external certificate provider keep being published
+	// after the new certificate was issued, replicas still serving a certificate issued by a previous CA remain trusted
+	CARotationGracePeriod = 24 * time.Hour
+)
+
+// MergeCABundle returns the CA bundle to publish for a certificate issued at the given time by the current CAs.
+// Like the internal renewer, previous CAs that are not expired are published along with the current ones,
+// they are dropped once the grace period after the certificate was issued elapsed.
+func MergeCABundle(previous, current []byte, issued, now time.Time) []byte {
+	if now.After(issued.Add(CARotationGracePeriod)) {
+		return current
+	}
+	currentCerts := pemToCertificates(current)
+	var kept []*x509.Certificate
+	for _, cert := range removeExpiredCertificates(now, pemToCertificates(previous)...) {
+		if !slices.ContainsFunc(currentCerts, cert.Equal) {
+			kept = append(kept, cert)
+		}
+	}
+	if len(kept) == 0 {
+		return current
+	}
+	return append(certificateToPem(kept...), current...)
+}
+
+// ValidateExternalSecret validates a TLS secret issued by an external certificate provider and returns
+// the CA bundle to inject in webhook configurations along with the serving certificate.
+// The secret must contain a matching key pair, the certificate must be valid at the given time,
+// cover the server name and chain up to the CA stored in ca.crt.
+func ValidateExternalSecret(secret *corev1.Secret, now time.Time, serverName string) ([]byte, *x509.Certificate, error) {
+	if secret == nil {
+		return nil, nil, errors.New("TLS secret not found")
+	}
+	certPem := secret.Data[corev1.TLSCertKey]
+	keyPem := secret.Data[corev1.TLSPrivateKeyKey]
+	caPem := secret.Data[CAKey]
+	if len(certPem) == 0 || len(keyPem) == 0 {
+		return nil, nil, fmt.Errorf("secret %s/%s must contain %s and %s", secret.Namespace, secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	if len(caPem) == 0 {
+		return nil, nil, fmt.Errorf("secret %s/%s must contain the issuing CA in %s", secret.Namespace, secret.Name, CAKey)
+	}
+	if _, err := cryptotls.X509KeyPair(certPem, keyPem); err != nil {
+		return nil, nil, fmt.Errorf("invalid key pair in secret %s/%s (%w)", secret.Namespace, secret.Name, err)
+	}
+	certs := pemToCertificates(certPem)
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("no certificate found in secret %s/%s", secret.Namespace, secret.Name)
+	}
+	caCerts := pemToCertificates(caPem)
+	if len(caCerts) == 0 {
+		return nil, nil, fmt.Errorf("no CA certificate found in secret %s/%s", secret.Namespace, secret.Name)
+	}
+	cert := certs[0]
+	if now.Before(cert.NotBefore) {
+		return nil, nil, fmt.Errorf("certificate in secret %s/%s is not valid before %s", secret.Namespace, secret.Name, cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.After(cert.NotAfter) {
+		return nil, nil, fmt.Errorf("certificate in secret %s/%s expired at %s", secret.Namespace, secret.Name, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if serverName != "" {
+		if err := cert.VerifyHostname(serverName); err != nil {
+			return nil, nil, fmt.Errorf("certificate in secret %s/%s is not valid for %s (%w)", secret.Namespace, secret.Name, serverName, err)
+		}
+	}
+	roots := x509.NewCertPool()
+	for _, ca := range caCerts {
+		roots.AddCert(ca)
+	}
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range certs[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, nil, fmt.Errorf("certificate in secret %s/%s is not issued by the CA in %s (%w)", secret.Namespace, secret.Name, CAKey, err)
+	}
+	return caPem, cert, nil
+}
+
+type externalCertValidator struct {
+	client     corev1client.SecretInterface
+	tlsSecret  string
+	serverName string
+}
+
+// NewExternalCertValidator returns a CertValidator checking a TLS secret issued by an external certificate provider
+func NewExternalCertValidator(client corev1client.SecretInterface, tlsSecret string, serverName string) CertValidator {
+	return &externalCertValidator{
+		client:     client,
+		tlsSecret:  tlsSecret,
+		serverName: serverName,
+	}
+}
+
+// ValidateCert validates the external TLS secret
+func (v *externalCertValidator) ValidateCert(ctx context.Context) (bool, error) {
+	secret, err := v.client.Get(ctx, v.tlsSecret, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if _, _, err := ValidateExternalSecret(secret, time.Now(), v.serverName); err != nil {
+		return false, err
+	}
+	return true, nil
+}
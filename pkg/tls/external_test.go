@@ -0,0 +1,126 @@
+package tls
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const externalServerName = "kyverno-svc.kyverno.svc"
+
+func newExternalSecret(t *testing.T, dnsNames ...string) *corev1.Secret {
+	t.Helper()
+	caKey, caCert, err := generateCA(nil, time.Hour, ECDSA)
+	require.NoError(t, err)
+	key, cert, err := generateTLS("", caCert, caKey, time.Hour, "kyverno-svc", dnsNames, ECDSA)
+	require.NoError(t, err)
+	keyPem, err := privateKeyToPem(key)
+	require.NoError(t, err)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "external-tls",
+			Namespace: "kyverno",
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certificateToPem(cert),
+			corev1.TLSPrivateKeyKey: keyPem,
+			CAKey:                   certificateToPem(caCert),
+		},
+	}
+}
+
+func TestValidateExternalSecret(t *testing.T) {
+	now := time.Now()
+	t.Run("valid", func(t *testing.T) {
+		secret := newExternalSecret(t, externalServerName)
+		caBundle, cert, err := ValidateExternalSecret(secret, now, externalServerName)
+		require.NoError(t, err)
+		assert.Equal(t, secret.Data[CAKey], caBundle)
+		assert.Equal(t, []string{externalServerName}, cert.DNSNames)
+	})
+	t.Run("nil secret", func(t *testing.T) {
+		_, _, err := ValidateExternalSecret(nil, now, externalServerName)
+		assert.Error(t, err)
+	})
+	t.Run("missing CA", func(t *testing.T) {
+		secret := newExternalSecret(t, externalServerName)
+		delete(secret.Data, CAKey)
+		_, _, err := ValidateExternalSecret(secret, now, externalServerName)
+		assert.ErrorContains(t, err, CAKey)
+	})
+	t.Run("mismatched key", func(t *testing.T) {
+		secret := newExternalSecret(t, externalServerName)
+		other := newExternalSecret(t, externalServerName)
+		secret.Data[corev1.TLSPrivateKeyKey] = other.Data[corev1.TLSPrivateKeyKey]
+		_, _, err := ValidateExternalSecret(secret, now, externalServerName)
+		assert.ErrorContains(t, err, "invalid key pair")
+	})
+	t.Run("wrong CA", func(t *testing.T) {
+		secret := newExternalSecret(t, externalServerName)
+		other := newExternalSecret(t, externalServerName)
+		secret.Data[CAKey] = other.Data[CAKey]
+		_, _, err := ValidateExternalSecret(secret, now, externalServerName)
+		assert.ErrorContains(t, err, "not issued by the CA")
+	})
+	t.Run("wrong server name", func(t *testing.T) {
+		secret := newExternalSecret(t, "other.kyverno.svc")
+		_, _, err := ValidateExternalSecret(secret, now, externalServerName)
+		assert.ErrorContains(t, err, "not valid for")
+	})
+	t.Run("expired", func(t *testing.T) {
+		secret := newExternalSecret(t, externalServerName)
+		_, _, err := ValidateExternalSecret(secret, now.Add(2*time.Hour), externalServerName)
+		assert.ErrorContains(t, err, "expired")
+	})
+	t.Run("not yet valid", func(t *testing.T) {
+		secret := newExternalSecret(t, externalServerName)
+		_, _, err := ValidateExternalSecret(secret, now.Add(-2*time.Hour), externalServerName)
+		assert.ErrorContains(t, err, "not valid before")
+	})
+}
+
+func TestExternalCertValidator(t *testing.T) {
+	secret := newExternalSecret(t, externalServerName)
+	client := fake.NewSimpleClientset(secret).CoreV1().Secrets("kyverno")
+	valid, err := NewExternalCertValidator(client, secret.Name, externalServerName).ValidateCert(context.TODO())
+	require.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = NewExternalCertValidator(client, secret.Name, "other.kyverno.svc").ValidateCert(context.TODO())
+	assert.Error(t, err)
+	assert.False(t, valid)
+	valid, err = NewExternalCertValidator(client, "missing", externalServerName).ValidateCert(context.TODO())
+	assert.Error(t, err)
+	assert.False(t, valid)
+}
+
+func TestMergeCABundle(t *testing.T) {
+	now := time.Now()
+	_, previous, err := generateCA(nil, time.Hour, ECDSA)
+	require.NoError(t, err)
+	_, current, err := generateCA(nil, time.Hour, ECDSA)
+	require.NoError(t, err)
+	_, expired, err := generateCA(nil, -time.Minute, ECDSA)
+	require.NoError(t, err)
+	currentPem := certificateToPem(current)
+
+	// previous CAs are published along with the current one during the grace period
+	merged := MergeCABundle(certificateToPem(previous, expired), currentPem, now.Add(-time.Hour), now)
+	certs := pemToCertificates(merged)
+	require.Len(t, certs, 2)
+	assert.True(t, certs[0].Equal(previous))
+	assert.True(t, certs[1].Equal(current))
+	// the bundle is stable once merged
+	assert.Equal(t, merged, MergeCABundle(merged, currentPem, now.Add(-time.Hour), now))
+	// previous CAs are dropped after the grace period
+	assert.Equal(t, currentPem, MergeCABundle(merged, currentPem, now.Add(-CARotationGracePeriod-time.Minute), now))
+	// nothing to keep when the CA didn't change
+	assert.Equal(t, currentPem, MergeCABundle(currentPem, currentPem, now, now))
+	assert.Equal(t, currentPem, MergeCABundle([]byte("invalid"), currentPem, now, now))
+}
@@ -74,3 +74,20 @@ func StringValue(value string) string {
 	}
 	return value
 }
+
+// PolicyAttributes returns span attributes identifying a policy.
+func PolicyAttributes(kind, namespace, name string) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		PolicyKindKey.String(kind),
+		PolicyNameKey.String(name),
+	}
+	if namespace != "" {
+		attributes = append(attributes, PolicyNamespaceKey.String(namespace))
+	}
+	return attributes
+}
+
+// RuleAttributes returns span attributes identifying a policy rule.
+func RuleAttributes(kind, namespace, name, rule string) []attribute.KeyValue {
+	return append(PolicyAttributes(kind, namespace, name), RuleNameKey.String(rule))
+}
@@ -2,6 +2,8 @@ package tracing
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -10,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -17,25 +20,122 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// ProtocolGRPC exports traces using OTLP over gRPC
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP exports traces using OTLP over HTTP
+	ProtocolHTTP = "http"
+)
+
+// DefaultPort returns the default OTLP receiver port of the given protocol.
+func DefaultPort(protocol string) string {
+	if protocol == ProtocolHTTP {
+		return "4318"
+	}
+	return "4317"
+}
+
+type options struct {
+	protocol      string
+	headers       map[string]string
+	samplingRatio float64
+}
+
+// Option configures the tracing setup.
+type Option func(*options)
+
+// WithProtocol sets the OTLP protocol used to export traces, defaults to ProtocolGRPC.
+func WithProtocol(protocol string) Option {
+	return func(o *options) {
+		o.protocol = protocol
+	}
+}
+
+// WithHeaders sets headers sent with every export request, typically used for collector authentication.
+func WithHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		o.headers = headers
+	}
+}
+
+// WithSamplingRatio configures parent based sampling, root spans are sampled with the given ratio.
+func WithSamplingRatio(ratio float64) Option {
+	return func(o *options) {
+		o.samplingRatio = ratio
+	}
+}
+
+func newOptions(opts ...Option) options {
+	o := options{
+		protocol:      ProtocolGRPC,
+		samplingRatio: 1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func newSampler(ratio float64) sdktrace.Sampler {
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+}
+
+func newClient(ctx context.Context, log logr.Logger, address, certs string, kubeClient kubernetes.Interface, o options) (otlptrace.Client, error) {
+	switch o.protocol {
+	case ProtocolGRPC:
+		grpcOptions := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(address),
+		}
+		if len(o.headers) != 0 {
+			grpcOptions = append(grpcOptions, otlptracegrpc.WithHeaders(o.headers))
+		}
+		if certs != "" {
+			// here the certificates are stored as configmaps
+			transportCreds, err := tlsutils.FetchCert(ctx, certs, kubeClient)
+			if err != nil {
+				log.Error(err, "Error fetching certificate from secret")
+			}
+			grpcOptions = append(grpcOptions, otlptracegrpc.WithTLSCredentials(transportCreds))
+		} else {
+			grpcOptions = append(grpcOptions, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.NewClient(grpcOptions...), nil
+	case ProtocolHTTP:
+		httpOptions := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(address),
+		}
+		if len(o.headers) != 0 {
+			httpOptions = append(httpOptions, otlptracehttp.WithHeaders(o.headers))
+		}
+		if certs != "" {
+			certPool, err := tlsutils.FetchCertPool(ctx, certs, kubeClient)
+			if err != nil {
+				log.Error(err, "Error fetching certificate from secret")
+				return nil, err
+			}
+			httpOptions = append(httpOptions, otlptracehttp.WithTLSClientConfig(&tls.Config{
+				RootCAs:    certPool,
+				MinVersion: tls.VersionTLS12,
+			}))
+		} else {
+			httpOptions = append(httpOptions, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.NewClient(httpOptions...), nil
+	default:
+		return nil, fmt.Errorf("unsupported tracing protocol: %s", o.protocol)
+	}
+}
+
 // NewTraceConfig generates the initial tracing configuration with 'address' as the endpoint to connect to the Opentelemetry Collector
-func NewTraceConfig(log logr.Logger, tracerName, address, certs string, kubeClient kubernetes.Interface) (func(), error) {
+func NewTraceConfig(log logr.Logger, tracerName, address, certs string, kubeClient kubernetes.Interface, opts ...Option) (func(), error) {
 	ctx := context.Background()
-	var client otlptrace.Client
-	if certs != "" {
-		// here the certificates are stored as configmaps
-		transportCreds, err := tlsutils.FetchCert(ctx, certs, kubeClient)
-		if err != nil {
-			log.Error(err, "Error fetching certificate from secret")
-		}
-		client = otlptracegrpc.NewClient(
-			otlptracegrpc.WithEndpoint(address),
-			otlptracegrpc.WithTLSCredentials(transportCreds),
-		)
-	} else {
-		client = otlptracegrpc.NewClient(
-			otlptracegrpc.WithEndpoint(address),
-			otlptracegrpc.WithInsecure(),
-		)
+	o := newOptions(opts...)
+	if o.samplingRatio < 0 || o.samplingRatio > 1 {
+		return nil, fmt.Errorf("invalid tracing sampling ratio %v, it must be between 0 and 1", o.samplingRatio)
+	}
+	client, err := newClient(ctx, log, address, certs, kubeClient, o)
+	if err != nil {
+		return nil, err
 	}
 	// create New Exporter for exporting metrics
 	traceExp, err := otlptrace.New(ctx, client)
@@ -60,6 +160,7 @@ func NewTraceConfig(log logr.Logger, tracerName, address, certs string, kubeClie
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(traceExp),
 		sdktrace.WithResource(res),
+		sdktrace.WithSampler(newSampler(o.samplingRatio)),
 	)
 	// set global propagator to tracecontext (the default is no-op).
 	otel.SetTracerProvider(tp)
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewOptions(t *testing.T) {
+	o := newOptions()
+	assert.Equal(t, ProtocolGRPC, o.protocol)
+	assert.Equal(t, float64(1), o.samplingRatio)
+	assert.Nil(t, o.headers)
+
+	o = newOptions(
+		WithProtocol(ProtocolHTTP),
+		WithSamplingRatio(0.25),
+		WithHeaders(map[string]string{"authorization": "Bearer token"}),
+	)
+	assert.Equal(t, ProtocolHTTP, o.protocol)
+	assert.Equal(t, 0.25, o.samplingRatio)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token"}, o.headers)
+}
+
+func TestDefaultPort(t *testing.T) {
+	assert.Equal(t, "4317", DefaultPort(ProtocolGRPC))
+	assert.Equal(t, "4318", DefaultPort(ProtocolHTTP))
+}
+
+func TestNewSampler(t *testing.T) {
+	traceID := trace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	never := newSampler(0)
+	always := newSampler(1)
+	root := sdktrace.SamplingParameters{TraceID: traceID}
+	assert.Equal(t, sdktrace.Drop, never.ShouldSample(root).Decision)
+	assert.Equal(t, sdktrace.RecordAndSample, always.ShouldSample(root).Decision)
+	// sampled parents are always followed
+	parent := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	assert.Equal(t, sdktrace.RecordAndSample, never.ShouldSample(sdktrace.SamplingParameters{ParentContext: parent, TraceID: traceID}).Decision)
+}
+
+func TestNewTraceConfigErrors(t *testing.T) {
+	_, err := NewTraceConfig(logr.Discard(), "test", "localhost:4317", "", nil, WithSamplingRatio(2))
+	assert.Error(t, err)
+	_, err = NewTraceConfig(logr.Discard(), "test", "localhost:4317", "", nil, WithProtocol("udp"))
+	assert.Error(t, err)
+}
+
+func TestPolicyAttributes(t *testing.T) {
+	assert.Equal(t, 2, len(PolicyAttributes("ClusterPolicy", "", "pol")))
+	attributes := RuleAttributes("Policy", "ns", "pol", "rule")
+	assert.Equal(t, 4, len(attributes))
+	assert.Contains(t, attributes, PolicyNamespaceKey.String("ns"))
+	assert.Contains(t, attributes, RuleNameKey.String("rule"))
+}
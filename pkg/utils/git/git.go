@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
@@ -19,17 +20,27 @@ import (
 type CloneFunc func(path string, fs billy.Filesystem, branch string, auth http.BasicAuth) (*git.Repository, error)
 
 func Clone(path string, fs billy.Filesystem, branch string, auth http.BasicAuth) (*git.Repository, error) {
+	co := cloneOptions(path, branch, auth)
+	co.Progress = os.Stdout
+	return git.Clone(memory.NewStorage(), fs, co)
+}
+
+// CloneContext clones the branch of a repository without reporting progress, it stops when the context is done.
+func CloneContext(ctx context.Context, path string, fs billy.Filesystem, branch string, auth http.BasicAuth) (*git.Repository, error) {
+	return git.CloneContext(ctx, memory.NewStorage(), fs, cloneOptions(path, branch, auth))
+}
+
+func cloneOptions(path string, branch string, auth http.BasicAuth) *git.CloneOptions {
 	co := &git.CloneOptions{
 		URL:           path,
 		ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch)),
-		Progress:      os.Stdout,
 		SingleBranch:  true,
 		Depth:         1,
 	}
 	if auth.Username != "" && auth.Password != "" {
 		co.Auth = &auth
 	}
-	return git.Clone(memory.NewStorage(), fs, co)
+	return co
 }
 
 func ListFiles(fs billy.Filesystem, path string, predicate func(fs.FileInfo) bool) ([]string, error) {
@@ -16,6 +16,20 @@ func FetchCert(
 	certs string,
 	kubeClient kubernetes.Interface,
 ) (credentials.TransportCredentials, error) {
+	cp, err := FetchCertPool(ctx, certs, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	transportCreds := credentials.NewClientTLSFromCert(cp, "")
+	return transportCreds, nil
+}
+
+// FetchCertPool builds a cert pool from the "ca.pem" key of the given secret in the kyverno namespace.
+func FetchCertPool(
+	ctx context.Context,
+	certs string,
+	kubeClient kubernetes.Interface,
+) (*x509.CertPool, error) {
 	secret, err := kubeClient.CoreV1().Secrets(config.KyvernoNamespace()).Get(ctx, certs, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching certificate from secret")
@@ -25,7 +39,5 @@ func FetchCert(
 	if !cp.AppendCertsFromPEM(secret.Data["ca.pem"]) {
 		return nil, fmt.Errorf("credentials: failed to append certificates")
 	}
-
-	transportCreds := credentials.NewClientTLSFromCert(cp, "")
-	return transportCreds, nil
+	return cp, nil
 }
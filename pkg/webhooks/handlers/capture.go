@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CapturedAdmissionReview is a captured admission request with the response returned to the API server.
+// It is an admission.k8s.io/v1 AdmissionReview extended with what Kyverno resolved to evaluate the request.
+type CapturedAdmissionReview struct {
+	admissionv1.AdmissionReview `json:",inline"`
+	// Webhook is the name of the webhook that served the request (MUTATE, VALIDATE, VPOL or NVPOL).
+	Webhook string `json:"webhook,omitempty"`
+	// FailurePolicy is the failure policy of the policies evaluated by the webhook (all, fail or ignore).
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	// URLParams holds the policy key of fine grained webhooks.
+	URLParams string `json:"urlParams,omitempty"`
+	// Policies holds the names of the policies evaluated by CEL policy webhooks.
+	Policies []string `json:"policies,omitempty"`
+	// Roles is the list of roles bound to the requester.
+	Roles []string `json:"roles,omitempty"`
+	// ClusterRoles is the list of cluster roles bound to the requester.
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+	// GroupVersionKind is the top level GVK of the requested resource.
+	GroupVersionKind metav1.GroupVersionKind `json:"groupVersionKind"`
+	// RequestTime is the time at which the webhook received the request.
+	RequestTime metav1.Time `json:"requestTime"`
+}
+
+// captureBufferSize is the number of captures waiting to be written, captures are dropped when the buffer is full.
+const captureBufferSize = 100
+
+// CaptureWriter writes captured admission requests in a directory, captures are written by Run so that
+// admission requests are not slowed down by the disk.
+// Captures stop once the directory holds the maximum number of captures, a limit of zero or less disables the maximum.
+type CaptureWriter struct {
+	dir     string
+	limit   int64
+	count   atomic.Int64
+	dropped atomic.Int64
+	queue   chan *CapturedAdmissionReview
+}
+
+// NewCaptureWriter returns a writer capturing admission requests in dir, capture is disabled when dir is empty.
+// Captures already present in the directory count towards the limit.
+func NewCaptureWriter(dir string, limit int) *CaptureWriter {
+	if dir == "" {
+		return nil
+	}
+	writer := &CaptureWriter{dir: dir, limit: int64(limit), queue: make(chan *CapturedAdmissionReview, captureBufferSize)}
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+				writer.count.Add(1)
+			}
+		}
+	}
+	return writer
+}
+
+// reserve returns false when the maximum number of captures is reached.
+func (w *CaptureWriter) reserve(logger logr.Logger) bool {
+	count := w.count.Add(1)
+	if w.limit <= 0 || count <= w.limit {
+		return true
+	}
+	if count == w.limit+1 {
+		logger.Info("maximum number of captured admission requests reached, capture stopped", "dir", w.dir, "limit", w.limit)
+	}
+	return false
+}
+
+// Run writes the buffered captures until the context is cancelled.
+func (w *CaptureWriter) Run(ctx context.Context, logger logr.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case review := <-w.queue:
+			if err := writeCapture(w.dir, review); err != nil {
+				logger.Error(err, "failed to capture admission request")
+			}
+		}
+	}
+}
+
+// enqueue buffers a capture, it is dropped and released from the limit when the buffer is full.
+func (w *CaptureWriter) enqueue(logger logr.Logger, review *CapturedAdmissionReview) {
+	select {
+	case w.queue <- review:
+	default:
+		w.count.Add(-1)
+		if w.dropped.Add(1) == 1 {
+			logger.Info("capture buffer is full, admission requests are dropped", "dir", w.dir, "size", captureBufferSize)
+		}
+	}
+}
+
+// WithCapture writes the admission requests served by the webhook and their responses with the given writer.
+func (inner AdmissionHandler) WithCapture(writer *CaptureWriter, webhook, failurePolicy string) AdmissionHandler {
+	if writer == nil {
+		return inner
+	}
+	return inner.withCapture(writer, webhook, failurePolicy).WithTrace("CAPTURE")
+}
+
+func (inner AdmissionHandler) withCapture(writer *CaptureWriter, webhook, failurePolicy string) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		response := inner(ctx, logger, request, startTime)
+		if !writer.reserve(logger) {
+			return response
+		}
+		review, err := newCapturedAdmissionReview(ctx, webhook, failurePolicy, request, response, startTime)
+		if err != nil {
+			logger.Error(err, "failed to capture admission request")
+			return response
+		}
+		writer.enqueue(logger, review)
+		return response
+	}
+}
+
+func newCapturedAdmissionReview(ctx context.Context, webhook, failurePolicy string, request AdmissionRequest, response AdmissionResponse, startTime time.Time) (*CapturedAdmissionReview, error) {
+	admissionRequest := *request.AdmissionRequest.DeepCopy()
+	if strings.EqualFold(admissionRequest.Kind.Kind, "Secret") {
+		var err error
+		if admissionRequest.Object, err = redactRaw(admissionRequest.Object); err != nil {
+			return nil, err
+		}
+		if admissionRequest.OldObject, err = redactRaw(admissionRequest.OldObject); err != nil {
+			return nil, err
+		}
+	}
+	var policies []string
+	if params := httprouter.ParamsFromContext(ctx); params != nil {
+		if names := strings.TrimLeft(params.ByName("policies"), "/"); names != "" {
+			policies = strings.Split(names, "/")
+		}
+	}
+	return &CapturedAdmissionReview{
+		AdmissionReview: admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: admissionv1.SchemeGroupVersion.String(),
+				Kind:       "AdmissionReview",
+			},
+			Request:  &admissionRequest,
+			Response: response.DeepCopy(),
+		},
+		Webhook:          webhook,
+		FailurePolicy:    failurePolicy,
+		URLParams:        request.URLParams,
+		Policies:         policies,
+		Roles:            request.Roles,
+		ClusterRoles:     request.ClusterRoles,
+		GroupVersionKind: metav1.GroupVersionKind(request.GroupVersionKind),
+		RequestTime:      metav1.NewTime(startTime),
+	}, nil
+}
+
+func redactRaw(raw runtime.RawExtension) (runtime.RawExtension, error) {
+	if len(raw.Raw) == 0 {
+		return raw, nil
+	}
+	object, err := kubeutils.BytesToUnstructured(raw.Raw)
+	if err != nil {
+		return raw, err
+	}
+	redacted, err := kubeutils.RedactSecret(object)
+	if err != nil {
+		return raw, err
+	}
+	data, err := redacted.MarshalJSON()
+	if err != nil {
+		return raw, err
+	}
+	return runtime.RawExtension{Raw: data}, nil
+}
+
+func writeCapture(dir string, review *CapturedAdmissionReview) error {
+	data, err := json.MarshalIndent(review, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s-%s.json", review.RequestTime.UnixNano(), review.Request.UID, strings.ToLower(review.Webhook))
+	return os.WriteFile(filepath.Join(dir, name), data, 0o600)
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestWithCaptureDisabled(t *testing.T) {
+	called := false
+	inner := AdmissionHandler(func(context.Context, logr.Logger, AdmissionRequest, time.Time) AdmissionResponse {
+		called = true
+		return AdmissionResponse{Allowed: true}
+	})
+	response := inner.WithCapture(NewCaptureWriter("", 10), "VALIDATE", "all")(context.TODO(), logr.Discard(), AdmissionRequest{}, time.Now())
+	assert.True(t, called)
+	assert.True(t, response.Allowed)
+}
+
+func TestWithCapture(t *testing.T) {
+	dir := t.TempDir()
+	inner := AdmissionHandler(func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		return AdmissionResponse{UID: request.UID, Allowed: false, Result: &metav1.Status{Message: "denied"}}
+	})
+	request := AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "42",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "secrets"},
+			Name:      "credentials",
+			Namespace: "default",
+			Operation: admissionv1.Update,
+			UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev"}},
+			Object: runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"credentials","namespace":"default"},"data":{"password":"c2VjcmV0"}}`),
+			},
+			OldObject: runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"credentials","namespace":"default"},"data":{"password":"b2xk"}}`),
+			},
+			DryRun: ptr.To(true),
+		},
+		Roles:            []string{"default:developer"},
+		ClusterRoles:     []string{"view"},
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Secret"},
+		URLParams:        "/require-labels",
+	}
+	ctx := context.WithValue(context.TODO(), httprouter.ParamsKey, httprouter.Params{{Key: "policies", Value: "/a/b"}})
+	startTime := time.Unix(1700000000, 1000)
+	writer := NewCaptureWriter(dir, 10)
+	runCtx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go writer.Run(runCtx, logr.Discard())
+	response := inner.WithCapture(writer, "VALIDATE", "fail")(ctx, logr.Discard(), request, startTime)
+	assert.False(t, response.Allowed)
+
+	var data []byte
+	require.Eventually(t, func() bool {
+		var err error
+		data, err = os.ReadFile(filepath.Join(dir, "1700000000000001000-42-validate.json"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	var review CapturedAdmissionReview
+	require.NoError(t, json.Unmarshal(data, &review))
+	assert.Equal(t, "admission.k8s.io/v1", review.APIVersion)
+	assert.Equal(t, "AdmissionReview", review.Kind)
+	assert.Equal(t, "VALIDATE", review.Webhook)
+	assert.Equal(t, "fail", review.FailurePolicy)
+	assert.Equal(t, "/require-labels", review.URLParams)
+	assert.Equal(t, []string{"a", "b"}, review.Policies)
+	assert.Equal(t, []string{"default:developer"}, review.Roles)
+	assert.Equal(t, []string{"view"}, review.ClusterRoles)
+	assert.Equal(t, metav1.GroupVersionKind{Version: "v1", Kind: "Secret"}, review.GroupVersionKind)
+	assert.True(t, review.RequestTime.Equal(&metav1.Time{Time: time.Unix(1700000000, 0)}))
+	require.NotNil(t, review.Request)
+	assert.Equal(t, request.UserInfo, review.Request.UserInfo)
+	assert.Equal(t, admissionv1.Update, review.Request.Operation)
+	assert.Equal(t, ptr.To(true), review.Request.DryRun)
+	assert.NotContains(t, string(review.Request.Object.Raw), "c2VjcmV0")
+	assert.NotContains(t, string(review.Request.OldObject.Raw), "b2xk")
+	require.NotNil(t, review.Response)
+	assert.False(t, review.Response.Allowed)
+	assert.Equal(t, "denied", review.Response.Result.Message)
+
+	// the review can be decoded as a standard AdmissionReview
+	var standard admissionv1.AdmissionReview
+	require.NoError(t, json.Unmarshal(data, &standard))
+	assert.Equal(t, types.UID("42"), standard.Request.UID)
+}
+
+func TestWithCaptureLimit(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1-0-validate.json"), []byte("{}"), 0o600))
+	inner := AdmissionHandler(func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		return AdmissionResponse{UID: request.UID, Allowed: true}
+	})
+	writer := NewCaptureWriter(dir, 3)
+	handler := inner.WithCapture(writer, "VALIDATE", "fail")
+	for i := 0; i < 5; i++ {
+		request := AdmissionRequest{AdmissionRequest: admissionv1.AdmissionRequest{UID: types.UID(fmt.Sprint(i))}}
+		response := handler(context.TODO(), logr.Discard(), request, time.Unix(1700000000, int64(i)))
+		assert.True(t, response.Allowed, "requests are served once the limit is reached")
+	}
+	assert.Len(t, writer.queue, 2, "existing captures count towards the limit")
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go writer.Run(ctx, logr.Discard())
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		return err == nil && len(entries) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWithCaptureBufferFull(t *testing.T) {
+	inner := AdmissionHandler(func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		return AdmissionResponse{UID: request.UID, Allowed: true}
+	})
+	writer := NewCaptureWriter(t.TempDir(), 0)
+	handler := inner.WithCapture(writer, "VALIDATE", "fail")
+	for i := 0; i < captureBufferSize+5; i++ {
+		request := AdmissionRequest{AdmissionRequest: admissionv1.AdmissionRequest{UID: types.UID(fmt.Sprint(i))}}
+		response := handler(context.TODO(), logr.Discard(), request, time.Unix(1700000000, int64(i)))
+		assert.True(t, response.Allowed, "requests are served when the buffer is full")
+	}
+	assert.Len(t, writer.queue, captureBufferSize)
+	assert.Equal(t, int64(5), writer.dropped.Load())
+	assert.Equal(t, int64(captureBufferSize), writer.count.Load(), "dropped captures do not count towards the limit")
+}
@@ -116,6 +116,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 				patches = append(patches, resp.GetPatches()...)
 				verifiedImageData.Merge(ivm)
 			},
+			trace.WithAttributes(tracing.PolicyAttributes(policy.GetKind(), policy.GetNamespace(), policy.GetName())...),
 		)
 	}
 
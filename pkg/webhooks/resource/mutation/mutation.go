@@ -147,6 +147,7 @@ func (v *mutationHandler) applyMutations(
 
 				return nil
 			},
+			trace.WithAttributes(tracing.PolicyAttributes(policy.GetKind(), policy.GetNamespace(), policy.GetName())...),
 		)
 		if err != nil {
 			return nil, nil, err
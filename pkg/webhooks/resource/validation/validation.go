@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/rollout"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
@@ -92,6 +93,12 @@ func (v *validationHandler) HandleValidationEnforce(
 	}
 
 	var engineResponses []engineapi.EngineResponse
+	// responses of the policies that are audited because their staged rollout doesn't enforce them in the namespace yet
+	var rolloutResponses []engineapi.EngineResponse
+	var namespaceLabels map[string]string
+	if hasRollout(policies) {
+		namespaceLabels = v.getNamespaceLabels(logger, request.Namespace)
+	}
 	failurePolicy := kyvernov1.Ignore
 	for _, policy := range policies {
 		tracing.ChildSpan(
@@ -100,7 +107,8 @@ func (v *validationHandler) HandleValidationEnforce(
 			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
 			func(ctx context.Context, span trace.Span) {
 				policyContext := policyContext.WithPolicy(policy)
-				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+				enforced := rollout.Enforced(policy.GetSpec().Rollout, request.Namespace, namespaceLabels, admissionRequestTimestamp)
+				if enforced && policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
 					failurePolicy = kyvernov1.Fail
 				}
 
@@ -111,6 +119,11 @@ func (v *validationHandler) HandleValidationEnforce(
 					return
 				}
 
+				if !enforced {
+					logger.V(2).Info("policy not enforced in the current rollout stage", "policy", policy.GetName(), "namespace", request.Namespace)
+					rolloutResponses = append(rolloutResponses, engineResponse)
+					return
+				}
 				engineResponses = append(engineResponses, engineResponse)
 				if !engineResponse.IsSuccessful() {
 					logger.V(2).Info("validation failed", "action", "Enforce", "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
@@ -121,6 +134,7 @@ func (v *validationHandler) HandleValidationEnforce(
 					logger.V(2).Info("validation passed", "policy", policy.GetName())
 				}
 			},
+			trace.WithAttributes(tracing.PolicyAttributes(policy.GetKind(), policy.GetNamespace(), policy.GetName())...),
 		)
 	}
 
@@ -142,6 +156,7 @@ func (v *validationHandler) HandleValidationEnforce(
 
 				auditWarnEngineResponses = append(auditWarnEngineResponses, engineResponse)
 			},
+			trace.WithAttributes(tracing.PolicyAttributes(policy.GetKind(), policy.GetNamespace(), policy.GetName())...),
 		)
 	}
 
@@ -152,6 +167,7 @@ func (v *validationHandler) HandleValidationEnforce(
 		return false, webhookutils.GetBlockedMessages(engineResponses), nil, engineResponses
 	}
 
+	engineResponses = append(engineResponses, rolloutResponses...)
 	// create the admission report if any of the policies involved doesn't have the report exclusion label
 	if NeedsReports(request, policyContext.NewResource(), v.admissionReports) && hasReportablePolicy(policies) {
 		go func() { //nolint:gosec // background context is intentional: the goroutine outlives the request
@@ -243,6 +259,7 @@ func (v *validationHandler) buildAuditResponses(
 				response := v.engine.Validate(ctx, policyContext)
 				responses = append(responses, response)
 			},
+			trace.WithAttributes(tracing.PolicyAttributes(policy.GetKind(), policy.GetNamespace(), policy.GetName())...),
 		)
 	}
 	return responses, nil
@@ -284,6 +301,27 @@ func (v *validationHandler) createReports(
 	return nil
 }
 
+func (v *validationHandler) getNamespaceLabels(logger logr.Logger, namespace string) map[string]string {
+	if namespace == "" {
+		return nil
+	}
+	ns, err := v.nsLister.Get(namespace)
+	if err != nil {
+		logger.V(4).Info("failed to get namespace", "namespace", namespace, "error", err)
+		return nil
+	}
+	return ns.GetLabels()
+}
+
+func hasRollout(policies []kyvernov1.PolicyInterface) bool {
+	for _, pol := range policies {
+		if pol.GetSpec().Rollout != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func hasReportablePolicy(policies []kyvernov1.PolicyInterface) bool {
 	for _, pol := range policies {
 		if reportutils.IsPolicyReportable(pol) {
@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	event "github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/rollout"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
@@ -23,6 +24,7 @@ import (
 	"go.uber.org/multierr"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type handler struct {
@@ -31,6 +33,7 @@ type handler struct {
 	kyvernoClient    versioned.Interface
 	admissionReports bool
 	eventGen         event.Interface
+	nsLister         corev1listers.NamespaceLister
 }
 
 func New(
@@ -39,6 +42,7 @@ func New(
 	kyvernoClient versioned.Interface,
 	admissionReports bool,
 	eventGen event.Interface,
+	nsLister corev1listers.NamespaceLister,
 ) *handler {
 	return &handler{
 		context:          context,
@@ -46,10 +50,11 @@ func New(
 		kyvernoClient:    kyvernoClient,
 		admissionReports: admissionReports,
 		eventGen:         eventGen,
+		nsLister:         nsLister,
 	}
 }
 
-func (h *handler) ValidateClustered(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, _ string, _ time.Time) handlers.AdmissionResponse {
+func (h *handler) ValidateClustered(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, _ string, startTime time.Time) handlers.AdmissionResponse {
 	var policies []string
 	if params := httprouter.ParamsFromContext(ctx); params != nil {
 		if params := strings.Split(strings.TrimLeft(params.ByName("policies"), "/"), "/"); len(params) != 0 {
@@ -57,10 +62,10 @@ func (h *handler) ValidateClustered(ctx context.Context, logger logr.Logger, adm
 		}
 	}
 	predicate := vpolengine.And(vpolengine.MatchNames(policies...), vpolengine.ClusteredPolicy())
-	return h.validate(ctx, logger, admissionRequest, predicate)
+	return h.validate(ctx, logger, admissionRequest, predicate, startTime)
 }
 
-func (h *handler) ValidateNamespaced(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, _ string, _ time.Time) handlers.AdmissionResponse {
+func (h *handler) ValidateNamespaced(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, _ string, startTime time.Time) handlers.AdmissionResponse {
 	var policies []string
 	if params := httprouter.ParamsFromContext(ctx); params != nil {
 		if params := strings.Split(strings.TrimLeft(params.ByName("policies"), "/"), "/"); len(params) != 0 {
@@ -68,15 +73,16 @@ func (h *handler) ValidateNamespaced(ctx context.Context, logger logr.Logger, ad
 		}
 	}
 	predicate := vpolengine.And(vpolengine.MatchNames(policies...), vpolengine.NamespacedPolicy(admissionRequest.Namespace))
-	return h.validate(ctx, logger, admissionRequest, predicate)
+	return h.validate(ctx, logger, admissionRequest, predicate, startTime)
 }
 
-func (h *handler) validate(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, predicate vpolengine.Predicate) handlers.AdmissionResponse {
+func (h *handler) validate(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, predicate vpolengine.Predicate, startTime time.Time) handlers.AdmissionResponse {
 	request := celengine.RequestFromAdmission(h.context, admissionRequest.AdmissionRequest)
 	response, err := h.engine.Handle(ctx, request, predicate)
 	if err != nil {
 		return admissionutils.Response(admissionRequest.UID, err)
 	}
+	h.applyRollout(logger, admissionRequest, &response, startTime)
 	var group wait.Group
 	defer group.Wait()
 	group.Start(func() {
@@ -85,6 +91,43 @@ func (h *handler) validate(ctx context.Context, logger logr.Logger, admissionReq
 	return h.admissionResponse(request, response)
 }
 
+// applyRollout audits instead of denying the policies whose staged rollout doesn't enforce them in the request namespace
+// at the time the request was received
+func (h *handler) applyRollout(logger logr.Logger, admissionRequest handlers.AdmissionRequest, response *vpolengine.EngineResponse, startTime time.Time) {
+	var namespaceLabels map[string]string
+	var namespaceFetched bool
+	for i, policy := range response.Policies {
+		if !policy.Actions.Has(admissionregistrationv1.Deny) {
+			continue
+		}
+		policyRollout, err := rollout.FromAnnotations(policy.Policy.GetAnnotations())
+		if err != nil {
+			logger.Error(err, "failed to get policy rollout", "policy", policy.Policy.GetName())
+			continue
+		}
+		if policyRollout == nil {
+			continue
+		}
+		if !namespaceFetched && admissionRequest.Namespace != "" && h.nsLister != nil {
+			if ns, err := h.nsLister.Get(admissionRequest.Namespace); err != nil {
+				logger.V(4).Info("failed to get namespace", "namespace", admissionRequest.Namespace, "error", err)
+			} else {
+				namespaceLabels = ns.GetLabels()
+			}
+			namespaceFetched = true
+		}
+		if rollout.Enforced(policyRollout, admissionRequest.Namespace, namespaceLabels, startTime) {
+			continue
+		}
+		logger.V(2).Info("policy not enforced in the current rollout stage", "policy", policy.Policy.GetName(), "namespace", admissionRequest.Namespace)
+		// actions are shared with the compiled policy, they must not be modified in place
+		actions := policy.Actions.Clone()
+		actions.Delete(admissionregistrationv1.Deny)
+		actions.Insert(admissionregistrationv1.Audit)
+		response.Policies[i].Actions = actions
+	}
+}
+
 func (h *handler) audit(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, request vpolengine.EngineRequest, response vpolengine.EngineResponse) {
 	blocked := false
 	for _, p := range response.Policies {
@@ -73,6 +73,11 @@ func NewServer(
 	vpolLogger := logger.WithName("vpol")
 	ivpolLogger := logger.WithName("ivpol")
 	mpolLogger := logger.WithName("mpol")
+	// only the webhooks the replay command can reproduce capture their requests
+	capture := handlers.NewCaptureWriter(debugModeOpts.CaptureDir, debugModeOpts.CaptureLimit)
+	if capture != nil {
+		go capture.Run(ctx, logger.WithName("capture"))
+	}
 	mux.HandlerFunc(
 		"POST",
 		"/mpol/*policies",
@@ -104,6 +109,7 @@ func NewServer(
 		"POST",
 		"/vpol/*policies",
 		handlerFunc("VALIDATE", resourceHandlers.ValidatingPolicies, "").
+			WithCapture(capture, "VPOL", "").
 			WithFilter(configuration).
 			WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
 			WithDump(debugModeOpts.DumpPayload).
@@ -117,6 +123,7 @@ func NewServer(
 		"POST",
 		"/nvpol/*policies",
 		handlerFunc("VALIDATE", resourceHandlers.NamespacedValidatingPolicies, "").
+			WithCapture(capture, "NVPOL", "").
 			WithFilter(configuration).
 			WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
 			WithDump(debugModeOpts.DumpPayload).
@@ -214,6 +221,7 @@ func NewServer(
 		"MUTATE",
 		config.MutatingWebhookServicePath,
 		resourceHandlers.Mutation,
+		capture,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
 				WithFilter(configuration).
@@ -231,6 +239,7 @@ func NewServer(
 		"VALIDATE",
 		config.ValidatingWebhookServicePath,
 		resourceHandlers.Validation,
+		capture,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
 				WithFilter(configuration).
@@ -401,10 +410,11 @@ func registerWebhookHandlers(
 	name string,
 	basePath string,
 	handler Handler,
+	capture *handlers.CaptureWriter,
 	builder func(handler handlers.AdmissionHandler) handlers.HttpHandler,
 ) {
-	ignore := handlerFunc(name, handler, "ignore")
-	fail := handlerFunc(name, handler, "fail")
+	ignore := handlerFunc(name, handler, "ignore").WithCapture(capture, name, "ignore")
+	fail := handlerFunc(name, handler, "fail").WithCapture(capture, name, "fail")
 	mux.HandlerFunc("POST", basePath+"/ignore", builder(ignore).ToHandlerFunc(name))
 	mux.HandlerFunc("POST", basePath+"/fail", builder(fail).ToHandlerFunc(name))
 	mux.HandlerFunc("POST", basePath+"/ignore"+config.FineGrainedWebhookPath+"/*policy", builder(ignore).ToHandlerFunc(name))
@@ -416,11 +426,12 @@ func registerWebhookHandlersWithAll(
 	name string,
 	basePath string,
 	handler Handler,
+	capture *handlers.CaptureWriter,
 	builder func(handler handlers.AdmissionHandler) handlers.HttpHandler,
 ) {
-	all := handlerFunc(name, handler, "all")
+	all := handlerFunc(name, handler, "all").WithCapture(capture, name, "all")
 	mux.HandlerFunc("POST", basePath, builder(all).ToHandlerFunc(name))
-	registerWebhookHandlers(mux, name, basePath, handler, builder)
+	registerWebhookHandlers(mux, name, basePath, handler, capture, builder)
 }
 
 func handlerFunc(name string, handler Handler, failurePolicy string) handlers.AdmissionHandler {
@@ -13,6 +13,10 @@ import (
 type DebugModeOptions struct {
 	// DumpPayload is used to activate/deactivate debug mode.
 	DumpPayload bool
+	// CaptureDir is the directory where admission requests are captured, capture is disabled when empty.
+	CaptureDir string
+	// CaptureLimit is the maximum number of captures written in CaptureDir, zero or less means no limit.
+	CaptureLimit int
 }
 
 type Handler interface {
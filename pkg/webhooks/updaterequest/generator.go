@@ -2,6 +2,7 @@ package updaterequest
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v7"
@@ -11,6 +12,7 @@ import (
 	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	generatorutils "github.com/kyverno/kyverno/pkg/utils/generator"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -30,15 +32,42 @@ type generator struct {
 	urLister kyvernov2listers.UpdateRequestNamespaceLister
 
 	urGenerator generatorutils.UpdateRequestGenerator
+
+	// batching
+	window    time.Duration
+	urMetrics metrics.UpdateRequestMetrics
+	lock      sync.Mutex
+	batch     map[string]kyvernov2.UpdateRequestSpec
+	order     []string
+	received  map[kyvernov2.RequestType]int
+	coalesced map[kyvernov2.RequestType]int
+}
+
+type Option func(*generator)
+
+// WithBatchWindow coalesces the update requests for the same policy, rules and trigger
+// received within the window, only the most recent one is created.
+func WithBatchWindow(window time.Duration) Option {
+	return func(g *generator) {
+		g.window = window
+	}
 }
 
 // NewGenerator returns a new instance of UpdateRequest resource generator
-func NewGenerator(client versioned.Interface, urInformer kyvernov2informers.UpdateRequestInformer, urGenerator generatorutils.UpdateRequestGenerator) Generator {
-	return &generator{
+func NewGenerator(client versioned.Interface, urInformer kyvernov2informers.UpdateRequestInformer, urGenerator generatorutils.UpdateRequestGenerator, opts ...Option) Generator {
+	g := &generator{
 		client:      client,
 		urLister:    urInformer.Lister().UpdateRequests(config.KyvernoNamespace()),
 		urGenerator: urGenerator,
+		urMetrics:   metrics.GetUpdateRequestMetrics(),
+		batch:       map[string]kyvernov2.UpdateRequestSpec{},
+		received:    map[kyvernov2.RequestType]int{},
+		coalesced:   map[kyvernov2.RequestType]int{},
+	}
+	for _, opt := range opts {
+		opt(g)
 	}
+	return g
 }
 
 // Apply creates update request resource
@@ -47,10 +76,59 @@ func (g *generator) Apply(ctx context.Context, ur kyvernov2.UpdateRequestSpec) e
 		return nil
 	}
 	logger.V(4).Info("apply Update Request", "request", ur)
-	go g.applyResource(context.TODO(), ur) //nolint:gosec // background context is intentional: the goroutine outlives the request
+	if g.urMetrics != nil {
+		g.urMetrics.RecordReceived(ctx, string(ur.GetRequestType()))
+	}
+	if g.window <= 0 {
+		go g.applyResource(context.TODO(), ur) //nolint:gosec // background context is intentional: the goroutine outlives the request
+		return nil
+	}
+	g.enqueue(ctx, ur)
 	return nil
 }
 
+// enqueue adds the update request to the current batch, superseding any update request with the same key.
+// The batch is flushed when the window started by its first update request elapses.
+func (g *generator) enqueue(ctx context.Context, ur kyvernov2.UpdateRequestSpec) {
+	key := common.UpdateRequestKey(ur)
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.received[ur.GetRequestType()]++
+	if _, ok := g.batch[key]; ok {
+		logger.V(4).Info("coalescing Update Request", "request", ur)
+		g.coalesced[ur.GetRequestType()]++
+		if g.urMetrics != nil {
+			g.urMetrics.RecordCoalesced(ctx, string(ur.GetRequestType()))
+		}
+	} else {
+		g.order = append(g.order, key)
+		if len(g.order) == 1 {
+			time.AfterFunc(g.window, g.flush)
+		}
+	}
+	g.batch[key] = ur
+}
+
+func (g *generator) flush() {
+	g.lock.Lock()
+	batch, order, received, coalesced := g.batch, g.order, g.received, g.coalesced
+	g.batch = map[string]kyvernov2.UpdateRequestSpec{}
+	g.order = nil
+	g.received = map[kyvernov2.RequestType]int{}
+	g.coalesced = map[kyvernov2.RequestType]int{}
+	g.lock.Unlock()
+	ctx := context.TODO()
+	if g.urMetrics != nil {
+		for requestType, count := range received {
+			g.urMetrics.RecordCoalesceRatio(ctx, string(requestType), float64(coalesced[requestType])/float64(count))
+		}
+	}
+	logger.V(4).Info("flushing Update Requests", "count", len(order))
+	for _, key := range order {
+		go g.applyResource(ctx, batch[key])
+	}
+}
+
 func (g *generator) applyResource(ctx context.Context, urSpec kyvernov2.UpdateRequestSpec) {
 	exbackoff := &backoff.ExponentialBackOff{
 		InitialInterval:     500 * time.Millisecond,
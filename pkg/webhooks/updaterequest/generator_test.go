@@ -0,0 +1,52 @@
+package updaterequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_Coalesce(t *testing.T) {
+	newSpec := func(trigger string, synchronize bool) kyvernov2.UpdateRequestSpec {
+		return kyvernov2.UpdateRequestSpec{
+			Type:   kyvernov2.Generate,
+			Policy: "policy",
+			RuleContext: []kyvernov2.RuleContext{{
+				Rule:        "rule",
+				Trigger:     kyvernov1.ResourceSpec{Kind: "Namespace", Name: trigger},
+				Synchronize: synchronize,
+			}},
+		}
+	}
+	g := &generator{
+		window:    time.Hour,
+		batch:     map[string]kyvernov2.UpdateRequestSpec{},
+		received:  map[kyvernov2.RequestType]int{},
+		coalesced: map[kyvernov2.RequestType]int{},
+	}
+	g.enqueue(context.TODO(), newSpec("foo", false))
+	g.enqueue(context.TODO(), newSpec("bar", false))
+	g.enqueue(context.TODO(), newSpec("foo", true))
+	assert.Len(t, g.order, 2)
+	assert.Len(t, g.batch, 2)
+	assert.Equal(t, 3, g.received[kyvernov2.Generate])
+	assert.Equal(t, 1, g.coalesced[kyvernov2.Generate])
+	// the most recent update request wins but keeps its position in the batch
+	assert.Equal(t, "foo", g.batch[g.order[0]].RuleContext[0].Trigger.Name)
+	assert.True(t, g.batch[g.order[0]].RuleContext[0].Synchronize)
+	assert.Equal(t, "bar", g.batch[g.order[1]].RuleContext[0].Trigger.Name)
+
+	// update requests in a different admission context or deleting downstream resources are kept
+	deleteDownstream := newSpec("foo", true)
+	deleteDownstream.RuleContext[0].DeleteDownstream = true
+	g.enqueue(context.TODO(), deleteDownstream)
+	otherUser := newSpec("foo", true)
+	otherUser.Context.UserRequestInfo.AdmissionUserInfo.Username = "alice"
+	g.enqueue(context.TODO(), otherUser)
+	assert.Len(t, g.order, 4)
+	assert.Equal(t, 1, g.coalesced[kyvernov2.Generate])
+}
@@ -117,7 +117,7 @@ func TestPolicyReport_VpolAuditEmitsFailResult(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, testEnv.KyvernoClient, true, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, testEnv.KyvernoClient, true, eventGen, nil)
 
 	podJSON := []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
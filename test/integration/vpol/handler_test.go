@@ -99,7 +99,7 @@ func TestValidate_DenyPolicy_BlocksNonCompliantResource(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("prod-app", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -127,7 +127,7 @@ func TestValidate_DenyPolicy_AllowsCompliantResource(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("staging-app", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -155,7 +155,7 @@ func TestValidate_WarnPolicy_AllowsButWarns(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("no-limits-pod", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -188,7 +188,7 @@ func TestValidate_MatchCondition_SkipsNonMatchingResource(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("my-app", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -216,7 +216,7 @@ func TestValidate_EventsGenerated_OnDeny(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("bad-pod", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -252,7 +252,7 @@ func TestValidate_CELVariables_UsedInValidation(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("no-label-pod", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -283,7 +283,7 @@ func TestValidate_AuditAction_AllowsNonCompliantButFiresEvents(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("unlabeled-pod", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -339,7 +339,7 @@ func TestValidate_MultiplePolicies_DenyAndWarnCombined(t *testing.T) {
 	waitForPolicyReady(t, 2)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	policyCtx := framework.ContextWithPolicies(context.Background(), "deny-latest-image", "warn-missing-limits")
 	resp := h.ValidateClustered(policyCtx, logr.Discard(), framework.PodAdmissionRequest("bad-pod", "default", []byte(`{
@@ -378,7 +378,7 @@ func TestValidate_MultipleValidations_PartialFailureDenies(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	// Pod uses nginx:1.25 (passes image check) but has no cost-center label (fails label check)
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("partial-pod", "default", []byte(`{
@@ -431,7 +431,7 @@ func TestValidateNamespaced_PolicyOnlyAppliesToItsNamespace(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	// Pod in team-a (same namespace as policy) — should be denied.
 	ctx := framework.ContextWithPolicies(context.Background(), "require-owner-label")
@@ -475,7 +475,7 @@ func TestValidate_PolicyExceptionSkipsValidation(t *testing.T) {
 
 	// Verify the policy actually denies before the exception is created.
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 	podJSON := []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
 		"metadata": {"name": "db-migration", "namespace": "default"},
@@ -550,7 +550,7 @@ func TestValidate_FailurePolicy_FailBlocksOnMatchConditionError(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("test-pod", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",
@@ -586,7 +586,7 @@ func TestValidate_FailurePolicy_IgnoreSkipsOnMatchConditionError(t *testing.T) {
 	waitForPolicyReady(t, 1)
 
 	eventGen := &framework.MockEventGen{}
-	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen)
+	h := vpol.New(engine, testEnv.ContextProvider, nil, false, eventGen, nil)
 
 	resp := h.ValidateClustered(context.Background(), logr.Discard(), framework.PodAdmissionRequest("test-pod", "default", []byte(`{
 		"apiVersion": "v1", "kind": "Pod",